@@ -0,0 +1,39 @@
+package main
+
+import (
+	"cinema-system/internal/services"
+	"crypto/hmac"
+	"io"
+	"log"
+	"net/http"
+	"os"
+)
+
+func main() {
+	addr := os.Getenv("WEBHOOK_RECEIVER_ADDR")
+	if addr == "" {
+		addr = ":9090"
+	}
+	secret := os.Getenv("WEBHOOK_SECRET")
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := services.SignWebhook(secret, r.Header.Get("X-Webhook-Timestamp"), string(body))
+		if secret != "" && !hmac.Equal([]byte(expected), []byte(r.Header.Get("X-Webhook-Signature"))) {
+			log.Printf("rejected %s delivery %s: bad signature", r.Header.Get("X-Webhook-Event"), r.Header.Get("X-Webhook-Delivery"))
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		log.Printf("received %s delivery %s: %s", r.Header.Get("X-Webhook-Event"), r.Header.Get("X-Webhook-Delivery"), body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	log.Printf("Webhook test receiver listening on %s", addr)
+	log.Fatal(http.ListenAndServe(addr, nil))
+}
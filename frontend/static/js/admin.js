@@ -61,6 +61,7 @@
             form.name.value = data.name;
             form.location.value = data.location;
             form.type.value = data.type || 'STANDARD';
+            form.currency.value = data.currency || 'KZT';
             form.total_rows.value = data.total_rows;
             form.seats_per_row.value = data.seats_per_row;
         } else if (type === 'movie') {
@@ -72,6 +73,7 @@
             form.trailer_url.value = data.trailer_url || '';
             form.age_limit.value = data.age_limit || 0;
             form.is_coming_soon.checked = !!data.is_coming_soon;
+            form.review_policy.value = data.review_policy || 'OPEN';
 
             var genreIds = data.genre_ids || [];
             form.querySelectorAll('input[name="genre"]').forEach(function (cb) {
@@ -86,7 +88,7 @@
                 var localISO = dt.getFullYear() + '-' + pad(dt.getMonth() + 1) + '-' + pad(dt.getDate()) + 'T' + pad(dt.getHours()) + ':' + pad(dt.getMinutes());
                 form.start_time.value = localISO;
             }
-            form.price.value = data.price;
+            form.price.value = data.price ? (data.price.amount / 100).toFixed(2) : '';
         } else if (type === 'genre') {
             form.name.value = data.name;
         }
@@ -202,7 +204,7 @@
     }
 
     function loadMovies() {
-        window.api.fetchMovies()
+        window.api.adminFetchMovies()
             .then(function (data) {
                 allMovies = data || [];
                 renderMovies();
@@ -247,7 +249,11 @@
                     <button type="button" class="btn btn-danger btn-sm del-movie" data-id="${m.id}">Delete</button>
                 </div>
             `;
-            li.querySelector('.edit-movie').onclick = function () { startEdit('movie', m); };
+            li.querySelector('.edit-movie').onclick = function () {
+                window.api.adminFetchMovie(m.id)
+                    .then(function (movie) { startEdit('movie', movie); })
+                    .catch(function (err) { showError(err.message); });
+            };
             ul.appendChild(li);
         });
 
@@ -297,7 +303,7 @@
             li.className = 'admin-list-item';
             li.innerHTML = `
                 <div class="item-info">
-                  <strong>${start}</strong> — Price: ${s.price} ₸<br>
+                  <strong>${start}</strong> — Price: ${window.formatMoney(s.price)}<br>
                   <small>Movie: ${s.movie_id}, Hall: ${s.hall_id}</small>
                 </div>
                 <div class="item-actions">
@@ -368,6 +374,66 @@
         document.getElementById('next-bookings').disabled = bookingsPage >= totalPages;
     }
 
+    function renderBars(el, rows, value, label) {
+        var max = rows.reduce(function (m, r) { return Math.max(m, value(r)); }, 0) || 1;
+        el.innerHTML = rows.length ? rows.map(function (r) {
+            var pct = Math.round((value(r) / max) * 100);
+            return `
+                <div style="display: flex; align-items: center; gap: 0.5rem; font-size: 0.85rem;">
+                    <span style="width: 6rem;">${r.label}</span>
+                    <div style="flex: 1; background: #333; height: 8px; border-radius: 4px;">
+                        <div style="width: ${pct}%; background: var(--accent); height: 8px; border-radius: 4px;"></div>
+                    </div>
+                    <span style="width: 8rem; text-align: right; color: var(--text-dim);">${label(r)}</span>
+                </div>`;
+        }).join('') : '<p class="card-meta">No data for this period.</p>';
+    }
+
+    function loadAnalytics() {
+        var form = document.getElementById('form-analytics');
+        if (!form) return;
+        var params = {};
+        ['from', 'to', 'location', 'interval'].forEach(function (name) {
+            if (form[name].value) params[name] = form[name].value;
+        });
+
+        window.api.adminFetchAnalytics('summary', params)
+            .then(function (summary) {
+                var totals = summary.totals.map(function (t) {
+                    return `${t.tickets_sold} tickets, ${window.formatMoney(t.revenue)} revenue, average ${window.formatMoney(t.average_price)}, refund rate ${(t.refund_rate * 100).toFixed(1)}%`;
+                });
+                document.getElementById('analytics-summary').innerHTML =
+                    (totals.length ? totals.join('<br>') : 'No sales for this period.') +
+                    `<br>Occupancy: ${(summary.occupancy.occupancy * 100).toFixed(1)}% across ${summary.occupancy.sessions} session(s)`;
+
+                var ul = document.getElementById('analytics-top-movies');
+                ul.innerHTML = '';
+                summary.top_movies.forEach(function (m) {
+                    var li = document.createElement('li');
+                    li.className = 'admin-list-item';
+                    li.innerHTML = `<div class="item-info"><strong>${m.label}</strong> — ${m.tickets_sold} tickets, ${window.formatMoney(m.revenue)}</div>`;
+                    ul.appendChild(li);
+                });
+            })
+            .catch(function (err) { showError(err.message); });
+
+        window.api.adminFetchAnalytics('sales', Object.assign({ group_by: 'period' }, params))
+            .then(function (report) {
+                renderBars(document.getElementById('analytics-revenue'), report.rows,
+                    function (r) { return r.revenue.amount; },
+                    function (r) { return window.formatMoney(r.revenue); });
+            })
+            .catch(function (err) { showError(err.message); });
+
+        window.api.adminFetchAnalytics('occupancy', params)
+            .then(function (report) {
+                renderBars(document.getElementById('analytics-occupancy'), report.series,
+                    function (r) { return r.occupancy; },
+                    function (r) { return (r.occupancy * 100).toFixed(1) + '% (' + r.sold + '/' + r.capacity + ')'; });
+            })
+            .catch(function (err) { showError(err.message); });
+    }
+
     function init() {
         updateNav();
         if (!window.auth || !auth.isLoggedIn() || !auth.isAdmin()) {
@@ -382,6 +448,26 @@
         loadSessions();
         loadBookings();
         loadGenres();
+        loadAnalytics();
+
+        document.getElementById('form-analytics').onsubmit = function (e) {
+            e.preventDefault();
+            loadAnalytics();
+        };
+
+        document.getElementById('form-export').onsubmit = function (e) {
+            e.preventDefault();
+            var dataset = e.target.source.value;
+            var params = { format: e.target.format.value };
+            if (dataset.indexOf('analytics/') === 0) {
+                var filters = document.getElementById('form-analytics');
+                ['from', 'to', 'location', 'interval'].forEach(function (name) {
+                    if (filters[name].value) params[name] = filters[name].value;
+                });
+            }
+            window.api.adminDownloadExport(dataset, params)
+                .catch(function (err) { showError(err.message); });
+        };
 
         document.querySelectorAll('.cancel-btn').forEach(function (btn) {
             btn.onclick = function () {
@@ -437,6 +523,7 @@
                 name: this.name.value.trim(),
                 location: this.location.value.trim(),
                 type: this.type.value,
+                currency: this.currency.value,
                 total_rows: parseInt(this.total_rows.value, 10) || 0,
                 seats_per_row: parseInt(this.seats_per_row.value, 10) || 0
             };
@@ -469,7 +556,8 @@
                 age_limit: parseInt(this.age_limit ? this.age_limit.value : 0, 10) || 0,
                 rating: parseFloat(this.rating.value) || 0,
                 genre_ids: genresList,
-                is_coming_soon: this.is_coming_soon ? this.is_coming_soon.checked : false
+                is_coming_soon: this.is_coming_soon ? this.is_coming_soon.checked : false,
+                review_policy: this.review_policy ? this.review_policy.value : 'OPEN'
             };
             var promise = id
                 ? window.api.adminUpdateMovie(id, payload)
@@ -510,7 +598,7 @@
                 movie_id: this.movie_id.value,
                 hall_id: this.hall_id.value,
                 start_time: start.toISOString(),
-                price: parseFloat(this.price.value) || 0
+                price: window.toMinorUnits(this.price.value)
             };
             if (!payload.movie_id || !payload.hall_id) { showError('Please select a movie and a hall'); return; }
             var promise = id
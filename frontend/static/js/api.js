@@ -9,10 +9,28 @@
       } catch (e) {
         return { ok: false, status: response.status, data: { error: 'Invalid server response: ' + text.substring(0, 100) } };
       }
+      if (!response.ok && data && data.detail && !data.error) {
+        data.error = data.detail;
+        if (Array.isArray(data.errors) && data.errors.length) {
+          data.error += ': ' + data.errors.map(function (e) { return e.field + ' ' + e.message; }).join(', ');
+        }
+      }
       return { ok: response.ok, status: response.status, data: data };
     });
   }
 
+  var CURRENCY_SYMBOLS = { KZT: '₸', RUB: '₽', USD: '$', EUR: '€' };
+
+  window.formatMoney = function (money) {
+    if (!money) return '0.00 ₸';
+    var symbol = CURRENCY_SYMBOLS[money.currency] || money.currency;
+    return (money.amount / 100).toFixed(2) + ' ' + symbol;
+  };
+
+  window.toMinorUnits = function (value) {
+    return Math.round(parseFloat(value) * 100) || 0;
+  };
+
   window.showToast = function (msg, type = 'success') {
     var container = document.getElementById('toast-container');
     if (!container) {
@@ -59,6 +77,10 @@
     options = options || {};
     var headers = options.headers || {};
     var body = options.body;
+    var lang = localStorage.getItem('lang');
+    if (lang && !headers['Accept-Language']) {
+      headers['Accept-Language'] = lang;
+    }
     var isJson = body && typeof body === 'object' && !(body instanceof FormData);
     if (isJson) {
       headers['Content-Type'] = 'application/json';
@@ -84,11 +106,81 @@
       });
   }
 
+  function fetchAllPages(path, params, options, errorMessage) {
+    var items = [];
+    function load(cursor) {
+      var query = new URLSearchParams(params || {});
+      query.set('limit', '100');
+      if (cursor) query.set('cursor', cursor);
+      return request('GET', path + '?' + query.toString(), options).then(function (res) {
+        if (!res.ok) throw new Error((res.data && res.data.error) || errorMessage);
+        items = items.concat(res.data && Array.isArray(res.data.items) ? res.data.items : []);
+        return res.data && res.data.next_cursor ? load(res.data.next_cursor) : items;
+      });
+    }
+    return load('');
+  }
+
+  function fetchPage(path, params, options, errorMessage) {
+    var query = new URLSearchParams();
+    Object.keys(params || {}).forEach(function (key) {
+      if (params[key] !== undefined && params[key] !== null && params[key] !== '') query.set(key, params[key]);
+    });
+    return request('GET', path + '?' + query.toString(), options).then(function (res) {
+      if (!res.ok) throw new Error((res.data && res.data.error) || errorMessage);
+      return {
+        items: res.data && Array.isArray(res.data.items) ? res.data.items : [],
+        nextCursor: (res.data && res.data.next_cursor) || ''
+      };
+    });
+  }
+
+  window.createCursorPager = function (ids, loadPage, onError) {
+    var cursors = [];
+    var nextCursor = '';
+    var prev = document.getElementById(ids.prev);
+    var next = document.getElementById(ids.next);
+    var info = document.getElementById(ids.info);
+
+    function show(index, cursor) {
+      if (prev) prev.disabled = true;
+      if (next) next.disabled = true;
+      return loadPage(cursor)
+        .then(function (page) {
+          cursors = cursors.slice(0, index).concat([cursor]);
+          nextCursor = page.nextCursor;
+        })
+        .catch(onError)
+        .then(function () {
+          if (info) info.textContent = 'Page ' + Math.max(cursors.length, 1);
+          if (prev) prev.disabled = cursors.length < 2;
+          if (next) next.disabled = !nextCursor;
+        });
+    }
+
+    if (prev) prev.onclick = function () {
+      if (cursors.length > 1) show(cursors.length - 2, cursors[cursors.length - 2]);
+    };
+    if (next) next.onclick = function () {
+      if (nextCursor) show(cursors.length, nextCursor);
+    };
+
+    return {
+      reset: function () { return show(0, ''); },
+      reload: function () {
+        return cursors.length ? show(cursors.length - 1, cursors[cursors.length - 1]) : show(0, '');
+      }
+    };
+  };
+
   window.api = {
 
-    fetchMovies: function () {
-      return request('GET', '/movies').then(function (res) {
-        if (!res.ok) throw new Error(res.data.error || 'Failed to load movies');
+    fetchMovies: function (params) {
+      return fetchPage('/movies', params, {}, 'Failed to load movies');
+    },
+    fetchGenres: function () {
+      return request('GET', '/genres').then(function (res) {
+        if (!res.ok) throw new Error(res.data.error || 'Failed to load genres');
         return Array.isArray(res.data) ? res.data : [];
       });
     },
@@ -209,22 +301,37 @@
         return res.data;
       });
     },
-    fetchMyBookings: function () {
-      return request('GET', '/bookings/my', {
-        headers: authHeaders()
-      }).then(function (res) {
-        if (!res.ok) throw new Error(res.data.error || 'Failed to load bookings');
-        return Array.isArray(res.data) ? res.data : [];
-      });
+    fetchMyBookings: function (params) {
+      return fetchPage('/bookings/my', params, { headers: authHeaders() }, 'Failed to load bookings');
     },
 
 
-    fetchMovieReviews: function (movieId) {
-      return request('GET', '/reviews/movie/' + encodeURIComponent(movieId), {
+    fetchSimilarMovies: function (movieId) {
+      return request('GET', '/movies/' + encodeURIComponent(movieId) + '/similar').then(function (res) {
+        if (!res.ok) throw new Error(res.data.error || 'Failed to load similar movies');
+        return res.data.items || [];
+      });
+    },
+    fetchRecommendations: function () {
+      return request('GET', '/recommendations', { headers: authHeaders() }).then(function (res) {
+        if (!res.ok) throw new Error(res.data.error || 'Failed to load recommendations');
+        return res.data.items || [];
+      });
+    },
+    fetchMovieReviews: function (movieId, params) {
+      return fetchAllPages('/reviews/movie/' + encodeURIComponent(movieId), params, {
         headers: authHeaders()
+      }, 'Failed to load reviews').catch(function () {
+        return [];
+      });
+    },
+    voteReview: function (reviewId, helpful) {
+      return request('POST', '/reviews/' + encodeURIComponent(reviewId) + '/vote', {
+        headers: authHeaders(),
+        body: { helpful: helpful }
       }).then(function (res) {
-        if (!res.ok) return [];
-        return Array.isArray(res.data) ? res.data : [];
+        if (!res.ok) throw new Error(res.data.error || 'Failed to vote');
+        return res.data;
       });
     },
     createReview: function (payload) {
@@ -300,10 +407,7 @@
 
 
     adminFetchHalls: function () {
-      return request('GET', '/admin/halls', { headers: authHeaders() }).then(function (res) {
-        if (!res.ok) throw new Error(res.data.error || 'Failed to load halls');
-        return Array.isArray(res.data) ? res.data : [];
-      });
+      return fetchAllPages('/admin/halls', null, { headers: authHeaders() }, 'Failed to load halls');
     },
     adminCreateHall: function (payload) {
       return request('POST', '/admin/halls', { headers: authHeaders(), body: payload }).then(function (res) {
@@ -323,6 +427,15 @@
         return res.data;
       });
     },
+    adminFetchMovies: function () {
+      return fetchAllPages('/admin/movies', null, { headers: authHeaders() }, 'Failed to load movies');
+    },
+    adminFetchMovie: function (id) {
+      return request('GET', '/admin/movies/' + encodeURIComponent(id), { headers: authHeaders() }).then(function (res) {
+        if (!res.ok) throw new Error(res.data.error || 'Failed to load movie');
+        return res.data;
+      });
+    },
     adminCreateMovie: function (payload) {
       return request('POST', '/admin/movies', { headers: authHeaders(), body: payload }).then(function (res) {
         if (!res.ok) throw new Error(res.data.error || 'Failed to create movie');
@@ -384,13 +497,37 @@
     },
 
     adminFetchBookings: function () {
-      return request('GET', '/admin/bookings', { headers: authHeaders() }).then(function (res) {
-        if (!res.ok) throw new Error(res.data.error || 'Failed to load bookings');
-        return Array.isArray(res.data) ? res.data : [];
+      return fetchAllPages('/admin/bookings', null, { headers: authHeaders() }, 'Failed to load bookings');
+    },
+    adminFetchAnalytics: function (report, params) {
+      var qs = new URLSearchParams(params || {}).toString();
+      return request('GET', '/admin/analytics/' + report + (qs ? '?' + qs : ''), { headers: authHeaders() }).then(function (res) {
+        if (!res.ok) throw new Error(res.data.error || 'Failed to load analytics');
+        return res.data;
+      });
+    },
+    adminDownloadExport: function (dataset, params) {
+      var qs = new URLSearchParams(params || {}).toString();
+      return fetch(API_BASE + '/admin/export/' + dataset + (qs ? '?' + qs : ''), { headers: authHeaders() }).then(function (response) {
+        if (!response.ok) {
+          return withJson(response).then(function (res) {
+            throw new Error((res.data && res.data.error) || 'Failed to export');
+          });
+        }
+        var match = /filename="([^"]+)"/.exec(response.headers.get('Content-Disposition') || '');
+        return response.blob().then(function (blob) {
+          var link = document.createElement('a');
+          link.href = URL.createObjectURL(blob);
+          link.download = match ? match[1] : 'export';
+          document.body.appendChild(link);
+          link.click();
+          document.body.removeChild(link);
+          URL.revokeObjectURL(link.href);
+        });
       });
     },
     adminFetchGenres: function () {
-      return request('GET', '/genres').then(function (res) {
+      return request('GET', '/admin/genres', { headers: authHeaders() }).then(function (res) {
         if (!res.ok) throw new Error(res.data.error || 'Failed to load genres');
         return Array.isArray(res.data) ? res.data : [];
       });
@@ -1,6 +1,4 @@
 (function () {
-  var allMovies = [];
-
   function updateNav() {
     var span = document.getElementById('user-span');
     var navProfile = document.getElementById('nav-profile');
@@ -31,66 +29,45 @@
     }
   }
 
-  function ensureGenres(movies) {
+  var PAGE_SIZE = 20;
+  var pager = null;
+
+  function loadGenres() {
     var select = document.getElementById('filter-genre');
     if (!select) return;
-    var genres = {};
-    movies.forEach(function (m) {
-      if (!m.genres) return;
-      var list = Array.isArray(m.genres) ? m.genres : String(m.genres).split(',');
-      list.forEach(function (g) {
-        var trimmed = String(g || '').trim();
-        if (trimmed) genres[trimmed] = true;
-      });
-    });
-    var current = select.value;
-    select.innerHTML = '<option value="">All</option>';
-    Object.keys(genres)
-      .sort()
-      .forEach(function (g) {
-        var opt = document.createElement('option');
-        opt.value = g;
-        opt.textContent = g;
-        select.appendChild(opt);
-      });
-    if (current && genres[current]) {
-      select.value = current;
-    }
+    window.api
+      .fetchGenres()
+      .then(function (genres) {
+        var current = select.value;
+        select.innerHTML = '<option value="">All</option>';
+        genres
+          .slice()
+          .sort(function (a, b) { return String(a.name || '').localeCompare(String(b.name || '')); })
+          .forEach(function (g) {
+            var opt = document.createElement('option');
+            opt.value = g.id;
+            opt.textContent = g.name;
+            select.appendChild(opt);
+          });
+        select.value = current;
+      })
+      .catch(function () {});
   }
 
-  var moviesWithSessions = new Set();
-
   function renderMovies(movies) {
     var grid = document.getElementById('movies-list');
     if (!grid) return;
     grid.innerHTML = '';
-    var searchInput = document.getElementById('search-title');
-    var genreSelect = document.getElementById('filter-genre');
-    var q = (searchInput && searchInput.value ? searchInput.value : '').toLowerCase().trim();
-    var genre = (genreSelect && genreSelect.value) || '';
-
-    var filtered = (movies || []).filter(function (m) {
-      var title = (m.name || m.title || '').toLowerCase();
-      var matchTitle = !q || title.indexOf(q) !== -1;
-      var genres = Array.isArray(m.genres) ? m.genres : (m.genres ? String(m.genres).split(',') : []);
-      var matchGenre =
-        !genre ||
-        genres.some(function (g) {
-          return String(g || '').trim() === genre;
-        });
-      var isPlayingNow = !m.is_coming_soon;
-      var hasSessions = moviesWithSessions.has(m.id);
-      return matchTitle && matchGenre && isPlayingNow && hasSessions;
-    });
+    movies = movies || [];
 
-    if (filtered.length === 0) {
+    if (movies.length === 0) {
       var empty = document.createElement('p');
       empty.textContent = 'No movies currently playing.';
       grid.appendChild(empty);
       return;
     }
 
-    filtered.forEach(function (m) {
+    movies.forEach(function (m) {
       var cardLink = document.createElement('a');
       cardLink.href = 'movie.html?id=' + encodeURIComponent(m.id || '');
       cardLink.className = 'card';
@@ -101,7 +78,7 @@
       img.src = m.poster_url || '';
       img.alt = m.name || 'Movie poster';
 
-      if (filtered.indexOf(m) < 2) {
+      if (movies.indexOf(m) < 2) {
         img.setAttribute('fetchpriority', 'high');
       } else {
         img.loading = 'lazy';
@@ -149,42 +126,51 @@
     });
   }
 
-  function load() {
+  function showError(err) {
+    var errorEl = document.getElementById('movies-error');
+    if (errorEl) {
+      errorEl.textContent = (err && err.message) || 'Failed to load movies.';
+      errorEl.style.display = 'block';
+    }
+  }
+
+  function loadPage(cursor) {
     var errorEl = document.getElementById('movies-error');
     if (errorEl) {
       errorEl.style.display = 'none';
       errorEl.textContent = '';
     }
+    var searchInput = document.getElementById('search-title');
+    var genreSelect = document.getElementById('filter-genre');
 
-    Promise.all([
-      window.api.fetchMovies(),
-      window.api.fetchUpcomingMovieIDs()
-    ])
-      .then(function (results) {
-        var movies = results[0];
-        var sessionMovieIDs = results[1];
-
-        moviesWithSessions = new Set(sessionMovieIDs);
-        allMovies = movies;
-
-        ensureGenres(allMovies);
-        renderMovies(allMovies);
+    return window.api
+      .fetchMovies({
+        q: searchInput ? searchInput.value.trim() : '',
+        genre: genreSelect ? genreSelect.value : '',
+        coming_soon: 'false',
+        showing: 'true',
+        limit: PAGE_SIZE,
+        cursor: cursor
       })
-      .catch(function (err) {
-        if (errorEl) {
-          errorEl.textContent = err.message || 'Failed to load movies.';
-          errorEl.style.display = 'block';
-        }
+      .then(function (page) {
+        renderMovies(page.items);
+        return page;
       });
   }
 
   function init() {
     updateNav();
+    pager = window.createCursorPager({ prev: 'prev-movies', next: 'next-movies', info: 'movies-page-info' }, loadPage, showError);
     var search = document.getElementById('search-title');
     var genre = document.getElementById('filter-genre');
-    if (search) search.addEventListener('input', function () { renderMovies(allMovies); });
-    if (genre) genre.addEventListener('change', function () { renderMovies(allMovies); });
-    load();
+    var searchTimer = null;
+    if (search) search.addEventListener('input', function () {
+      clearTimeout(searchTimer);
+      searchTimer = setTimeout(pager.reset, 300);
+    });
+    if (genre) genre.addEventListener('change', function () { pager.reset(); });
+    loadGenres();
+    pager.reset();
   }
 
   if (document.readyState === 'loading') {
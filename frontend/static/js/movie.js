@@ -38,6 +38,28 @@
         return;
     }
 
+    function renderHistogram(stats) {
+        var el = document.getElementById('rating-histogram');
+        if (!el || !stats || !stats.count) {
+            return;
+        }
+        var rows = '';
+        for (var score = 10; score >= 0; score--) {
+            var n = (stats.histogram && stats.histogram[score]) || 0;
+            var pct = Math.round((n / stats.count) * 100);
+            rows += `
+                <div style="display: flex; align-items: center; gap: 0.5rem; font-size: 0.85rem;">
+                    <span style="width: 1.5rem; text-align: right;">${score}</span>
+                    <div style="flex: 1; background: #333; height: 6px; border-radius: 3px;">
+                        <div style="width: ${pct}%; background: var(--accent); height: 6px; border-radius: 3px;"></div>
+                    </div>
+                    <span style="width: 2rem; color: var(--text-dim);">${n}</span>
+                </div>`;
+        }
+        el.innerHTML = `<div class="card-meta" style="margin-bottom: 0.5rem;">${stats.count} rating(s), average ${stats.average.toFixed(1)}</div>` + rows;
+        el.style.display = 'block';
+    }
+
     function loadMovieDetails() {
         window.api
             .fetchMovieDetails(movieId)
@@ -61,6 +83,7 @@
                 if (ratEl) {
                     ratEl.textContent = 'Rating: ' + (movie.rating != null ? movie.rating.toFixed(1) : '—') + '/10';
                 }
+                renderHistogram(movie.rating_stats);
                 var trailer = document.getElementById('movie-trailer');
                 if (movie.trailer_url) {
                     trailer.src = movie.trailer_url.replace('watch?v=', 'embed/');
@@ -68,8 +91,23 @@
                 } else {
                     document.getElementById('movie-trailer-wrap').style.display = 'none';
                 }
-                return window.api.fetchMovieReviews(movieId);
+                return loadReviews();
             })
+            .catch(function (err) {
+                var errEl = document.getElementById('movie-error');
+                if (errEl) {
+                    errEl.textContent = err.message || 'Failed to load.';
+                    errEl.style.display = 'block';
+                }
+            });
+    }
+
+    function loadReviews() {
+        var params = { sort: document.getElementById('review-sort').value };
+        if (document.getElementById('review-with-comment').checked) {
+            params.has_comment = 'true';
+        }
+        return window.api.fetchMovieReviews(movieId, params)
             .then(function (reviews) {
                 var ul = document.getElementById('reviews-list');
                 ul.innerHTML = '';
@@ -82,21 +120,50 @@
                     li.className = 'review-item';
                     li.innerHTML = `
                         <div class="review-rating">${r.rating || 0}/10</div>
-                        <div class="card-meta" style="margin-bottom: 0.5rem; color: var(--accent);">By: ${r.user_name || 'Anonymous'}</div>
+                        <div class="card-meta" style="margin-bottom: 0.5rem; color: var(--accent);">By: ${r.user_name || 'Anonymous'}${r.verified_viewer ? ' <span class="badge">Verified viewer</span>' : ''}</div>
                         <p class="review-comment">${r.comment || ''}</p>
+                        ${r.reply ? `<p class="review-comment" style="margin-left: 1rem; color: var(--text-dim);"><strong>Cinema reply:</strong> ${r.reply.text}</p>` : ''}
+                        <div class="card-meta" style="display: flex; gap: 0.5rem; align-items: center;">
+                            <button type="button" class="btn btn-ghost vote-btn" data-id="${r.id}" data-helpful="true">Helpful (${r.helpful_count || 0})</button>
+                            <button type="button" class="btn btn-ghost vote-btn" data-id="${r.id}" data-helpful="false">Not helpful (${r.unhelpful_count || 0})</button>
+                        </div>
                     `;
                     ul.appendChild(li);
                 });
-            })
-            .catch(function (err) {
-                var errEl = document.getElementById('movie-error');
-                if (errEl) {
-                    errEl.textContent = err.message || 'Failed to load.';
-                    errEl.style.display = 'block';
-                }
+                ul.querySelectorAll('.vote-btn').forEach(function (btn) {
+                    btn.addEventListener('click', function () {
+                        window.api.voteReview(btn.dataset.id, btn.dataset.helpful === 'true')
+                            .then(loadReviews)
+                            .catch(function (err) { alert(err.message); });
+                    });
+                });
             });
     }
 
+    function loadSimilar() {
+        window.api.fetchSimilarMovies(movieId)
+            .then(function (items) {
+                var section = document.getElementById('similar-section');
+                var list = document.getElementById('similar-list');
+                if (!section || !list || items.length === 0) return;
+                list.innerHTML = '';
+                items.forEach(function (item) {
+                    var card = document.createElement('a');
+                    card.className = 'card';
+                    card.href = 'movie.html?id=' + encodeURIComponent(item.movie_id);
+                    card.innerHTML = `
+                        <div class="card-body">
+                            <div class="card-title">${item.movie.name}</div>
+                            <div class="card-meta" style="color: var(--text-dim);">${item.explanation}</div>
+                        </div>
+                    `;
+                    list.appendChild(card);
+                });
+                section.style.display = 'block';
+            })
+            .catch(function () {});
+    }
+
     function handleBuyTickets() {
         if (window.auth && auth.isLoggedIn && auth.isLoggedIn()) {
             location.href = 'cinemas.html?movieId=' + encodeURIComponent(movieId);
@@ -115,10 +182,13 @@
     function init() {
         updateNav();
         loadMovieDetails();
+        loadSimilar();
         var buyBtn = document.getElementById('buy-tickets-btn');
         if (buyBtn && movieId) {
             buyBtn.addEventListener('click', handleBuyTickets);
         }
+        document.getElementById('review-sort').addEventListener('change', loadReviews);
+        document.getElementById('review-with-comment').addEventListener('change', loadReviews);
 
         var formReview = document.getElementById('form-review');
         if (formReview) {
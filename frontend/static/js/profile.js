@@ -46,7 +46,12 @@
         document.getElementById('profile-content').style.display = 'block';
         showError('');
 
+        loadProfile();
+        loadBookings();
+        loadReviews();
+    }
 
+    function loadProfile() {
         window.api.fetchMe()
             .then(function (user) {
 
@@ -60,9 +65,6 @@
             .catch(function () {
                 renderProfile(auth.getUser());
             });
-
-        loadBookings();
-        loadReviews();
     }
 
     function renderProfile(u) {
@@ -75,20 +77,40 @@
         var adminLink = document.getElementById('profile-admin-link');
         if (adminLink) adminLink.style.display = (u.role === 'ADMIN') ? 'inline-block' : 'none';
 
-        document.getElementById('user-balance').textContent = u.balance != null ? u.balance.toFixed(2) : '0.00';
+        document.getElementById('user-balance').textContent = window.formatMoney(u.balance);
     }
 
+    var BOOKINGS_PAGE_SIZE = 10;
+    var bookingsPager = null;
+
     function loadBookings() {
-        window.api.fetchMyBookings()
-            .then(function (data) {
+        if (!bookingsPager) {
+            bookingsPager = window.createCursorPager({ prev: 'prev-bookings', next: 'next-bookings', info: 'bookings-page-info' }, loadBookingsPage, showBookingsError);
+        }
+        bookingsPager.reset();
+    }
+
+    function showBookingsError() {
+        var empty = document.getElementById('bookings-empty');
+        if (empty) {
+            empty.textContent = 'Error loading bookings. Please try again later.';
+            empty.style.display = 'block';
+            empty.style.color = '#ef4444';
+        }
+    }
+
+    function loadBookingsPage(cursor) {
+        return window.api.fetchMyBookings({ limit: BOOKINGS_PAGE_SIZE, cursor: cursor })
+            .then(function (page) {
+                var data = page.items;
                 var list = document.getElementById('bookings-list');
                 var empty = document.getElementById('bookings-empty');
-                if (!list) return;
+                if (!list) return page;
                 list.innerHTML = '';
                 if (!Array.isArray(data) || data.length === 0) {
                     empty.textContent = 'You have no bookings yet.';
                     empty.style.display = 'block';
-                    return;
+                    return page;
                 }
                 empty.style.display = 'none';
                 data.forEach(function (ticket) {
@@ -115,19 +137,14 @@
                         if (!id) return;
                         if (!confirm('Cancel this booking?')) return;
                         window.api.cancelBooking(id)
-                            .then(function () { load(); })
+                            .then(function () {
+                                loadProfile();
+                                bookingsPager.reload();
+                            })
                             .catch(function (err) { showError((err && err.message) || 'Cancel failed'); });
                     };
                 });
-            })
-            .catch(function (err) {
-
-                var empty = document.getElementById('bookings-empty');
-                if (empty) {
-                    empty.textContent = 'Error loading bookings. Please try again later.';
-                    empty.style.display = 'block';
-                    empty.style.color = '#ef4444';
-                }
+                return page;
             });
     }
 
@@ -151,6 +168,7 @@
                         <div class="card-body">
                             <div class="card-title" style="color: var(--accent)">Rating: ${r.rating}/10</div>
                             <div class="card-meta" style="margin-bottom: 0.5rem; color: var(--text-dim); font-weight: 600;">Movie: ${r.movie_title || 'Unknown Movie'}</div>
+                            ${r.status && r.status !== 'PUBLISHED' ? `<div class="card-meta" style="color: var(--text-dim);">Status: ${r.status}${r.moderation_reason ? ' (' + r.moderation_reason + ')' : ''}</div>` : ''}
                             <p class="card-meta" style="margin-top: 0.5rem; color: white;">"${r.comment}"</p>
                             <div style="margin-top: 1rem; border-top: 1px solid #333; padding-top: 0.75rem; display: flex; gap: 0.75rem;">
                                 <button type="button" class="btn btn-ghost edit-review-btn" 
@@ -152,7 +152,7 @@
 
       var meta = document.createElement('div');
       meta.className = 'card-meta';
-      meta.textContent = 'Price: ' + (s.price != null ? s.price : 0) + ' ₸';
+      meta.textContent = 'Price: ' + window.formatMoney(s.price);
 
       var badges = document.createElement('div');
       badges.className = 'badge-row';
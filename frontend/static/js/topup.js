@@ -102,7 +102,7 @@
             e.preventDefault();
             var payload = {
                 payment_card_id: this.card_id.value,
-                amount: parseFloat(this.amount.value)
+                amount: window.toMinorUnits(this.amount.value)
             };
             window.api.topUpBalance(payload)
                 .then(function () {
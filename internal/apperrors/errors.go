@@ -0,0 +1,188 @@
+package apperrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindValidation
+	KindNotFound
+	KindConflict
+	KindUnauthorized
+	KindForbidden
+	KindInsufficientFunds
+	KindUnavailable
+	KindNotImplemented
+)
+
+var kindStatus = map[Kind]int{
+	KindInternal:          http.StatusInternalServerError,
+	KindValidation:        http.StatusBadRequest,
+	KindNotFound:          http.StatusNotFound,
+	KindConflict:          http.StatusConflict,
+	KindUnauthorized:      http.StatusUnauthorized,
+	KindForbidden:         http.StatusForbidden,
+	KindInsufficientFunds: http.StatusPaymentRequired,
+	KindUnavailable:       http.StatusServiceUnavailable,
+	KindNotImplemented:    http.StatusNotImplemented,
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Param   string `json:"-"`
+}
+
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Params  map[string]interface{}
+	Fields  []FieldError
+	detail  string
+	cause   error
+}
+
+func newError(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func Validation(code, message string, fields ...FieldError) *Error {
+	err := newError(KindValidation, code, message)
+	err.Fields = fields
+	return err
+}
+
+func NotFound(code, message string) *Error {
+	return newError(KindNotFound, code, message)
+}
+
+func Conflict(code, message string) *Error {
+	return newError(KindConflict, code, message)
+}
+
+func Unauthorized(code, message string) *Error {
+	return newError(KindUnauthorized, code, message)
+}
+
+func Forbidden(code, message string) *Error {
+	return newError(KindForbidden, code, message)
+}
+
+func InsufficientFunds(code, message string) *Error {
+	return newError(KindInsufficientFunds, code, message)
+}
+
+func Unavailable(code, message string) *Error {
+	return newError(KindUnavailable, code, message)
+}
+
+func NotImplemented(code, message string) *Error {
+	return newError(KindNotImplemented, code, message)
+}
+
+func Internal(cause error) *Error {
+	err := newError(KindInternal, "internal_error", "internal server error")
+	err.cause = cause
+	return err
+}
+
+func (e *Error) clone() *Error {
+	c := *e
+	return &c
+}
+
+func (e *Error) With(key string, value interface{}) *Error {
+	c := e.clone()
+	c.Params = make(map[string]interface{}, len(e.Params)+1)
+	for k, v := range e.Params {
+		c.Params[k] = v
+	}
+	c.Params[key] = value
+	return c
+}
+
+func (e *Error) WithFields(fields ...FieldError) *Error {
+	c := e.clone()
+	c.Fields = append(append([]FieldError(nil), e.Fields...), fields...)
+	return c
+}
+
+func (e *Error) Wrap(cause error) *Error {
+	c := e.clone()
+	c.cause = cause
+	return c
+}
+
+func (e *Error) Error() string {
+	message := interpolate(e.Message, e.Params)
+	if e.detail != "" {
+		message += ": " + e.detail
+	}
+	if e.Kind == KindInternal && e.cause != nil {
+		message += ": " + e.cause.Error()
+	}
+	return message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+func (e *Error) Status() int {
+	return kindStatus[e.Kind]
+}
+
+func interpolate(message string, params map[string]interface{}) string {
+	for key, value := range params {
+		message = strings.ReplaceAll(message, "{"+key+"}", fmt.Sprint(value))
+	}
+	return message
+}
+
+func From(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		if appErr == err {
+			return appErr
+		}
+		c := appErr.clone()
+		c.detail = strings.TrimPrefix(strings.TrimPrefix(err.Error(), appErr.Error()), ": ")
+		if c.detail == err.Error() {
+			c.detail = ""
+		}
+		return c
+	}
+
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return NotFound("not_found", "resource not found").Wrap(err)
+	case errors.Is(err, context.DeadlineExceeded), mongo.IsTimeout(err), mongo.IsNetworkError(err), isServerSelection(err):
+		return Unavailable("service_unavailable", "service temporarily unavailable").Wrap(err)
+	default:
+		return Internal(err)
+	}
+}
+
+func isServerSelection(err error) bool {
+	return strings.Contains(err.Error(), "server selection error")
+}
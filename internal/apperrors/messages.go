@@ -0,0 +1,358 @@
+package apperrors
+
+var catalog = map[string]map[string]string{
+	"en": {
+		"internal_error":      "internal server error",
+		"service_unavailable": "service temporarily unavailable",
+		"service_starting":    "service is starting, try again shortly",
+		"not_found":           "resource not found",
+		"invalid_request":     "invalid request",
+		"invalid_id":          "invalid {field}",
+		"invalid_query":       "invalid query",
+
+		"authentication_required":      "authentication required",
+		"invalid_token":                "invalid or expired token",
+		"invalid_authorization_header": "invalid authorization header format",
+		"admin_required":               "admin access required",
+		"staff_required":               "staff access required",
+
+		"user_not_found":             "user not found",
+		"movie_not_found":            "movie not found",
+		"genre_not_found":            "genre not found",
+		"session_not_found":          "session not found",
+		"hall_not_found":             "hall not found",
+		"ticket_not_found":           "ticket not found",
+		"payment_not_found":          "payment not found",
+		"payment_card_not_found":     "payment card not found",
+		"review_not_found":           "review not found",
+		"notification_not_found":     "notification not found",
+		"webhook_not_found":          "webhook subscription not found",
+		"webhook_delivery_not_found": "webhook delivery not found",
+		"wallet_pass_not_found":      "pass not found",
+		"exchange_rate_not_found":    "no exchange rate from {from} to {to}",
+		"review_vote_not_found":      "vote not found",
+		"review_reply_not_found":     "review has no reply",
+
+		"no_seats_selected":          "no seats selected",
+		"invalid_seat":               "invalid seat position: row {row}, seat {seat}",
+		"invalid_ticket_type":        "invalid ticket type: {type}",
+		"kid_ticket_not_allowed":     "kids tickets are not allowed for 18+ movies",
+		"invalid_rating":             "rating must be between 0 and 10",
+		"movie_id_required":          "movie ID is required",
+		"hall_id_required":           "hall ID is required",
+		"session_in_past":            "cannot schedule sessions in the past",
+		"invalid_movie_query":        "invalid movie query",
+		"invalid_analytics_query":    "invalid analytics query",
+		"invalid_export_option":      "invalid export option {option}: {value}",
+		"unknown_export_column":      "unknown export column: {column}",
+		"unsupported_export_report":  "unsupported export report: {report}",
+		"invalid_webhook_url":        "webhook URL must be an absolute http or https URL",
+		"unsupported_event_type":     "unsupported event type: {type}",
+		"unsupported_qr_format":      "unsupported QR format: {format}",
+		"invalid_ticket_token":       "invalid ticket token",
+		"unsupported_language":       "unsupported language: {lang}",
+		"unsupported_currency":       "unsupported currency: {currency}",
+		"invalid_price":              "price must not be negative",
+		"same_currency":              "exchange rate currencies must differ",
+		"review_too_short":           "review must be at least {min} characters",
+		"review_too_long":            "review must be at most {max} characters",
+		"moderation_reason_required": "a reason is required to reject a review",
+		"cannot_report_own_review":   "you cannot report your own review",
+		"cannot_vote_own_review":     "you cannot vote on your own review",
+		"invalid_exchange_rate":      "exchange rate must be a positive decimal number",
+		"malformed_amount":           "amount must be a decimal number",
+
+		"invalid_reminder_lead_time": "reminder lead time must be between 15 minutes and 24 hours",
+		"invalid_card_number":        "card number must be exactly 16 digits",
+		"invalid_expiry_date":        "expiry date must be a valid MM/YY date",
+		"card_expired":               "card has expired",
+		"invalid_cvv":                "CVV must be 3 or 4 digits",
+		"card_holder_required":       "card holder name is required",
+		"payment_code_required":      "code is required",
+		"invalid_amount":             "amount must be greater than 0",
+		"amount_limit_exceeded":      "amount exceeds maximum limit of {limit}",
+		"payment_card_required":      "payment card ID is required",
+		"invalid_document_kind":      "kind must be TICKET or RECEIPT",
+		"cinema_name_required":       "cinema name is required",
+		"invalid_accent_color":       "accent color must be in #RRGGBB format",
+		"invalid_vat_rate":           "VAT rate must be between 0 and 1",
+
+		"user_already_exists":        "user with this email already exists",
+		"email_in_use":               "email already in use",
+		"seat_unavailable":           "seat already booked: row {row}, seat {seat}",
+		"review_already_exists":      "user already reviewed this movie",
+		"ticket_already_cancelled":   "ticket already cancelled",
+		"ticket_already_used":        "used tickets cannot be cancelled",
+		"ticket_not_valid":           "ticket is {status}",
+		"hall_occupied":              "the selected hall is already occupied during this time period",
+		"payment_not_refundable":     "only completed payments can be refunded",
+		"notification_not_retryable": "only failed notifications can be retried",
+		"currency_mismatch":          "currency mismatch: {left} and {right}",
+		"balance_currency_mismatch":  "amount is in {currency} but your balance is in {balance}",
+		"hall_currency_unsupported":  "halls can only be priced in {currency} while balances are kept in {currency}",
+		"review_already_reported":    "you already reported this review",
+		"review_not_reportable":      "only published reviews can be reported",
+		"review_not_votable":         "only published reviews can be voted on",
+
+		"ticket_forbidden":         "unauthorized access to ticket",
+		"payment_forbidden":        "unauthorized access to payment",
+		"payment_card_forbidden":   "unauthorized access to payment card",
+		"review_forbidden":         "unauthorized access to review",
+		"verified_viewer_required": "only viewers with a ticket for this movie can review it",
+
+		"insufficient_balance": "insufficient balance: need {need}, have {have}",
+
+		"invalid_credentials": "invalid credentials",
+		"wallet_unauthorized": "invalid pass authentication token",
+
+		"apple_wallet_disabled":  "apple wallet is not configured",
+		"google_wallet_disabled": "google wallet is not configured",
+
+		"field.required": "is required",
+		"field.email":    "must be a valid email address",
+		"field.min":      "must be at least {param}",
+		"field.max":      "must be at most {param}",
+		"field.gte":      "must be greater than or equal to {param}",
+		"field.lte":      "must be less than or equal to {param}",
+		"field.gt":       "must be greater than {param}",
+		"field.oneof":    "must be one of: {param}",
+		"field.datetime": "must be a date and time in {param} format",
+		"field.invalid":  "is invalid",
+	},
+	"ru": {
+		"internal_error":      "внутренняя ошибка сервера",
+		"service_unavailable": "сервис временно недоступен",
+		"service_starting":    "сервис запускается, повторите попытку позже",
+		"not_found":           "ресурс не найден",
+		"invalid_request":     "некорректный запрос",
+		"invalid_id":          "некорректный {field}",
+		"invalid_query":       "некорректные параметры запроса",
+
+		"authentication_required":      "требуется авторизация",
+		"invalid_token":                "недействительный или просроченный токен",
+		"invalid_authorization_header": "некорректный формат заголовка Authorization",
+		"admin_required":               "требуются права администратора",
+		"staff_required":               "требуются права сотрудника",
+
+		"user_not_found":             "пользователь не найден",
+		"movie_not_found":            "фильм не найден",
+		"genre_not_found":            "жанр не найден",
+		"session_not_found":          "сеанс не найден",
+		"hall_not_found":             "зал не найден",
+		"ticket_not_found":           "билет не найден",
+		"payment_not_found":          "платёж не найден",
+		"payment_card_not_found":     "платёжная карта не найдена",
+		"review_not_found":           "отзыв не найден",
+		"notification_not_found":     "уведомление не найдено",
+		"webhook_not_found":          "подписка на вебхук не найдена",
+		"webhook_delivery_not_found": "доставка вебхука не найдена",
+		"wallet_pass_not_found":      "пропуск не найден",
+		"exchange_rate_not_found":    "нет курса обмена из {from} в {to}",
+		"review_vote_not_found":      "голос не найден",
+		"review_reply_not_found":     "у отзыва нет ответа",
+
+		"no_seats_selected":          "не выбрано ни одного места",
+		"invalid_seat":               "некорректное место: ряд {row}, место {seat}",
+		"invalid_ticket_type":        "некорректный тип билета: {type}",
+		"kid_ticket_not_allowed":     "детские билеты недоступны для фильмов 18+",
+		"invalid_rating":             "оценка должна быть от 0 до 10",
+		"movie_id_required":          "необходимо указать ID фильма",
+		"hall_id_required":           "необходимо указать ID зала",
+		"session_in_past":            "нельзя запланировать сеанс в прошлом",
+		"invalid_movie_query":        "некорректный запрос фильмов",
+		"invalid_analytics_query":    "некорректный запрос аналитики",
+		"invalid_export_option":      "некорректный параметр экспорта {option}: {value}",
+		"unknown_export_column":      "неизвестная колонка экспорта: {column}",
+		"unsupported_export_report":  "неподдерживаемый отчёт для экспорта: {report}",
+		"invalid_webhook_url":        "URL вебхука должен быть абсолютным http или https адресом",
+		"unsupported_event_type":     "неподдерживаемый тип события: {type}",
+		"unsupported_qr_format":      "неподдерживаемый формат QR: {format}",
+		"invalid_ticket_token":       "недействительный токен билета",
+		"unsupported_language":       "неподдерживаемый язык: {lang}",
+		"unsupported_currency":       "неподдерживаемая валюта: {currency}",
+		"invalid_price":              "цена не может быть отрицательной",
+		"same_currency":              "валюты курса обмена должны различаться",
+		"review_too_short":           "отзыв должен содержать не менее {min} символов",
+		"review_too_long":            "отзыв должен содержать не более {max} символов",
+		"moderation_reason_required": "для отклонения отзыва нужно указать причину",
+		"cannot_report_own_review":   "нельзя пожаловаться на собственный отзыв",
+		"cannot_vote_own_review":     "нельзя голосовать за собственный отзыв",
+		"invalid_exchange_rate":      "курс обмена должен быть положительным десятичным числом",
+		"malformed_amount":           "сумма должна быть десятичным числом",
+
+		"invalid_reminder_lead_time": "время напоминания должно быть от 15 минут до 24 часов",
+		"invalid_card_number":        "номер карты должен состоять ровно из 16 цифр",
+		"invalid_expiry_date":        "срок действия должен быть корректной датой в формате MM/YY",
+		"card_expired":               "срок действия карты истёк",
+		"invalid_cvv":                "CVV должен состоять из 3 или 4 цифр",
+		"card_holder_required":       "необходимо указать имя владельца карты",
+		"payment_code_required":      "необходимо указать код",
+		"invalid_amount":             "сумма должна быть больше 0",
+		"amount_limit_exceeded":      "сумма превышает лимит в {limit}",
+		"payment_card_required":      "необходимо указать ID платёжной карты",
+		"invalid_document_kind":      "тип должен быть TICKET или RECEIPT",
+		"cinema_name_required":       "необходимо указать название кинотеатра",
+		"invalid_accent_color":       "цвет должен быть в формате #RRGGBB",
+		"invalid_vat_rate":           "ставка НДС должна быть от 0 до 1",
+
+		"user_already_exists":        "пользователь с таким email уже существует",
+		"email_in_use":               "email уже используется",
+		"seat_unavailable":           "место уже занято: ряд {row}, место {seat}",
+		"review_already_exists":      "вы уже оставили отзыв на этот фильм",
+		"ticket_already_cancelled":   "билет уже отменён",
+		"ticket_already_used":        "использованные билеты нельзя отменить",
+		"ticket_not_valid":           "билет недействителен: {status}",
+		"hall_occupied":              "выбранный зал уже занят в это время",
+		"payment_not_refundable":     "вернуть можно только завершённые платежи",
+		"notification_not_retryable": "повторить можно только неудавшиеся уведомления",
+		"currency_mismatch":          "несовпадение валют: {left} и {right}",
+		"balance_currency_mismatch":  "сумма указана в {currency}, а ваш баланс в {balance}",
+		"hall_currency_unsupported":  "цены в залах можно указывать только в {currency}, пока балансы ведутся в {currency}",
+		"review_already_reported":    "вы уже пожаловались на этот отзыв",
+		"review_not_reportable":      "пожаловаться можно только на опубликованный отзыв",
+		"review_not_votable":         "голосовать можно только за опубликованные отзывы",
+
+		"ticket_forbidden":         "нет доступа к билету",
+		"payment_forbidden":        "нет доступа к платежу",
+		"payment_card_forbidden":   "нет доступа к платёжной карте",
+		"review_forbidden":         "нет доступа к отзыву",
+		"verified_viewer_required": "оставить отзыв могут только зрители с билетом на этот фильм",
+
+		"insufficient_balance": "недостаточно средств: нужно {need}, доступно {have}",
+
+		"invalid_credentials": "неверный email или пароль",
+		"wallet_unauthorized": "недействительный токен пропуска",
+
+		"apple_wallet_disabled":  "Apple Wallet не настроен",
+		"google_wallet_disabled": "Google Wallet не настроен",
+
+		"field.required": "обязательное поле",
+		"field.email":    "должен быть корректным email адресом",
+		"field.min":      "должно быть не меньше {param}",
+		"field.max":      "должно быть не больше {param}",
+		"field.gte":      "должно быть больше или равно {param}",
+		"field.lte":      "должно быть меньше или равно {param}",
+		"field.gt":       "должно быть больше {param}",
+		"field.oneof":    "должно быть одним из: {param}",
+		"field.datetime": "должно быть датой и временем в формате {param}",
+		"field.invalid":  "некорректное значение",
+	},
+	"kk": {
+		"internal_error":      "сервердің ішкі қатесі",
+		"service_unavailable": "қызмет уақытша қолжетімсіз",
+		"service_starting":    "қызмет іске қосылуда, кейінірек қайталаңыз",
+		"not_found":           "ресурс табылмады",
+		"invalid_request":     "сұраныс қате",
+		"invalid_id":          "{field} қате",
+		"invalid_query":       "сұраныс параметрлері қате",
+
+		"authentication_required":      "авторизация қажет",
+		"invalid_token":                "токен жарамсыз немесе мерзімі өткен",
+		"invalid_authorization_header": "Authorization тақырыбының форматы қате",
+		"admin_required":               "әкімші құқығы қажет",
+		"staff_required":               "қызметкер құқығы қажет",
+
+		"user_not_found":             "пайдаланушы табылмады",
+		"movie_not_found":            "фильм табылмады",
+		"genre_not_found":            "жанр табылмады",
+		"session_not_found":          "сеанс табылмады",
+		"hall_not_found":             "зал табылмады",
+		"ticket_not_found":           "билет табылмады",
+		"payment_not_found":          "төлем табылмады",
+		"payment_card_not_found":     "төлем картасы табылмады",
+		"review_not_found":           "пікір табылмады",
+		"notification_not_found":     "хабарландыру табылмады",
+		"webhook_not_found":          "вебхук жазылымы табылмады",
+		"webhook_delivery_not_found": "вебхук жеткізілімі табылмады",
+		"wallet_pass_not_found":      "өткізу билеті табылмады",
+		"exchange_rate_not_found":    "{from} валютасынан {to} валютасына айырбастау бағамы жоқ",
+		"review_vote_not_found":      "дауыс табылмады",
+		"review_reply_not_found":     "пікірге жауап жоқ",
+
+		"no_seats_selected":          "бірде-бір орын таңдалмаған",
+		"invalid_seat":               "орын қате: {row}-қатар, {seat}-орын",
+		"invalid_ticket_type":        "билет түрі қате: {type}",
+		"kid_ticket_not_allowed":     "18+ фильмдерге балалар билеті сатылмайды",
+		"invalid_rating":             "баға 0 мен 10 аралығында болуы керек",
+		"movie_id_required":          "фильм ID-і көрсетілуі керек",
+		"hall_id_required":           "зал ID-і көрсетілуі керек",
+		"session_in_past":            "өткен уақытқа сеанс жоспарлауға болмайды",
+		"invalid_movie_query":        "фильмдер сұранысы қате",
+		"invalid_analytics_query":    "аналитика сұранысы қате",
+		"invalid_export_option":      "экспорт параметрі қате {option}: {value}",
+		"unknown_export_column":      "белгісіз экспорт бағаны: {column}",
+		"unsupported_export_report":  "экспортқа қолдау көрсетілмейтін есеп: {report}",
+		"invalid_webhook_url":        "вебхук URL-і толық http немесе https мекенжайы болуы керек",
+		"unsupported_event_type":     "оқиға түріне қолдау көрсетілмейді: {type}",
+		"unsupported_qr_format":      "QR форматына қолдау көрсетілмейді: {format}",
+		"invalid_ticket_token":       "билет токені жарамсыз",
+		"unsupported_language":       "тілге қолдау көрсетілмейді: {lang}",
+		"unsupported_currency":       "қолдау көрсетілмейтін валюта: {currency}",
+		"invalid_price":              "баға теріс болмауы керек",
+		"same_currency":              "айырбастау бағамының валюталары әртүрлі болуы керек",
+		"review_too_short":           "пікір кемінде {min} таңбадан тұруы керек",
+		"review_too_long":            "пікір {max} таңбадан аспауы керек",
+		"moderation_reason_required": "пікірді қабылдамау үшін себебін көрсету керек",
+		"cannot_report_own_review":   "өз пікіріңізге шағымдана алмайсыз",
+		"cannot_vote_own_review":     "өз пікіріңізге дауыс бере алмайсыз",
+		"invalid_exchange_rate":      "айырбастау бағамы оң ондық сан болуы керек",
+		"malformed_amount":           "сома ондық сан болуы керек",
+
+		"invalid_reminder_lead_time": "еске салу уақыты 15 минуттан 24 сағатқа дейін болуы керек",
+		"invalid_card_number":        "карта нөмірі дәл 16 цифрдан тұруы керек",
+		"invalid_expiry_date":        "жарамдылық мерзімі MM/YY форматындағы дұрыс күн болуы керек",
+		"card_expired":               "картаның мерзімі өтіп кеткен",
+		"invalid_cvv":                "CVV 3 немесе 4 цифрдан тұруы керек",
+		"card_holder_required":       "карта иесінің аты көрсетілуі керек",
+		"payment_code_required":      "код көрсетілуі керек",
+		"invalid_amount":             "сома 0-ден үлкен болуы керек",
+		"amount_limit_exceeded":      "сома {limit} шегінен асады",
+		"payment_card_required":      "төлем картасының ID-і көрсетілуі керек",
+		"invalid_document_kind":      "түрі TICKET немесе RECEIPT болуы керек",
+		"cinema_name_required":       "кинотеатр атауы көрсетілуі керек",
+		"invalid_accent_color":       "түс #RRGGBB форматында болуы керек",
+		"invalid_vat_rate":           "ҚҚС мөлшерлемесі 0 мен 1 аралығында болуы керек",
+
+		"user_already_exists":        "бұл email-мен пайдаланушы бар",
+		"email_in_use":               "email бос емес",
+		"seat_unavailable":           "орын бос емес: {row}-қатар, {seat}-орын",
+		"review_already_exists":      "сіз бұл фильмге пікір қалдырғансыз",
+		"ticket_already_cancelled":   "билет бұрын қайтарылған",
+		"ticket_already_used":        "пайдаланылған билетті қайтаруға болмайды",
+		"ticket_not_valid":           "билет жарамсыз: {status}",
+		"hall_occupied":              "таңдалған зал бұл уақытта бос емес",
+		"payment_not_refundable":     "тек аяқталған төлемдерді қайтаруға болады",
+		"notification_not_retryable": "тек сәтсіз хабарландыруларды қайталауға болады",
+		"currency_mismatch":          "валюталар сәйкес келмейді: {left} және {right}",
+		"balance_currency_mismatch":  "сома {currency} валютасында, ал балансыңыз {balance} валютасында",
+		"hall_currency_unsupported":  "баланстар {currency} валютасында жүргізілгенше, зал бағалары тек {currency} валютасында көрсетіледі",
+		"review_already_reported":    "сіз бұл пікірге шағымданып қойғансыз",
+		"review_not_reportable":      "тек жарияланған пікірге шағымдануға болады",
+		"review_not_votable":         "тек жарияланған пікірлерге дауыс беруге болады",
+
+		"ticket_forbidden":         "билетке қолжетімділік жоқ",
+		"payment_forbidden":        "төлемге қолжетімділік жоқ",
+		"payment_card_forbidden":   "төлем картасына қолжетімділік жоқ",
+		"review_forbidden":         "пікірге қолжетімділік жоқ",
+		"verified_viewer_required": "бұл фильмге тек билеті бар көрермендер пікір қалдыра алады",
+
+		"insufficient_balance": "қаражат жеткіліксіз: {need} қажет, {have} бар",
+
+		"invalid_credentials": "email немесе құпиясөз қате",
+		"wallet_unauthorized": "өткізу билетінің токені жарамсыз",
+
+		"apple_wallet_disabled":  "Apple Wallet бапталмаған",
+		"google_wallet_disabled": "Google Wallet бапталмаған",
+
+		"field.required": "міндетті өріс",
+		"field.email":    "дұрыс email мекенжайы болуы керек",
+		"field.min":      "кемінде {param} болуы керек",
+		"field.max":      "көп дегенде {param} болуы керек",
+		"field.gte":      "{param} мәнінен кем болмауы керек",
+		"field.lte":      "{param} мәнінен аспауы керек",
+		"field.gt":       "{param} мәнінен үлкен болуы керек",
+		"field.oneof":    "мына мәндердің бірі болуы керек: {param}",
+		"field.datetime": "{param} форматындағы күн мен уақыт болуы керек",
+		"field.invalid":  "мәні қате",
+	},
+}
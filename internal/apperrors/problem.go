@@ -0,0 +1,60 @@
+package apperrors
+
+import (
+	"cinema-system/internal/i18n"
+	"net/http"
+)
+
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail"`
+	Code     string       `json:"code"`
+	Instance string       `json:"instance,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+func NewProblem(err *Error, lang, instance string) *Problem {
+	status := err.Status()
+	problem := &Problem{
+		Type:     "urn:cinema-system:error:" + err.Code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   Localize(err, lang),
+		Code:     err.Code,
+		Instance: instance,
+	}
+	for _, f := range err.Fields {
+		if f.Message == "" {
+			f.Message = localizeField(f, lang)
+		}
+		problem.Errors = append(problem.Errors, f)
+	}
+	return problem
+}
+
+func Localize(err *Error, lang string) string {
+	message := err.Message
+	if translated, ok := catalog[lang][err.Code]; ok {
+		message = translated
+	}
+	message = interpolate(message, err.Params)
+	if err.detail != "" {
+		message += ": " + err.detail
+	}
+	return message
+}
+
+func localizeField(f FieldError, lang string) string {
+	message, ok := catalog[lang]["field."+f.Code]
+	if !ok {
+		if message, ok = catalog[i18n.Default]["field."+f.Code]; !ok {
+			message = catalog[lang]["field.invalid"]
+			if message == "" {
+				message = catalog[i18n.Default]["field.invalid"]
+			}
+		}
+	}
+	return interpolate(message, map[string]interface{}{"param": f.Param})
+}
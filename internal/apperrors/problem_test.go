@@ -0,0 +1,83 @@
+package apperrors
+
+import (
+	"cinema-system/internal/i18n"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestFrom(t *testing.T) {
+	base := Conflict("seat_unavailable", "seat already booked: row {row}, seat {seat}")
+
+	tests := []struct {
+		name   string
+		err    error
+		code   string
+		status int
+		text   string
+	}{
+		{"typed", base.With("row", 1).With("seat", 2), "seat_unavailable", http.StatusConflict, "seat already booked: row 1, seat 2"},
+		{"wrapped", fmt.Errorf("%w: extra context", base.With("row", 1).With("seat", 2)), "seat_unavailable", http.StatusConflict, "seat already booked: row 1, seat 2: extra context"},
+		{"no documents", mongo.ErrNoDocuments, "not_found", http.StatusNotFound, "resource not found"},
+		{"unknown", errors.New("boom"), "internal_error", http.StatusInternalServerError, "internal server error: boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := From(tt.err)
+			if err.Code != tt.code || err.Status() != tt.status || err.Error() != tt.text {
+				t.Fatalf("From = (%q, %d, %q), want (%q, %d, %q)", err.Code, err.Status(), err.Error(), tt.code, tt.status, tt.text)
+			}
+		})
+	}
+}
+
+func TestNewProblem(t *testing.T) {
+	err := InsufficientFunds("insufficient_balance", "insufficient balance: need {need}, have {have}").
+		With("need", "$20.00").With("have", "$5.00")
+
+	problem := NewProblem(err, "ru", "/api/sessions/1/book")
+	if problem.Status != http.StatusPaymentRequired || problem.Code != "insufficient_balance" {
+		t.Fatalf("problem = %+v", problem)
+	}
+	if want := "недостаточно средств: нужно $20.00, доступно $5.00"; problem.Detail != want {
+		t.Fatalf("Detail = %q, want %q", problem.Detail, want)
+	}
+	if problem.Type != "urn:cinema-system:error:insufficient_balance" || problem.Instance != "/api/sessions/1/book" {
+		t.Fatalf("problem = %+v", problem)
+	}
+
+	validation := Validation("invalid_request", "invalid request",
+		FieldError{Field: "email", Code: "email"},
+		FieldError{Field: "password", Code: "min", Param: "6"},
+		FieldError{Field: "phone_number", Code: "e164"},
+	)
+	problem = NewProblem(validation, "en", "")
+	want := []string{"must be a valid email address", "must be at least 6", "is invalid"}
+	if len(problem.Errors) != len(want) {
+		t.Fatalf("Errors = %+v", problem.Errors)
+	}
+	for i, f := range problem.Errors {
+		if f.Message != want[i] {
+			t.Errorf("Errors[%d].Message = %q, want %q", i, f.Message, want[i])
+		}
+	}
+}
+
+func TestCatalogComplete(t *testing.T) {
+	for _, lang := range i18n.Supported {
+		messages, ok := catalog[lang]
+		if !ok {
+			t.Errorf("%s: missing catalog", lang)
+			continue
+		}
+		for code := range catalog[i18n.Default] {
+			if _, ok := messages[code]; !ok {
+				t.Errorf("%s: missing translation for %q", lang, code)
+			}
+		}
+	}
+}
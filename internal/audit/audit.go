@@ -0,0 +1,138 @@
+package audit
+
+import (
+	"bytes"
+	"cinema-system/internal/models"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var redactedFields = map[string]bool{
+	"secret":   true,
+	"password": true,
+	"token":    true,
+	"cvv":      true,
+}
+
+type Actor struct {
+	UserID    primitive.ObjectID
+	Role      string
+	IP        string
+	RequestID string
+}
+
+type contextKey struct{}
+
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, contextKey{}, actor)
+}
+
+func ActorFrom(ctx context.Context) Actor {
+	actor, _ := ctx.Value(contextKey{}).(Actor)
+	return actor
+}
+
+func Diff(before, after interface{}) ([]models.AuditChange, error) {
+	old, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(old)+len(updated))
+	for key := range old {
+		keys = append(keys, key)
+	}
+	for key := range updated {
+		if _, ok := old[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []models.AuditChange
+	for _, key := range keys {
+		if bytes.Equal(old[key], updated[key]) {
+			continue
+		}
+		changes = append(changes, models.AuditChange{Field: key, Before: old[key], After: updated[key]})
+	}
+	return changes, nil
+}
+
+func fields(value interface{}) (map[string]json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	for key, field := range out {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, field); err != nil {
+			return nil, err
+		}
+		out[key] = compact.Bytes()
+		if redactedFields[key] {
+			sum := sha256.Sum256(compact.Bytes())
+			out[key], _ = json.Marshal("redacted:" + hex.EncodeToString(sum[:6]))
+		}
+	}
+	return out, nil
+}
+
+type hashed struct {
+	Seq        int64                `json:"seq"`
+	PrevHash   string               `json:"prev_hash"`
+	ActorID    string               `json:"actor_id"`
+	ActorRole  string               `json:"actor_role"`
+	Action     string               `json:"action"`
+	EntityType string               `json:"entity_type"`
+	EntityID   string               `json:"entity_id"`
+	Reference  string               `json:"reference"`
+	Method     string               `json:"method"`
+	Path       string               `json:"path"`
+	Status     int                  `json:"status"`
+	Changes    []models.AuditChange `json:"changes"`
+	IP         string               `json:"ip"`
+	RequestID  string               `json:"request_id"`
+	CreatedAt  string               `json:"created_at"`
+}
+
+func Hash(key []byte, entry *models.AuditEntry) string {
+	raw, _ := json.Marshal(hashed{
+		Seq:        entry.Seq,
+		PrevHash:   entry.PrevHash,
+		ActorID:    entry.ActorID.Hex(),
+		ActorRole:  entry.ActorRole,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		Reference:  entry.Reference,
+		Method:     entry.Method,
+		Path:       entry.Path,
+		Status:     entry.Status,
+		Changes:    entry.Changes,
+		IP:         entry.IP,
+		RequestID:  entry.RequestID,
+		CreatedAt:  entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+	mac := hmac.New(sha256.New, key)
+	mac.Write(raw)
+	return hex.EncodeToString(mac.Sum(nil))
+}
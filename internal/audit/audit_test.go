@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"cinema-system/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+type document struct {
+	Name   string `json:"name"`
+	Rows   int    `json:"rows"`
+	Secret string `json:"secret,omitempty"`
+}
+
+func TestDiff(t *testing.T) {
+	changes, err := Diff(
+		document{Name: "Hall 1", Rows: 5, Secret: "old"},
+		document{Name: "Hall 1", Rows: 8, Secret: "new"},
+	)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Field != "rows" || changes[1].Field != "secret" {
+		t.Fatalf("changes = %+v", changes)
+	}
+	if string(changes[0].Before) != "5" || string(changes[0].After) != "8" {
+		t.Errorf("rows change = %s -> %s", changes[0].Before, changes[0].After)
+	}
+	if strings.Contains(string(changes[1].Before), "old") || strings.Contains(string(changes[1].After), "new") {
+		t.Errorf("secret was not redacted: %s -> %s", changes[1].Before, changes[1].After)
+	}
+
+	created, err := Diff(nil, document{Name: "Hall 2"})
+	if err != nil || len(created) != 2 || created[0].Before != nil {
+		t.Errorf("create diff = %+v, %v", created, err)
+	}
+}
+
+func TestHashIsKeyed(t *testing.T) {
+	entry := &models.AuditEntry{Seq: 1, Action: "balance.top_up", EntityType: "users", CreatedAt: time.Unix(1700000000, 0)}
+
+	hash := Hash([]byte("key-one"), entry)
+	if hash != Hash([]byte("key-one"), entry) {
+		t.Fatal("hash is not deterministic")
+	}
+	if hash == Hash([]byte("key-two"), entry) {
+		t.Fatal("hash does not depend on the key")
+	}
+	entry.Action = "balance.booking"
+	if hash == Hash([]byte("key-one"), entry) {
+		t.Fatal("hash does not depend on the entry")
+	}
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type AnalyticsConfig struct {
+	Timezone string `config:"timezone" env:"ANALYTICS_TIMEZONE"`
+}
+
+func DefaultAnalyticsConfig() *AnalyticsConfig {
+	return &AnalyticsConfig{Timezone: "+05:00"}
+}
+
+func (c AnalyticsConfig) Location() *time.Location {
+	loc, err := parseTimezone(c.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func (c AnalyticsConfig) validate() error {
+	if _, err := parseTimezone(c.Timezone); err != nil {
+		return fmt.Errorf("analytics.timezone (ANALYTICS_TIMEZONE) must be a UTC offset such as +05:00 or an IANA zone such as Asia/Almaty")
+	}
+	return nil
+}
+
+func parseTimezone(value string) (*time.Location, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "Local" {
+		return nil, fmt.Errorf("invalid timezone %q", value)
+	}
+	if value[0] == '+' || value[0] == '-' {
+		offset, err := time.Parse("-07:00", value)
+		if err != nil {
+			return nil, err
+		}
+		_, seconds := offset.Zone()
+		return time.FixedZone(value, seconds), nil
+	}
+	return time.LoadLocation(value)
+}
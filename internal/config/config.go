@@ -0,0 +1,272 @@
+package config
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+
+	minSecretLength = 32
+)
+
+type Config struct {
+	Env           string             `config:"env" env:"APP_ENV" flag:"env" usage:"runtime environment: development or production"`
+	Server        ServerConfig       `config:"server"`
+	Database      DatabaseConfig     `config:"database"`
+	Log           LogConfig          `config:"log"`
+	Auth          AuthConfig         `config:"auth"`
+	Tracing       TracingConfig      `config:"tracing"`
+	Notifications NotificationConfig `config:"notifications"`
+	Moderation    ModerationConfig   `config:"moderation"`
+	Rating        RatingConfig       `config:"rating"`
+	Wallet        WalletSettings     `config:"wallet"`
+	Analytics     AnalyticsConfig    `config:"analytics"`
+
+	warnings []string
+}
+
+type LogConfig struct {
+	Level  string `config:"level" env:"LOG_LEVEL" flag:"log-level" usage:"log level: debug, info, warn or error"`
+	Format string `config:"format" env:"LOG_FORMAT" flag:"log-format" usage:"log format: json or text"`
+}
+
+type AuthConfig struct {
+	JWTSecret           string        `config:"jwt_secret" env:"JWT_SECRET" secret:"true"`
+	TicketSigningSecret string        `config:"ticket_signing_secret" env:"TICKET_SIGNING_SECRET" secret:"true"`
+	AuditSecret         string        `config:"audit_secret" env:"AUDIT_SECRET" secret:"true"`
+	TokenTTL            time.Duration `config:"token_ttl" env:"JWT_TOKEN_TTL"`
+}
+
+type TracingConfig struct {
+	Exporter     string `config:"exporter" env:"OTEL_TRACES_EXPORTER"`
+	OTLPEndpoint string `config:"otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	File         string `config:"file" env:"OTEL_TRACES_FILE"`
+	ServiceName  string `config:"service_name" env:"OTEL_SERVICE_NAME"`
+}
+
+type SMTPSettings struct {
+	Host     string `config:"host" env:"SMTP_HOST"`
+	Port     int    `config:"port" env:"SMTP_PORT"`
+	Username string `config:"username" env:"SMTP_USERNAME"`
+	Password string `config:"password" env:"SMTP_PASSWORD" secret:"true"`
+	From     string `config:"from" env:"SMTP_FROM"`
+}
+
+type SMSSettings struct {
+	URL    string `config:"url" env:"SMS_API_URL"`
+	APIKey string `config:"api_key" env:"SMS_API_KEY" secret:"true"`
+	Sender string `config:"sender" env:"SMS_SENDER"`
+}
+
+type NotificationConfig struct {
+	LogFile string       `config:"log_file" env:"NOTIFICATION_LOG_FILE"`
+	SMTP    SMTPSettings `config:"smtp"`
+	SMS     SMSSettings  `config:"sms"`
+}
+
+func Default() *Config {
+	return &Config{
+		Env:      EnvDevelopment,
+		Server:   *DefaultServerConfig(),
+		Database: DatabaseConfig{URI: "mongodb://localhost:27017", Name: "cinema_db"},
+		Log:      LogConfig{Level: "info", Format: "json"},
+		Auth:     AuthConfig{TokenTTL: 72 * time.Hour},
+		Tracing:  TracingConfig{Exporter: "none", ServiceName: "cinema-system"},
+		Notifications: NotificationConfig{
+			SMTP: SMTPSettings{Port: 587},
+		},
+		Moderation: *DefaultModerationConfig(),
+		Rating:     *DefaultRatingConfig(),
+		Wallet:     DefaultWalletSettings(),
+		Analytics:  *DefaultAnalyticsConfig(),
+	}
+}
+
+func Load(args []string) (*Config, []string, error) {
+	cfg := Default()
+	fields := fieldsOf(reflect.ValueOf(cfg).Elem(), "")
+
+	flags := flag.NewFlagSet("cinema-system", flag.ContinueOnError)
+	configFile := flags.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or TOML config file (CONFIG_FILE)")
+	var flagValues []flagValue
+	for _, f := range fields {
+		if f.flag == "" {
+			continue
+		}
+		value := flagValue{field: f, raw: new(string)}
+		flags.Var(value, f.flag, fmt.Sprintf("%s (%s)", f.usage, f.env))
+		flagValues = append(flagValues, value)
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if *configFile != "" {
+		if err := applyFile(*configFile, fields); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := applyEnv(fields); err != nil {
+		return nil, nil, err
+	}
+
+	set := map[string]bool{}
+	flags.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for _, value := range flagValues {
+		if !set[value.field.flag] {
+			continue
+		}
+		if err := value.field.set(*value.raw); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	cfg.normalize()
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return cfg, flags.Args(), nil
+}
+
+func (c *Config) normalize() {
+	c.Env = strings.ToLower(strings.TrimSpace(c.Env))
+	c.Log.Level = strings.ToLower(c.Log.Level)
+	c.Log.Format = strings.ToLower(c.Log.Format)
+	c.Tracing.Exporter = strings.ToLower(c.Tracing.Exporter)
+	if c.Tracing.Exporter == "none" && c.Tracing.OTLPEndpoint != "" {
+		c.Tracing.Exporter = "otlp"
+	}
+	c.Moderation.normalize()
+
+	if c.Auth.JWTSecret == "" && c.Env != EnvProduction {
+		raw := make([]byte, minSecretLength)
+		rand.Read(raw)
+		c.Auth.JWTSecret = hex.EncodeToString(raw)
+		c.warnings = append(c.warnings, "JWT_SECRET is not set; using a random secret, tokens will not survive a restart")
+	}
+	if c.Auth.TicketSigningSecret == "" {
+		c.Auth.TicketSigningSecret = c.Auth.JWTSecret
+	}
+	if c.Auth.AuditSecret == "" {
+		c.Auth.AuditSecret = c.Auth.JWTSecret
+	}
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Env != EnvDevelopment && c.Env != EnvProduction {
+		errs = append(errs, fmt.Errorf("env (APP_ENV) must be %q or %q", EnvDevelopment, EnvProduction))
+	}
+	errs = append(errs,
+		c.Server.validate(),
+		c.Database.validate(),
+		c.Log.validate(),
+		c.Auth.validate(c.Env == EnvProduction),
+		c.Tracing.validate(),
+		c.Notifications.validate(),
+		c.Moderation.validate(),
+		c.Rating.validate(),
+		c.Wallet.validate(),
+		c.Analytics.validate(),
+	)
+	return errors.Join(errs...)
+}
+
+func (c *Config) Production() bool {
+	return c.Env == EnvProduction
+}
+
+func (c *Config) Warnings() []string {
+	return c.warnings
+}
+
+func (c *Config) Redacted() map[string]any {
+	out := map[string]any{}
+	for _, f := range fieldsOf(reflect.ValueOf(c).Elem(), "") {
+		parts := strings.Split(f.path, ".")
+		node := out
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := node[part].(map[string]any)
+			if !ok {
+				child = map[string]any{}
+				node[part] = child
+			}
+			node = child
+		}
+		node[parts[len(parts)-1]] = f.display()
+	}
+	return out
+}
+
+func (c *Config) Print(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c.Redacted()); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func (c LogConfig) validate() error {
+	var errs []error
+	switch c.Level {
+	case "debug", "info", "warn", "warning", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log.level (LOG_LEVEL) must be debug, info, warn or error"))
+	}
+	if c.Format != "json" && c.Format != "text" {
+		errs = append(errs, fmt.Errorf("log.format (LOG_FORMAT) must be json or text"))
+	}
+	return errors.Join(errs...)
+}
+
+func (c AuthConfig) validate(production bool) error {
+	var errs []error
+	if c.TokenTTL <= 0 {
+		errs = append(errs, fmt.Errorf("auth.token_ttl (JWT_TOKEN_TTL) must be positive"))
+	}
+	if !production {
+		return errors.Join(errs...)
+	}
+	if c.JWTSecret == "" {
+		errs = append(errs, fmt.Errorf("auth.jwt_secret (JWT_SECRET) is required in production"))
+	} else if len(c.JWTSecret) < minSecretLength || c.JWTSecret == "default-secret" {
+		errs = append(errs, fmt.Errorf("auth.jwt_secret (JWT_SECRET) must be at least %d characters in production", minSecretLength))
+	}
+	if c.TicketSigningSecret != "" && len(c.TicketSigningSecret) < minSecretLength {
+		errs = append(errs, fmt.Errorf("auth.ticket_signing_secret (TICKET_SIGNING_SECRET) must be at least %d characters in production", minSecretLength))
+	}
+	if c.AuditSecret != "" && len(c.AuditSecret) < minSecretLength {
+		errs = append(errs, fmt.Errorf("auth.audit_secret (AUDIT_SECRET) must be at least %d characters in production", minSecretLength))
+	}
+	return errors.Join(errs...)
+}
+
+func (c TracingConfig) validate() error {
+	switch c.Exporter {
+	case "none", "otlp", "stdout", "console", "file":
+		return nil
+	default:
+		return fmt.Errorf("tracing.exporter (OTEL_TRACES_EXPORTER) must be none, otlp, stdout or file")
+	}
+}
+
+func (c NotificationConfig) validate() error {
+	if c.SMTP.Host != "" && (c.SMTP.Port < 1 || c.SMTP.Port > 65535) {
+		return fmt.Errorf("notifications.smtp.port (SMTP_PORT) must be a valid port")
+	}
+	return nil
+}
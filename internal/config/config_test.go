@@ -0,0 +1,167 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func isolateEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("CONFIG_FILE", "")
+	for _, f := range fieldsOf(reflect.ValueOf(Default()).Elem(), "") {
+		if f.env != "" {
+			t.Setenv(f.env, "")
+		}
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	isolateEnv(t)
+
+	cfg, args, err := Load([]string{"migrate", "up"})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if strings.Join(args, " ") != "migrate up" {
+		t.Fatalf("args = %v, want migrate up", args)
+	}
+	if cfg.Env != EnvDevelopment || cfg.Server.Port != "8080" || cfg.Database.Name != "cinema_db" || cfg.Auth.TokenTTL != 72*time.Hour {
+		t.Fatalf("unexpected defaults: %+v", cfg)
+	}
+	if cfg.Auth.JWTSecret == "" || cfg.Auth.TicketSigningSecret != cfg.Auth.JWTSecret || len(cfg.Warnings()) != 1 {
+		t.Fatalf("development secret not generated: %+v, warnings %v", cfg.Auth, cfg.Warnings())
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	isolateEnv(t)
+	path := writeFile(t, "cinema.yaml", `
+server:
+  port: "9000"
+  write_timeout: 90s
+database:
+  name: from_file
+  auto_migrate: true
+log:
+  level: warn
+moderation:
+  banned_words: [Spam, " scam "]
+`)
+	t.Setenv("MONGO_DATABASE", "from_env")
+	t.Setenv("LOG_LEVEL", "error")
+
+	cfg, _, err := Load([]string{"-config", path, "-log-level", "debug"})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Server.Port != "9000" || cfg.Server.WriteTimeout != 90*time.Second || !cfg.Database.AutoMigrate {
+		t.Fatalf("file values not applied: %+v", cfg)
+	}
+	if cfg.Database.Name != "from_env" {
+		t.Fatalf("database.name = %q, want env to override file", cfg.Database.Name)
+	}
+	if cfg.Log.Level != "debug" {
+		t.Fatalf("log.level = %q, want flag to override env", cfg.Log.Level)
+	}
+	if strings.Join(cfg.Moderation.BannedWords, ",") != "spam,scam" {
+		t.Fatalf("banned words = %v", cfg.Moderation.BannedWords)
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	isolateEnv(t)
+	path := writeFile(t, "cinema.toml", `
+env = "production"
+
+[auth]
+jwt_secret = "0123456789abcdef0123456789abcdef"
+token_ttl = "12h"
+
+[rating]
+bayesian = true
+prior_mean = 7.5
+`)
+	t.Setenv("CONFIG_FILE", path)
+
+	cfg, _, err := Load(nil)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !cfg.Production() || cfg.Auth.TokenTTL != 12*time.Hour || !cfg.Rating.Bayesian || cfg.Rating.PriorMean != 7.5 {
+		t.Fatalf("toml values not applied: %+v", cfg)
+	}
+	if len(cfg.Warnings()) != 0 {
+		t.Fatalf("warnings = %v", cfg.Warnings())
+	}
+}
+
+func TestLoadRejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		env  map[string]string
+		want string
+	}{
+		{name: "unknown key", file: "server:\n  prot: 80\n", want: `unknown key "server.prot"`},
+		{name: "bad duration", env: map[string]string{"HTTP_READ_TIMEOUT": "soon"}, want: "server.read_timeout (HTTP_READ_TIMEOUT): invalid duration"},
+		{name: "missing production secret", env: map[string]string{"APP_ENV": "production"}, want: "JWT_SECRET) is required in production"},
+		{name: "weak production secret", env: map[string]string{"APP_ENV": "production", "JWT_SECRET": "default-secret"}, want: "must be at least 32 characters"},
+		{name: "bad port", env: map[string]string{"PORT": "http"}, want: "server.port (PORT)"},
+		{name: "bad rating", env: map[string]string{"RATING_PRIOR_MEAN": "11"}, want: "RATING_PRIOR_MEAN"},
+		{name: "bad analytics timezone", env: map[string]string{"ANALYTICS_TIMEZONE": "+5"}, want: "ANALYTICS_TIMEZONE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateEnv(t)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			var args []string
+			if tt.file != "" {
+				args = []string{"-config", writeFile(t, "cinema.yaml", tt.file)}
+			}
+			_, _, err := Load(args)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Load error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := Default()
+	cfg.Database.URI = "mongodb://cinema:hunter2@db:27017/?authSource=admin"
+	cfg.Auth.JWTSecret = "top-secret"
+	cfg.Notifications.SMTP.Password = "smtp-pass"
+
+	var out strings.Builder
+	if err := cfg.Print(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"hunter2", "top-secret", "smtp-pass"} {
+		if strings.Contains(out.String(), secret) {
+			t.Fatalf("printed config leaks %q:\n%s", secret, out.String())
+		}
+	}
+
+	redactedCfg := cfg.Redacted()
+	if got := redactedCfg["database"].(map[string]any)["uri"]; got != "mongodb://cinema:xxxxx@db:27017/?authSource=admin" {
+		t.Fatalf("database.uri = %v", got)
+	}
+	if got := redactedCfg["auth"].(map[string]any)["ticket_signing_secret"]; got != "" {
+		t.Fatalf("empty secret shown as %v", got)
+	}
+}
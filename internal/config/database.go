@@ -1,15 +1,36 @@
 package config
 
 import (
+	"cinema-system/internal/metrics"
+	"cinema-system/internal/tracing"
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
+type DatabaseConfig struct {
+	URI         string `config:"uri" env:"MONGO_URI" flag:"mongo-uri" usage:"MongoDB connection string" secret:"url"`
+	Name        string `config:"name" env:"MONGO_DATABASE" flag:"mongo-database" usage:"MongoDB database name"`
+	AutoMigrate bool   `config:"auto_migrate" env:"AUTO_MIGRATE" flag:"auto-migrate" usage:"apply pending migrations on startup"`
+}
+
+func (c DatabaseConfig) validate() error {
+	var errs []error
+	if c.URI == "" {
+		errs = append(errs, errors.New("database.uri (MONGO_URI) is required"))
+	}
+	if c.Name == "" {
+		errs = append(errs, errors.New("database.name (MONGO_DATABASE) is required"))
+	}
+	return errors.Join(errs...)
+}
+
 type Database struct {
 	Client   *mongo.Client
 	Database *mongo.Database
@@ -19,7 +40,7 @@ func NewDatabase(uri, dbName string) (*Database, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	clientOptions := options.Client().ApplyURI(uri)
+	clientOptions := options.Client().ApplyURI(uri).SetMonitor(tracing.MongoMonitor(metrics.MongoMonitor()))
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
@@ -30,7 +51,7 @@ func NewDatabase(uri, dbName string) (*Database, error) {
 		return nil, fmt.Errorf("failed to ping MongoDB: %w", err)
 	}
 
-	log.Println("Successfully connected to MongoDB!")
+	slog.Info("connected to MongoDB", "database", dbName)
 
 	return &Database{
 		Client:   client,
@@ -43,3 +64,7 @@ func (db *Database) Disconnect() error {
 	defer cancel()
 	return db.Client.Disconnect(ctx)
 }
+
+func (db *Database) Ping(ctx context.Context) error {
+	return db.Client.Ping(ctx, readpref.Primary())
+}
@@ -0,0 +1,262 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "[redacted]"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+type field struct {
+	path   string
+	env    string
+	flag   string
+	usage  string
+	secret string
+	value  reflect.Value
+}
+
+func fieldsOf(v reflect.Value, prefix string) []field {
+	var fields []field
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		key := sf.Tag.Get("config")
+		if key == "" || !sf.IsExported() {
+			continue
+		}
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if sf.Type.Kind() == reflect.Struct {
+			fields = append(fields, fieldsOf(v.Field(i), path)...)
+			continue
+		}
+		fields = append(fields, field{
+			path:   path,
+			env:    sf.Tag.Get("env"),
+			flag:   sf.Tag.Get("flag"),
+			usage:  sf.Tag.Get("usage"),
+			secret: sf.Tag.Get("secret"),
+			value:  v.Field(i),
+		})
+	}
+	return fields
+}
+
+func (f field) source() string {
+	if f.env != "" {
+		return fmt.Sprintf("%s (%s)", f.path, f.env)
+	}
+	return f.path
+}
+
+func (f field) set(raw string) error {
+	switch {
+	case f.value.Type() == durationType:
+		d, err := time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("%s: invalid duration %q", f.source(), raw)
+		}
+		f.value.SetInt(int64(d))
+	case f.value.Kind() == reflect.String:
+		f.value.SetString(raw)
+	case f.value.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("%s: invalid boolean %q", f.source(), raw)
+		}
+		f.value.SetBool(b)
+	case f.value.Kind() == reflect.Int:
+		n, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("%s: invalid integer %q", f.source(), raw)
+		}
+		f.value.SetInt(int64(n))
+	case f.value.Kind() == reflect.Float64:
+		n, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return fmt.Errorf("%s: invalid number %q", f.source(), raw)
+		}
+		f.value.SetFloat(n)
+	case f.value.Kind() == reflect.Slice && f.value.Type().Elem().Kind() == reflect.String:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		f.value.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("%s: unsupported field type %s", f.path, f.value.Type())
+	}
+	return nil
+}
+
+func (f field) assign(value any) error {
+	switch v := value.(type) {
+	case string:
+		return f.set(v)
+	case bool:
+		return f.set(strconv.FormatBool(v))
+	case int:
+		return f.set(strconv.Itoa(v))
+	case int64:
+		return f.set(strconv.FormatInt(v, 10))
+	case float64:
+		if f.value.Kind() == reflect.Int && v == float64(int64(v)) {
+			return f.set(strconv.FormatInt(int64(v), 10))
+		}
+		return f.set(strconv.FormatFloat(v, 'f', -1, 64))
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		if f.value.Kind() != reflect.Slice {
+			return fmt.Errorf("%s: expected a single value, got a list", f.path)
+		}
+		f.value.Set(reflect.ValueOf(items))
+		return nil
+	default:
+		return fmt.Errorf("%s: unsupported value %v", f.path, value)
+	}
+}
+
+func (f field) display() any {
+	switch {
+	case f.secret == "url":
+		u, err := url.Parse(f.value.String())
+		if err != nil {
+			return redacted
+		}
+		return u.Redacted()
+	case f.secret != "":
+		if f.value.IsZero() {
+			return ""
+		}
+		return redacted
+	case f.value.Type() == durationType:
+		return time.Duration(f.value.Int()).String()
+	default:
+		return f.value.Interface()
+	}
+}
+
+type flagValue struct {
+	field field
+	raw   *string
+}
+
+func (v flagValue) String() string {
+	if v.raw == nil {
+		return ""
+	}
+	return *v.raw
+}
+
+func (v flagValue) Set(raw string) error {
+	*v.raw = raw
+	return nil
+}
+
+func (v flagValue) IsBoolFlag() bool {
+	return v.field.value.Kind() == reflect.Bool
+}
+
+func decodeFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	values := map[string]any{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported format, use .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+func flatten(values map[string]any, prefix string, out map[string]any) {
+	for key, value := range values {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]any); ok {
+			flatten(nested, path, out)
+			continue
+		}
+		out[path] = value
+	}
+}
+
+func applyFile(path string, fields []field) error {
+	values, err := decodeFile(path)
+	if err != nil {
+		return err
+	}
+	flat := map[string]any{}
+	flatten(values, "", flat)
+
+	byPath := make(map[string]field, len(fields))
+	for _, f := range fields {
+		byPath[f.path] = f
+	}
+
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		f, ok := byPath[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("config file %s: unknown key %q", path, key))
+			continue
+		}
+		if err := f.assign(flat[key]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func applyEnv(fields []field) error {
+	var errs []error
+	for _, f := range fields {
+		if f.env == "" {
+			continue
+		}
+		if raw, ok := os.LookupEnv(f.env); ok && raw != "" {
+			if err := f.set(raw); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
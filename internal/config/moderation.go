@@ -0,0 +1,55 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type ModerationConfig struct {
+	BannedWords      []string `config:"banned_words" env:"REVIEW_BANNED_WORDS"`
+	AllowLinks       bool     `config:"allow_links" env:"REVIEW_ALLOW_LINKS"`
+	MinLength        int      `config:"min_length" env:"REVIEW_MIN_LENGTH"`
+	MaxLength        int      `config:"max_length" env:"REVIEW_MAX_LENGTH"`
+	RequireApproval  bool     `config:"require_approval" env:"REVIEW_REQUIRE_APPROVAL"`
+	ReportThreshold  int      `config:"report_threshold" env:"REVIEW_REPORT_THRESHOLD"`
+	UnverifiedWeight float64  `config:"unverified_weight" env:"REVIEW_UNVERIFIED_WEIGHT"`
+}
+
+func DefaultModerationConfig() *ModerationConfig {
+	return &ModerationConfig{
+		MaxLength:        2000,
+		ReportThreshold:  3,
+		UnverifiedWeight: 0.5,
+	}
+}
+
+func (c *ModerationConfig) normalize() {
+	words := c.BannedWords[:0]
+	for _, word := range c.BannedWords {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			words = append(words, word)
+		}
+	}
+	c.BannedWords = words
+}
+
+func (c ModerationConfig) validate() error {
+	var errs []error
+	for name, value := range map[string]int{
+		"moderation.min_length (REVIEW_MIN_LENGTH)":             c.MinLength,
+		"moderation.max_length (REVIEW_MAX_LENGTH)":             c.MaxLength,
+		"moderation.report_threshold (REVIEW_REPORT_THRESHOLD)": c.ReportThreshold,
+	} {
+		if value < 0 {
+			errs = append(errs, fmt.Errorf("%s must be a non-negative integer", name))
+		}
+	}
+	if c.UnverifiedWeight <= 0 || c.UnverifiedWeight > 1 {
+		errs = append(errs, fmt.Errorf("moderation.unverified_weight (REVIEW_UNVERIFIED_WEIGHT) must be in (0, 1]"))
+	}
+	if c.MaxLength > 0 && c.MinLength > c.MaxLength {
+		errs = append(errs, fmt.Errorf("moderation.min_length (REVIEW_MIN_LENGTH) must not exceed REVIEW_MAX_LENGTH"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+type RatingConfig struct {
+	Bayesian             bool    `config:"bayesian" env:"RATING_BAYESIAN"`
+	PriorMean            float64 `config:"prior_mean" env:"RATING_PRIOR_MEAN"`
+	PriorWeight          float64 `config:"prior_weight" env:"RATING_PRIOR_WEIGHT"`
+	RecomputeConcurrency int     `config:"recompute_concurrency" env:"RATING_RECOMPUTE_CONCURRENCY"`
+}
+
+func DefaultRatingConfig() *RatingConfig {
+	return &RatingConfig{
+		PriorMean:            6,
+		PriorWeight:          5,
+		RecomputeConcurrency: 4,
+	}
+}
+
+func (c RatingConfig) validate() error {
+	var errs []error
+	if c.PriorMean < 0 || c.PriorMean > 10 {
+		errs = append(errs, fmt.Errorf("rating.prior_mean (RATING_PRIOR_MEAN) must be between 0 and 10"))
+	}
+	if c.PriorWeight < 0 {
+		errs = append(errs, fmt.Errorf("rating.prior_weight (RATING_PRIOR_WEIGHT) must be a non-negative number"))
+	}
+	if c.RecomputeConcurrency < 1 {
+		errs = append(errs, fmt.Errorf("rating.recompute_concurrency (RATING_RECOMPUTE_CONCURRENCY) must be a positive integer"))
+	}
+	return errors.Join(errs...)
+}
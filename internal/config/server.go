@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type ServerConfig struct {
+	Port              string        `config:"port" env:"PORT" flag:"port" usage:"HTTP listen port"`
+	MetricsPort       string        `config:"metrics_port" env:"METRICS_PORT" flag:"metrics-port" usage:"listen port for /metrics, kept off the public API port"`
+	ReadHeaderTimeout time.Duration `config:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT"`
+	ReadTimeout       time.Duration `config:"read_timeout" env:"HTTP_READ_TIMEOUT"`
+	WriteTimeout      time.Duration `config:"write_timeout" env:"HTTP_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `config:"idle_timeout" env:"HTTP_IDLE_TIMEOUT"`
+	ShutdownTimeout   time.Duration `config:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+	StartupTimeout    time.Duration `config:"startup_timeout" env:"STARTUP_TIMEOUT"`
+}
+
+func DefaultServerConfig() *ServerConfig {
+	return &ServerConfig{
+		Port:              "8080",
+		MetricsPort:       "9091",
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       15 * time.Second,
+		WriteTimeout:      60 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		ShutdownTimeout:   30 * time.Second,
+		StartupTimeout:    2 * time.Minute,
+	}
+}
+
+func (c ServerConfig) validate() error {
+	var errs []error
+	if port, err := strconv.Atoi(c.Port); err != nil || port < 1 || port > 65535 {
+		errs = append(errs, fmt.Errorf("server.port (PORT) must be between 1 and 65535"))
+	}
+	if port, err := strconv.Atoi(c.MetricsPort); err != nil || port < 1 || port > 65535 {
+		errs = append(errs, fmt.Errorf("server.metrics_port (METRICS_PORT) must be between 1 and 65535"))
+	} else if c.MetricsPort == c.Port {
+		errs = append(errs, fmt.Errorf("server.metrics_port (METRICS_PORT) must differ from server.port (PORT)"))
+	}
+	for name, value := range map[string]time.Duration{
+		"server.read_header_timeout (HTTP_READ_HEADER_TIMEOUT)": c.ReadHeaderTimeout,
+		"server.read_timeout (HTTP_READ_TIMEOUT)":               c.ReadTimeout,
+		"server.write_timeout (HTTP_WRITE_TIMEOUT)":             c.WriteTimeout,
+		"server.idle_timeout (HTTP_IDLE_TIMEOUT)":               c.IdleTimeout,
+		"server.shutdown_timeout (SHUTDOWN_TIMEOUT)":            c.ShutdownTimeout,
+		"server.startup_timeout (STARTUP_TIMEOUT)":              c.StartupTimeout,
+	} {
+		if value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be a positive duration such as 30s", name))
+		}
+	}
+	return errors.Join(errs...)
+}
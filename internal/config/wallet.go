@@ -0,0 +1,195 @@
+package config
+
+import (
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type AppleWalletConfig struct {
+	PassTypeID       string
+	TeamID           string
+	OrganizationName string
+	WebServiceURL    string
+	APNsURL          string
+	IconFile         string
+	KeyPair          tls.Certificate
+	Certificate      *x509.Certificate
+	WWDR             *x509.Certificate
+}
+
+type GoogleServiceAccount struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+type GoogleWalletConfig struct {
+	IssuerID    string
+	IssuerName  string
+	Account     GoogleServiceAccount
+	SigningKey  *rsa.PrivateKey
+	Origins     []string
+	APIEndpoint string
+}
+
+type WalletConfig struct {
+	Apple  *AppleWalletConfig
+	Google *GoogleWalletConfig
+}
+
+type AppleWalletSettings struct {
+	CertFile         string `config:"cert_file" env:"APPLE_PASS_CERT_FILE"`
+	KeyFile          string `config:"key_file" env:"APPLE_PASS_KEY_FILE"`
+	WWDRCertFile     string `config:"wwdr_cert_file" env:"APPLE_WWDR_CERT_FILE"`
+	PassTypeID       string `config:"pass_type_id" env:"APPLE_PASS_TYPE_ID"`
+	TeamID           string `config:"team_id" env:"APPLE_TEAM_ID"`
+	OrganizationName string `config:"organization" env:"APPLE_PASS_ORGANIZATION"`
+	WebServiceURL    string `config:"web_service_url" env:"APPLE_PASS_WEB_SERVICE_URL"`
+	APNsURL          string `config:"apns_url" env:"APPLE_APNS_URL"`
+	IconFile         string `config:"icon_file" env:"APPLE_PASS_ICON_FILE"`
+}
+
+type GoogleWalletSettings struct {
+	CredentialsFile string `config:"credentials_file" env:"GOOGLE_WALLET_CREDENTIALS_FILE"`
+	IssuerID        string `config:"issuer_id" env:"GOOGLE_WALLET_ISSUER_ID"`
+	IssuerName      string `config:"issuer_name" env:"GOOGLE_WALLET_ISSUER_NAME"`
+	APIEndpoint     string `config:"api_url" env:"GOOGLE_WALLET_API_URL"`
+	Origin          string `config:"origin" env:"GOOGLE_WALLET_ORIGIN"`
+}
+
+type WalletSettings struct {
+	Apple  AppleWalletSettings  `config:"apple"`
+	Google GoogleWalletSettings `config:"google"`
+}
+
+func DefaultWalletSettings() WalletSettings {
+	return WalletSettings{
+		Apple: AppleWalletSettings{
+			OrganizationName: "Mangekyo Films",
+			APNsURL:          "https://api.push.apple.com",
+		},
+		Google: GoogleWalletSettings{
+			IssuerName:  "Mangekyo Films",
+			APIEndpoint: "https://walletobjects.googleapis.com/walletobjects/v1",
+		},
+	}
+}
+
+func (s WalletSettings) validate() error {
+	var errs []error
+	if s.Apple.CertFile != "" && (s.Apple.PassTypeID == "" || s.Apple.TeamID == "") {
+		errs = append(errs, errors.New("wallet.apple: APPLE_PASS_TYPE_ID and APPLE_TEAM_ID are required"))
+	}
+	if s.Google.CredentialsFile != "" && s.Google.IssuerID == "" {
+		errs = append(errs, errors.New("wallet.google: GOOGLE_WALLET_ISSUER_ID is required"))
+	}
+	return errors.Join(errs...)
+}
+
+func LoadWalletConfig(settings WalletSettings) (*WalletConfig, error) {
+	cfg := &WalletConfig{}
+
+	if settings.Apple.CertFile != "" {
+		apple, err := loadAppleWalletConfig(settings.Apple)
+		if err != nil {
+			return nil, fmt.Errorf("apple wallet: %w", err)
+		}
+		cfg.Apple = apple
+	}
+
+	if settings.Google.CredentialsFile != "" {
+		google, err := loadGoogleWalletConfig(settings.Google)
+		if err != nil {
+			return nil, fmt.Errorf("google wallet: %w", err)
+		}
+		cfg.Google = google
+	}
+
+	return cfg, nil
+}
+
+func loadAppleWalletConfig(settings AppleWalletSettings) (*AppleWalletConfig, error) {
+	cfg := &AppleWalletConfig{
+		PassTypeID:       settings.PassTypeID,
+		TeamID:           settings.TeamID,
+		OrganizationName: settings.OrganizationName,
+		WebServiceURL:    settings.WebServiceURL,
+		APNsURL:          settings.APNsURL,
+		IconFile:         settings.IconFile,
+	}
+
+	if cfg.PassTypeID == "" || cfg.TeamID == "" {
+		return nil, errors.New("APPLE_PASS_TYPE_ID and APPLE_TEAM_ID are required")
+	}
+
+	keyPair, err := tls.LoadX509KeyPair(settings.CertFile, settings.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pass certificate: %w", err)
+	}
+	cfg.KeyPair = keyPair
+
+	cfg.Certificate, err = x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pass certificate: %w", err)
+	}
+
+	if settings.WWDRCertFile != "" {
+		cfg.WWDR, err = loadCertificate(settings.WWDRCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load WWDR certificate: %w", err)
+		}
+	}
+
+	return cfg, nil
+}
+
+func loadGoogleWalletConfig(settings GoogleWalletSettings) (*GoogleWalletConfig, error) {
+	cfg := &GoogleWalletConfig{
+		IssuerID:    settings.IssuerID,
+		IssuerName:  settings.IssuerName,
+		APIEndpoint: settings.APIEndpoint,
+	}
+	if cfg.IssuerID == "" {
+		return nil, errors.New("GOOGLE_WALLET_ISSUER_ID is required")
+	}
+	if settings.Origin != "" {
+		cfg.Origins = []string{settings.Origin}
+	}
+
+	data, err := os.ReadFile(settings.CredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials: %w", err)
+	}
+	if err := json.Unmarshal(data, &cfg.Account); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials: %w", err)
+	}
+	if cfg.Account.TokenURI == "" {
+		cfg.Account.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+
+	cfg.SigningKey, err = jwt.ParseRSAPrivateKeyFromPEM([]byte(cfg.Account.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	return cfg, nil
+}
+
+func loadCertificate(path string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		data = block.Bytes
+	}
+	return x509.ParseCertificate(data)
+}
@@ -0,0 +1,144 @@
+package events
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	pollInterval = 2 * time.Second
+	claimLease   = 30 * time.Second
+	retryBase    = 5 * time.Second
+	maxRetryWait = 30 * time.Minute
+)
+
+type Handler func(ctx context.Context, event *models.OutboxEvent) error
+
+type subscription struct {
+	name     string
+	handler  Handler
+	external bool
+}
+
+type Dispatcher struct {
+	outboxRepo    repositories.OutboxStore
+	processedRepo repositories.ProcessedEventStore
+	transactor    repositories.TransactionRunner
+	subscriptions map[string][]subscription
+	now           func() time.Time
+}
+
+func NewDispatcher(outboxRepo repositories.OutboxStore, processedRepo repositories.ProcessedEventStore, transactor repositories.TransactionRunner) *Dispatcher {
+	return &Dispatcher{
+		outboxRepo:    outboxRepo,
+		processedRepo: processedRepo,
+		transactor:    transactor,
+		subscriptions: make(map[string][]subscription),
+		now:           time.Now,
+	}
+}
+
+func (d *Dispatcher) Subscribe(eventType, name string, handler Handler) {
+	d.subscriptions[eventType] = append(d.subscriptions[eventType], subscription{name: name, handler: handler})
+}
+
+func (d *Dispatcher) SubscribeExternal(eventType, name string, handler Handler) {
+	d.subscriptions[eventType] = append(d.subscriptions[eventType], subscription{name: name, handler: handler, external: true})
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.DispatchPending(ctx); err != nil {
+			slog.ErrorContext(ctx, "events: dispatch failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) DispatchPending(ctx context.Context) error {
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		event, err := d.outboxRepo.ClaimNext(ctx, d.now(), claimLease)
+		if err != nil {
+			return err
+		}
+		if event == nil {
+			return nil
+		}
+
+		if err := d.dispatch(ctx, event); err != nil {
+			return err
+		}
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, event *models.OutboxEvent) error {
+	var failures []string
+	for _, sub := range d.subscriptions[event.Type] {
+		var err error
+		if sub.external {
+			err = d.run(ctx, sub, event)
+		} else {
+			err = d.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+				return d.run(ctx, sub, event)
+			})
+		}
+		if err != nil && !errors.Is(err, repositories.ErrAlreadyProcessed) {
+			failures = append(failures, fmt.Sprintf("%s: %v", sub.name, err))
+		}
+	}
+
+	attempts := event.Attempts + 1
+	if len(failures) == 0 {
+		return d.outboxRepo.MarkProcessed(ctx, event.ID, attempts, d.now())
+	}
+
+	status := models.OutboxPending
+	if attempts >= models.MaxOutboxAttempts {
+		status = models.OutboxFailed
+		slog.WarnContext(ctx, "events: giving up", "type", event.Type, "event_id", event.ID.Hex(), "attempts", attempts)
+	}
+
+	wait := retryBase * time.Duration(1<<uint(attempts-1))
+	if wait > maxRetryWait {
+		wait = maxRetryWait
+	}
+	return d.outboxRepo.MarkAttemptFailed(ctx, event.ID, attempts, strings.Join(failures, "; "), status, d.now().Add(wait))
+}
+
+func (d *Dispatcher) run(ctx context.Context, sub subscription, event *models.OutboxEvent) error {
+	done, err := d.processedRepo.Exists(ctx, event.ID, sub.name)
+	if err != nil || done {
+		return err
+	}
+	if err := d.invoke(ctx, sub, event); err != nil {
+		return err
+	}
+	return d.processedRepo.Record(ctx, event.ID, sub.name)
+}
+
+func (d *Dispatcher) invoke(ctx context.Context, sub subscription, event *models.OutboxEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+	return sub.handler(ctx, event)
+}
@@ -0,0 +1,264 @@
+package events
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"cinema-system/internal/repositories/memory"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type dispatcherFixture struct {
+	store      *memory.Store
+	dispatcher *Dispatcher
+	movie      *models.Movie
+	clock      time.Time
+	calls      map[string]int
+}
+
+func newDispatcherFixture(t *testing.T, processed repositories.ProcessedEventStore) *dispatcherFixture {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.NewStore()
+	if processed == nil {
+		processed = store.ProcessedEvents
+	}
+
+	f := &dispatcherFixture{store: store, movie: &models.Movie{Name: "Dune"}, calls: map[string]int{}}
+	if err := store.Movies.Create(ctx, f.movie); err != nil {
+		t.Fatalf("Create movie: %v", err)
+	}
+	event, err := New(TicketBooked, primitive.NewObjectID(), TicketBookedPayload{TicketIDs: []primitive.ObjectID{primitive.NewObjectID()}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := store.Outbox.Add(ctx, event); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	f.clock = event.NextAttemptAt.Add(time.Second)
+
+	f.dispatcher = NewDispatcher(store.Outbox, processed, store.Transactor)
+	f.dispatcher.now = func() time.Time { return f.clock }
+	f.dispatcher.Subscribe(TicketBooked, "movies.popularity", func(ctx context.Context, event *models.OutboxEvent) error {
+		f.calls["movies.popularity"]++
+		return store.Movies.IncrementPopularity(ctx, f.movie.ID, 1)
+	})
+	return f
+}
+
+func (f *dispatcherFixture) popularity(t *testing.T) int {
+	t.Helper()
+	movie, err := f.store.Movies.FindByID(context.Background(), f.movie.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	return movie.Popularity
+}
+
+func (f *dispatcherFixture) assertNothingPending(t *testing.T) {
+	t.Helper()
+	event, err := f.store.Outbox.ClaimNext(context.Background(), f.clock.Add(24*time.Hour), claimLease)
+	if err != nil || event != nil {
+		t.Fatalf("ClaimNext = %+v, %v, want no pending event", event, err)
+	}
+}
+
+func TestDispatcherRetriesOnlyFailedSubscribers(t *testing.T) {
+	ctx := context.Background()
+	f := newDispatcherFixture(t, nil)
+	f.dispatcher.Subscribe(TicketBooked, "flaky", func(context.Context, *models.OutboxEvent) error {
+		f.calls["flaky"]++
+		if f.calls["flaky"] == 1 {
+			return errors.New("smtp timeout")
+		}
+		return nil
+	})
+
+	if err := f.dispatcher.DispatchPending(ctx); err != nil {
+		t.Fatalf("DispatchPending: %v", err)
+	}
+	if err := f.dispatcher.DispatchPending(ctx); err != nil || f.calls["flaky"] != 1 {
+		t.Fatalf("DispatchPending before backoff: %v, calls = %v", err, f.calls)
+	}
+
+	f.clock = f.clock.Add(retryBase + time.Second)
+	pending, err := f.store.Outbox.ClaimNext(ctx, f.clock, claimLease)
+	if err != nil || pending == nil || pending.Attempts != 1 || pending.LastError != "flaky: smtp timeout" {
+		t.Fatalf("event after failure = %+v, err = %v", pending, err)
+	}
+	if err := f.dispatcher.dispatch(ctx, pending); err != nil {
+		t.Fatalf("dispatch retry: %v", err)
+	}
+	if f.calls["flaky"] != 2 || f.calls["movies.popularity"] != 1 || f.popularity(t) != 1 {
+		t.Fatalf("calls = %v, popularity = %d, want the succeeded subscriber skipped on retry", f.calls, f.popularity(t))
+	}
+	f.assertNothingPending(t)
+}
+
+type failingProcessedStore struct {
+	*memory.ProcessedEventRepository
+	failures int
+}
+
+func (s *failingProcessedStore) Record(ctx context.Context, eventID primitive.ObjectID, handler string) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("write concern timeout")
+	}
+	return s.ProcessedEventRepository.Record(ctx, eventID, handler)
+}
+
+func TestDispatcherRollsBackHandlerWhenMarkerFails(t *testing.T) {
+	ctx := context.Background()
+	processed := &failingProcessedStore{ProcessedEventRepository: memory.NewProcessedEventRepository(), failures: 1}
+	f := newDispatcherFixture(t, processed)
+
+	if err := f.dispatcher.DispatchPending(ctx); err != nil {
+		t.Fatalf("DispatchPending: %v", err)
+	}
+	if f.popularity(t) != 0 {
+		t.Fatalf("popularity = %d, want handler rolled back with its marker", f.popularity(t))
+	}
+
+	f.clock = f.clock.Add(retryBase + time.Second)
+	pending, err := f.store.Outbox.ClaimNext(ctx, f.clock, claimLease)
+	if err != nil || pending == nil || pending.Attempts != 1 || pending.LastError != "movies.popularity: write concern timeout" {
+		t.Fatalf("event after marker failure = %+v, err = %v", pending, err)
+	}
+	if err := f.dispatcher.dispatch(ctx, pending); err != nil {
+		t.Fatalf("dispatch retry: %v", err)
+	}
+	if f.calls["movies.popularity"] != 2 || f.popularity(t) != 1 {
+		t.Fatalf("calls = %v, popularity = %d, want counted once", f.calls, f.popularity(t))
+	}
+	f.assertNothingPending(t)
+}
+
+type unavailableProcessedStore struct {
+	*memory.ProcessedEventRepository
+}
+
+func (unavailableProcessedStore) Exists(ctx context.Context, eventID primitive.ObjectID, handler string) (bool, error) {
+	return false, errors.New("server selection timeout")
+}
+
+func TestDispatcherBacksOffAndGivesUpOnStoreErrors(t *testing.T) {
+	ctx := context.Background()
+	f := newDispatcherFixture(t, unavailableProcessedStore{memory.NewProcessedEventRepository()})
+
+	for i := 0; i < models.MaxOutboxAttempts; i++ {
+		if err := f.dispatcher.DispatchPending(ctx); err != nil {
+			t.Fatalf("DispatchPending #%d: %v", i+1, err)
+		}
+		if i == 0 {
+			if event, err := f.store.Outbox.ClaimNext(ctx, f.clock, claimLease); err != nil || event != nil {
+				t.Fatalf("ClaimNext = %+v, %v, want the event backed off", event, err)
+			}
+		}
+		f.clock = f.clock.Add(maxRetryWait + time.Second)
+	}
+
+	f.assertNothingPending(t)
+	if f.calls["movies.popularity"] != 0 {
+		t.Fatalf("calls = %v, want handler never reached", f.calls)
+	}
+}
+
+type inTransactionKey struct{}
+
+type markingTransactor struct {
+	*memory.Transactor
+}
+
+func (t markingTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return t.Transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		return fn(context.WithValue(ctx, inTransactionKey{}, true))
+	})
+}
+
+func TestDispatcherRunsExternalSubscribersOutsideTransactions(t *testing.T) {
+	ctx := context.Background()
+	f := newDispatcherFixture(t, nil)
+	f.dispatcher.transactor = markingTransactor{f.store.Transactor}
+
+	inTransaction := map[string]bool{}
+	f.dispatcher.Subscribe(TicketBooked, "local", func(ctx context.Context, event *models.OutboxEvent) error {
+		inTransaction["local"] = ctx.Value(inTransactionKey{}) != nil
+		return nil
+	})
+	f.dispatcher.SubscribeExternal(TicketBooked, "push", func(ctx context.Context, event *models.OutboxEvent) error {
+		f.calls["push"]++
+		inTransaction["push"] = ctx.Value(inTransactionKey{}) != nil
+		if f.calls["push"] == 1 {
+			return errors.New("apns unavailable")
+		}
+		return nil
+	})
+
+	if err := f.dispatcher.DispatchPending(ctx); err != nil {
+		t.Fatalf("DispatchPending: %v", err)
+	}
+	if !inTransaction["local"] || inTransaction["push"] {
+		t.Fatalf("in transaction = %v, want only the local subscriber inside one", inTransaction)
+	}
+
+	f.clock = f.clock.Add(retryBase + time.Second)
+	if err := f.dispatcher.DispatchPending(ctx); err != nil {
+		t.Fatalf("DispatchPending retry: %v", err)
+	}
+	if f.calls["push"] != 2 || f.calls["movies.popularity"] != 1 {
+		t.Fatalf("calls = %v, want only the external subscriber retried", f.calls)
+	}
+	f.assertNothingPending(t)
+}
+
+func TestDispatcherLeaseExpiryDoesNotReapplyHandler(t *testing.T) {
+	ctx := context.Background()
+	f := newDispatcherFixture(t, nil)
+
+	stalled, err := f.store.Outbox.ClaimNext(ctx, f.clock, claimLease)
+	if err != nil || stalled == nil {
+		t.Fatalf("ClaimNext = %v, %v", stalled, err)
+	}
+	if err := f.dispatcher.DispatchPending(ctx); err != nil {
+		t.Fatalf("DispatchPending under lease: %v", err)
+	}
+	if f.calls["movies.popularity"] != 0 {
+		t.Fatalf("leased event dispatched again before expiry")
+	}
+
+	f.clock = f.clock.Add(claimLease + time.Second)
+	if err := f.dispatcher.DispatchPending(ctx); err != nil {
+		t.Fatalf("DispatchPending after lease: %v", err)
+	}
+	if err := f.dispatcher.dispatch(ctx, stalled); err != nil {
+		t.Fatalf("stalled worker dispatch: %v", err)
+	}
+	if f.calls["movies.popularity"] != 1 || f.popularity(t) != 1 {
+		t.Fatalf("calls = %v, popularity = %d, want the stalled worker to skip the handler", f.calls, f.popularity(t))
+	}
+}
+
+func TestDispatcherGivesUpAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	f := newDispatcherFixture(t, nil)
+	f.dispatcher.Subscribe(TicketBooked, "broken", func(context.Context, *models.OutboxEvent) error {
+		panic("nil map")
+	})
+
+	for i := 0; i < models.MaxOutboxAttempts; i++ {
+		if err := f.dispatcher.DispatchPending(ctx); err != nil {
+			t.Fatalf("DispatchPending #%d: %v", i+1, err)
+		}
+		f.clock = f.clock.Add(maxRetryWait + time.Second)
+	}
+
+	f.assertNothingPending(t)
+	if f.calls["movies.popularity"] != 1 || f.popularity(t) != 1 {
+		t.Fatalf("calls = %v, popularity = %d", f.calls, f.popularity(t))
+	}
+}
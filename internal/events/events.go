@@ -0,0 +1,121 @@
+package events
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/money"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	TicketBooked       = "TicketBooked"
+	TicketCancelled    = "TicketCancelled"
+	ReviewCreated      = "ReviewCreated"
+	ReviewUpdated      = "ReviewUpdated"
+	ReviewDeleted      = "ReviewDeleted"
+	PaymentCompleted   = "PaymentCompleted"
+	PaymentRefunded    = "PaymentRefunded"
+	SessionCreated     = "SessionCreated"
+	SessionCancelled   = "SessionCancelled"
+	SessionRescheduled = "SessionRescheduled"
+
+	WalletPassesChanged = "WalletPassesChanged"
+)
+
+type TicketBookedPayload struct {
+	UserID    primitive.ObjectID   `bson:"user_id"`
+	SessionID primitive.ObjectID   `bson:"session_id"`
+	PaymentID primitive.ObjectID   `bson:"payment_id"`
+	TicketIDs []primitive.ObjectID `bson:"ticket_ids"`
+	Total     money.Money          `bson:"total"`
+}
+
+type TicketCancelledPayload struct {
+	TicketID  primitive.ObjectID `bson:"ticket_id"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	SessionID primitive.ObjectID `bson:"session_id"`
+	PaymentID primitive.ObjectID `bson:"payment_id"`
+	Refund    money.Money        `bson:"refund"`
+}
+
+type ReviewPayload struct {
+	ReviewID primitive.ObjectID `bson:"review_id"`
+	MovieID  primitive.ObjectID `bson:"movie_id"`
+	UserID   primitive.ObjectID `bson:"user_id"`
+	Rating   int                `bson:"rating"`
+}
+
+type PaymentCompletedPayload struct {
+	PaymentID       primitive.ObjectID `bson:"payment_id"`
+	UserID          primitive.ObjectID `bson:"user_id"`
+	Amount          money.Money        `bson:"amount"`
+	TransactionCode string             `bson:"transaction_code"`
+}
+
+type PaymentRefundedPayload struct {
+	PaymentID       primitive.ObjectID `bson:"payment_id"`
+	UserID          primitive.ObjectID `bson:"user_id"`
+	Amount          money.Money        `bson:"amount"`
+	TransactionCode string             `bson:"transaction_code"`
+}
+
+type SessionPayload struct {
+	SessionID primitive.ObjectID `bson:"session_id"`
+	MovieID   primitive.ObjectID `bson:"movie_id"`
+	HallID    primitive.ObjectID `bson:"hall_id"`
+	StartTime time.Time          `bson:"start_time"`
+	EndTime   time.Time          `bson:"end_time"`
+	Price     money.Money        `bson:"price"`
+}
+
+type WalletPassesChangedPayload struct {
+	SessionID     primitive.ObjectID   `bson:"session_id,omitempty"`
+	TicketIDs     []primitive.ObjectID `bson:"ticket_ids"`
+	SerialNumbers []string             `bson:"serial_numbers"`
+	Voided        bool                 `bson:"voided"`
+}
+
+func NewSessionPayload(session *models.Session) SessionPayload {
+	return SessionPayload{
+		SessionID: session.ID,
+		MovieID:   session.MovieID,
+		HallID:    session.HallID,
+		StartTime: session.StartTime,
+		EndTime:   session.EndTime,
+		Price:     session.Price,
+	}
+}
+
+func (p SessionPayload) Session() *models.Session {
+	return &models.Session{
+		ID:        p.SessionID,
+		MovieID:   p.MovieID,
+		HallID:    p.HallID,
+		StartTime: p.StartTime,
+		EndTime:   p.EndTime,
+		Price:     p.Price,
+	}
+}
+
+func New(eventType string, aggregateID primitive.ObjectID, payload interface{}) (*models.OutboxEvent, error) {
+	raw, err := bson.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &models.OutboxEvent{
+		Type:          eventType,
+		AggregateID:   aggregateID,
+		Payload:       raw,
+		Status:        models.OutboxPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+func Decode(event *models.OutboxEvent, payload interface{}) error {
+	return bson.Unmarshal(event.Payload, payload)
+}
@@ -0,0 +1,38 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+type csvWriter struct {
+	writer *csv.Writer
+	opts   Options
+	record []string
+}
+
+func newCSVWriter(w io.Writer, opts Options) *csvWriter {
+	writer := csv.NewWriter(w)
+	if opts.DecimalComma {
+		writer.Comma = ';'
+	}
+	return &csvWriter{writer: writer, opts: opts}
+}
+
+func (w *csvWriter) writeRow(cells []interface{}) error {
+	w.record = w.record[:0]
+	for _, cell := range cells {
+		text := cellText(cell, w.opts)
+		if _, numeric := numericValue(cell); !numeric && text != "" && strings.ContainsRune("=+-@", rune(text[0])) {
+			text = "'" + text
+		}
+		w.record = append(w.record, text)
+	}
+	return w.writer.Write(w.record)
+}
+
+func (w *csvWriter) close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
@@ -0,0 +1,221 @@
+package export
+
+import (
+	"cinema-system/internal/apperrors"
+	"cinema-system/internal/money"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrInvalidOption = apperrors.Validation("invalid_export_option", "invalid export option {option}: {value}")
+	ErrUnknownColumn = apperrors.Validation("unknown_export_column", "unknown export column: {column}")
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+var DateFormats = map[string]string{
+	"iso":      time.RFC3339,
+	"datetime": "2006-01-02 15:04:05",
+	"date":     "2006-01-02",
+	"eu":       "02.01.2006 15:04",
+	"us":       "01/02/2006 15:04",
+}
+
+type Options struct {
+	Format       Format
+	Columns      []string
+	DateLayout   string
+	Location     *time.Location
+	DecimalComma bool
+}
+
+func DefaultOptions() Options {
+	return Options{Format: FormatCSV, DateLayout: time.RFC3339, Location: time.UTC}
+}
+
+func ParseOptions(values url.Values) (Options, error) {
+	opts := DefaultOptions()
+
+	if raw := values.Get("format"); raw != "" {
+		opts.Format = Format(strings.ToLower(raw))
+		if opts.Format != FormatCSV && opts.Format != FormatXLSX {
+			return opts, ErrInvalidOption.With("option", "format").With("value", raw)
+		}
+	}
+
+	if raw := values.Get("columns"); raw != "" {
+		for _, column := range strings.Split(raw, ",") {
+			if column = strings.TrimSpace(column); column != "" {
+				opts.Columns = append(opts.Columns, column)
+			}
+		}
+	}
+
+	if raw := values.Get("date_format"); raw != "" {
+		layout, ok := DateFormats[raw]
+		if !ok {
+			return opts, ErrInvalidOption.With("option", "date_format").With("value", raw)
+		}
+		opts.DateLayout = layout
+	}
+
+	if raw := values.Get("tz"); raw != "" {
+		location, err := time.LoadLocation(raw)
+		if err != nil {
+			return opts, ErrInvalidOption.With("option", "tz").With("value", raw)
+		}
+		opts.Location = location
+	}
+
+	switch raw := values.Get("decimal"); raw {
+	case "", ".":
+	case ",":
+		opts.DecimalComma = true
+	default:
+		return opts, ErrInvalidOption.With("option", "decimal").With("value", raw)
+	}
+
+	return opts, nil
+}
+
+type Column[T any] struct {
+	Key    string
+	Header string
+	Value  func(*T) interface{}
+}
+
+type rowWriter interface {
+	writeRow(cells []interface{}) error
+	close() error
+}
+
+type Table[T any] struct {
+	columns []Column[T]
+	writer  rowWriter
+	cells   []interface{}
+}
+
+func NewTable[T any](w io.Writer, columns []Column[T], opts Options) (*Table[T], error) {
+	selected, err := selectColumns(columns, opts.Columns)
+	if err != nil {
+		return nil, err
+	}
+	if opts.DateLayout == "" {
+		opts.DateLayout = time.RFC3339
+	}
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+
+	var writer rowWriter
+	if opts.Format == FormatXLSX {
+		writer, err = newXLSXWriter(w, opts)
+	} else {
+		writer = newCSVWriter(w, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]interface{}, len(selected))
+	for i, column := range selected {
+		header[i] = column.Header
+	}
+	if err := writer.writeRow(header); err != nil {
+		return nil, err
+	}
+	return &Table[T]{columns: selected, writer: writer, cells: make([]interface{}, len(selected))}, nil
+}
+
+func selectColumns[T any](columns []Column[T], keys []string) ([]Column[T], error) {
+	if len(keys) == 0 {
+		return columns, nil
+	}
+	byKey := make(map[string]Column[T], len(columns))
+	for _, column := range columns {
+		byKey[column.Key] = column
+	}
+	selected := make([]Column[T], 0, len(keys))
+	for _, key := range keys {
+		column, ok := byKey[key]
+		if !ok {
+			return nil, ErrUnknownColumn.With("column", key)
+		}
+		selected = append(selected, column)
+	}
+	return selected, nil
+}
+
+func (t *Table[T]) Write(item *T) error {
+	for i, column := range t.columns {
+		t.cells[i] = column.Value(item)
+	}
+	return t.writer.writeRow(t.cells)
+}
+
+func (t *Table[T]) Close() error {
+	return t.writer.close()
+}
+
+func cellText(value interface{}, opts Options) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case primitive.ObjectID:
+		return v.Hex()
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.In(opts.Location).Format(opts.DateLayout)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return cellText(*v, opts)
+	default:
+		if number, ok := numericValue(value); ok {
+			if opts.DecimalComma {
+				number = strings.Replace(number, ".", ",", 1)
+			}
+			return number
+		}
+		return fmt.Sprint(v)
+	}
+}
+
+func numericValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case money.Money:
+		return v.Decimal(), true
+	default:
+		return "", false
+	}
+}
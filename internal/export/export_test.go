@@ -0,0 +1,130 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"cinema-system/internal/apperrors"
+	"cinema-system/internal/money"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+type row struct {
+	Name   string
+	Amount money.Money
+	At     time.Time
+}
+
+var rowColumns = []Column[row]{
+	{Key: "name", Header: "Name", Value: func(r *row) interface{} { return r.Name }},
+	{Key: "amount", Header: "Amount", Value: func(r *row) interface{} { return r.Amount }},
+	{Key: "at", Header: "At", Value: func(r *row) interface{} { return r.At }},
+}
+
+func writeRows(t *testing.T, opts Options, rows ...row) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	table, err := NewTable(&buf, rowColumns, opts)
+	if err != nil {
+		t.Fatalf("new table: %v", err)
+	}
+	for i := range rows {
+		if err := table.Write(&rows[i]); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	if err := table.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestCSVColumnsAndFormats(t *testing.T) {
+	opts, err := ParseOptions(url.Values{
+		"columns":     {"at,amount,name"},
+		"date_format": {"date"},
+		"tz":          {"Asia/Almaty"},
+		"decimal":     {","},
+	})
+	if err != nil {
+		t.Fatalf("parse options: %v", err)
+	}
+
+	at := time.Date(2026, 10, 18, 22, 30, 0, 0, time.UTC)
+	got := string(writeRows(t, opts,
+		row{Name: "Dune", Amount: money.New(123450, "KZT"), At: at},
+		row{Name: "=cmd()", Amount: money.New(-500, "KZT")},
+	))
+	want := "At;Amount;Name\n2026-10-19;1234,50;Dune\n;-5,00;'=cmd()\n"
+	if got != want {
+		t.Errorf("csv = %q, want %q", got, want)
+	}
+}
+
+func TestParseOptionsRejectsUnknownValues(t *testing.T) {
+	for _, values := range []url.Values{
+		{"format": {"pdf"}},
+		{"date_format": {"roman"}},
+		{"tz": {"Mars/Base"}},
+		{"decimal": {"_"}},
+	} {
+		_, err := ParseOptions(values)
+		var appErr *apperrors.Error
+		if !errors.As(err, &appErr) || appErr.Code != "invalid_export_option" {
+			t.Errorf("ParseOptions(%v) error = %v, want invalid_export_option", values, err)
+		}
+	}
+}
+
+func TestUnknownColumnWritesNothing(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := NewTable(&buf, rowColumns, Options{Columns: []string{"name", "password"}})
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Code != "unknown_export_column" {
+		t.Fatalf("error = %v, want unknown_export_column", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("wrote %d bytes before failing", buf.Len())
+	}
+}
+
+func TestXLSXWorkbook(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Format = FormatXLSX
+	data := writeRows(t, opts, row{Name: "Tom & Jerry", Amount: money.New(250, "USD")})
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("open zip: %v", err)
+	}
+	var sheet string
+	for _, file := range archive.File {
+		if file.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("open sheet: %v", err)
+		}
+		raw, _ := io.ReadAll(rc)
+		rc.Close()
+		sheet = string(raw)
+	}
+	if sheet == "" {
+		t.Fatal("sheet1.xml missing")
+	}
+	for _, want := range []string{
+		`<c r="A1" t="inlineStr"><is><t xml:space="preserve">Name</t></is></c>`,
+		`<t xml:space="preserve">Tom &amp; Jerry</t>`,
+		`<c r="B2"><v>2.50</v></c>`,
+		`<row r="2">`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet missing %s", want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AnalyticsHandler struct {
+	analyticsService *services.AnalyticsService
+}
+
+func NewAnalyticsHandler(analyticsService *services.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{analyticsService: analyticsService}
+}
+
+func bindAnalyticsQuery(c *gin.Context) (models.AnalyticsQuery, bool) {
+	var query models.AnalyticsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(bindingError(err))
+		return query, false
+	}
+	return query, true
+}
+
+func (h *AnalyticsHandler) GetSummary(c *gin.Context) {
+	query, ok := bindAnalyticsQuery(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.analyticsService.GetSummary(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+func (h *AnalyticsHandler) GetSales(c *gin.Context) {
+	query, ok := bindAnalyticsQuery(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.analyticsService.GetSales(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *AnalyticsHandler) GetOccupancy(c *gin.Context) {
+	query, ok := bindAnalyticsQuery(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.analyticsService.GetOccupancy(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"cinema-system/internal/repositories"
+	"cinema-system/internal/services"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	auditService *services.AuditService
+	location     *time.Location
+}
+
+func NewAuditHandler(auditService *services.AuditService, location *time.Location) *AuditHandler {
+	return &AuditHandler{auditService: auditService, location: location}
+}
+
+func (h *AuditHandler) GetEntries(c *gin.Context) {
+	query, ok := bindListQuery(c, repositories.AuditListSpec, h.location)
+	if !ok {
+		return
+	}
+
+	page, err := h.auditService.List(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	respondPage(c, page)
+}
+
+func (h *AuditHandler) Verify(c *gin.Context) {
+	result, err := h.auditService.Verify(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
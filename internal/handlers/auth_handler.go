@@ -1,13 +1,12 @@
 package handlers
 
 import (
+	"cinema-system/internal/middleware"
 	"cinema-system/internal/models"
 	"cinema-system/internal/services"
-	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	go_playground_validator "github.com/go-playground/validator/v10"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -22,17 +21,13 @@ func NewAuthHandler(authService *services.AuthService) *AuthHandler {
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req models.UserRegistration
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": formatValidationError(err)})
+		c.Error(bindingError(err))
 		return
 	}
 
 	user, token, err := h.authService.Register(c.Request.Context(), req)
 	if err != nil {
-		if errors.Is(err, services.ErrUserAlreadyExists) {
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -46,17 +41,13 @@ func (h *AuthHandler) Register(c *gin.Context) {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req models.UserLogin
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": formatValidationError(err)})
+		c.Error(bindingError(err))
 		return
 	}
 
 	user, token, err := h.authService.Login(c.Request.Context(), req)
 	if err != nil {
-		if errors.Is(err, services.ErrInvalidCredentials) {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -67,40 +58,16 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	})
 }
 
-func formatValidationError(err error) string {
-	var ve go_playground_validator.ValidationErrors
-	if errors.As(err, &ve) {
-		for _, fe := range ve {
-			switch fe.Field() {
-			case "Email":
-				return "Please enter a valid email address"
-			case "Password":
-				if fe.Tag() == "min" {
-					return "Password must be at least 6 characters long"
-				}
-				return "Password is required"
-			case "FirstName":
-				return "First name must be at least 2 characters long"
-			case "LastName":
-				return "Last name must be at least 2 characters long"
-			case "PhoneNumber":
-				return "Phone number is required"
-			}
-		}
-	}
-	return "Invalid input data"
-}
-
 func (h *AuthHandler) GetMe(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(middleware.ErrAuthenticationRequired)
 		return
 	}
 
 	user, err := h.authService.GetUserByID(c.Request.Context(), userID.(primitive.ObjectID))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		c.Error(err)
 		return
 	}
 
@@ -118,12 +85,12 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindingError(err))
 		return
 	}
 
 	if err := h.authService.UpdateProfile(c.Request.Context(), userID, req.FirstName, req.LastName, req.Email, req.PhoneNumber); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
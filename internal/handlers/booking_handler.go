@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
 	"cinema-system/internal/services"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -11,10 +13,11 @@ import (
 
 type BookingHandler struct {
 	bookingService *services.BookingService
+	location       *time.Location
 }
 
-func NewBookingHandler(bookingService *services.BookingService) *BookingHandler {
-	return &BookingHandler{bookingService: bookingService}
+func NewBookingHandler(bookingService *services.BookingService, location *time.Location) *BookingHandler {
+	return &BookingHandler{bookingService: bookingService, location: location}
 }
 
 type BookTicketSeat struct {
@@ -33,13 +36,13 @@ func (h *BookingHandler) BookTickets(c *gin.Context) {
 
 	var req BookTicketsRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindingError(err))
 		return
 	}
 
 	sessionID, err := primitive.ObjectIDFromHex(req.SessionID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session ID"})
+		c.Error(invalidID("session ID"))
 		return
 	}
 
@@ -54,7 +57,7 @@ func (h *BookingHandler) BookTickets(c *gin.Context) {
 
 	tickets, err := h.bookingService.BookTickets(c.Request.Context(), userID, sessionID, seatRequests)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -65,12 +68,12 @@ func (h *BookingHandler) CancelTicket(c *gin.Context) {
 	userID := c.MustGet("userID").(primitive.ObjectID)
 	ticketID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ticket ID"})
+		c.Error(invalidID("ticket ID"))
 		return
 	}
 
 	if err := h.bookingService.CancelTicket(c.Request.Context(), ticketID, userID); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -80,25 +83,30 @@ func (h *BookingHandler) CancelTicket(c *gin.Context) {
 func (h *BookingHandler) GetMyTickets(c *gin.Context) {
 	userID := c.MustGet("userID").(primitive.ObjectID)
 
-	tickets, err := h.bookingService.GetUserTickets(c.Request.Context(), userID)
+	query, ok := bindListQuery(c, repositories.TicketListSpec, h.location)
+	if !ok {
+		return
+	}
+
+	page, err := h.bookingService.GetUserTickets(c.Request.Context(), userID, query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, tickets)
+	respondPage(c, page)
 }
 
 func (h *BookingHandler) GetSessionTickets(c *gin.Context) {
 	sessionID, err := primitive.ObjectIDFromHex(c.Param("sessionId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session ID"})
+		c.Error(invalidID("session ID"))
 		return
 	}
 
 	tickets, err := h.bookingService.GetSessionTickets(c.Request.Context(), sessionID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -106,24 +114,29 @@ func (h *BookingHandler) GetSessionTickets(c *gin.Context) {
 }
 
 func (h *BookingHandler) GetAllBookings(c *gin.Context) {
-	tickets, err := h.bookingService.GetAllBookings(c.Request.Context())
+	query, ok := bindListQuery(c, repositories.TicketListSpec, h.location)
+	if !ok {
+		return
+	}
+
+	page, err := h.bookingService.GetAllBookings(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, tickets)
+	respondPage(c, page)
 }
 
 func (h *BookingHandler) GetSessionBookedSeats(c *gin.Context) {
 	sessionID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session ID"})
+		c.Error(invalidID("session ID"))
 		return
 	}
 	seats, err := h.bookingService.GetSessionBookedSeats(c.Request.Context(), sessionID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, seats)
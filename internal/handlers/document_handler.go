@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/services"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type DocumentHandler struct {
+	documentService *services.DocumentService
+}
+
+func NewDocumentHandler(documentService *services.DocumentService) *DocumentHandler {
+	return &DocumentHandler{documentService: documentService}
+}
+
+func (h *DocumentHandler) GetBookingPDF(c *gin.Context) {
+	userID := c.MustGet("userID").(primitive.ObjectID)
+	ticketID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("ticket ID"))
+		return
+	}
+
+	pdf, err := h.documentService.RenderBookingPDF(c.Request.Context(), ticketID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="ticket-%s.pdf"`, ticketID.Hex()))
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+func (h *DocumentHandler) GetPaymentReceipt(c *gin.Context) {
+	userID := c.MustGet("userID").(primitive.ObjectID)
+	paymentID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("payment ID"))
+		return
+	}
+
+	pdf, err := h.documentService.RenderPaymentReceipt(c.Request.Context(), paymentID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="receipt-%s.pdf"`, paymentID.Hex()))
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+func (h *DocumentHandler) GetTemplate(c *gin.Context) {
+	kind := models.DocumentKind(strings.ToUpper(c.Param("kind")))
+	if kind != models.DocumentTicket && kind != models.DocumentReceipt {
+		c.Error(models.ErrInvalidDocumentKind)
+		return
+	}
+
+	template, err := h.documentService.GetTemplate(c.Request.Context(), kind)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+func (h *DocumentHandler) UpdateTemplate(c *gin.Context) {
+	var template models.DocumentTemplate
+	if err := c.ShouldBindJSON(&template); err != nil {
+		c.Error(bindingError(err))
+		return
+	}
+	template.Kind = models.DocumentKind(strings.ToUpper(c.Param("kind")))
+
+	if err := h.documentService.UpdateTemplate(c.Request.Context(), &template); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "template updated successfully"})
+}
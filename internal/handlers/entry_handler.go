@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type EntryHandler struct {
+	entryService *services.EntryService
+}
+
+func NewEntryHandler(entryService *services.EntryService) *EntryHandler {
+	return &EntryHandler{entryService: entryService}
+}
+
+func (h *EntryHandler) GetTicketQR(c *gin.Context) {
+	userID := c.MustGet("userID").(primitive.ObjectID)
+	ticketID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("ticket ID"))
+		return
+	}
+
+	token, err := h.entryService.GetTicketToken(c.Request.Context(), ticketID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	image, contentType, err := h.entryService.RenderQRCode(token, c.Query("format"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, contentType, image)
+}
+
+func (h *EntryHandler) GetTicketToken(c *gin.Context) {
+	userID := c.MustGet("userID").(primitive.ObjectID)
+	ticketID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("ticket ID"))
+		return
+	}
+
+	token, err := h.entryService.GetTicketToken(c.Request.Context(), ticketID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token})
+}
+
+func (h *EntryHandler) ScanTicket(c *gin.Context) {
+	var req models.ScanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(bindingError(err))
+		return
+	}
+
+	result, err := h.entryService.ScanTicket(c.Request.Context(), req.Token)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	switch result.Result {
+	case models.ScanDuplicate:
+		c.JSON(http.StatusConflict, result)
+	case models.ScanRejected:
+		c.JSON(http.StatusUnprocessableEntity, result)
+	default:
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func (h *EntryHandler) GetSessionManifest(c *gin.Context) {
+	sessionID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("session ID"))
+		return
+	}
+
+	manifest, err := h.entryService.GetSessionManifest(c.Request.Context(), sessionID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, manifest)
+}
+
+func (h *EntryHandler) SyncScans(c *gin.Context) {
+	var req models.ScanSyncRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(bindingError(err))
+		return
+	}
+
+	results, err := h.entryService.SyncScans(c.Request.Context(), req.Scans)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"results": results})
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"cinema-system/internal/apperrors"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	go_playground_validator "github.com/go-playground/validator/v10"
+)
+
+var (
+	errInvalidRequest   = apperrors.Validation("invalid_request", "invalid request")
+	errInvalidStartTime = errInvalidRequest.WithFields(apperrors.FieldError{Field: "start_time", Code: "datetime", Param: "RFC 3339"})
+)
+
+func bindingError(err error) error {
+	var ve go_playground_validator.ValidationErrors
+	if !errors.As(err, &ve) {
+		return fmt.Errorf("%w: %v", errInvalidRequest, err)
+	}
+
+	fields := make([]apperrors.FieldError, 0, len(ve))
+	for _, fe := range ve {
+		fields = append(fields, apperrors.FieldError{
+			Field: fieldName(fe.Field()),
+			Code:  fe.Tag(),
+			Param: fe.Param(),
+		})
+	}
+	return errInvalidRequest.WithFields(fields...)
+}
+
+func invalidID(field string) error {
+	return apperrors.Validation("invalid_id", "invalid {field}").With("field", field)
+}
+
+func fieldName(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/money"
+	"cinema-system/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExchangeRateHandler struct {
+	exchangeRateService *services.ExchangeRateService
+}
+
+func NewExchangeRateHandler(exchangeRateService *services.ExchangeRateService) *ExchangeRateHandler {
+	return &ExchangeRateHandler{exchangeRateService: exchangeRateService}
+}
+
+func (h *ExchangeRateHandler) GetCurrencies(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"default":    money.DefaultCurrency,
+		"currencies": money.Currencies(),
+	})
+}
+
+func (h *ExchangeRateHandler) GetRates(c *gin.Context) {
+	rates, err := h.exchangeRateService.GetRates(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, rates)
+}
+
+func (h *ExchangeRateHandler) SetRate(c *gin.Context) {
+	var req models.ExchangeRateUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(bindingError(err))
+		return
+	}
+
+	rate, err := h.exchangeRateService.SetRate(c.Request.Context(), c.Param("from"), c.Param("to"), req.Rate)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, rate)
+}
+
+func (h *ExchangeRateHandler) DeleteRate(c *gin.Context) {
+	if err := h.exchangeRateService.DeleteRate(c.Request.Context(), c.Param("from"), c.Param("to")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "exchange rate deleted successfully"})
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"cinema-system/internal/export"
+	"cinema-system/internal/repositories"
+	"cinema-system/internal/services"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExportHandler struct {
+	exportService *services.ExportService
+	location      *time.Location
+}
+
+func NewExportHandler(exportService *services.ExportService, location *time.Location) *ExportHandler {
+	return &ExportHandler{exportService: exportService, location: location}
+}
+
+type attachmentWriter struct {
+	c        *gin.Context
+	filename string
+	opts     export.Options
+	started  bool
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.c.Header("Content-Type", w.opts.Format.ContentType())
+		w.c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s.%s"`, w.filename, time.Now().UTC().Format("20060102"), w.opts.Format))
+		w.c.Status(http.StatusOK)
+	}
+	return w.c.Writer.Write(p)
+}
+
+func (h *ExportHandler) stream(c *gin.Context, name string, write func(io.Writer, export.Options) error) {
+	opts, err := export.ParseOptions(c.Request.URL.Query())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+	w := &attachmentWriter{c: c, filename: name, opts: opts}
+	if err := write(w, opts); err != nil {
+		if !w.started {
+			c.Error(err)
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "export aborted", "export", name, "error", err)
+		c.Abort()
+	}
+}
+
+func (h *ExportHandler) exportList(c *gin.Context, name string, spec repositories.ListSpec, write func(*gin.Context, io.Writer, *repositories.ListQuery, export.Options) error) {
+	query, ok := bindListQuery(c, spec, h.location)
+	if !ok {
+		return
+	}
+	h.stream(c, name, func(w io.Writer, opts export.Options) error {
+		return write(c, w, query, opts)
+	})
+}
+
+func (h *ExportHandler) ExportTickets(c *gin.Context) {
+	h.exportList(c, "tickets", repositories.TicketListSpec, func(c *gin.Context, w io.Writer, query *repositories.ListQuery, opts export.Options) error {
+		return h.exportService.ExportTickets(c.Request.Context(), w, query, opts)
+	})
+}
+
+func (h *ExportHandler) ExportPayments(c *gin.Context) {
+	h.exportList(c, "payments", repositories.PaymentListSpec, func(c *gin.Context, w io.Writer, query *repositories.ListQuery, opts export.Options) error {
+		return h.exportService.ExportPayments(c.Request.Context(), w, query, opts)
+	})
+}
+
+func (h *ExportHandler) ExportRefunds(c *gin.Context) {
+	h.exportList(c, "refunds", repositories.PaymentListSpec, func(c *gin.Context, w io.Writer, query *repositories.ListQuery, opts export.Options) error {
+		return h.exportService.ExportRefunds(c.Request.Context(), w, query, opts)
+	})
+}
+
+func (h *ExportHandler) ExportUsers(c *gin.Context) {
+	h.exportList(c, "users", repositories.UserListSpec, func(c *gin.Context, w io.Writer, query *repositories.ListQuery, opts export.Options) error {
+		return h.exportService.ExportUsers(c.Request.Context(), w, query, opts)
+	})
+}
+
+func (h *ExportHandler) ExportAnalytics(c *gin.Context) {
+	query, ok := bindAnalyticsQuery(c)
+	if !ok {
+		return
+	}
+	report := c.Param("report")
+	h.stream(c, report, func(w io.Writer, opts export.Options) error {
+		return h.exportService.ExportAnalytics(c.Request.Context(), w, report, query, opts)
+	})
+}
@@ -20,12 +20,12 @@ func NewGenreHandler(genreService *services.GenreService) *GenreHandler {
 func (h *GenreHandler) CreateGenre(c *gin.Context) {
 	var genre models.Genre
 	if err := c.ShouldBindJSON(&genre); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindingError(err))
 		return
 	}
 
 	if err := h.genreService.CreateGenre(c.Request.Context(), &genre); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -35,7 +35,7 @@ func (h *GenreHandler) CreateGenre(c *gin.Context) {
 func (h *GenreHandler) GetAllGenres(c *gin.Context) {
 	genres, err := h.genreService.GetAllGenres(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, genres)
@@ -44,18 +44,18 @@ func (h *GenreHandler) GetAllGenres(c *gin.Context) {
 func (h *GenreHandler) UpdateGenre(c *gin.Context) {
 	id, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid genre ID"})
+		c.Error(invalidID("genre ID"))
 		return
 	}
 
 	var genre models.Genre
 	if err := c.ShouldBindJSON(&genre); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindingError(err))
 		return
 	}
 
 	if err := h.genreService.UpdateGenre(c.Request.Context(), id, &genre); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -65,14 +65,63 @@ func (h *GenreHandler) UpdateGenre(c *gin.Context) {
 func (h *GenreHandler) DeleteGenre(c *gin.Context) {
 	id, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid genre ID"})
+		c.Error(invalidID("genre ID"))
 		return
 	}
 
 	if err := h.genreService.DeleteGenre(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "genre deleted successfully"})
 }
+
+func (h *GenreHandler) GetTranslations(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("genre ID"))
+		return
+	}
+
+	translations, err := h.genreService.GetTranslations(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, translations)
+}
+
+func (h *GenreHandler) SetTranslation(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("genre ID"))
+		return
+	}
+
+	var translation models.GenreTranslation
+	if err := c.ShouldBindJSON(&translation); err != nil {
+		c.Error(bindingError(err))
+		return
+	}
+
+	if err := h.genreService.SetTranslation(c.Request.Context(), id, c.Param("lang"), translation); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, translation)
+}
+
+func (h *GenreHandler) DeleteTranslation(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("genre ID"))
+		return
+	}
+
+	if err := h.genreService.DeleteTranslation(c.Request.Context(), id, c.Param("lang")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "translation deleted successfully"})
+}
@@ -2,30 +2,39 @@ package handlers
 
 import (
 	"cinema-system/internal/models"
+	"cinema-system/internal/money"
 	"cinema-system/internal/repositories"
+	"cinema-system/internal/services"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type HallHandler struct {
-	hallRepo *repositories.HallRepository
+	hallRepo repositories.HallStore
+	location *time.Location
 }
 
-func NewHallHandler(hallRepo *repositories.HallRepository) *HallHandler {
-	return &HallHandler{hallRepo: hallRepo}
+func NewHallHandler(hallRepo repositories.HallStore, location *time.Location) *HallHandler {
+	return &HallHandler{hallRepo: hallRepo, location: location}
 }
 
 func (h *HallHandler) CreateHall(c *gin.Context) {
 	var hall models.Hall
 	if err := c.ShouldBindJSON(&hall); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindingError(err))
+		return
+	}
+
+	if err := normalizeHallCurrency(&hall); err != nil {
+		c.Error(err)
 		return
 	}
 
 	if err := h.hallRepo.Create(c.Request.Context(), &hall); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -33,24 +42,29 @@ func (h *HallHandler) CreateHall(c *gin.Context) {
 }
 
 func (h *HallHandler) GetAllHalls(c *gin.Context) {
-	halls, err := h.hallRepo.GetAll(c.Request.Context())
+	query, ok := bindListQuery(c, repositories.HallListSpec, h.location)
+	if !ok {
+		return
+	}
+
+	page, err := h.hallRepo.List(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, halls)
+	respondPage(c, page)
 }
 
 func (h *HallHandler) GetHall(c *gin.Context) {
 	id, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hall ID"})
+		c.Error(invalidID("hall ID"))
 		return
 	}
 	hall, err := h.hallRepo.FindByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "hall not found"})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, hall)
@@ -59,18 +73,23 @@ func (h *HallHandler) GetHall(c *gin.Context) {
 func (h *HallHandler) UpdateHall(c *gin.Context) {
 	id, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hall ID"})
+		c.Error(invalidID("hall ID"))
 		return
 	}
 
 	var hall models.Hall
 	if err := c.ShouldBindJSON(&hall); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindingError(err))
+		return
+	}
+
+	if err := normalizeHallCurrency(&hall); err != nil {
+		c.Error(err)
 		return
 	}
 
 	if err := h.hallRepo.Update(c.Request.Context(), id, &hall); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -80,14 +99,30 @@ func (h *HallHandler) UpdateHall(c *gin.Context) {
 func (h *HallHandler) DeleteHall(c *gin.Context) {
 	id, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hall ID"})
+		c.Error(invalidID("hall ID"))
 		return
 	}
 
 	if err := h.hallRepo.Delete(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "hall deleted successfully"})
 }
+
+func normalizeHallCurrency(hall *models.Hall) error {
+	if hall.Currency == "" {
+		hall.Currency = money.DefaultCurrency
+		return nil
+	}
+	currency, err := money.Lookup(hall.Currency)
+	if err != nil {
+		return err
+	}
+	if currency.Code != money.DefaultCurrency {
+		return services.ErrHallCurrency.With("currency", money.DefaultCurrency)
+	}
+	hall.Currency = currency.Code
+	return nil
+}
@@ -18,24 +18,30 @@ func NewMovieHandler(movieService *services.MovieService) *MovieHandler {
 }
 
 func (h *MovieHandler) GetAllMovies(c *gin.Context) {
-	movies, err := h.movieService.GetAllMovies(c.Request.Context())
+	var query models.MovieQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(bindingError(err))
+		return
+	}
+
+	page, err := h.movieService.SearchMovies(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, movies)
+	respondPage(c, page)
 }
 
 func (h *MovieHandler) GetMovie(c *gin.Context) {
 	id, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie ID"})
+		c.Error(invalidID("movie ID"))
 		return
 	}
 
 	movie, err := h.movieService.GetMovieByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "movie not found"})
+		c.Error(err)
 		return
 	}
 
@@ -45,12 +51,12 @@ func (h *MovieHandler) GetMovie(c *gin.Context) {
 func (h *MovieHandler) CreateMovie(c *gin.Context) {
 	var movie models.Movie
 	if err := c.ShouldBindJSON(&movie); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindingError(err))
 		return
 	}
 
 	if err := h.movieService.CreateMovie(c.Request.Context(), &movie); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -60,18 +66,18 @@ func (h *MovieHandler) CreateMovie(c *gin.Context) {
 func (h *MovieHandler) UpdateMovie(c *gin.Context) {
 	id, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie ID"})
+		c.Error(invalidID("movie ID"))
 		return
 	}
 
 	var movie models.Movie
 	if err := c.ShouldBindJSON(&movie); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindingError(err))
 		return
 	}
 
 	if err := h.movieService.UpdateMovie(c.Request.Context(), id, &movie); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -81,14 +87,63 @@ func (h *MovieHandler) UpdateMovie(c *gin.Context) {
 func (h *MovieHandler) DeleteMovie(c *gin.Context) {
 	id, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie ID"})
+		c.Error(invalidID("movie ID"))
 		return
 	}
 
 	if err := h.movieService.DeleteMovie(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "movie deleted successfully"})
 }
+
+func (h *MovieHandler) GetTranslations(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("movie ID"))
+		return
+	}
+
+	translations, err := h.movieService.GetTranslations(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, translations)
+}
+
+func (h *MovieHandler) SetTranslation(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("movie ID"))
+		return
+	}
+
+	var translation models.MovieTranslation
+	if err := c.ShouldBindJSON(&translation); err != nil {
+		c.Error(bindingError(err))
+		return
+	}
+
+	if err := h.movieService.SetTranslation(c.Request.Context(), id, c.Param("lang"), translation); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, translation)
+}
+
+func (h *MovieHandler) DeleteTranslation(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("movie ID"))
+		return
+	}
+
+	if err := h.movieService.DeleteTranslation(c.Request.Context(), id, c.Param("lang")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "translation deleted successfully"})
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+func NewNotificationHandler(notificationService *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	userID := c.MustGet("userID").(primitive.ObjectID)
+
+	prefs, err := h.notificationService.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
+
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	userID := c.MustGet("userID").(primitive.ObjectID)
+
+	var prefs models.NotificationPreferences
+	if err := c.ShouldBindJSON(&prefs); err != nil {
+		c.Error(bindingError(err))
+		return
+	}
+
+	if err := h.notificationService.UpdatePreferences(c.Request.Context(), userID, &prefs); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
+
+func (h *NotificationHandler) GetMyNotifications(c *gin.Context) {
+	userID := c.MustGet("userID").(primitive.ObjectID)
+
+	items, err := h.notificationService.GetUserNotifications(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+func (h *NotificationHandler) GetFailedNotifications(c *gin.Context) {
+	items, err := h.notificationService.GetFailedNotifications(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+func (h *NotificationHandler) RetryNotification(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("notification ID"))
+		return
+	}
+
+	if err := h.notificationService.RetryNotification(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "notification queued for retry"})
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func bindListQuery(c *gin.Context, spec repositories.ListSpec, location *time.Location) (*repositories.ListQuery, bool) {
+	query, err := repositories.ParseListQuery(c.Request.URL.Query(), spec, location)
+	if err != nil {
+		c.Error(err)
+		return nil, false
+	}
+	return query, true
+}
+
+func respondPage[T any](c *gin.Context, page *models.Page[T]) {
+	next := *c.Request.URL
+	values := next.Query()
+	switch {
+	case page.NextCursor != "":
+		values.Set("cursor", page.NextCursor)
+	case page.Page > 0 && page.Page*page.Limit < page.Total:
+		values.Set("page", strconv.FormatInt(page.Page+1, 10))
+	default:
+		c.JSON(http.StatusOK, page)
+		return
+	}
+	next.RawQuery = values.Encode()
+	page.Next = next.RequestURI()
+
+	c.JSON(http.StatusOK, page)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"cinema-system/internal/middleware"
 	"cinema-system/internal/models"
 	"cinema-system/internal/services"
 	"net/http"
@@ -20,19 +21,19 @@ func NewPaymentCardHandler(cardService *services.PaymentCardService) *PaymentCar
 func (h *PaymentCardHandler) CreateCard(c *gin.Context) {
 	var req models.PaymentCardCreate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindingError(err))
 		return
 	}
 
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(middleware.ErrAuthenticationRequired)
 		return
 	}
 
 	card, err := h.cardService.CreateCard(c.Request.Context(), userID.(primitive.ObjectID), req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -45,13 +46,13 @@ func (h *PaymentCardHandler) CreateCard(c *gin.Context) {
 func (h *PaymentCardHandler) GetMyCards(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(middleware.ErrAuthenticationRequired)
 		return
 	}
 
 	cards, err := h.cardService.GetUserCards(c.Request.Context(), userID.(primitive.ObjectID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -61,19 +62,19 @@ func (h *PaymentCardHandler) GetMyCards(c *gin.Context) {
 func (h *PaymentCardHandler) GetCard(c *gin.Context) {
 	cardID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid card ID"})
+		c.Error(invalidID("card ID"))
 		return
 	}
 
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(middleware.ErrAuthenticationRequired)
 		return
 	}
 
 	card, err := h.cardService.GetCardByID(c.Request.Context(), cardID, userID.(primitive.ObjectID))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -83,19 +84,19 @@ func (h *PaymentCardHandler) GetCard(c *gin.Context) {
 func (h *PaymentCardHandler) DeleteCard(c *gin.Context) {
 	cardID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid card ID"})
+		c.Error(invalidID("card ID"))
 		return
 	}
 
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(middleware.ErrAuthenticationRequired)
 		return
 	}
 
 	err = h.cardService.DeleteCard(c.Request.Context(), cardID, userID.(primitive.ObjectID))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -105,13 +106,13 @@ func (h *PaymentCardHandler) DeleteCard(c *gin.Context) {
 func (h *PaymentCardHandler) GetUserCards(c *gin.Context) {
 	userID, err := primitive.ObjectIDFromHex(c.Param("userId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		c.Error(invalidID("user ID"))
 		return
 	}
 
 	cards, err := h.cardService.GetUserCards(c.Request.Context(), userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"cinema-system/internal/middleware"
 	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
 	"cinema-system/internal/services"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -11,28 +14,29 @@ import (
 
 type PaymentHandler struct {
 	paymentService *services.PaymentService
+	location       *time.Location
 }
 
-func NewPaymentHandler(paymentService *services.PaymentService) *PaymentHandler {
-	return &PaymentHandler{paymentService: paymentService}
+func NewPaymentHandler(paymentService *services.PaymentService, location *time.Location) *PaymentHandler {
+	return &PaymentHandler{paymentService: paymentService, location: location}
 }
 
 func (h *PaymentHandler) CreatePayment(c *gin.Context) {
 	var req models.PaymentCreate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindingError(err))
 		return
 	}
 
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(middleware.ErrAuthenticationRequired)
 		return
 	}
 
 	payment, err := h.paymentService.CreatePayment(c.Request.Context(), userID.(primitive.ObjectID), req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -45,13 +49,13 @@ func (h *PaymentHandler) CreatePayment(c *gin.Context) {
 func (h *PaymentHandler) GetMyPayments(c *gin.Context) {
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(middleware.ErrAuthenticationRequired)
 		return
 	}
 
 	payments, err := h.paymentService.GetUserPayments(c.Request.Context(), userID.(primitive.ObjectID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -61,19 +65,19 @@ func (h *PaymentHandler) GetMyPayments(c *gin.Context) {
 func (h *PaymentHandler) GetPayment(c *gin.Context) {
 	paymentID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payment ID"})
+		c.Error(invalidID("payment ID"))
 		return
 	}
 
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(middleware.ErrAuthenticationRequired)
 		return
 	}
 
 	payment, err := h.paymentService.GetPaymentByID(c.Request.Context(), paymentID, userID.(primitive.ObjectID))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -83,19 +87,19 @@ func (h *PaymentHandler) GetPayment(c *gin.Context) {
 func (h *PaymentHandler) RefundPayment(c *gin.Context) {
 	paymentID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payment ID"})
+		c.Error(invalidID("payment ID"))
 		return
 	}
 
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(middleware.ErrAuthenticationRequired)
 		return
 	}
 
 	err = h.paymentService.RefundPayment(c.Request.Context(), paymentID, userID.(primitive.ObjectID))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -103,25 +107,30 @@ func (h *PaymentHandler) RefundPayment(c *gin.Context) {
 }
 
 func (h *PaymentHandler) GetAllPayments(c *gin.Context) {
-	payments, err := h.paymentService.GetAllPayments(c.Request.Context())
+	query, ok := bindListQuery(c, repositories.PaymentListSpec, h.location)
+	if !ok {
+		return
+	}
+
+	page, err := h.paymentService.GetAllPayments(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"payments": payments})
+	respondPage(c, page)
 }
 
 func (h *PaymentHandler) GetUserPaymentsByID(c *gin.Context) {
 	userID, err := primitive.ObjectIDFromHex(c.Param("userId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		c.Error(invalidID("user ID"))
 		return
 	}
 
 	payments, err := h.paymentService.GetUserPayments(c.Request.Context(), userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -131,19 +140,19 @@ func (h *PaymentHandler) GetUserPaymentsByID(c *gin.Context) {
 func (h *PaymentHandler) TopUpBalance(c *gin.Context) {
 	var req models.PaymentCreate
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindingError(err))
 		return
 	}
 
 	userID, exists := c.Get("userID")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		c.Error(middleware.ErrAuthenticationRequired)
 		return
 	}
 
 	payment, err := h.paymentService.TopUpBalance(c.Request.Context(), userID.(primitive.ObjectID), req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RecommendationHandler struct {
+	recommendationService *services.RecommendationService
+}
+
+func NewRecommendationHandler(recommendationService *services.RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{recommendationService: recommendationService}
+}
+
+func (h *RecommendationHandler) GetMyRecommendations(c *gin.Context) {
+	userID := c.MustGet("userID").(primitive.ObjectID)
+
+	var query models.RecommendationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(bindingError(err))
+		return
+	}
+
+	rec, err := h.recommendationService.GetForUser(c.Request.Context(), userID, query.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rec)
+}
+
+func (h *RecommendationHandler) GetSimilarMovies(c *gin.Context) {
+	movieID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("movie ID"))
+		return
+	}
+
+	var query models.RecommendationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(bindingError(err))
+		return
+	}
+
+	rec, err := h.recommendationService.GetSimilar(c.Request.Context(), movieID, query.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rec)
+}
@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
 	"cinema-system/internal/services"
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -11,10 +14,11 @@ import (
 
 type ReviewHandler struct {
 	reviewService *services.ReviewService
+	location      *time.Location
 }
 
-func NewReviewHandler(reviewService *services.ReviewService) *ReviewHandler {
-	return &ReviewHandler{reviewService: reviewService}
+func NewReviewHandler(reviewService *services.ReviewService, location *time.Location) *ReviewHandler {
+	return &ReviewHandler{reviewService: reviewService, location: location}
 }
 
 func (h *ReviewHandler) CreateReview(c *gin.Context) {
@@ -22,14 +26,14 @@ func (h *ReviewHandler) CreateReview(c *gin.Context) {
 
 	var review models.Review
 	if err := c.ShouldBindJSON(&review); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindingError(err))
 		return
 	}
 
 	review.UserID = userID
 
 	if err := h.reviewService.CreateReview(c.Request.Context(), &review); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -39,17 +43,22 @@ func (h *ReviewHandler) CreateReview(c *gin.Context) {
 func (h *ReviewHandler) GetMovieReviews(c *gin.Context) {
 	movieID, err := primitive.ObjectIDFromHex(c.Param("movieId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie ID"})
+		c.Error(invalidID("movie ID"))
 		return
 	}
 
-	reviews, err := h.reviewService.GetMovieReviews(c.Request.Context(), movieID)
+	query, ok := bindListQuery(c, repositories.ReviewListSpec, h.location)
+	if !ok {
+		return
+	}
+
+	page, err := h.reviewService.GetMovieReviews(c.Request.Context(), movieID, query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, reviews)
+	respondPage(c, page)
 }
 
 func (h *ReviewHandler) GetMyReviews(c *gin.Context) {
@@ -57,7 +66,7 @@ func (h *ReviewHandler) GetMyReviews(c *gin.Context) {
 
 	reviews, err := h.reviewService.GetMyReviews(c.Request.Context(), userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -67,7 +76,7 @@ func (h *ReviewHandler) GetMyReviews(c *gin.Context) {
 func (h *ReviewHandler) UpdateReview(c *gin.Context) {
 	reviewID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review ID"})
+		c.Error(invalidID("review ID"))
 		return
 	}
 
@@ -78,12 +87,12 @@ func (h *ReviewHandler) UpdateReview(c *gin.Context) {
 		Comment string `json:"comment"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(bindingError(err))
 		return
 	}
 
 	if err := h.reviewService.UpdateReview(c.Request.Context(), reviewID, userID, req.Rating, req.Comment); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -93,7 +102,7 @@ func (h *ReviewHandler) UpdateReview(c *gin.Context) {
 func (h *ReviewHandler) DeleteReview(c *gin.Context) {
 	reviewID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid review ID"})
+		c.Error(invalidID("review ID"))
 		return
 	}
 
@@ -102,9 +111,190 @@ func (h *ReviewHandler) DeleteReview(c *gin.Context) {
 	isAdmin := role == string(models.RoleAdmin)
 
 	if err := h.reviewService.DeleteReview(c.Request.Context(), reviewID, userID, isAdmin); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "review deleted successfully"})
 }
+
+func (h *ReviewHandler) ReportReview(c *gin.Context) {
+	reviewID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("review ID"))
+		return
+	}
+
+	userID := c.MustGet("userID").(primitive.ObjectID)
+
+	var req models.ReviewReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(bindingError(err))
+		return
+	}
+
+	report, err := h.reviewService.ReportReview(c.Request.Context(), reviewID, userID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+func (h *ReviewHandler) GetModerationQueue(c *gin.Context) {
+	query, ok := bindListQuery(c, repositories.ReviewModerationListSpec, h.location)
+	if !ok {
+		return
+	}
+
+	page, err := h.reviewService.GetModerationQueue(c.Request.Context(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	respondPage(c, page)
+}
+
+func (h *ReviewHandler) GetReviewReports(c *gin.Context) {
+	reviewID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("review ID"))
+		return
+	}
+
+	reports, err := h.reviewService.GetReviewReports(c.Request.Context(), reviewID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reports)
+}
+
+func (h *ReviewHandler) ApproveReview(c *gin.Context) {
+	h.moderate(c, h.reviewService.ApproveReview)
+}
+
+func (h *ReviewHandler) RejectReview(c *gin.Context) {
+	h.moderate(c, h.reviewService.RejectReview)
+}
+
+func (h *ReviewHandler) HideReview(c *gin.Context) {
+	h.moderate(c, h.reviewService.HideReview)
+}
+
+func (h *ReviewHandler) moderate(c *gin.Context, action func(context.Context, primitive.ObjectID, primitive.ObjectID, string) (*models.Review, error)) {
+	reviewID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("review ID"))
+		return
+	}
+
+	moderatorID := c.MustGet("userID").(primitive.ObjectID)
+
+	var req models.ModerationDecision
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.Error(bindingError(err))
+			return
+		}
+	}
+
+	review, err := action(c.Request.Context(), reviewID, moderatorID, req.Reason)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+func (h *ReviewHandler) RecomputeRatings(c *gin.Context) {
+	c.JSON(http.StatusAccepted, h.reviewService.RequestRecompute())
+}
+
+func (h *ReviewHandler) GetRecomputeStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, h.reviewService.RecomputeStatus())
+}
+
+func (h *ReviewHandler) VoteReview(c *gin.Context) {
+	reviewID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("review ID"))
+		return
+	}
+
+	userID := c.MustGet("userID").(primitive.ObjectID)
+
+	var req models.ReviewVoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(bindingError(err))
+		return
+	}
+
+	review, err := h.reviewService.VoteReview(c.Request.Context(), reviewID, userID, *req.Helpful)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+func (h *ReviewHandler) RemoveVote(c *gin.Context) {
+	reviewID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("review ID"))
+		return
+	}
+
+	userID := c.MustGet("userID").(primitive.ObjectID)
+
+	if err := h.reviewService.RemoveVote(c.Request.Context(), reviewID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "vote removed successfully"})
+}
+
+func (h *ReviewHandler) ReplyToReview(c *gin.Context) {
+	reviewID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("review ID"))
+		return
+	}
+
+	staffID := c.MustGet("userID").(primitive.ObjectID)
+
+	var req models.ReviewReplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(bindingError(err))
+		return
+	}
+
+	review, err := h.reviewService.ReplyToReview(c.Request.Context(), reviewID, staffID, req.Text)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+func (h *ReviewHandler) DeleteReply(c *gin.Context) {
+	reviewID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("review ID"))
+		return
+	}
+
+	if err := h.reviewService.DeleteReply(c.Request.Context(), reviewID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "reply deleted successfully"})
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"cinema-system/internal/models"
+	"cinema-system/internal/money"
 	"cinema-system/internal/services"
 	"net/http"
 	"time"
@@ -11,42 +12,46 @@ import (
 )
 
 type SessionHandler struct {
-	sessionService *services.SessionService
+	sessionService      *services.SessionService
+	exchangeRateService *services.ExchangeRateService
 }
 
-func NewSessionHandler(sessionService *services.SessionService) *SessionHandler {
-	return &SessionHandler{sessionService: sessionService}
+func NewSessionHandler(sessionService *services.SessionService, exchangeRateService *services.ExchangeRateService) *SessionHandler {
+	return &SessionHandler{
+		sessionService:      sessionService,
+		exchangeRateService: exchangeRateService,
+	}
 }
 
 type CreateSessionRequest struct {
-	MovieID   string  `json:"movie_id" binding:"required"`
-	HallID    string  `json:"hall_id" binding:"required"`
-	StartTime string  `json:"start_time" binding:"required"`
-	Price     float64 `json:"price"`
+	MovieID   string `json:"movie_id" binding:"required"`
+	HallID    string `json:"hall_id" binding:"required"`
+	StartTime string `json:"start_time" binding:"required"`
+	Price     int64  `json:"price"`
 }
 
 func (h *SessionHandler) CreateSession(c *gin.Context) {
 	var req CreateSessionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		c.Error(bindingError(err))
 		return
 	}
 
 	movieID, err := primitive.ObjectIDFromHex(req.MovieID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid movie ID format"})
+		c.Error(invalidID("movie ID"))
 		return
 	}
 
 	hallID, err := primitive.ObjectIDFromHex(req.HallID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid hall ID format"})
+		c.Error(invalidID("hall ID"))
 		return
 	}
 
 	startTime, err := time.Parse(time.RFC3339, req.StartTime)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start time format (expected ISO-8601)"})
+		c.Error(errInvalidStartTime)
 		return
 	}
 
@@ -54,11 +59,11 @@ func (h *SessionHandler) CreateSession(c *gin.Context) {
 		MovieID:   movieID,
 		HallID:    hallID,
 		StartTime: startTime,
-		Price:     req.Price,
+		Price:     money.Money{Amount: req.Price},
 	}
 
 	if err := h.sessionService.CreateSession(c.Request.Context(), &session); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -68,7 +73,11 @@ func (h *SessionHandler) CreateSession(c *gin.Context) {
 func (h *SessionHandler) GetUpcomingSessions(c *gin.Context) {
 	sessions, err := h.sessionService.GetUpcomingSessions(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
+		return
+	}
+
+	if !h.applyDisplayPrices(c, sessions) {
 		return
 	}
 
@@ -78,7 +87,7 @@ func (h *SessionHandler) GetUpcomingSessions(c *gin.Context) {
 func (h *SessionHandler) GetUpcomingMovieIDs(c *gin.Context) {
 	ids, err := h.sessionService.GetUpcomingMovieIDs(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -88,13 +97,17 @@ func (h *SessionHandler) GetUpcomingMovieIDs(c *gin.Context) {
 func (h *SessionHandler) GetMovieSessions(c *gin.Context) {
 	movieID, err := primitive.ObjectIDFromHex(c.Param("movieId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid movie ID"})
+		c.Error(invalidID("movie ID"))
 		return
 	}
 
 	sessions, err := h.sessionService.GetSessionsByMovie(c.Request.Context(), movieID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
+		return
+	}
+
+	if !h.applyDisplayPrices(c, sessions) {
 		return
 	}
 
@@ -104,45 +117,49 @@ func (h *SessionHandler) GetMovieSessions(c *gin.Context) {
 func (h *SessionHandler) GetSession(c *gin.Context) {
 	sessionID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session ID"})
+		c.Error(invalidID("session ID"))
 		return
 	}
 	session, err := h.sessionService.GetSessionByID(c.Request.Context(), sessionID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, session)
+	sessions := []models.Session{*session}
+	if !h.applyDisplayPrices(c, sessions) {
+		return
+	}
+	c.JSON(http.StatusOK, sessions[0])
 }
 
 func (h *SessionHandler) UpdateSession(c *gin.Context) {
 	id, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session ID"})
+		c.Error(invalidID("session ID"))
 		return
 	}
 
 	var req CreateSessionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		c.Error(bindingError(err))
 		return
 	}
 
 	movieID, err := primitive.ObjectIDFromHex(req.MovieID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid movie ID format"})
+		c.Error(invalidID("movie ID"))
 		return
 	}
 
 	hallID, err := primitive.ObjectIDFromHex(req.HallID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid hall ID format"})
+		c.Error(invalidID("hall ID"))
 		return
 	}
 
 	startTime, err := time.Parse(time.RFC3339, req.StartTime)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start time format (expected ISO-8601)"})
+		c.Error(errInvalidStartTime)
 		return
 	}
 
@@ -150,11 +167,11 @@ func (h *SessionHandler) UpdateSession(c *gin.Context) {
 		MovieID:   movieID,
 		HallID:    hallID,
 		StartTime: startTime,
-		Price:     req.Price,
+		Price:     money.Money{Amount: req.Price},
 	}
 
 	if err := h.sessionService.UpdateSession(c.Request.Context(), id, &session); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -164,14 +181,26 @@ func (h *SessionHandler) UpdateSession(c *gin.Context) {
 func (h *SessionHandler) DeleteSession(c *gin.Context) {
 	sessionID, err := primitive.ObjectIDFromHex(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session ID"})
+		c.Error(invalidID("session ID"))
 		return
 	}
 
 	if err := h.sessionService.DeleteSession(c.Request.Context(), sessionID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "session deleted successfully"})
 }
+
+func (h *SessionHandler) applyDisplayPrices(c *gin.Context, sessions []models.Session) bool {
+	currency := c.Query("currency")
+	if currency == "" {
+		return true
+	}
+	if err := h.exchangeRateService.ApplyDisplayPrices(c.Request.Context(), sessions, currency); err != nil {
+		c.Error(err)
+		return false
+	}
+	return true
+}
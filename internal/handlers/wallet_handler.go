@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"cinema-system/internal/apperrors"
+	"cinema-system/internal/models"
+	"cinema-system/internal/services"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WalletHandler struct {
+	walletService *services.WalletService
+}
+
+func NewWalletHandler(walletService *services.WalletService) *WalletHandler {
+	return &WalletHandler{walletService: walletService}
+}
+
+func (h *WalletHandler) GetApplePass(c *gin.Context) {
+	userID := c.MustGet("userID").(primitive.ObjectID)
+	ticketID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("ticket ID"))
+		return
+	}
+
+	pass, err := h.walletService.GenerateApplePass(c.Request.Context(), ticketID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="ticket-%s.pkpass"`, ticketID.Hex()))
+	c.Data(http.StatusOK, "application/vnd.apple.pkpass", pass)
+}
+
+func (h *WalletHandler) GetGoogleSaveLink(c *gin.Context) {
+	userID := c.MustGet("userID").(primitive.ObjectID)
+	ticketID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("ticket ID"))
+		return
+	}
+
+	link, err := h.walletService.GoogleSaveLink(c.Request.Context(), ticketID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"save_url": link})
+}
+
+func applePassToken(c *gin.Context) string {
+	return strings.TrimPrefix(c.GetHeader("Authorization"), "ApplePass ")
+}
+
+func (h *WalletHandler) RegisterDevice(c *gin.Context) {
+	var req models.WalletRegistrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(bindingError(err))
+		return
+	}
+
+	created, err := h.walletService.RegisterDevice(
+		c.Request.Context(),
+		c.Param("deviceId"),
+		c.Param("passTypeId"),
+		c.Param("serial"),
+		applePassToken(c),
+		req.PushToken,
+	)
+	if err != nil {
+		c.Status(apperrors.From(err).Status())
+		return
+	}
+
+	if created {
+		c.Status(http.StatusCreated)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (h *WalletHandler) UnregisterDevice(c *gin.Context) {
+	err := h.walletService.UnregisterDevice(
+		c.Request.Context(),
+		c.Param("deviceId"),
+		c.Param("passTypeId"),
+		c.Param("serial"),
+		applePassToken(c),
+	)
+	if err != nil {
+		c.Status(apperrors.From(err).Status())
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (h *WalletHandler) GetUpdatedSerials(c *gin.Context) {
+	serials, err := h.walletService.GetUpdatedSerials(
+		c.Request.Context(),
+		c.Param("deviceId"),
+		c.Param("passTypeId"),
+		c.Query("passesUpdatedSince"),
+	)
+	if err != nil {
+		c.Status(apperrors.From(err).Status())
+		return
+	}
+
+	if serials == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+	c.JSON(http.StatusOK, serials)
+}
+
+func (h *WalletHandler) GetLatestPass(c *gin.Context) {
+	pass, updatedAt, err := h.walletService.GetLatestPass(
+		c.Request.Context(),
+		c.Param("passTypeId"),
+		c.Param("serial"),
+		applePassToken(c),
+	)
+	if err != nil {
+		c.Status(apperrors.From(err).Status())
+		return
+	}
+
+	c.Header("Last-Modified", updatedAt.UTC().Format(http.TimeFormat))
+	c.Data(http.StatusOK, "application/vnd.apple.pkpass", pass)
+}
+
+func (h *WalletHandler) Log(c *gin.Context) {
+	var req models.WalletLogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	for _, message := range req.Logs {
+		slog.InfoContext(c.Request.Context(), "wallet device log", "message", message)
+	}
+	c.Status(http.StatusOK)
+}
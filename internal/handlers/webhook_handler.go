@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+func (h *WebhookHandler) GetEventTypes(c *gin.Context) {
+	c.JSON(http.StatusOK, services.WebhookEventTypes)
+}
+
+func (h *WebhookHandler) CreateSubscription(c *gin.Context) {
+	var req models.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(bindingError(err))
+		return
+	}
+
+	subscription, err := h.webhookService.CreateSubscription(c.Request.Context(), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.WebhookSubscriptionCreated{WebhookSubscription: *subscription, Secret: subscription.Secret})
+}
+
+func (h *WebhookHandler) GetSubscriptions(c *gin.Context) {
+	subscriptions, err := h.webhookService.GetSubscriptions(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscriptions)
+}
+
+func (h *WebhookHandler) GetSubscription(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("webhook ID"))
+		return
+	}
+
+	subscription, err := h.webhookService.GetSubscription(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+func (h *WebhookHandler) UpdateSubscription(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("webhook ID"))
+		return
+	}
+
+	var req models.WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(bindingError(err))
+		return
+	}
+
+	subscription, err := h.webhookService.UpdateSubscription(c.Request.Context(), id, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+func (h *WebhookHandler) DeleteSubscription(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("webhook ID"))
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "webhook deleted successfully"})
+}
+
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("webhook ID"))
+		return
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.Error(invalidID("delivery ID"))
+		return
+	}
+
+	if err := h.webhookService.Redeliver(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "delivery queued for redelivery"})
+}
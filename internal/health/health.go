@@ -0,0 +1,162 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type Phase string
+
+const (
+	PhaseStarting Phase = "starting"
+	PhaseReady    Phase = "ready"
+	PhaseStopping Phase = "stopping"
+)
+
+const (
+	StatusOK      = "ok"
+	StatusError   = "error"
+	StatusRunning = "running"
+	StatusStopped = "stopped"
+	StatusFailed  = "failed"
+)
+
+const checkTimeout = 2 * time.Second
+
+type CheckFunc func(ctx context.Context) error
+
+type CheckResult struct {
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+type WorkerStatus struct {
+	Status    string    `json:"status"`
+	StartedAt time.Time `json:"started_at"`
+	Error     string    `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status  Phase                   `json:"status"`
+	Ready   bool                    `json:"ready"`
+	Checks  map[string]CheckResult  `json:"checks"`
+	Workers map[string]WorkerStatus `json:"workers"`
+}
+
+type namedCheck struct {
+	name  string
+	check CheckFunc
+}
+
+type Checker struct {
+	mu      sync.RWMutex
+	phase   Phase
+	checks  []namedCheck
+	workers map[string]*WorkerStatus
+	wg      sync.WaitGroup
+}
+
+func NewChecker() *Checker {
+	return &Checker{phase: PhaseStarting, workers: map[string]*WorkerStatus{}}
+}
+
+func (c *Checker) AddCheck(name string, check CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+func (c *Checker) Phase() Phase {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.phase
+}
+
+func (c *Checker) MarkReady() {
+	c.setPhase(PhaseReady)
+}
+
+func (c *Checker) MarkStopping() {
+	c.setPhase(PhaseStopping)
+}
+
+func (c *Checker) setPhase(phase Phase) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.phase = phase
+}
+
+func (c *Checker) Go(ctx context.Context, name string, run func(context.Context)) {
+	c.mu.Lock()
+	c.workers[name] = &WorkerStatus{Status: StatusRunning, StartedAt: time.Now().UTC()}
+	c.mu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		status, message := StatusStopped, ""
+		defer func() {
+			if r := recover(); r != nil {
+				status, message = StatusFailed, fmt.Sprint(r)
+				slog.Error("health: worker panicked", "worker", name, "panic", r)
+			}
+			c.mu.Lock()
+			c.workers[name].Status = status
+			c.workers[name].Error = message
+			c.mu.Unlock()
+		}()
+		run(ctx)
+	}()
+}
+
+func (c *Checker) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Checker) Check(ctx context.Context) Report {
+	c.mu.RLock()
+	phase := c.phase
+	checks := append([]namedCheck(nil), c.checks...)
+	workers := make(map[string]WorkerStatus, len(c.workers))
+	for name, status := range c.workers {
+		workers[name] = *status
+	}
+	c.mu.RUnlock()
+
+	report := Report{Status: phase, Ready: phase == PhaseReady, Checks: map[string]CheckResult{}, Workers: workers}
+
+	for _, nc := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		start := time.Now()
+		err := nc.check(checkCtx)
+		cancel()
+
+		result := CheckResult{Status: StatusOK, DurationMS: time.Since(start).Milliseconds()}
+		if err != nil {
+			result.Status, result.Error = StatusError, err.Error()
+			report.Ready = false
+		}
+		report.Checks[nc.name] = result
+	}
+
+	for _, worker := range workers {
+		if worker.Status != StatusRunning {
+			report.Ready = false
+		}
+	}
+	return report
+}
@@ -0,0 +1,67 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReadiness(t *testing.T) {
+	checker := NewChecker()
+	var dbErr error
+	checker.AddCheck("mongodb", func(context.Context) error { return dbErr })
+
+	if report := checker.Check(context.Background()); report.Ready || report.Status != PhaseStarting {
+		t.Fatalf("starting report = %+v, want not ready", report)
+	}
+
+	checker.MarkReady()
+	if report := checker.Check(context.Background()); !report.Ready || report.Checks["mongodb"].Status != StatusOK {
+		t.Fatalf("ready report = %+v", report)
+	}
+
+	dbErr = errors.New("connection refused")
+	report := checker.Check(context.Background())
+	if report.Ready || report.Checks["mongodb"].Error != "connection refused" {
+		t.Fatalf("failing check report = %+v", report)
+	}
+
+	dbErr = nil
+	checker.MarkStopping()
+	if report := checker.Check(context.Background()); report.Ready || report.Status != PhaseStopping {
+		t.Fatalf("stopping report = %+v", report)
+	}
+}
+
+func TestWorkers(t *testing.T) {
+	checker := NewChecker()
+	checker.MarkReady()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	checker.Go(ctx, "dispatcher", func(ctx context.Context) { <-ctx.Done() })
+	checker.Go(ctx, "broken", func(context.Context) { panic("boom") })
+
+	deadline := time.Now().Add(time.Second)
+	for checker.Check(context.Background()).Workers["broken"].Status != StatusFailed {
+		if time.Now().After(deadline) {
+			t.Fatal("panicking worker was not reported as failed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	report := checker.Check(context.Background())
+	if report.Ready || report.Workers["dispatcher"].Status != StatusRunning || report.Workers["broken"].Error != "boom" {
+		t.Fatalf("report = %+v", report)
+	}
+
+	cancel()
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), time.Second)
+	defer cancelWait()
+	if err := checker.Wait(waitCtx); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if status := checker.Check(context.Background()).Workers["dispatcher"].Status; status != StatusStopped {
+		t.Fatalf("dispatcher status = %s, want %s", status, StatusStopped)
+	}
+}
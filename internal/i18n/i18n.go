@@ -0,0 +1,72 @@
+package i18n
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+const Default = "en"
+
+var Supported = []string{"en", "ru", "kk"}
+
+type contextKey struct{}
+
+func IsSupported(lang string) bool {
+	for _, l := range Supported {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+func Negotiate(header string) string {
+	best, bestQ := Default, -1.0
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if i := strings.IndexByte(tag, '-'); i >= 0 {
+			tag = tag[:i]
+		}
+		if !IsSupported(tag) {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if parsed, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > bestQ {
+			best, bestQ = tag, q
+		}
+	}
+	return best
+}
+
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, contextKey{}, lang)
+}
+
+func Language(ctx context.Context) string {
+	if lang, ok := ctx.Value(contextKey{}).(string); ok {
+		return lang
+	}
+	return Default
+}
+
+type baseContentKey struct{}
+
+func WithBaseContent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, baseContentKey{}, true)
+}
+
+func BaseContent(ctx context.Context) bool {
+	base, _ := ctx.Value(baseContentKey{}).(bool)
+	return base
+}
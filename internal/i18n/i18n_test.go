@@ -0,0 +1,32 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := map[string]string{
+		"":                         "en",
+		"ru":                       "ru",
+		"kk-KZ,ru;q=0.8,en;q=0.5":  "kk",
+		"de,ru;q=0.4,en;q=0.9":     "en",
+		"fr-CA,fr;q=0.9":           "en",
+		"en;q=0.1, RU-ru;q=0.7, *": "ru",
+	}
+	for header, want := range tests {
+		if got := Negotiate(header); got != want {
+			t.Errorf("Negotiate(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	ctx := context.Background()
+	if got := Language(ctx); got != Default {
+		t.Fatalf("Language(empty) = %q, want %q", got, Default)
+	}
+	if got := Language(WithLanguage(ctx, "kk")); got != "kk" {
+		t.Fatalf("Language = %q, want kk", got)
+	}
+}
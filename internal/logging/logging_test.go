@@ -0,0 +1,34 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestRequestIDAttribute(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, "info", "json").With("component", "test")
+
+	logger.InfoContext(WithRequestID(context.Background(), "abc123"), "hello")
+	logger.DebugContext(context.Background(), "hidden")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("decode %q: %v", buf.String(), err)
+	}
+	if record["request_id"] != "abc123" || record["component"] != "test" || record["msg"] != "hello" {
+		t.Fatalf("record = %v", record)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	cases := map[string]slog.Level{"": slog.LevelInfo, "DEBUG": slog.LevelDebug, "warning": slog.LevelWarn, "error": slog.LevelError}
+	for raw, want := range cases {
+		if got := ParseLevel(raw); got != want {
+			t.Errorf("ParseLevel(%q) = %v, want %v", raw, got, want)
+		}
+	}
+}
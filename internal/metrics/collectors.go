@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var factory = promauto.With(Registry)
+
+var (
+	HTTPRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by route and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	MongoOperationDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "mongodb_operation_duration_seconds",
+		Help:    "MongoDB command latency by repository method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"repository", "method", "command", "outcome"})
+
+	TicketsSold = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "cinema_tickets_sold_total",
+		Help: "Tickets sold by ticket type.",
+	}, []string{"type"})
+
+	BookingFailures = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "cinema_booking_failures_total",
+		Help: "Failed booking attempts by error code.",
+	}, []string{"reason"})
+
+	TopUps = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "cinema_balance_top_ups_total",
+		Help: "Successful balance top-ups by currency.",
+	}, []string{"currency"})
+
+	Refunds = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "cinema_refunds_total",
+		Help: "Refunds by kind (booking or payment) and currency.",
+	}, []string{"kind", "currency"})
+)
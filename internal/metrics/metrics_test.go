@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandlerExposesRegisteredMetrics(t *testing.T) {
+	TicketsSold.WithLabelValues("ADULT").Inc()
+	HTTPRequestDuration.WithLabelValues("GET", "/api/movies", "200").Observe(0.05)
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	if got := recorder.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain; version=0.0.4") {
+		t.Fatalf("content type = %q", got)
+	}
+
+	body := recorder.Body.String()
+	for _, want := range []string{
+		"# TYPE cinema_tickets_sold_total counter\n",
+		`cinema_tickets_sold_total{type="ADULT"} 1` + "\n",
+		"# TYPE http_request_duration_seconds histogram\n",
+		`http_request_duration_seconds_bucket{method="GET",route="/api/movies",status="200",le="0.05"} 1` + "\n",
+		"go_goroutines ",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("exposition missing %q", want)
+		}
+	}
+}
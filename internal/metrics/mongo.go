@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+const repositoryPackage = "cinema-system/internal/repositories."
+
+type operation struct {
+	repository string
+	method     string
+	command    string
+}
+
+func MongoMonitor() *event.CommandMonitor {
+	var pending sync.Map
+	finish := func(requestID int64, duration time.Duration, outcome string) {
+		value, ok := pending.LoadAndDelete(requestID)
+		if !ok {
+			return
+		}
+		op := value.(operation)
+		MongoOperationDuration.WithLabelValues(op.repository, op.method, op.command, outcome).Observe(duration.Seconds())
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			repository, method := callingRepository()
+			pending.Store(e.RequestID, operation{repository: repository, method: method, command: e.CommandName})
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			finish(e.RequestID, e.Duration, "ok")
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			finish(e.RequestID, e.Duration, "error")
+		},
+	}
+}
+
+func callingRepository() (string, string) {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if name, ok := strings.CutPrefix(frame.Function, repositoryPackage); ok && strings.HasPrefix(name, "(*") {
+			receiver, method, _ := strings.Cut(strings.TrimPrefix(name, "(*"), ").")
+			method, _, _ = strings.Cut(method, ".")
+			return receiver, method
+		}
+		if !more {
+			return "other", "other"
+		}
+	}
+}
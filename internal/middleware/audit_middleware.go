@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"bytes"
+	"cinema-system/internal/apperrors"
+	"cinema-system/internal/models"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const auditBodyLimit = 64 << 10
+
+type Auditor interface {
+	Snapshot(ctx context.Context, entityType, entityID string) interface{}
+	Record(ctx context.Context, entry *models.AuditEntry, before, after interface{}) error
+}
+
+type captureWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *captureWriter) Write(data []byte) (int, error) {
+	if w.body.Len() < auditBodyLimit {
+		w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *captureWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+var auditVerbs = map[string]string{
+	http.MethodPost:   "create",
+	http.MethodPut:    "update",
+	http.MethodPatch:  "update",
+	http.MethodDelete: "delete",
+}
+
+func Audit(auditor Auditor, auditedReads ...string) gin.HandlerFunc {
+	reads := make(map[string]bool, len(auditedReads))
+	for _, route := range auditedReads {
+		reads[route] = true
+	}
+
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		verb, mutating := auditVerbs[c.Request.Method]
+		if !mutating && !(c.Request.Method == http.MethodGet && reads[route]) {
+			c.Next()
+			return
+		}
+		if !mutating {
+			verb = "read"
+		}
+
+		segments := strings.Split(strings.Trim(strings.TrimPrefix(route, "/api/admin"), "/"), "/")
+		entityType := segments[0]
+		if last := segments[len(segments)-1]; c.Request.Method == http.MethodPost && len(segments) > 1 && !strings.HasPrefix(last, ":") {
+			verb = last
+		}
+
+		var entityID string
+		if len(c.Params) > 0 {
+			entityID = c.Params[0].Value
+		}
+
+		var before interface{}
+		if mutating && entityID != "" {
+			before = auditor.Snapshot(c.Request.Context(), entityType, entityID)
+		}
+
+		var capture *captureWriter
+		if c.Request.Method == http.MethodPost && entityID == "" {
+			capture = &captureWriter{ResponseWriter: c.Writer}
+			c.Writer = capture
+		}
+
+		c.Next()
+
+		status := c.Writer.Status()
+		if last := c.Errors.Last(); last != nil {
+			status = apperrors.From(last.Err).Status()
+		}
+
+		var after interface{}
+		if mutating && status < http.StatusBadRequest {
+			if capture != nil {
+				var created struct {
+					ID string `json:"id"`
+				}
+				if json.Unmarshal(capture.body.Bytes(), &created) == nil {
+					entityID = created.ID
+				}
+			}
+			if entityID != "" && c.Request.Method != http.MethodDelete {
+				after = auditor.Snapshot(c.Request.Context(), entityType, entityID)
+			}
+		}
+
+		entry := &models.AuditEntry{
+			Action:     entityType + "." + verb,
+			EntityType: entityType,
+			EntityID:   entityID,
+			Method:     c.Request.Method,
+			Path:       route,
+			Status:     status,
+		}
+		if err := auditor.Record(c.Request.Context(), entry, before, after); err != nil {
+			slog.ErrorContext(c.Request.Context(), "audit: failed to record request", "method", c.Request.Method, "route", route, "error", err)
+		}
+	}
+}
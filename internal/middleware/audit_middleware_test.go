@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"cinema-system/internal/models"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type recordingAuditor struct {
+	entries []*models.AuditEntry
+}
+
+func (a *recordingAuditor) Snapshot(ctx context.Context, entityType, entityID string) interface{} {
+	return nil
+}
+
+func (a *recordingAuditor) Record(ctx context.Context, entry *models.AuditEntry, before, after interface{}) error {
+	a.entries = append(a.entries, entry)
+	return nil
+}
+
+func TestAuditRecordsMutationsAndListedReadsOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	auditor := &recordingAuditor{}
+	router := gin.New()
+	router.Use(Audit(auditor, "/api/admin/payment-cards/user/:userId"))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/api/admin/users", ok)
+	router.GET("/api/admin/payment-cards/user/:userId", ok)
+	router.DELETE("/api/admin/users/:id", ok)
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/api/admin/users", nil),
+		httptest.NewRequest(http.MethodGet, "/api/admin/payment-cards/user/42", nil),
+		httptest.NewRequest(http.MethodDelete, "/api/admin/users/42", nil),
+	} {
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if len(auditor.entries) != 2 {
+		t.Fatalf("entries = %d, want the card read and the delete", len(auditor.entries))
+	}
+	if got := auditor.entries[0]; got.Action != "payment-cards.read" || got.EntityID != "42" {
+		t.Errorf("read entry = %+v", got)
+	}
+	if got := auditor.entries[1]; got.Action != "users.delete" || got.EntityID != "42" {
+		t.Errorf("delete entry = %+v", got)
+	}
+}
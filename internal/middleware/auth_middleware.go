@@ -1,19 +1,28 @@
 package middleware
 
 import (
+	"cinema-system/internal/apperrors"
+	"cinema-system/internal/audit"
+	"cinema-system/internal/config"
 	"cinema-system/internal/models"
 	"fmt"
-	"net/http"
 	"strings"
 
-	"os"
-
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-func AuthRequired() gin.HandlerFunc {
+var (
+	ErrAuthenticationRequired     = apperrors.Unauthorized("authentication_required", "authentication required")
+	ErrInvalidAuthorizationHeader = apperrors.Unauthorized("invalid_authorization_header", "invalid authorization header format")
+	ErrInvalidToken               = apperrors.Unauthorized("invalid_token", "invalid or expired token")
+	ErrAdminRequired              = apperrors.Forbidden("admin_required", "admin access required")
+	ErrStaffRequired              = apperrors.Forbidden("staff_required", "staff access required")
+)
+
+func AuthRequired(auth *config.AuthConfig) gin.HandlerFunc {
+	secret := []byte(auth.JWTSecret)
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -22,64 +31,73 @@ func AuthRequired() gin.HandlerFunc {
 			if userID != "" {
 				objID, _ := primitive.ObjectIDFromHex(userID)
 				c.Set("userID", objID)
+				setActor(c, objID, c.GetHeader("X-User-Role"))
 				c.Next()
 				return
 			}
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+			c.Error(ErrAuthenticationRequired)
 			c.Abort()
 			return
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization header format"})
+			c.Error(ErrInvalidAuthorizationHeader)
 			c.Abort()
 			return
 		}
 
 		tokenString := parts[1]
-		secret := os.Getenv("JWT_SECRET")
-		if secret == "" {
-			secret = "default-secret"
-		}
-
 		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			return []byte(secret), nil
+			return secret, nil
 		})
 
 		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+			c.Error(ErrInvalidToken)
 			c.Abort()
 			return
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token claims"})
+			c.Error(ErrInvalidToken)
 			c.Abort()
 			return
 		}
 
 		userIDStr, ok := claims["user_id"].(string)
 		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user ID in token"})
+			c.Error(ErrInvalidToken)
 			c.Abort()
 			return
 		}
 
 		objID, err := primitive.ObjectIDFromHex(userIDStr)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid user ID format"})
+			c.Error(ErrInvalidToken)
 			c.Abort()
 			return
 		}
 
 		c.Set("userID", objID)
 		c.Set("role", claims["role"])
+		setActor(c, objID, claims["role"])
 		c.Next()
 	}
 }
 
+func setActor(c *gin.Context, userID primitive.ObjectID, role interface{}) {
+	actor := audit.Actor{
+		UserID:    userID,
+		IP:        c.ClientIP(),
+		RequestID: c.GetString("requestID"),
+	}
+	if role != nil {
+		actor.Role = strings.ToUpper(fmt.Sprintf("%v", role))
+	}
+	c.Request = c.Request.WithContext(audit.WithActor(c.Request.Context(), actor))
+}
+
 func AdminRequired() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		role, exists := c.Get("role")
@@ -88,10 +106,27 @@ func AdminRequired() gin.HandlerFunc {
 		}
 
 		if strings.ToUpper(fmt.Sprintf("%v", role)) != string(models.RoleAdmin) {
-			c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
+			c.Error(ErrAdminRequired)
 			c.Abort()
 			return
 		}
 		c.Next()
 	}
 }
+
+func StaffRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, exists := c.Get("role")
+		if !exists {
+			role = c.GetHeader("X-User-Role")
+		}
+
+		switch models.Role(strings.ToUpper(fmt.Sprintf("%v", role))) {
+		case models.RoleStaff, models.RoleAdmin:
+			c.Next()
+		default:
+			c.Error(ErrStaffRequired)
+			c.Abort()
+		}
+	}
+}
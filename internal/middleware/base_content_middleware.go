@@ -0,0 +1,14 @@
+package middleware
+
+import (
+	"cinema-system/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+func BaseContent() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(i18n.WithBaseContent(c.Request.Context()))
+		c.Next()
+	}
+}
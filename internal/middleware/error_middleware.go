@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"cinema-system/internal/apperrors"
+	"cinema-system/internal/i18n"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		last := c.Errors.Last()
+		if last == nil {
+			return
+		}
+
+		err := apperrors.From(last.Err)
+		if err.Kind == apperrors.KindInternal {
+			slog.ErrorContext(c.Request.Context(), "request failed", "method", c.Request.Method, "path", c.Request.URL.Path, "error", err)
+		}
+		if c.Writer.Written() {
+			return
+		}
+
+		problem := apperrors.NewProblem(err, i18n.Language(c.Request.Context()), c.Request.URL.Path)
+
+		c.Header("Content-Type", "application/problem+json")
+		c.JSON(problem.Status, problem)
+	}
+}
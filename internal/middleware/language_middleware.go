@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"cinema-system/internal/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Language() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := i18n.Negotiate(c.GetHeader("Accept-Language"))
+		c.Request = c.Request.WithContext(i18n.WithLanguage(c.Request.Context(), lang))
+		c.Header("Content-Language", lang)
+		c.Header("Vary", "Accept-Language")
+		c.Next()
+	}
+}
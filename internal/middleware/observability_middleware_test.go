@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"cinema-system/internal/tracing"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingContinuesIncomingTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracing.Install(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID(), Tracing())
+	router.GET("/api/movies/:id", func(c *gin.Context) {
+		_, span := tracing.Start(c.Request.Context(), "MovieService.GetMovie")
+		span.End()
+		c.Status(http.StatusNotFound)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/movies/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("ended spans = %d, want 2", len(spans))
+	}
+	child, server := spans[0], spans[1]
+
+	if got := server.Name(); got != "GET /api/movies/:id" {
+		t.Fatalf("server span name = %q", got)
+	}
+	if got := server.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("trace id = %s, want incoming trace", got)
+	}
+	if got := server.Parent().SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Fatalf("parent span id = %s, want incoming span", got)
+	}
+	if child.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Fatal("service span is not a child of the request span")
+	}
+
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range server.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	if attrs["http.route"].AsString() != "/api/movies/:id" || attrs["http.response.status_code"].AsInt64() != http.StatusNotFound {
+		t.Fatalf("attributes = %v", server.Attributes())
+	}
+}
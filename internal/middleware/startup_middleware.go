@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"cinema-system/internal/apperrors"
+	"cinema-system/internal/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+var ErrServiceStarting = apperrors.Unavailable("service_starting", "service is starting, try again shortly")
+
+func StartupGate(checker *health.Checker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if checker.Phase() == health.PhaseStarting {
+			c.Header("Retry-After", "5")
+			c.Error(ErrServiceStarting)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
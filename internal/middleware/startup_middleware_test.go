@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"cinema-system/internal/health"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestStartupGate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	checker := health.NewChecker()
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.GET("/readyz", func(c *gin.Context) { c.Status(http.StatusServiceUnavailable) })
+	router.Use(StartupGate(checker))
+	router.GET("/api/movies", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+
+	if got := serve("/readyz").Code; got != http.StatusServiceUnavailable {
+		t.Fatalf("probe status = %d, want probe handler to run", got)
+	}
+	starting := serve("/api/movies")
+	if starting.Code != http.StatusServiceUnavailable || starting.Header().Get("Retry-After") == "" {
+		t.Fatalf("status = %d, headers = %v, want 503 with Retry-After", starting.Code, starting.Header())
+	}
+
+	checker.MarkReady()
+	if got := serve("/api/movies").Code; got != http.StatusOK {
+		t.Fatalf("status after ready = %d, want 200", got)
+	}
+}
@@ -0,0 +1,735 @@
+package migrations
+
+import (
+	"cinema-system/internal/config"
+	"cinema-system/internal/i18n"
+	"cinema-system/internal/models"
+	"cinema-system/internal/money"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func All() []Migration {
+	return []Migration{
+		{
+			Version: 1,
+			Name:    "create_indexes",
+			Up:      createIndexes(initialIndexes),
+			Down:    dropIndexes(initialIndexes),
+		},
+		{
+			Version: 2,
+			Name:    "normalize_ticket_movie_titles",
+			Up:      normalizeTicketMovieTitles,
+		},
+		{
+			Version: 3,
+			Name:    "backfill_movie_popularity",
+			Up:      backfillMoviePopularity,
+		},
+		{
+			Version: 4,
+			Name:    "index_movie_translations",
+			Up:      replaceMovieTextIndex(translatedMovieTextIndex),
+			Down:    replaceMovieTextIndex(movieTextIndex),
+		},
+		{
+			Version: 5,
+			Name:    "convert_amounts_to_money",
+			Up:      convertAmountsToMoney,
+			Down:    convertMoneyToAmounts,
+		},
+		{
+			Version: 6,
+			Name:    "add_review_moderation",
+			Up:      addReviewModeration,
+			Down:    dropIndexes(reviewModerationIndexes),
+		},
+		{
+			Version: 7,
+			Name:    "backfill_movie_rating_stats",
+			Up:      backfillMovieRatingStats,
+			Down:    dropMovieRatingStats,
+		},
+		{
+			Version: 8,
+			Name:    "add_review_votes",
+			Up:      addReviewVotes,
+			Down:    dropIndexes(reviewVoteIndexes),
+		},
+		{
+			Version: 9,
+			Name:    "add_recommendations",
+			Up:      createIndexes(recommendationIndexes),
+			Down:    dropIndexes(recommendationIndexes),
+		},
+		{
+			Version: 10,
+			Name:    "add_audit_log",
+			Up:      createIndexes(auditIndexes),
+			Down:    dropIndexes(auditIndexes),
+		},
+		{
+			Version: 11,
+			Name:    "unique_wallet_pass_and_notification_keys",
+			Up:      addUniqueKeys,
+			Down:    replaceIndexes(uniqueKeyIndexes, nonUniqueKeyIndexes),
+		},
+		{
+			Version: 12,
+			Name:    "seed_audit_sequence_counter",
+			Up:      seedAuditCounter,
+			Down:    dropAuditCounter,
+		},
+		{
+			Version: 13,
+			Name:    "unique_active_ticket_seat",
+			Up:      addUniqueTicketSeat,
+			Down:    replaceIndexes(uniqueTicketSeatIndexes, nonUniqueTicketSeatIndexes),
+		},
+	}
+}
+
+const (
+	namespaceNotFoundCode = 26
+	indexNotFoundCode     = 27
+)
+
+type collectionIndexes struct {
+	collection string
+	indexes    []mongo.IndexModel
+}
+
+func index(name string, keys bson.D) mongo.IndexModel {
+	return mongo.IndexModel{Keys: keys, Options: options.Index().SetName(name)}
+}
+
+func uniqueIndex(name string, keys bson.D) mongo.IndexModel {
+	return mongo.IndexModel{Keys: keys, Options: options.Index().SetName(name).SetUnique(true)}
+}
+
+var movieTextIndex = mongo.IndexModel{
+	Keys: bson.D{{Key: "name", Value: "text"}, {Key: "description", Value: "text"}},
+	Options: options.Index().
+		SetName("movies_text").
+		SetWeights(bson.D{{Key: "name", Value: 10}, {Key: "description", Value: 2}}),
+}
+
+var translatedMovieTextIndex = func() mongo.IndexModel {
+	keys := bson.D{{Key: "name", Value: "text"}, {Key: "description", Value: "text"}}
+	weights := bson.D{{Key: "name", Value: 10}, {Key: "description", Value: 2}}
+	for _, lang := range i18n.Supported {
+		keys = append(keys,
+			bson.E{Key: "translations." + lang + ".name", Value: "text"},
+			bson.E{Key: "translations." + lang + ".description", Value: "text"},
+		)
+		weights = append(weights,
+			bson.E{Key: "translations." + lang + ".name", Value: 10},
+			bson.E{Key: "translations." + lang + ".description", Value: 2},
+		)
+	}
+	return mongo.IndexModel{
+		Keys:    keys,
+		Options: options.Index().SetName("movies_text").SetWeights(weights),
+	}
+}()
+
+var initialIndexes = []collectionIndexes{
+	{"users", []mongo.IndexModel{
+		uniqueIndex("users_email", bson.D{{Key: "email", Value: 1}}),
+	}},
+	{"movies", []mongo.IndexModel{
+		movieTextIndex,
+		index("movies_genres", bson.D{{Key: "genres", Value: 1}}),
+		index("movies_rating", bson.D{{Key: "rating", Value: -1}, {Key: "_id", Value: -1}}),
+		index("movies_popularity", bson.D{{Key: "popularity", Value: -1}, {Key: "_id", Value: -1}}),
+		index("movies_release_date", bson.D{{Key: "release_date", Value: -1}, {Key: "_id", Value: -1}}),
+		index("movies_name", bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}),
+	}},
+	{"movie_genres", []mongo.IndexModel{
+		index("movie_genres_movie_id", bson.D{{Key: "movie_id", Value: 1}}),
+		index("movie_genres_genre_id", bson.D{{Key: "genre_id", Value: 1}}),
+	}},
+	{"sessions", []mongo.IndexModel{
+		index("sessions_movie_id_start_time", bson.D{{Key: "movie_id", Value: 1}, {Key: "start_time", Value: 1}}),
+		index("sessions_hall_id_start_time", bson.D{{Key: "hall_id", Value: 1}, {Key: "start_time", Value: 1}, {Key: "end_time", Value: 1}}),
+		index("sessions_start_time", bson.D{{Key: "start_time", Value: 1}}),
+	}},
+	{"halls", []mongo.IndexModel{
+		index("halls_name", bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}),
+		index("halls_location", bson.D{{Key: "location", Value: 1}}),
+	}},
+	{"tickets", []mongo.IndexModel{
+		index("tickets_created_at", bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}),
+		index("tickets_user_id_created_at", bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}),
+		index("tickets_session_id_seat", bson.D{{Key: "session_id", Value: 1}, {Key: "row_number", Value: 1}, {Key: "seat_number", Value: 1}}),
+		index("tickets_payment_id", bson.D{{Key: "payment_id", Value: 1}}),
+		index("tickets_status_created_at", bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}),
+	}},
+	{"payments", []mongo.IndexModel{
+		index("payments_created_at", bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}),
+		index("payments_user_id_created_at", bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}),
+		index("payments_status_created_at", bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}),
+		index("payments_transaction_code", bson.D{{Key: "transaction_code", Value: 1}}),
+	}},
+	{"payment_cards", []mongo.IndexModel{
+		index("payment_cards_user_id", bson.D{{Key: "user_id", Value: 1}}),
+	}},
+	{"payment_codes", []mongo.IndexModel{
+		index("payment_codes_code", bson.D{{Key: "code", Value: 1}}),
+	}},
+	{"reviews", []mongo.IndexModel{
+		index("reviews_movie_id_created_at", bson.D{{Key: "movie_id", Value: 1}, {Key: "created_at", Value: -1}}),
+		index("reviews_movie_id_rating", bson.D{{Key: "movie_id", Value: 1}, {Key: "rating", Value: -1}}),
+		index("reviews_user_id_movie_id", bson.D{{Key: "user_id", Value: 1}, {Key: "movie_id", Value: 1}}),
+	}},
+	{"notifications", []mongo.IndexModel{
+		index("notifications_status_next_attempt_at", bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}),
+		index("notifications_user_id_created_at", bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}),
+		index("notifications_dedup_key", bson.D{{Key: "dedup_key", Value: 1}}),
+	}},
+	{"notification_preferences", []mongo.IndexModel{
+		index("notification_preferences_user_id", bson.D{{Key: "user_id", Value: 1}}),
+	}},
+	{"outbox", []mongo.IndexModel{
+		index("outbox_status_next_attempt_at", bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}),
+	}},
+	{"processed_events", []mongo.IndexModel{
+		uniqueIndex("processed_events_event_id_handler", bson.D{{Key: "event_id", Value: 1}, {Key: "handler", Value: 1}}),
+	}},
+	{"webhook_subscriptions", []mongo.IndexModel{
+		index("webhook_subscriptions_event_types", bson.D{{Key: "event_types", Value: 1}, {Key: "active", Value: 1}}),
+	}},
+	{"webhook_deliveries", []mongo.IndexModel{
+		index("webhook_deliveries_status_next_attempt_at", bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}),
+		index("webhook_deliveries_subscription_id_event_id", bson.D{{Key: "subscription_id", Value: 1}, {Key: "event_id", Value: 1}}),
+		index("webhook_deliveries_subscription_id_created_at", bson.D{{Key: "subscription_id", Value: 1}, {Key: "created_at", Value: -1}}),
+	}},
+	{"wallet_passes", []mongo.IndexModel{
+		index("wallet_passes_ticket_id", bson.D{{Key: "ticket_id", Value: 1}}),
+		index("wallet_passes_session_id", bson.D{{Key: "session_id", Value: 1}}),
+		index("wallet_passes_serial_number", bson.D{{Key: "serial_number", Value: 1}}),
+	}},
+	{"wallet_registrations", []mongo.IndexModel{
+		index("wallet_registrations_device_id", bson.D{{Key: "device_id", Value: 1}, {Key: "pass_type_id", Value: 1}}),
+		index("wallet_registrations_serial_number", bson.D{{Key: "serial_number", Value: 1}}),
+	}},
+}
+
+func createIndexes(specs []collectionIndexes) func(context.Context, *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		for _, spec := range specs {
+			for _, model := range spec.indexes {
+				_, err := db.Collection(spec.collection).Indexes().CreateOne(ctx, model)
+				if err != nil {
+					return fmt.Errorf("create index %s on %s: %w", *model.Options.Name, spec.collection, err)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func dropIndexes(specs []collectionIndexes) func(context.Context, *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		for _, spec := range specs {
+			for _, model := range spec.indexes {
+				name := *model.Options.Name
+				_, err := db.Collection(spec.collection).Indexes().DropOne(ctx, name)
+				if err != nil && !isCommandError(err, indexNotFoundCode, namespaceNotFoundCode) {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func replaceMovieTextIndex(model mongo.IndexModel) func(context.Context, *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		indexes := db.Collection("movies").Indexes()
+		if _, err := indexes.DropOne(ctx, *model.Options.Name); err != nil && !isCommandError(err, indexNotFoundCode, namespaceNotFoundCode) {
+			return err
+		}
+		_, err := indexes.CreateOne(ctx, model)
+		return err
+	}
+}
+
+func isCommandError(err error, codes ...int32) bool {
+	var cmdErr mongo.CommandError
+	if !errors.As(err, &cmdErr) {
+		return false
+	}
+	for _, code := range codes {
+		if cmdErr.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeTicketMovieTitles(ctx context.Context, db *mongo.Database) error {
+	tickets := db.Collection("tickets")
+	sessions := db.Collection("sessions")
+	movies := db.Collection("movies")
+
+	cursor, err := tickets.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"session_id": 1, "movie_title": 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	titles := map[primitive.ObjectID]string{}
+	titleForSession := func(sessionID primitive.ObjectID) (string, error) {
+		if title, ok := titles[sessionID]; ok {
+			return title, nil
+		}
+		var session struct {
+			MovieID primitive.ObjectID `bson:"movie_id"`
+		}
+		err := sessions.FindOne(ctx, bson.M{"_id": sessionID}).Decode(&session)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return "", err
+		}
+		var movie struct {
+			Name string `bson:"name"`
+		}
+		if err == nil {
+			err = movies.FindOne(ctx, bson.M{"_id": session.MovieID}).Decode(&movie)
+			if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+				return "", err
+			}
+		}
+		titles[sessionID] = strings.TrimSpace(movie.Name)
+		return titles[sessionID], nil
+	}
+
+	for cursor.Next(ctx) {
+		var ticket struct {
+			ID         primitive.ObjectID `bson:"_id"`
+			SessionID  primitive.ObjectID `bson:"session_id"`
+			MovieTitle string             `bson:"movie_title"`
+		}
+		if err := cursor.Decode(&ticket); err != nil {
+			return err
+		}
+
+		title := strings.TrimSpace(ticket.MovieTitle)
+		if title == "" {
+			if title, err = titleForSession(ticket.SessionID); err != nil {
+				return err
+			}
+		}
+		if title == ticket.MovieTitle {
+			continue
+		}
+
+		_, err := tickets.UpdateOne(ctx, bson.M{"_id": ticket.ID}, bson.M{"$set": bson.M{"movie_title": title}})
+		if err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func backfillMoviePopularity(ctx context.Context, db *mongo.Database) error {
+	cursor, err := db.Collection("tickets").Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": bson.M{"$in": bson.A{models.TicketPaid, models.TicketUsed}}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$session_id", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$lookup", Value: bson.M{"from": "sessions", "localField": "_id", "foreignField": "_id", "as": "session"}}},
+		{{Key: "$unwind", Value: "$session"}},
+		{{Key: "$group", Value: bson.M{"_id": "$session.movie_id", "count": bson.M{"$sum": "$count"}}}},
+	})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var counts []struct {
+		MovieID primitive.ObjectID `bson:"_id"`
+		Count   int                `bson:"count"`
+	}
+	if err = cursor.All(ctx, &counts); err != nil {
+		return err
+	}
+
+	movies := db.Collection("movies")
+	if _, err := movies.UpdateMany(ctx, bson.M{"popularity": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"popularity": 0}}); err != nil {
+		return err
+	}
+	for _, c := range counts {
+		if _, err := movies.UpdateOne(ctx, bson.M{"_id": c.MovieID}, bson.M{"$set": bson.M{"popularity": c.Count}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var moneyFields = []struct {
+	collection string
+	field      string
+}{
+	{"users", "balance"},
+	{"sessions", "price"},
+	{"tickets", "price"},
+	{"payments", "amount"},
+	{"payment_codes", "amount"},
+}
+
+var exchangeRateIndexes = []collectionIndexes{
+	{"exchange_rates", []mongo.IndexModel{
+		uniqueIndex("exchange_rates_from_to", bson.D{{Key: "from", Value: 1}, {Key: "to", Value: 1}}),
+	}},
+}
+
+func convertAmountsToMoney(ctx context.Context, db *mongo.Database) error {
+	for _, spec := range moneyFields {
+		minorUnits := bson.M{"$toLong": bson.M{"$floor": bson.M{"$add": bson.A{bson.M{"$multiply": bson.A{"$" + spec.field, 100}}, 0.5}}}}
+		_, err := db.Collection(spec.collection).UpdateMany(ctx,
+			bson.M{spec.field: bson.M{"$type": "number"}},
+			mongo.Pipeline{{{Key: "$set", Value: bson.M{spec.field: bson.M{"amount": minorUnits, "currency": money.DefaultCurrency}}}}},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := db.Collection("halls").UpdateMany(ctx,
+		bson.M{"currency": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"currency": money.DefaultCurrency}},
+	)
+	if err != nil {
+		return err
+	}
+	return createIndexes(exchangeRateIndexes)(ctx, db)
+}
+
+func convertMoneyToAmounts(ctx context.Context, db *mongo.Database) error {
+	if err := dropIndexes(exchangeRateIndexes)(ctx, db); err != nil {
+		return err
+	}
+
+	for _, spec := range moneyFields {
+		_, err := db.Collection(spec.collection).UpdateMany(ctx,
+			bson.M{spec.field + ".amount": bson.M{"$type": "number"}},
+			mongo.Pipeline{{{Key: "$set", Value: bson.M{spec.field: bson.M{"$divide": bson.A{"$" + spec.field + ".amount", 100}}}}}},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := db.Collection("halls").UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"currency": ""}})
+	return err
+}
+
+var reviewModerationIndexes = []collectionIndexes{
+	{"reviews", []mongo.IndexModel{
+		index("reviews_status_created_at", bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}}),
+		index("reviews_movie_id_status_created_at", bson.D{{Key: "movie_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}}),
+	}},
+	{"review_reports", []mongo.IndexModel{
+		uniqueIndex("review_reports_review_id_user_id", bson.D{{Key: "review_id", Value: 1}, {Key: "user_id", Value: 1}}),
+	}},
+}
+
+func addReviewModeration(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("reviews").UpdateMany(ctx,
+		bson.M{"status": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"status": models.ReviewPublished, "report_count": 0}},
+	)
+	if err != nil {
+		return err
+	}
+	return createIndexes(reviewModerationIndexes)(ctx, db)
+}
+
+func backfillMovieRatingStats(ctx context.Context, db *mongo.Database) error {
+	cursor, err := db.Collection("reviews").Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": models.ReviewPublished}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":    bson.M{"movie_id": "$movie_id", "rating": "$rating"},
+			"count":  bson.M{"$sum": 1},
+			"weight": bson.M{"$sum": bson.M{"$ifNull": bson.A{"$weight", 1}}},
+		}}},
+	})
+	if err != nil {
+		return err
+	}
+	var buckets []struct {
+		ID struct {
+			MovieID primitive.ObjectID `bson:"movie_id"`
+			Rating  int                `bson:"rating"`
+		} `bson:"_id"`
+		Count  int     `bson:"count"`
+		Weight float64 `bson:"weight"`
+	}
+	if err := cursor.All(ctx, &buckets); err != nil {
+		return err
+	}
+
+	stats := make(map[primitive.ObjectID]*models.RatingStats)
+	for _, bucket := range buckets {
+		movieStats, ok := stats[bucket.ID.MovieID]
+		if !ok {
+			movieStats = &models.RatingStats{Histogram: make(map[string]int)}
+			stats[bucket.ID.MovieID] = movieStats
+		}
+		movieStats.Count += bucket.Count
+		movieStats.Sum += float64(bucket.ID.Rating) * bucket.Weight
+		movieStats.Weight += bucket.Weight
+		movieStats.Histogram[strconv.Itoa(bucket.ID.Rating)] += bucket.Count
+	}
+
+	movies, err := db.Collection("movies").Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return err
+	}
+	defer movies.Close(ctx)
+
+	defaults := config.DefaultRatingConfig()
+	for movies.Next(ctx) {
+		var movie struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := movies.Decode(&movie); err != nil {
+			return err
+		}
+		movieStats, ok := stats[movie.ID]
+		if !ok {
+			movieStats = &models.RatingStats{}
+		}
+		movieStats.Compute(defaults.PriorMean, defaults.PriorWeight)
+		_, err := db.Collection("movies").UpdateOne(ctx,
+			bson.M{"_id": movie.ID},
+			bson.M{"$set": bson.M{"rating_stats": movieStats, "rating": movieStats.Average}},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return movies.Err()
+}
+
+func dropMovieRatingStats(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("movies").UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"rating_stats": ""}})
+	return err
+}
+
+var reviewVoteIndexes = []collectionIndexes{
+	{"reviews", []mongo.IndexModel{
+		index("reviews_movie_id_status_helpful_count", bson.D{{Key: "movie_id", Value: 1}, {Key: "status", Value: 1}, {Key: "helpful_count", Value: -1}, {Key: "_id", Value: -1}}),
+	}},
+	{"review_votes", []mongo.IndexModel{
+		uniqueIndex("review_votes_review_id_user_id", bson.D{{Key: "review_id", Value: 1}, {Key: "user_id", Value: 1}}),
+	}},
+}
+
+func addReviewVotes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("reviews").UpdateMany(ctx,
+		bson.M{"helpful_count": bson.M{"$exists": false}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{
+			"helpful_count":   0,
+			"unhelpful_count": 0,
+			"has_comment": bson.M{"$gt": bson.A{
+				bson.M{"$strLenCP": bson.M{"$trim": bson.M{"input": bson.M{"$ifNull": bson.A{"$comment", ""}}}}},
+				0,
+			}},
+		}}}},
+	)
+	if err != nil {
+		return err
+	}
+	return createIndexes(reviewVoteIndexes)(ctx, db)
+}
+
+var recommendationIndexes = []collectionIndexes{
+	{"recommendations", []mongo.IndexModel{
+		uniqueIndex("recommendations_kind_subject_id", bson.D{{Key: "kind", Value: 1}, {Key: "subject_id", Value: 1}}),
+		index("recommendations_computed_at", bson.D{{Key: "computed_at", Value: 1}}),
+	}},
+}
+
+var auditIndexes = []collectionIndexes{
+	{"audit_log", []mongo.IndexModel{
+		uniqueIndex("audit_log_seq", bson.D{{Key: "seq", Value: 1}}),
+		index("audit_log_created_at", bson.D{{Key: "created_at", Value: -1}}),
+		index("audit_log_actor_id", bson.D{{Key: "actor_id", Value: 1}, {Key: "seq", Value: -1}}),
+		index("audit_log_entity", bson.D{{Key: "entity_type", Value: 1}, {Key: "entity_id", Value: 1}, {Key: "seq", Value: -1}}),
+		index("audit_log_request_id", bson.D{{Key: "request_id", Value: 1}}),
+	}},
+}
+
+var uniqueKeyIndexes = []collectionIndexes{
+	{"notifications", []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "dedup_key", Value: 1}},
+			Options: options.Index().
+				SetName("notifications_dedup_key").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"dedup_key": bson.M{"$gt": ""}}),
+		},
+	}},
+	{"wallet_passes", []mongo.IndexModel{
+		uniqueIndex("wallet_passes_ticket_id", bson.D{{Key: "ticket_id", Value: 1}}),
+		uniqueIndex("wallet_passes_serial_number", bson.D{{Key: "serial_number", Value: 1}}),
+	}},
+}
+
+var nonUniqueKeyIndexes = []collectionIndexes{
+	{"notifications", []mongo.IndexModel{
+		index("notifications_dedup_key", bson.D{{Key: "dedup_key", Value: 1}}),
+	}},
+	{"wallet_passes", []mongo.IndexModel{
+		index("wallet_passes_ticket_id", bson.D{{Key: "ticket_id", Value: 1}}),
+		index("wallet_passes_serial_number", bson.D{{Key: "serial_number", Value: 1}}),
+	}},
+}
+
+func replaceIndexes(from, to []collectionIndexes) func(context.Context, *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		if err := dropIndexes(from)(ctx, db); err != nil {
+			return err
+		}
+		return createIndexes(to)(ctx, db)
+	}
+}
+
+func addUniqueKeys(ctx context.Context, db *mongo.Database) error {
+	notifications := db.Collection("notifications")
+	ids, err := duplicateIDs(ctx, notifications, "dedup_key", bson.M{"dedup_key": bson.M{"$gt": ""}})
+	if err != nil {
+		return err
+	}
+	if len(ids) > 0 {
+		if _, err := notifications.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}}, bson.M{"$set": bson.M{"dedup_key": ""}}); err != nil {
+			return err
+		}
+	}
+
+	passes := db.Collection("wallet_passes")
+	for _, field := range []string{"ticket_id", "serial_number"} {
+		ids, err := duplicateIDs(ctx, passes, field, bson.M{})
+		if err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			if _, err := passes.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}}); err != nil {
+				return err
+			}
+		}
+	}
+	return replaceIndexes(nonUniqueKeyIndexes, uniqueKeyIndexes)(ctx, db)
+}
+
+func duplicateIDs(ctx context.Context, collection *mongo.Collection, field string, match bson.M) ([]primitive.ObjectID, error) {
+	cursor, err := collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$" + field, "ids": bson.M{"$push": "$_id"}}}},
+		{{Key: "$match", Value: bson.M{"ids.1": bson.M{"$exists": true}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		IDs []primitive.ObjectID `bson:"ids"`
+	}
+	if err = cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+	var ids []primitive.ObjectID
+	for _, group := range groups {
+		ids = append(ids, group.IDs[1:]...)
+	}
+	return ids, nil
+}
+
+const auditCounterID = "audit_log"
+
+func seedAuditCounter(ctx context.Context, db *mongo.Database) error {
+	var last struct {
+		Seq int64 `bson:"seq"`
+	}
+	err := db.Collection("audit_log").FindOne(ctx, bson.M{}, options.FindOne().SetSort(bson.D{{Key: "seq", Value: -1}})).Decode(&last)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+	_, err = db.Collection("counters").UpdateOne(
+		ctx,
+		bson.M{"_id": auditCounterID},
+		bson.M{"$max": bson.M{"seq": last.Seq}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func dropAuditCounter(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("counters").DeleteOne(ctx, bson.M{"_id": auditCounterID})
+	return err
+}
+
+var activeTicketStatuses = bson.A{models.TicketBooked, models.TicketPaid, models.TicketUsed}
+
+var uniqueTicketSeatIndexes = []collectionIndexes{
+	{"tickets", []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "session_id", Value: 1}, {Key: "row_number", Value: 1}, {Key: "seat_number", Value: 1}},
+			Options: options.Index().
+				SetName("tickets_session_id_seat").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": bson.M{"$in": activeTicketStatuses}}),
+		},
+	}},
+}
+
+var nonUniqueTicketSeatIndexes = []collectionIndexes{
+	{"tickets", []mongo.IndexModel{
+		index("tickets_session_id_seat", bson.D{{Key: "session_id", Value: 1}, {Key: "row_number", Value: 1}, {Key: "seat_number", Value: 1}}),
+	}},
+}
+
+func addUniqueTicketSeat(ctx context.Context, db *mongo.Database) error {
+	cursor, err := db.Collection("tickets").Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": bson.M{"$in": activeTicketStatuses}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.D{{Key: "session_id", Value: "$session_id"}, {Key: "row", Value: "$row_number"}, {Key: "seat", Value: "$seat_number"}},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+	})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var seats []struct {
+		Seat struct {
+			SessionID primitive.ObjectID `bson:"session_id"`
+			Row       int                `bson:"row"`
+			Seat      int                `bson:"seat"`
+		} `bson:"_id"`
+	}
+	if err = cursor.All(ctx, &seats); err != nil {
+		return err
+	}
+	if len(seats) > 0 {
+		taken := make([]string, len(seats))
+		for i, s := range seats {
+			taken[i] = fmt.Sprintf("session %s row %d seat %d", s.Seat.SessionID.Hex(), s.Seat.Row, s.Seat.Seat)
+		}
+		return fmt.Errorf("%d seats are sold more than once, cancel and refund the extra tickets first: %s", len(seats), strings.Join(taken, ", "))
+	}
+	return replaceIndexes(nonUniqueTicketSeatIndexes, uniqueTicketSeatIndexes)(ctx, db)
+}
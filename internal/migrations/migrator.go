@@ -0,0 +1,193 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	migrationsCollection = "schema_migrations"
+	lockID               = "lock"
+	staleLockAfter       = 15 * time.Minute
+)
+
+var ErrLocked = errors.New("another migration run is in progress")
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, db *mongo.Database) error
+	Down    func(ctx context.Context, db *mongo.Database) error
+}
+
+type Record struct {
+	Version   int       `bson:"_id"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+type Status struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+type Migrator struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+	locks      *mongo.Collection
+	migrations []Migration
+}
+
+func NewMigrator(db *mongo.Database) *Migrator {
+	return newMigrator(db, All())
+}
+
+func newMigrator(db *mongo.Database, migrations []Migration) *Migrator {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return &Migrator{
+		db:         db,
+		collection: db.Collection(migrationsCollection),
+		locks:      db.Collection(migrationsCollection + "_lock"),
+		migrations: sorted,
+	}
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int]Record, error) {
+	cursor, err := m.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []Record
+	if err = cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]Record, len(records))
+	for _, r := range records {
+		applied[r.Version] = r
+	}
+	return applied, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, len(m.migrations))
+	for i, migration := range m.migrations {
+		record, ok := applied[migration.Version]
+		statuses[i] = Status{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Applied:   ok,
+			AppliedAt: record.AppliedAt,
+		}
+	}
+	return statuses, nil
+}
+
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+func (m *Migrator) lock(ctx context.Context) error {
+	now := time.Now()
+	_, err := m.locks.UpdateOne(
+		ctx,
+		bson.M{"_id": lockID, "locked_at": bson.M{"$lt": now.Add(-staleLockAfter)}},
+		bson.M{"$set": bson.M{"locked_at": now}},
+		options.Update().SetUpsert(true),
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrLocked
+	}
+	return err
+}
+
+func (m *Migrator) unlock(ctx context.Context) {
+	if _, err := m.locks.DeleteOne(ctx, bson.M{"_id": lockID}); err != nil {
+		slog.ErrorContext(ctx, "migrations: failed to release lock", "error", err)
+	}
+}
+
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	if err := m.lock(ctx); err != nil {
+		return 0, err
+	}
+	defer m.unlock(ctx)
+
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, migration := range pending {
+		slog.InfoContext(ctx, "migrations: applying", "version", migration.Version, "name", migration.Name)
+		if err := migration.Up(ctx, m.db); err != nil {
+			return i, fmt.Errorf("migration %04d %s failed: %w", migration.Version, migration.Name, err)
+		}
+		record := Record{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}
+		if _, err := m.collection.InsertOne(ctx, record); err != nil {
+			return i, err
+		}
+	}
+	return len(pending), nil
+}
+
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if err := m.lock(ctx); err != nil {
+		return 0, err
+	}
+	defer m.unlock(ctx)
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	reverted := 0
+	for i := len(m.migrations) - 1; i >= 0 && reverted < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		slog.InfoContext(ctx, "migrations: reverting", "version", migration.Version, "name", migration.Name)
+		if migration.Down != nil {
+			if err := migration.Down(ctx, m.db); err != nil {
+				return reverted, fmt.Errorf("revert of %04d %s failed: %w", migration.Version, migration.Name, err)
+			}
+		}
+		if _, err := m.collection.DeleteOne(ctx, bson.M{"_id": migration.Version}); err != nil {
+			return reverted, err
+		}
+		reverted++
+	}
+	return reverted, nil
+}
@@ -0,0 +1,225 @@
+package migrations
+
+import (
+	"cinema-system/internal/config"
+	"cinema-system/internal/models"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestAllVersionsAreUniqueAndIncreasing(t *testing.T) {
+	all := All()
+	for i := 1; i < len(all); i++ {
+		if all[i].Version <= all[i-1].Version {
+			t.Fatalf("migration %d %s is listed after %d %s", all[i].Version, all[i].Name, all[i-1].Version, all[i-1].Name)
+		}
+	}
+}
+
+func testDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI is not set")
+	}
+
+	db, err := config.NewDatabase(uri, "cinema_migrations_test_"+primitive.NewObjectID().Hex())
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Database.Drop(context.Background())
+		db.Disconnect()
+	})
+	return db.Database
+}
+
+func TestMigratorAppliesInVersionOrder(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+
+	var applied []int
+	step := func(version int) Migration {
+		return Migration{
+			Version: version,
+			Name:    "step",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				applied = append(applied, version)
+				return nil
+			},
+			Down: func(ctx context.Context, db *mongo.Database) error {
+				applied = append(applied, -version)
+				return nil
+			},
+		}
+	}
+
+	migrator := newMigrator(db, []Migration{step(3), step(1), step(2)})
+	if n, err := migrator.Up(ctx); err != nil || n != 3 {
+		t.Fatalf("Up = %d, %v", n, err)
+	}
+	if n, err := migrator.Down(ctx, 2); err != nil || n != 2 {
+		t.Fatalf("Down = %d, %v", n, err)
+	}
+	want := []int{1, 2, 3, -3, -2}
+	if len(applied) != len(want) {
+		t.Fatalf("applied = %v, want %v", applied, want)
+	}
+	for i := range want {
+		if applied[i] != want[i] {
+			t.Fatalf("applied = %v, want %v", applied, want)
+		}
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	for i, status := range statuses {
+		if status.Version != i+1 || status.Applied != (i == 0) {
+			t.Fatalf("statuses = %+v", statuses)
+		}
+	}
+}
+
+func TestMigratorStopsAtFailure(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+
+	boom := errors.New("boom")
+	ok := func(ctx context.Context, db *mongo.Database) error { return nil }
+	migrator := newMigrator(db, []Migration{
+		{Version: 1, Name: "ok", Up: ok},
+		{Version: 2, Name: "fails", Up: func(ctx context.Context, db *mongo.Database) error { return boom }},
+		{Version: 3, Name: "never", Up: ok},
+	})
+	if n, err := migrator.Up(ctx); !errors.Is(err, boom) || n != 1 {
+		t.Fatalf("Up = %d, %v, want 1 and %v", n, err, boom)
+	}
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	if len(pending) != 2 || pending[0].Version != 2 {
+		t.Fatalf("pending = %+v, want 2 and 3", pending)
+	}
+}
+
+func TestMigrationsAreIdempotent(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+
+	migrator := NewMigrator(db)
+	if n, err := migrator.Up(ctx); err != nil || n != len(All()) {
+		t.Fatalf("first Up = %d, %v", n, err)
+	}
+	if n, err := migrator.Up(ctx); err != nil || n != 0 {
+		t.Fatalf("second Up = %d, %v, want nothing pending", n, err)
+	}
+
+	retried := testDatabase(t)
+	for _, migration := range All() {
+		for run := 1; run <= 2; run++ {
+			if err := migration.Up(ctx, retried); err != nil {
+				t.Fatalf("run %d of %04d %s: %v", run, migration.Version, migration.Name, err)
+			}
+		}
+	}
+}
+
+func TestCreateIndexesFailsOnConflictingOptions(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+
+	if err := createIndexes(nonUniqueKeyIndexes)(ctx, db); err != nil {
+		t.Fatalf("create non-unique indexes: %v", err)
+	}
+	if err := createIndexes(uniqueKeyIndexes)(ctx, db); err == nil {
+		t.Fatal("creating a unique index over an existing non-unique one with the same name succeeded")
+	}
+	if err := addUniqueKeys(ctx, db); err != nil {
+		t.Fatalf("addUniqueKeys: %v", err)
+	}
+	if err := createIndexes(uniqueKeyIndexes)(ctx, db); err != nil {
+		t.Fatalf("re-creating identical indexes: %v", err)
+	}
+}
+
+func TestUniqueKeyIndexes(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+
+	ticketID := primitive.NewObjectID()
+	passes := db.Collection("wallet_passes")
+	notifications := db.Collection("notifications")
+	for _, doc := range []bson.M{
+		{"ticket_id": ticketID, "serial_number": ticketID.Hex()},
+		{"ticket_id": ticketID, "serial_number": ticketID.Hex()},
+	} {
+		if _, err := passes.InsertOne(ctx, doc); err != nil {
+			t.Fatalf("insert pass: %v", err)
+		}
+	}
+	for _, key := range []string{"reminder:1:EMAIL", "reminder:1:EMAIL", "", ""} {
+		if _, err := notifications.InsertOne(ctx, bson.M{"dedup_key": key}); err != nil {
+			t.Fatalf("insert notification: %v", err)
+		}
+	}
+
+	if _, err := NewMigrator(db).Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	if n, err := passes.CountDocuments(ctx, bson.M{"ticket_id": ticketID}); err != nil || n != 1 {
+		t.Fatalf("passes for ticket = %d, %v, want duplicates removed", n, err)
+	}
+	if n, err := notifications.CountDocuments(ctx, bson.M{"dedup_key": "reminder:1:EMAIL"}); err != nil || n != 1 {
+		t.Fatalf("notifications with key = %d, %v, want one", n, err)
+	}
+
+	if _, err := passes.InsertOne(ctx, bson.M{"ticket_id": ticketID, "serial_number": "other"}); !mongo.IsDuplicateKeyError(err) {
+		t.Fatalf("duplicate ticket_id err = %v", err)
+	}
+	if _, err := passes.InsertOne(ctx, bson.M{"ticket_id": primitive.NewObjectID(), "serial_number": ticketID.Hex()}); !mongo.IsDuplicateKeyError(err) {
+		t.Fatalf("duplicate serial_number err = %v", err)
+	}
+	if _, err := notifications.InsertOne(ctx, bson.M{"dedup_key": "reminder:1:EMAIL"}); !mongo.IsDuplicateKeyError(err) {
+		t.Fatalf("duplicate dedup_key err = %v", err)
+	}
+	if _, err := notifications.InsertOne(ctx, bson.M{"dedup_key": ""}); err != nil {
+		t.Fatalf("notifications without a key must not conflict: %v", err)
+	}
+}
+
+func TestUniqueTicketSeatIndex(t *testing.T) {
+	db := testDatabase(t)
+	ctx := context.Background()
+
+	sessionID := primitive.NewObjectID()
+	tickets := db.Collection("tickets")
+	seat := func(status models.TicketStatus) bson.M {
+		return bson.M{"session_id": sessionID, "row_number": 1, "seat_number": 1, "status": status}
+	}
+	for _, doc := range []bson.M{seat(models.TicketCancelled), seat(models.TicketPaid)} {
+		if _, err := tickets.InsertOne(ctx, doc); err != nil {
+			t.Fatalf("insert ticket: %v", err)
+		}
+	}
+
+	if _, err := NewMigrator(db).Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	if _, err := tickets.InsertOne(ctx, seat(models.TicketPaid)); !mongo.IsDuplicateKeyError(err) {
+		t.Fatalf("second active ticket err = %v", err)
+	}
+	if _, err := tickets.InsertOne(ctx, seat(models.TicketCancelled)); err != nil {
+		t.Fatalf("cancelled tickets must not conflict: %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"cinema-system/internal/money"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AnalyticsInterval string
+
+const (
+	IntervalDay   AnalyticsInterval = "day"
+	IntervalWeek  AnalyticsInterval = "week"
+	IntervalMonth AnalyticsInterval = "month"
+)
+
+func (i AnalyticsInterval) DateFormat() string {
+	switch i {
+	case IntervalWeek:
+		return "%G-W%V"
+	case IntervalMonth:
+		return "%Y-%m"
+	default:
+		return "%Y-%m-%d"
+	}
+}
+
+func (i AnalyticsInterval) Bucket(t time.Time, loc *time.Location) string {
+	t = t.In(loc)
+	switch i {
+	case IntervalWeek:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case IntervalMonth:
+		return t.Format("2006-01")
+	default:
+		return t.Format("2006-01-02")
+	}
+}
+
+type SalesDimension string
+
+const (
+	SalesTotal        SalesDimension = ""
+	SalesByMovie      SalesDimension = "movie"
+	SalesByHallType   SalesDimension = "hall_type"
+	SalesByTicketType SalesDimension = "ticket_type"
+	SalesByPeriod     SalesDimension = "period"
+)
+
+type AnalyticsQuery struct {
+	From     string            `form:"from"`
+	To       string            `form:"to"`
+	Location string            `form:"location"`
+	Interval AnalyticsInterval `form:"interval" binding:"omitempty,oneof=day week month"`
+	GroupBy  SalesDimension    `form:"group_by" binding:"omitempty,oneof=movie hall_type ticket_type period"`
+	Limit    int               `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type SessionOccupancy struct {
+	SessionID primitive.ObjectID `json:"session_id" bson:"session_id"`
+	MovieID   primitive.ObjectID `json:"movie_id" bson:"movie_id"`
+	MovieName string             `json:"movie_name" bson:"movie_name"`
+	HallID    primitive.ObjectID `json:"hall_id" bson:"hall_id"`
+	HallName  string             `json:"hall_name" bson:"hall_name"`
+	HallType  HallType           `json:"hall_type" bson:"hall_type"`
+	StartTime time.Time          `json:"start_time" bson:"start_time"`
+	Capacity  int                `json:"capacity" bson:"capacity"`
+	Sold      int                `json:"sold" bson:"sold"`
+	Occupancy float64            `json:"occupancy" bson:"-"`
+}
+
+func (s *SessionOccupancy) Compute() {
+	s.Occupancy = occupancy(s.Sold, s.Capacity)
+}
+
+type OccupancyBucket struct {
+	Key       string  `json:"key"`
+	Label     string  `json:"label"`
+	Sessions  int     `json:"sessions"`
+	Capacity  int     `json:"capacity"`
+	Sold      int     `json:"sold"`
+	Occupancy float64 `json:"occupancy"`
+}
+
+func (b *OccupancyBucket) Add(s SessionOccupancy) {
+	b.Sessions++
+	b.Capacity += s.Capacity
+	b.Sold += s.Sold
+	b.Occupancy = occupancy(b.Sold, b.Capacity)
+}
+
+func occupancy(sold, capacity int) float64 {
+	if capacity <= 0 {
+		return 0
+	}
+	return float64(sold) / float64(capacity)
+}
+
+type OccupancyReport struct {
+	From     time.Time          `json:"from"`
+	To       time.Time          `json:"to"`
+	Interval AnalyticsInterval  `json:"interval"`
+	Overall  OccupancyBucket    `json:"overall"`
+	Sessions []SessionOccupancy `json:"sessions"`
+	Halls    []OccupancyBucket  `json:"halls"`
+	Series   []OccupancyBucket  `json:"series"`
+}
+
+type SalesBucket struct {
+	Key            string      `json:"key" bson:"key"`
+	Label          string      `json:"label" bson:"label"`
+	Currency       string      `json:"currency" bson:"currency"`
+	TicketsSold    int         `json:"tickets_sold" bson:"sold"`
+	Refunded       int         `json:"refunded" bson:"refunded"`
+	RevenueAmount  int64       `json:"-" bson:"revenue"`
+	RefundedAmount int64       `json:"-" bson:"refunded_amount"`
+	Revenue        money.Money `json:"revenue" bson:"-"`
+	RefundedTotal  money.Money `json:"refunded_total" bson:"-"`
+	AveragePrice   money.Money `json:"average_price" bson:"-"`
+	RefundRate     float64     `json:"refund_rate" bson:"-"`
+}
+
+func (b *SalesBucket) Compute() {
+	b.Revenue = money.New(b.RevenueAmount, b.Currency)
+	b.RefundedTotal = money.New(b.RefundedAmount, b.Currency)
+	b.AveragePrice = money.Zero(b.Currency)
+	if b.TicketsSold > 0 {
+		b.AveragePrice = b.Revenue.Scale(1, int64(b.TicketsSold))
+	}
+	b.RefundRate = 0
+	if total := b.TicketsSold + b.Refunded; total > 0 {
+		b.RefundRate = float64(b.Refunded) / float64(total)
+	}
+}
+
+type SalesReport struct {
+	From     time.Time         `json:"from"`
+	To       time.Time         `json:"to"`
+	GroupBy  SalesDimension    `json:"group_by"`
+	Interval AnalyticsInterval `json:"interval,omitempty"`
+	Rows     []SalesBucket     `json:"rows"`
+}
+
+type AnalyticsSummary struct {
+	From      time.Time       `json:"from"`
+	To        time.Time       `json:"to"`
+	Totals    []SalesBucket   `json:"totals"`
+	TopMovies []SalesBucket   `json:"top_movies"`
+	Occupancy OccupancyBucket `json:"occupancy"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AuditChange struct {
+	Field  string          `json:"field" bson:"field"`
+	Before json.RawMessage `json:"before,omitempty" bson:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty" bson:"after,omitempty"`
+}
+
+type AuditEntry struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Seq        int64              `json:"seq" bson:"seq"`
+	ActorID    primitive.ObjectID `json:"actor_id" bson:"actor_id"`
+	ActorRole  string             `json:"actor_role,omitempty" bson:"actor_role,omitempty"`
+	Action     string             `json:"action" bson:"action"`
+	EntityType string             `json:"entity_type" bson:"entity_type"`
+	EntityID   string             `json:"entity_id,omitempty" bson:"entity_id,omitempty"`
+	Reference  string             `json:"reference,omitempty" bson:"reference,omitempty"`
+	Method     string             `json:"method,omitempty" bson:"method,omitempty"`
+	Path       string             `json:"path,omitempty" bson:"path,omitempty"`
+	Status     int                `json:"status,omitempty" bson:"status,omitempty"`
+	Changes    []AuditChange      `json:"changes,omitempty" bson:"changes,omitempty"`
+	IP         string             `json:"ip,omitempty" bson:"ip,omitempty"`
+	RequestID  string             `json:"request_id,omitempty" bson:"request_id,omitempty"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+	PrevHash   string             `json:"prev_hash" bson:"prev_hash"`
+	Hash       string             `json:"hash" bson:"hash"`
+}
+
+type AuditVerification struct {
+	Valid    bool   `json:"valid"`
+	Entries  int64  `json:"entries"`
+	LastHash string `json:"last_hash,omitempty"`
+	BrokenAt int64  `json:"broken_at,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
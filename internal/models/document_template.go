@@ -0,0 +1,67 @@
+package models
+
+import (
+	"cinema-system/internal/apperrors"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type DocumentKind string
+
+const (
+	DocumentTicket  DocumentKind = "TICKET"
+	DocumentReceipt DocumentKind = "RECEIPT"
+)
+
+type DocumentTemplate struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Kind        DocumentKind       `json:"kind" bson:"kind"`
+	CinemaName  string             `json:"cinema_name" bson:"cinema_name"`
+	Address     string             `json:"address" bson:"address"`
+	TaxID       string             `json:"tax_id" bson:"tax_id"`
+	HeaderText  string             `json:"header_text" bson:"header_text"`
+	FooterText  string             `json:"footer_text" bson:"footer_text"`
+	AccentColor string             `json:"accent_color" bson:"accent_color"`
+	LogoPNG     []byte             `json:"logo_png,omitempty" bson:"logo_png,omitempty"`
+	VATRate     float64            `json:"vat_rate" bson:"vat_rate"`
+	UpdatedAt   time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+func DefaultDocumentTemplate(kind DocumentKind) *DocumentTemplate {
+	template := &DocumentTemplate{
+		Kind:        kind,
+		CinemaName:  "Mangekyo Films",
+		AccentColor: "#B71C1C",
+		FooterText:  "Thank you for choosing our cinema!",
+		VATRate:     0.12,
+	}
+	if kind == DocumentReceipt {
+		template.HeaderText = "Payment receipt"
+	} else {
+		template.HeaderText = "Cinema ticket"
+	}
+	return template
+}
+
+var ErrInvalidDocumentKind = apperrors.Validation("invalid_document_kind", "kind must be TICKET or RECEIPT")
+
+func (t *DocumentTemplate) Validate() error {
+	if t.Kind != DocumentTicket && t.Kind != DocumentReceipt {
+		return ErrInvalidDocumentKind
+	}
+	if t.CinemaName == "" {
+		return apperrors.Validation("cinema_name_required", "cinema name is required")
+	}
+	if t.AccentColor != "" {
+		matched, _ := regexp.MatchString(`^#[0-9A-Fa-f]{6}$`, t.AccentColor)
+		if !matched {
+			return apperrors.Validation("invalid_accent_color", "accent color must be in #RRGGBB format")
+		}
+	}
+	if t.VATRate < 0 || t.VATRate >= 1 {
+		return apperrors.Validation("invalid_vat_rate", "VAT rate must be between 0 and 1")
+	}
+	return nil
+}
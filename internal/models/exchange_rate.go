@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ExchangeRate struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	From      string             `json:"from" bson:"from"`
+	To        string             `json:"to" bson:"to"`
+	Rate      string             `json:"rate" bson:"rate"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+type ExchangeRateUpdate struct {
+	Rate string `json:"rate" binding:"required"`
+}
@@ -1,6 +1,10 @@
 package models
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"cinema-system/internal/money"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 type HallType string
 
@@ -18,4 +22,12 @@ type Hall struct {
 	Location    string             `json:"location" bson:"location"`
 	TotalRows   int                `json:"total_rows" bson:"total_rows"`
 	SeatsPerRow int                `json:"seats_per_row" bson:"seats_per_row"`
+	Currency    string             `json:"currency" bson:"currency"`
+}
+
+func (h *Hall) PriceCurrency() string {
+	if h.Currency == "" {
+		return money.DefaultCurrency
+	}
+	return h.Currency
 }
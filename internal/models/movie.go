@@ -7,33 +7,108 @@ import (
 )
 
 type Movie struct {
-	ID           primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
-	Name         string               `json:"name" bson:"name"`
-	AgeRating    string               `json:"age_rating" bson:"age_rating"`
-	Duration     int                  `json:"duration" bson:"duration"`
-	Description  string               `json:"description" bson:"description"`
-	PosterURL    string               `json:"poster_url" bson:"poster_url"`
-	TrailerURL   string               `json:"trailer_url" bson:"trailer_url"`
-	AgeLimit     int                  `json:"age_limit" bson:"age_limit"`
-	Rating       float64              `json:"rating" bson:"rating"`
-	Genres       []primitive.ObjectID `json:"genre_ids" bson:"genres"`
-	GenreNames   []string             `json:"genres" bson:"-"`
-	IsComingSoon bool                 `json:"is_coming_soon" bson:"is_coming_soon"`
-	CreatedAt    time.Time            `json:"created_at" bson:"created_at"`
+	ID           primitive.ObjectID          `json:"id" bson:"_id,omitempty"`
+	Name         string                      `json:"name" bson:"name"`
+	AgeRating    string                      `json:"age_rating" bson:"age_rating"`
+	Duration     int                         `json:"duration" bson:"duration"`
+	Description  string                      `json:"description" bson:"description"`
+	PosterURL    string                      `json:"poster_url" bson:"poster_url"`
+	TrailerURL   string                      `json:"trailer_url" bson:"trailer_url"`
+	AgeLimit     int                         `json:"age_limit" bson:"age_limit"`
+	Rating       float64                     `json:"rating" bson:"rating"`
+	RatingStats  *RatingStats                `json:"rating_stats,omitempty" bson:"rating_stats,omitempty"`
+	Genres       []primitive.ObjectID        `json:"genre_ids" bson:"genres"`
+	GenreNames   []string                    `json:"genres" bson:"-"`
+	IsComingSoon bool                        `json:"is_coming_soon" bson:"is_coming_soon"`
+	ReleaseDate  time.Time                   `json:"release_date" bson:"release_date,omitempty"`
+	Popularity   int                         `json:"popularity" bson:"popularity"`
+	Score        float64                     `json:"score,omitempty" bson:"score,omitempty"`
+	Translations map[string]MovieTranslation `json:"translations,omitempty" bson:"translations,omitempty"`
+	ReviewPolicy ReviewPolicy                `json:"review_policy" bson:"review_policy,omitempty" binding:"omitempty,oneof=OPEN WEIGHTED VERIFIED_ONLY"`
+	CreatedAt    time.Time                   `json:"created_at" bson:"created_at"`
+}
+
+type ReviewPolicy string
+
+const (
+	ReviewPolicyOpen         ReviewPolicy = "OPEN"
+	ReviewPolicyWeighted     ReviewPolicy = "WEIGHTED"
+	ReviewPolicyVerifiedOnly ReviewPolicy = "VERIFIED_ONLY"
+)
+
+func (m *Movie) EffectiveReviewPolicy() ReviewPolicy {
+	if m.ReviewPolicy == "" {
+		return ReviewPolicyOpen
+	}
+	return m.ReviewPolicy
+}
+
+const (
+	MovieSortRelevance  = "relevance"
+	MovieSortRating     = "rating"
+	MovieSortRelease    = "release"
+	MovieSortName       = "name"
+	MovieSortPopularity = "popularity"
+
+	DefaultMoviePageSize = 20
+	MaxMoviePageSize     = 100
+)
+
+type MovieQuery struct {
+	Search     string   `form:"q"`
+	GenreIDs   []string `form:"genre"`
+	AgeRating  string   `form:"age_rating"`
+	MinRating  *float64 `form:"min_rating"`
+	ComingSoon *bool    `form:"coming_soon"`
+	Showing    bool     `form:"showing"`
+	ShowingOn  string   `form:"showing_on"`
+	Location   string   `form:"location"`
+	Sort       string   `form:"sort"`
+	Order      string   `form:"order"`
+	Limit      int      `form:"limit"`
+	Cursor     string   `form:"cursor"`
 }
 
 type Genre struct {
-	ID   primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	Name string             `json:"name" bson:"name"`
+	ID           primitive.ObjectID          `json:"id" bson:"_id,omitempty"`
+	Name         string                      `json:"name" bson:"name"`
+	Translations map[string]GenreTranslation `json:"translations,omitempty" bson:"translations,omitempty"`
 }
 
+type ReviewStatus string
+
+const (
+	ReviewPending   ReviewStatus = "PENDING"
+	ReviewPublished ReviewStatus = "PUBLISHED"
+	ReviewRejected  ReviewStatus = "REJECTED"
+	ReviewHidden    ReviewStatus = "HIDDEN"
+)
+
 type Review struct {
-	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	MovieID    primitive.ObjectID `json:"movie_id" bson:"movie_id"`
-	UserID     primitive.ObjectID `json:"user_id" bson:"user_id"`
-	Rating     int                `json:"rating" bson:"rating"`
-	Comment    string             `json:"comment" bson:"comment"`
-	UserName   string             `json:"user_name" bson:"user_name"`
-	MovieTitle string             `json:"movie_title" bson:"movie_title"`
-	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+	ID               primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	MovieID          primitive.ObjectID  `json:"movie_id" bson:"movie_id"`
+	UserID           primitive.ObjectID  `json:"user_id" bson:"user_id"`
+	Rating           int                 `json:"rating" bson:"rating"`
+	Comment          string              `json:"comment" bson:"comment"`
+	UserName         string              `json:"user_name" bson:"user_name"`
+	MovieTitle       string              `json:"movie_title" bson:"movie_title"`
+	Status           ReviewStatus        `json:"status" bson:"status"`
+	ModerationReason string              `json:"moderation_reason,omitempty" bson:"moderation_reason,omitempty"`
+	ModeratedBy      *primitive.ObjectID `json:"moderated_by,omitempty" bson:"moderated_by,omitempty"`
+	ModeratedAt      *time.Time          `json:"moderated_at,omitempty" bson:"moderated_at,omitempty"`
+	ReportCount      int                 `json:"report_count" bson:"report_count"`
+	VerifiedViewer   bool                `json:"verified_viewer" bson:"verified_viewer"`
+	Weight           float64             `json:"weight" bson:"weight,omitempty"`
+	HasComment       bool                `json:"-" bson:"has_comment"`
+	HelpfulCount     int                 `json:"helpful_count" bson:"helpful_count"`
+	UnhelpfulCount   int                 `json:"unhelpful_count" bson:"unhelpful_count"`
+	Reply            *ReviewReply        `json:"reply,omitempty" bson:"reply,omitempty"`
+	CreatedAt        time.Time           `json:"created_at" bson:"created_at"`
+}
+
+func (r *Review) RatingWeight() float64 {
+	if r.Weight <= 0 {
+		return 1
+	}
+	return r.Weight
 }
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"cinema-system/internal/apperrors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type NotificationKind string
+
+const (
+	NotificationBookingConfirmed NotificationKind = "BOOKING_CONFIRMED"
+	NotificationSessionReminder  NotificationKind = "SESSION_REMINDER"
+	NotificationSessionCancelled NotificationKind = "SESSION_CANCELLED"
+)
+
+type NotificationChannel string
+
+const (
+	ChannelEmail NotificationChannel = "EMAIL"
+	ChannelSMS   NotificationChannel = "SMS"
+)
+
+type NotificationStatus string
+
+const (
+	NotificationPending NotificationStatus = "PENDING"
+	NotificationSent    NotificationStatus = "SENT"
+	NotificationFailed  NotificationStatus = "FAILED"
+)
+
+const (
+	MaxNotificationAttempts    = 5
+	DefaultReminderLeadMinutes = 120
+)
+
+type Notification struct {
+	ID            primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	UserID        primitive.ObjectID  `json:"user_id" bson:"user_id"`
+	Kind          NotificationKind    `json:"kind" bson:"kind"`
+	Channel       NotificationChannel `json:"channel" bson:"channel"`
+	Recipient     string              `json:"recipient" bson:"recipient"`
+	Subject       string              `json:"subject" bson:"subject"`
+	Body          string              `json:"body" bson:"body"`
+	DedupKey      string              `json:"-" bson:"dedup_key"`
+	Status        NotificationStatus  `json:"status" bson:"status"`
+	Attempts      int                 `json:"attempts" bson:"attempts"`
+	LastError     string              `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	NextAttemptAt time.Time           `json:"next_attempt_at" bson:"next_attempt_at"`
+	SentAt        *time.Time          `json:"sent_at,omitempty" bson:"sent_at,omitempty"`
+	CreatedAt     time.Time           `json:"created_at" bson:"created_at"`
+}
+
+type NotificationPreferences struct {
+	UserID              primitive.ObjectID `json:"user_id" bson:"user_id"`
+	EmailEnabled        bool               `json:"email_enabled" bson:"email_enabled"`
+	SMSEnabled          bool               `json:"sms_enabled" bson:"sms_enabled"`
+	BookingConfirmation bool               `json:"booking_confirmation" bson:"booking_confirmation"`
+	Reminders           bool               `json:"reminders" bson:"reminders"`
+	ReminderLeadMinutes int                `json:"reminder_lead_minutes" bson:"reminder_lead_minutes"`
+	Cancellations       bool               `json:"cancellations" bson:"cancellations"`
+	UpdatedAt           time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+func DefaultNotificationPreferences(userID primitive.ObjectID) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:              userID,
+		EmailEnabled:        true,
+		SMSEnabled:          false,
+		BookingConfirmation: true,
+		Reminders:           true,
+		ReminderLeadMinutes: DefaultReminderLeadMinutes,
+		Cancellations:       true,
+	}
+}
+
+func (p *NotificationPreferences) Validate() error {
+	if p.ReminderLeadMinutes < 15 || p.ReminderLeadMinutes > 24*60 {
+		return apperrors.Validation("invalid_reminder_lead_time", "reminder lead time must be between 15 minutes and 24 hours")
+	}
+	return nil
+}
+
+func (p *NotificationPreferences) Wants(kind NotificationKind) bool {
+	switch kind {
+	case NotificationBookingConfirmed:
+		return p.BookingConfirmation
+	case NotificationSessionReminder:
+		return p.Reminders
+	case NotificationSessionCancelled:
+		return p.Cancellations
+	}
+	return false
+}
+
+func (p *NotificationPreferences) Channels() []NotificationChannel {
+	var channels []NotificationChannel
+	if p.EmailEnabled {
+		channels = append(channels, ChannelEmail)
+	}
+	if p.SMSEnabled {
+		channels = append(channels, ChannelSMS)
+	}
+	return channels
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "PENDING"
+	OutboxProcessed OutboxStatus = "PROCESSED"
+	OutboxFailed    OutboxStatus = "FAILED"
+)
+
+const MaxOutboxAttempts = 10
+
+type OutboxEvent struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Type          string             `json:"type" bson:"type"`
+	AggregateID   primitive.ObjectID `json:"aggregate_id" bson:"aggregate_id"`
+	Payload       bson.Raw           `json:"-" bson:"payload"`
+	Status        OutboxStatus       `json:"status" bson:"status"`
+	Attempts      int                `json:"attempts" bson:"attempts"`
+	LastError     string             `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	NextAttemptAt time.Time          `json:"next_attempt_at" bson:"next_attempt_at"`
+	ProcessedAt   *time.Time         `json:"processed_at,omitempty" bson:"processed_at,omitempty"`
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
+}
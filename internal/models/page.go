@@ -0,0 +1,10 @@
+package models
+
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int64  `json:"total"`
+	Limit      int64  `json:"limit"`
+	Page       int64  `json:"page,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Next       string `json:"next,omitempty"`
+}
@@ -1,7 +1,9 @@
 package models
 
 import (
-	"errors"
+	"cinema-system/internal/apperrors"
+	"cinema-system/internal/money"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -16,32 +18,40 @@ const (
 	PaymentRefunded  PaymentStatus = "REFUNDED"
 )
 
-const (
-	MaxPaymentAmount = 100000.0
-)
+var MaxPaymentAmounts = map[string]int64{
+	"KZT": 10000000,
+	"RUB": 2000000,
+	"USD": 20000,
+	"EUR": 20000,
+}
 
 type Payment struct {
 	ID              primitive.ObjectID `json:"id" bson:"_id,omitempty"`
 	UserID          primitive.ObjectID `json:"user_id" bson:"user_id"`
 	PaymentCardID   primitive.ObjectID `json:"payment_card_id" bson:"payment_card_id"`
 	TransactionCode string             `json:"transaction_code" bson:"transaction_code"`
-	Amount          float64            `json:"amount" bson:"amount"`
+	Amount          money.Money        `json:"amount" bson:"amount"`
 	Status          PaymentStatus      `json:"status" bson:"status"`
 	CreatedAt       time.Time          `json:"created_at" bson:"created_at"`
 }
 
 type PaymentCreate struct {
 	PaymentCardID primitive.ObjectID `json:"payment_card_id" binding:"required"`
-	Amount        float64            `json:"amount" binding:"required,gt=0"`
+	Amount        int64              `json:"amount" binding:"required,gt=0"`
+	Currency      string             `json:"currency"`
 }
 
-func ValidateAmount(amount float64) error {
-	if amount <= 0 {
-		return errors.New("amount must be greater than 0")
+func ValidateAmount(amount money.Money) error {
+	if _, err := money.Lookup(amount.Currency); err != nil {
+		return err
+	}
+
+	if !amount.IsPositive() {
+		return apperrors.Validation("invalid_amount", "amount must be greater than 0")
 	}
 
-	if amount > MaxPaymentAmount {
-		return errors.New("amount exceeds maximum limit of 100,000 tenge")
+	if limit := money.New(MaxPaymentAmounts[amount.Currency], amount.Currency); amount.Amount > limit.Amount {
+		return apperrors.Validation("amount_limit_exceeded", "amount exceeds maximum limit of {limit}").With("limit", limit.String())
 	}
 
 	return nil
@@ -49,12 +59,25 @@ func ValidateAmount(amount float64) error {
 
 func (pc *PaymentCreate) Validate() error {
 	if pc.PaymentCardID.IsZero() {
-		return errors.New("payment card ID is required")
+		return apperrors.Validation("payment_card_required", "payment card ID is required")
 	}
 
-	if err := ValidateAmount(pc.Amount); err != nil {
-		return err
+	if pc.Currency != "" {
+		if _, err := money.Lookup(pc.Currency); err != nil {
+			return err
+		}
+	}
+
+	if pc.Amount <= 0 {
+		return apperrors.Validation("invalid_amount", "amount must be greater than 0")
 	}
 
 	return nil
 }
+
+func (pc *PaymentCreate) Money(currency string) money.Money {
+	if pc.Currency != "" {
+		currency = strings.ToUpper(pc.Currency)
+	}
+	return money.New(pc.Amount, currency)
+}
@@ -1,7 +1,7 @@
 package models
 
 import (
-	"errors"
+	"cinema-system/internal/apperrors"
 	"regexp"
 	"strconv"
 	"time"
@@ -28,12 +28,12 @@ type PaymentCardCreate struct {
 
 func ValidateCardNumber(cardNumber string) error {
 	if len(cardNumber) != 16 {
-		return errors.New("card number must be exactly 16 digits")
+		return apperrors.Validation("invalid_card_number", "card number must be exactly 16 digits")
 	}
 
 	matched, _ := regexp.MatchString(`^\d{16}$`, cardNumber)
 	if !matched {
-		return errors.New("card number must contain only digits")
+		return apperrors.Validation("invalid_card_number", "card number must contain only digits")
 	}
 
 	return nil
@@ -42,17 +42,17 @@ func ValidateCardNumber(cardNumber string) error {
 func ValidateExpiryDate(expiryDate string) error {
 	matched, _ := regexp.MatchString(`^\d{2}/\d{2}$`, expiryDate)
 	if !matched {
-		return errors.New("expiry date must be in MM/YY format")
+		return apperrors.Validation("invalid_expiry_date", "expiry date must be in MM/YY format")
 	}
 
 	month, err := strconv.Atoi(expiryDate[0:2])
 	if err != nil || month < 1 || month > 12 {
-		return errors.New("invalid month in expiry date (must be 01-12)")
+		return apperrors.Validation("invalid_expiry_date", "invalid month in expiry date (must be 01-12)")
 	}
 
 	year, err := strconv.Atoi(expiryDate[3:5])
 	if err != nil {
-		return errors.New("invalid year in expiry date")
+		return apperrors.Validation("invalid_expiry_date", "invalid year in expiry date")
 	}
 
 	now := time.Now()
@@ -60,7 +60,7 @@ func ValidateExpiryDate(expiryDate string) error {
 	currentMonth := int(now.Month())
 
 	if year < currentYear || (year == currentYear && month < currentMonth) {
-		return errors.New("card has expired")
+		return apperrors.Validation("card_expired", "card has expired")
 	}
 
 	return nil
@@ -68,12 +68,12 @@ func ValidateExpiryDate(expiryDate string) error {
 
 func ValidateCVV(cvv string) error {
 	if len(cvv) < 3 || len(cvv) > 4 {
-		return errors.New("CVV must be 3 or 4 digits")
+		return apperrors.Validation("invalid_cvv", "CVV must be 3 or 4 digits")
 	}
 
 	matched, _ := regexp.MatchString(`^\d{3,4}$`, cvv)
 	if !matched {
-		return errors.New("CVV must contain only digits")
+		return apperrors.Validation("invalid_cvv", "CVV must contain only digits")
 	}
 
 	return nil
@@ -81,7 +81,7 @@ func ValidateCVV(cvv string) error {
 
 func (pc *PaymentCardCreate) Validate() error {
 	if pc.CardHolderName == "" {
-		return errors.New("card holder name is required")
+		return apperrors.Validation("card_holder_required", "card holder name is required")
 	}
 
 	if err := ValidateCardNumber(pc.CardNumber); err != nil {
@@ -1,7 +1,8 @@
 package models
 
 import (
-	"errors"
+	"cinema-system/internal/apperrors"
+	"cinema-system/internal/money"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -10,7 +11,7 @@ import (
 type PaymentCode struct {
 	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
 	Code      string             `json:"code" bson:"code"`
-	Amount    float64            `json:"amount" bson:"amount"`
+	Amount    money.Money        `json:"amount" bson:"amount"`
 	IsUsed    bool               `json:"is_used" bson:"is_used"`
 	UsedBy    primitive.ObjectID `json:"used_by" bson:"used_by,omitempty"`
 	UsedAt    time.Time          `json:"used_at" bson:"used_at,omitempty"`
@@ -23,10 +24,7 @@ type RedeemCodeRequest struct {
 
 func (pc *PaymentCode) Validate() error {
 	if pc.Code == "" {
-		return errors.New("code is required")
+		return apperrors.Validation("payment_code_required", "code is required")
 	}
-	if pc.Amount <= 0 {
-		return errors.New("amount must be greater than 0")
-	}
-	return nil
+	return ValidateAmount(pc.Amount)
 }
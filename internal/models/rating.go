@@ -0,0 +1,92 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	MinReviewRating = 0
+	MaxReviewRating = 10
+)
+
+const (
+	RecomputeIdle    = "idle"
+	RecomputeQueued  = "queued"
+	RecomputeRunning = "running"
+)
+
+type RatingRecomputeStatus struct {
+	State      string     `json:"state"`
+	Movies     int        `json:"movies"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+type RatingPrior struct {
+	Mean     float64
+	Weight   float64
+	Bayesian bool
+}
+
+type RatingStats struct {
+	Count     int            `json:"count" bson:"count"`
+	Sum       float64        `json:"-" bson:"sum"`
+	Weight    float64        `json:"-" bson:"weight"`
+	Average   float64        `json:"average" bson:"average"`
+	Bayesian  float64        `json:"bayesian" bson:"bayesian"`
+	Histogram map[string]int `json:"histogram" bson:"histogram"`
+}
+
+func (s *RatingStats) Add(rating int, weight float64) {
+	s.apply(rating, weight, 1)
+}
+
+func (s *RatingStats) Remove(rating int, weight float64) {
+	s.apply(rating, weight, -1)
+}
+
+func (s *RatingStats) apply(rating int, weight float64, sign int) {
+	if s.Histogram == nil {
+		s.Histogram = make(map[string]int)
+	}
+	s.Count += sign
+	s.Sum += float64(sign) * float64(rating) * weight
+	s.Weight += float64(sign) * weight
+	s.Histogram[strconv.Itoa(rating)] += sign
+}
+
+func (s *RatingStats) IsZero() bool {
+	if s.Count != 0 || s.Sum != 0 || s.Weight != 0 {
+		return false
+	}
+	for _, n := range s.Histogram {
+		if n != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *RatingStats) Rating(prior RatingPrior) float64 {
+	if prior.Bayesian {
+		return s.Bayesian
+	}
+	return s.Average
+}
+
+func (s *RatingStats) Compute(priorMean, priorWeight float64) {
+	full := make(map[string]int, MaxReviewRating-MinReviewRating+1)
+	for score := MinReviewRating; score <= MaxReviewRating; score++ {
+		full[strconv.Itoa(score)] = s.Histogram[strconv.Itoa(score)]
+	}
+	s.Histogram = full
+
+	if s.Count <= 0 || s.Weight <= 0 {
+		s.Count, s.Sum, s.Weight, s.Average, s.Bayesian = 0, 0, 0, 0, 0
+		return
+	}
+	s.Average = s.Sum / s.Weight
+	s.Bayesian = (priorMean*priorWeight + s.Sum) / (priorWeight + s.Weight)
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RecommendationKind string
+
+const (
+	RecommendationForUser RecommendationKind = "USER"
+	RecommendationSimilar RecommendationKind = "SIMILAR"
+	RecommendationPopular RecommendationKind = "POPULAR"
+)
+
+type RecommendationReason string
+
+const (
+	ReasonLiked        RecommendationReason = "LIKED"
+	ReasonWatched      RecommendationReason = "WATCHED"
+	ReasonGenre        RecommendationReason = "GENRE"
+	ReasonCoBooked     RecommendationReason = "CO_BOOKED"
+	ReasonSharedGenres RecommendationReason = "SHARED_GENRES"
+	ReasonPopular      RecommendationReason = "POPULAR"
+)
+
+type Recommendation struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Kind       RecommendationKind `json:"kind" bson:"kind"`
+	SubjectID  primitive.ObjectID `json:"subject_id" bson:"subject_id"`
+	Items      []RecommendedMovie `json:"items" bson:"items"`
+	ComputedAt time.Time          `json:"computed_at" bson:"computed_at"`
+}
+
+type RecommendedMovie struct {
+	MovieID     primitive.ObjectID   `json:"movie_id" bson:"movie_id"`
+	Score       float64              `json:"score" bson:"score"`
+	Reason      RecommendationReason `json:"reason" bson:"reason"`
+	BasedOn     *primitive.ObjectID  `json:"based_on,omitempty" bson:"based_on,omitempty"`
+	GenreIDs    []primitive.ObjectID `json:"genre_ids,omitempty" bson:"genre_ids,omitempty"`
+	Explanation string               `json:"explanation" bson:"-"`
+	Movie       *Movie               `json:"movie,omitempty" bson:"-"`
+}
+
+type RecommendationQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=50"`
+}
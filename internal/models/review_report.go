@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReportReason string
+
+const (
+	ReportSpam      ReportReason = "SPAM"
+	ReportOffensive ReportReason = "OFFENSIVE"
+	ReportSpoiler   ReportReason = "SPOILER"
+	ReportOffTopic  ReportReason = "OFF_TOPIC"
+	ReportOther     ReportReason = "OTHER"
+)
+
+type ReviewReport struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ReviewID   primitive.ObjectID `json:"review_id" bson:"review_id"`
+	UserID     primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Reason     ReportReason       `json:"reason" bson:"reason"`
+	Comment    string             `json:"comment,omitempty" bson:"comment,omitempty"`
+	Resolved   bool               `json:"resolved" bson:"resolved"`
+	ResolvedAt *time.Time         `json:"resolved_at,omitempty" bson:"resolved_at,omitempty"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+}
+
+type ReviewReportRequest struct {
+	Reason  ReportReason `json:"reason" binding:"required,oneof=SPAM OFFENSIVE SPOILER OFF_TOPIC OTHER"`
+	Comment string       `json:"comment" binding:"max=500"`
+}
+
+type ModerationDecision struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
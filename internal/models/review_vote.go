@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReviewVote struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ReviewID  primitive.ObjectID `json:"review_id" bson:"review_id"`
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Helpful   bool               `json:"helpful" bson:"helpful"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
+
+type ReviewVoteRequest struct {
+	Helpful *bool `json:"helpful" binding:"required"`
+}
+
+type ReviewReply struct {
+	Text       string             `json:"text" bson:"text"`
+	AuthorID   primitive.ObjectID `json:"author_id" bson:"author_id"`
+	AuthorName string             `json:"author_name" bson:"author_name"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+type ReviewReplyRequest struct {
+	Text string `json:"text" binding:"required,max=2000"`
+}
@@ -1,15 +1,18 @@
 package models
 
 import (
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"cinema-system/internal/money"
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Session struct {
-	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	MovieID   primitive.ObjectID `json:"movie_id" bson:"movie_id"`
-	HallID    primitive.ObjectID `json:"hall_id" bson:"hall_id"`
-	StartTime time.Time          `json:"start_time" bson:"start_time"`
-	EndTime   time.Time          `json:"end_time" bson:"end_time"`
-	Price     float64            `json:"price" bson:"price"`
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	MovieID      primitive.ObjectID `json:"movie_id" bson:"movie_id"`
+	HallID       primitive.ObjectID `json:"hall_id" bson:"hall_id"`
+	StartTime    time.Time          `json:"start_time" bson:"start_time"`
+	EndTime      time.Time          `json:"end_time" bson:"end_time"`
+	Price        money.Money        `json:"price" bson:"price"`
+	DisplayPrice *money.Money       `json:"display_price,omitempty" bson:"-"`
 }
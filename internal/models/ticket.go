@@ -1,6 +1,7 @@
 package models
 
 import (
+	"cinema-system/internal/money"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -12,6 +13,7 @@ const (
 	TicketBooked    TicketStatus = "BOOKED"
 	TicketPaid      TicketStatus = "PAID"
 	TicketCancelled TicketStatus = "CANCELLED"
+	TicketUsed      TicketStatus = "USED"
 )
 
 type TicketType string
@@ -31,8 +33,59 @@ type Ticket struct {
 	RowNumber  int                `json:"row_number" bson:"row_number"`
 	SeatNumber int                `json:"seat_number" bson:"seat_number"`
 	Type       TicketType         `json:"type" bson:"type"`
-	Price      float64            `json:"price" bson:"price"`
+	Price      money.Money        `json:"price" bson:"price"`
 	MovieTitle string             `json:"movie_title" bson:"movie_title"`
 	Status     TicketStatus       `json:"status" bson:"status"`
+	UsedAt     *time.Time         `json:"used_at,omitempty" bson:"used_at,omitempty"`
 	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
 }
+
+type ScanResult string
+
+const (
+	ScanAdmitted  ScanResult = "ADMITTED"
+	ScanDuplicate ScanResult = "DUPLICATE"
+	ScanRejected  ScanResult = "REJECTED"
+)
+
+type ScanRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type TicketScan struct {
+	Result    ScanResult `json:"result"`
+	Reason    string     `json:"reason,omitempty"`
+	Ticket    *Ticket    `json:"ticket,omitempty"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	DeviceID  string     `json:"device_id,omitempty"`
+	ClientID  string     `json:"client_id,omitempty"`
+	TokenHash string     `json:"token_hash,omitempty"`
+}
+
+type OfflineScan struct {
+	Token     string    `json:"token" binding:"required"`
+	ScannedAt time.Time `json:"scanned_at" binding:"required"`
+	DeviceID  string    `json:"device_id"`
+	ClientID  string    `json:"client_id" binding:"max=128"`
+}
+
+type ScanSyncRequest struct {
+	Scans []OfflineScan `json:"scans" binding:"required,dive"`
+}
+
+type ManifestEntry struct {
+	TicketID   primitive.ObjectID `json:"ticket_id"`
+	RowNumber  int                `json:"row_number"`
+	SeatNumber int                `json:"seat_number"`
+	Status     TicketStatus       `json:"status"`
+	TokenHash  string             `json:"token_hash"`
+}
+
+type SessionManifest struct {
+	SessionID   primitive.ObjectID `json:"session_id"`
+	MovieTitle  string             `json:"movie_title"`
+	ValidFrom   time.Time          `json:"valid_from"`
+	ValidUntil  time.Time          `json:"valid_until"`
+	GeneratedAt time.Time          `json:"generated_at"`
+	Tickets     []ManifestEntry    `json:"tickets"`
+}
@@ -0,0 +1,29 @@
+package models
+
+type MovieTranslation struct {
+	Name        string `json:"name" bson:"name" binding:"required"`
+	Description string `json:"description" bson:"description"`
+}
+
+type GenreTranslation struct {
+	Name string `json:"name" bson:"name" binding:"required"`
+}
+
+func (m *Movie) Localize(lang string) {
+	if t, ok := m.Translations[lang]; ok {
+		if t.Name != "" {
+			m.Name = t.Name
+		}
+		if t.Description != "" {
+			m.Description = t.Description
+		}
+	}
+	m.Translations = nil
+}
+
+func (g *Genre) Localize(lang string) {
+	if t, ok := g.Translations[lang]; ok && t.Name != "" {
+		g.Name = t.Name
+	}
+	g.Translations = nil
+}
@@ -1,8 +1,10 @@
 package models
 
 import (
-	"go.mongodb.org/mongo-driver/bson/primitive"
+	"cinema-system/internal/money"
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Role string
@@ -10,6 +12,7 @@ type Role string
 const (
 	RoleGuest Role = "GUEST"
 	RoleUser  Role = "USER"
+	RoleStaff Role = "STAFF"
 	RoleAdmin Role = "ADMIN"
 )
 
@@ -21,7 +24,7 @@ type User struct {
 	PhoneNumber  string             `json:"phone_number" bson:"phone_number"`
 	PasswordHash string             `json:"-" bson:"password_hash"`
 	Role         Role               `json:"role" bson:"role"`
-	Balance      float64            `json:"balance" bson:"balance"`
+	Balance      money.Money        `json:"balance" bson:"balance"`
 	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
 }
 
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WalletPass struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	TicketID     primitive.ObjectID `json:"ticket_id" bson:"ticket_id"`
+	SessionID    primitive.ObjectID `json:"session_id" bson:"session_id"`
+	SerialNumber string             `json:"serial_number" bson:"serial_number"`
+	AuthToken    string             `json:"-" bson:"auth_token"`
+	Voided       bool               `json:"voided" bson:"voided"`
+	UpdatedAt    time.Time          `json:"updated_at" bson:"updated_at"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+}
+
+type WalletRegistration struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	DeviceID     string             `json:"device_id" bson:"device_id"`
+	PushToken    string             `json:"push_token" bson:"push_token"`
+	PassTypeID   string             `json:"pass_type_id" bson:"pass_type_id"`
+	SerialNumber string             `json:"serial_number" bson:"serial_number"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+}
+
+type WalletRegistrationRequest struct {
+	PushToken string `json:"pushToken" binding:"required"`
+}
+
+type WalletLogRequest struct {
+	Logs []string `json:"logs"`
+}
+
+type WalletSerials struct {
+	LastUpdated   string   `json:"lastUpdated"`
+	SerialNumbers []string `json:"serialNumbers"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+const MaxWebhookAttempts = 8
+
+type WebhookSubscription struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	URL         string             `json:"url" bson:"url"`
+	EventTypes  []string           `json:"event_types" bson:"event_types"`
+	Secret      string             `json:"-" bson:"secret"`
+	SecretHint  string             `json:"secret_hint,omitempty" bson:"secret_hint,omitempty"`
+	Description string             `json:"description,omitempty" bson:"description,omitempty"`
+	Active      bool               `json:"active" bson:"active"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+type WebhookSubscriptionCreated struct {
+	WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+type WebhookSubscriptionRequest struct {
+	URL         string   `json:"url" binding:"required,url"`
+	EventTypes  []string `json:"event_types" binding:"required,min=1"`
+	Secret      string   `json:"secret"`
+	Description string   `json:"description"`
+	Active      *bool    `json:"active"`
+}
+
+type WebhookDelivery struct {
+	ID             primitive.ObjectID    `json:"id" bson:"_id,omitempty"`
+	SubscriptionID primitive.ObjectID    `json:"subscription_id" bson:"subscription_id"`
+	EventID        primitive.ObjectID    `json:"event_id" bson:"event_id"`
+	EventType      string                `json:"event_type" bson:"event_type"`
+	URL            string                `json:"url" bson:"url"`
+	Payload        string                `json:"payload" bson:"payload"`
+	Status         WebhookDeliveryStatus `json:"status" bson:"status"`
+	Attempts       int                   `json:"attempts" bson:"attempts"`
+	ResponseCode   int                   `json:"response_code,omitempty" bson:"response_code,omitempty"`
+	ResponseBody   string                `json:"response_body,omitempty" bson:"response_body,omitempty"`
+	LastError      string                `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at" bson:"next_attempt_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty" bson:"delivered_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at" bson:"created_at"`
+}
@@ -0,0 +1,180 @@
+package money
+
+import (
+	"cinema-system/internal/apperrors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const DefaultCurrency = "KZT"
+
+var (
+	ErrUnsupportedCurrency = apperrors.Validation("unsupported_currency", "unsupported currency: {currency}")
+	ErrCurrencyMismatch    = apperrors.Conflict("currency_mismatch", "currency mismatch: {left} and {right}")
+	ErrInvalidRate         = apperrors.Validation("invalid_exchange_rate", "exchange rate must be a positive decimal number")
+	ErrMalformedAmount     = apperrors.Validation("malformed_amount", "amount must be a decimal number")
+)
+
+type Currency struct {
+	Code     string `json:"code"`
+	Symbol   string `json:"symbol"`
+	Exponent int    `json:"exponent"`
+}
+
+var currencies = map[string]Currency{
+	"KZT": {Code: "KZT", Symbol: "₸", Exponent: 2},
+	"RUB": {Code: "RUB", Symbol: "₽", Exponent: 2},
+	"USD": {Code: "USD", Symbol: "$", Exponent: 2},
+	"EUR": {Code: "EUR", Symbol: "€", Exponent: 2},
+}
+
+func Lookup(code string) (Currency, error) {
+	currency, ok := currencies[strings.ToUpper(code)]
+	if !ok {
+		return Currency{}, ErrUnsupportedCurrency.With("currency", code)
+	}
+	return currency, nil
+}
+
+func IsSupported(code string) bool {
+	_, ok := currencies[code]
+	return ok
+}
+
+func Currencies() []Currency {
+	out := make([]Currency, 0, len(currencies))
+	for _, code := range []string{"KZT", "RUB", "USD", "EUR"} {
+		out = append(out, currencies[code])
+	}
+	return out
+}
+
+type Money struct {
+	Amount   int64  `json:"amount" bson:"amount"`
+	Currency string `json:"currency" bson:"currency"`
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+func Zero(currency string) Money {
+	return Money{Currency: currency}
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+func (m Money) SameCurrency(other Money) bool {
+	return m.Currency == other.Currency
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if !m.SameCurrency(other) {
+		return Money{}, ErrCurrencyMismatch.With("left", m.Currency).With("right", other.Currency)
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if !m.SameCurrency(other) {
+		return Money{}, ErrCurrencyMismatch.With("left", m.Currency).With("right", other.Currency)
+	}
+	return Money{Amount: m.Amount - other.Amount, Currency: m.Currency}, nil
+}
+
+func (m Money) LessThan(other Money) (bool, error) {
+	if !m.SameCurrency(other) {
+		return false, ErrCurrencyMismatch.With("left", m.Currency).With("right", other.Currency)
+	}
+	return m.Amount < other.Amount, nil
+}
+
+func (m Money) Scale(num, den int64) Money {
+	return Money{Amount: roundHalfUp(new(big.Rat).SetFrac64(m.Amount*num, den)), Currency: m.Currency}
+}
+
+func (m Money) Micros() int64 {
+	return m.Amount * pow10(6-m.exponent()).Int64()
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+func (m Money) Decimal() string {
+	exponent := m.exponent()
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	if exponent == 0 {
+		return fmt.Sprintf("%s%d", sign, amount)
+	}
+	unit := pow10(exponent).Int64()
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/unit, exponent, amount%unit)
+}
+
+func (m Money) exponent() int {
+	if currency, ok := currencies[m.Currency]; ok {
+		return currency.Exponent
+	}
+	return 2
+}
+
+func Parse(value, currency string) (Money, error) {
+	info, err := Lookup(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	rat, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return Money{}, ErrMalformedAmount
+	}
+	rat.Mul(rat, new(big.Rat).SetInt(pow10(info.Exponent)))
+	return Money{Amount: roundHalfUp(rat), Currency: info.Code}, nil
+}
+
+func ParseRate(rate string) (*big.Rat, error) {
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(rate))
+	if !ok || value.Sign() <= 0 {
+		return nil, ErrInvalidRate.With("rate", rate)
+	}
+	return value, nil
+}
+
+func (m Money) Convert(to string, rate *big.Rat) (Money, error) {
+	target, err := Lookup(to)
+	if err != nil {
+		return Money{}, err
+	}
+	value := new(big.Rat).SetFrac(big.NewInt(m.Amount), pow10(m.exponent()))
+	value.Mul(value, rate)
+	value.Mul(value, new(big.Rat).SetInt(pow10(target.Exponent)))
+	return Money{Amount: roundHalfUp(value), Currency: target.Code}, nil
+}
+
+func roundHalfUp(value *big.Rat) int64 {
+	num := new(big.Int).Abs(value.Num())
+	den := value.Denom()
+	quotient, remainder := new(big.Int).QuoRem(num, den, new(big.Int))
+	if remainder.Mul(remainder, big.NewInt(2)).Cmp(den) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if value.Sign() < 0 {
+		quotient.Neg(quotient)
+	}
+	return quotient.Int64()
+}
+
+func pow10(exponent int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
@@ -0,0 +1,80 @@
+package money
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestScaleRoundsHalfUp(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		num, den int64
+		want     int64
+	}{
+		{1000, 80, 100, 800},
+		{1005, 70, 100, 704},
+		{1005, 80, 100, 804},
+		{999, 50, 100, 500},
+		{11200, 1200, 11200, 1200},
+		{-1005, 70, 100, -704},
+	}
+	for _, tt := range tests {
+		if got := New(tt.amount, "KZT").Scale(tt.num, tt.den); got.Amount != tt.want || got.Currency != "KZT" {
+			t.Errorf("Scale(%d, %d/%d) = %+v, want %d KZT", tt.amount, tt.num, tt.den, got, tt.want)
+		}
+	}
+}
+
+func TestArithmeticRequiresSameCurrency(t *testing.T) {
+	sum, err := New(150, "KZT").Add(New(250, "KZT"))
+	if err != nil || sum != New(400, "KZT") {
+		t.Fatalf("Add = %+v, %v", sum, err)
+	}
+	if _, err := New(150, "KZT").Sub(New(1, "USD")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("err = %v, want %v", err, ErrCurrencyMismatch)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	rate, err := ParseRate("0.0021")
+	if err != nil {
+		t.Fatalf("ParseRate: %v", err)
+	}
+	converted, err := New(250000, "KZT").Convert("USD", rate)
+	if err != nil || converted != New(525, "USD") {
+		t.Fatalf("Convert = %+v, %v", converted, err)
+	}
+
+	inverse, err := New(525, "USD").Convert("KZT", rate.Inv(rate))
+	if err != nil || inverse != New(250000, "KZT") {
+		t.Fatalf("inverse Convert = %+v, %v", inverse, err)
+	}
+
+	for _, raw := range []string{"", "abc", "0", "-1.5"} {
+		if _, err := ParseRate(raw); !errors.Is(err, ErrInvalidRate) {
+			t.Errorf("ParseRate(%q) err = %v, want %v", raw, err, ErrInvalidRate)
+		}
+	}
+}
+
+func TestParseAndString(t *testing.T) {
+	tests := []struct {
+		raw, currency string
+		want          Money
+		text          string
+	}{
+		{"1500", "KZT", New(150000, "KZT"), "1500.00 KZT"},
+		{"12.345", "usd", New(1235, "USD"), "12.35 USD"},
+		{"0.05", "EUR", New(5, "EUR"), "0.05 EUR"},
+		{"-3.1", "RUB", New(-310, "RUB"), "-3.10 RUB"},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.raw, tt.currency)
+		if err != nil || got != tt.want || got.String() != tt.text {
+			t.Errorf("Parse(%q, %q) = %+v (%s), %v; want %+v (%s)", tt.raw, tt.currency, got, got, err, tt.want, tt.text)
+		}
+	}
+	if _, err := Parse("10", "XYZ"); !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Fatalf("err = %v, want %v", err, ErrUnsupportedCurrency)
+	}
+}
@@ -0,0 +1,44 @@
+package notifications
+
+import (
+	"cinema-system/internal/config"
+	"cinema-system/internal/models"
+	"context"
+	"log/slog"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Channel interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+func NewChannels(cfg config.NotificationConfig) map[models.NotificationChannel]Channel {
+	sink := NewFileSink(cfg.LogFile)
+	channels := map[models.NotificationChannel]Channel{
+		models.ChannelEmail: sink,
+		models.ChannelSMS:   sink,
+	}
+
+	if cfg.SMTP.Host != "" {
+		channels[models.ChannelEmail] = NewSMTPChannel(SMTPConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+		})
+	} else {
+		slog.Warn("notifications: SMTP_HOST not set, emails go to the local sink")
+	}
+
+	if cfg.SMS.URL != "" {
+		channels[models.ChannelSMS] = NewSMSChannel(NewHTTPSMSProvider(cfg.SMS.URL, cfg.SMS.APIKey, cfg.SMS.Sender))
+	}
+
+	return channels
+}
@@ -0,0 +1,52 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+type SMTPChannel struct {
+	config SMTPConfig
+}
+
+func NewSMTPChannel(config SMTPConfig) *SMTPChannel {
+	return &SMTPChannel{config: config}
+}
+
+func (c *SMTPChannel) Send(ctx context.Context, msg Message) error {
+	if msg.To == "" {
+		return errors.New("email recipient is empty")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if c.config.Username != "" {
+		auth = smtp.PlainAuth("", c.config.Username, c.config.Password, c.config.Host)
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "From: %s\r\n", c.config.From)
+	fmt.Fprintf(&body, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&body, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&body, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	body.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	addr := fmt.Sprintf("%s:%d", c.config.Host, c.config.Port)
+	return smtp.SendMail(addr, auth, c.config.From, []string{msg.To}, []byte(body.String()))
+}
@@ -0,0 +1,77 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type SMSProvider interface {
+	SendSMS(ctx context.Context, to, text string) error
+}
+
+type SMSChannel struct {
+	provider SMSProvider
+}
+
+func NewSMSChannel(provider SMSProvider) *SMSChannel {
+	return &SMSChannel{provider: provider}
+}
+
+func (c *SMSChannel) Send(ctx context.Context, msg Message) error {
+	if msg.To == "" {
+		return errors.New("phone number is empty")
+	}
+	return c.provider.SendSMS(ctx, msg.To, msg.Body)
+}
+
+type HTTPSMSProvider struct {
+	url    string
+	apiKey string
+	sender string
+	client *http.Client
+}
+
+func NewHTTPSMSProvider(url, apiKey, sender string) *HTTPSMSProvider {
+	return &HTTPSMSProvider{
+		url:    url,
+		apiKey: apiKey,
+		sender: sender,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *HTTPSMSProvider) SendSMS(ctx context.Context, to, text string) error {
+	payload, err := json.Marshal(map[string]string{
+		"to":      to,
+		"from":    p.sender,
+		"message": text,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sms provider returned %s", resp.Status)
+	}
+	return nil
+}
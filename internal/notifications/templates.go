@@ -0,0 +1,77 @@
+package notifications
+
+import (
+	"cinema-system/internal/models"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+type TemplateData struct {
+	UserName        string
+	MovieTitle      string
+	HallName        string
+	StartTime       string
+	Seats           []string
+	Total           string
+	TransactionCode string
+}
+
+type messageTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+var templates = map[models.NotificationKind]messageTemplate{
+	models.NotificationBookingConfirmed: mustTemplate(
+		"Booking confirmed: {{.MovieTitle}}",
+		`Hi {{.UserName}},
+
+your booking for {{.MovieTitle}} is confirmed.
+Session: {{.StartTime}}, hall {{.HallName}}
+Seats: {{join .Seats ", "}}
+Total: {{.Total}}
+Transaction: {{.TransactionCode}}
+
+Enjoy the movie!`),
+	models.NotificationSessionReminder: mustTemplate(
+		"Reminder: {{.MovieTitle}} starts at {{.StartTime}}",
+		`Hi {{.UserName}},
+
+{{.MovieTitle}} starts at {{.StartTime}} in hall {{.HallName}}.
+Your seats: {{join .Seats ", "}}
+
+See you soon!`),
+	models.NotificationSessionCancelled: mustTemplate(
+		"Session cancelled: {{.MovieTitle}}",
+		`Hi {{.UserName}},
+
+unfortunately the {{.StartTime}} session of {{.MovieTitle}} in hall {{.HallName}} has been cancelled.
+Affected seats: {{join .Seats ", "}}
+
+We apologise for the inconvenience.`),
+}
+
+func mustTemplate(subject, body string) messageTemplate {
+	funcs := template.FuncMap{"join": strings.Join}
+	return messageTemplate{
+		subject: template.Must(template.New("subject").Funcs(funcs).Parse(subject)),
+		body:    template.Must(template.New("body").Funcs(funcs).Parse(body)),
+	}
+}
+
+func Render(kind models.NotificationKind, data TemplateData) (string, string, error) {
+	tmpl, ok := templates[kind]
+	if !ok {
+		return "", "", fmt.Errorf("no template for notification kind %s", kind)
+	}
+
+	var subject, body strings.Builder
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return "", "", err
+	}
+	return subject.String(), body.String(), nil
+}
@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"cinema-system/internal/models"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type AnalyticsFilter struct {
+	From     time.Time
+	To       time.Time
+	HallIDs  []primitive.ObjectID
+	Interval models.AnalyticsInterval
+	Location *time.Location
+}
+
+type AnalyticsRepository struct {
+	tickets  *mongo.Collection
+	sessions *mongo.Collection
+}
+
+func NewAnalyticsRepository(db *mongo.Database) *AnalyticsRepository {
+	return &AnalyticsRepository{
+		tickets:  db.Collection("tickets"),
+		sessions: db.Collection("sessions"),
+	}
+}
+
+func (r *AnalyticsRepository) SessionOccupancy(ctx context.Context, filter AnalyticsFilter) ([]models.SessionOccupancy, error) {
+	match := bson.M{"start_time": bson.M{"$gte": filter.From, "$lt": filter.To}}
+	if filter.HallIDs != nil {
+		match["hall_id"] = bson.M{"$in": filter.HallIDs}
+	}
+
+	cursor, err := r.sessions.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": "tickets",
+			"let":  bson.M{"sid": "$_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$session_id", "$$sid"}},
+					bson.M{"$ne": bson.A{"$status", models.TicketCancelled}},
+				}}}},
+				bson.M{"$count": "n"},
+			},
+			"as": "sold",
+		}}},
+		{{Key: "$lookup", Value: bson.M{"from": "halls", "localField": "hall_id", "foreignField": "_id", "as": "hall"}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$hall", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$lookup", Value: bson.M{"from": "movies", "localField": "movie_id", "foreignField": "_id", "as": "movie"}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$movie", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":        0,
+			"session_id": "$_id",
+			"movie_id":   1,
+			"movie_name": "$movie.name",
+			"hall_id":    1,
+			"hall_name":  "$hall.name",
+			"hall_type":  "$hall.type",
+			"start_time": 1,
+			"capacity":   bson.M{"$ifNull": bson.A{bson.M{"$multiply": bson.A{"$hall.total_rows", "$hall.seats_per_row"}}, 0}},
+			"sold":       bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$sold.n", 0}}, 0}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "start_time", Value: 1}, {Key: "session_id", Value: 1}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []models.SessionOccupancy
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func salesKey(groupBy models.SalesDimension, filter AnalyticsFilter) interface{} {
+	switch groupBy {
+	case models.SalesByMovie:
+		return "$session.movie_id"
+	case models.SalesByHallType:
+		return bson.M{"$ifNull": bson.A{"$hall.type", ""}}
+	case models.SalesByTicketType:
+		return "$type"
+	case models.SalesByPeriod:
+		return bson.M{"$dateToString": bson.M{"format": filter.Interval.DateFormat(), "date": "$created_at", "timezone": filter.Location.String()}}
+	default:
+		return "total"
+	}
+}
+
+func countIf(cond bson.M, value interface{}) bson.M {
+	return bson.M{"$sum": bson.M{"$cond": bson.A{cond, value, 0}}}
+}
+
+func (r *AnalyticsRepository) Sales(ctx context.Context, filter AnalyticsFilter, groupBy models.SalesDimension) ([]models.SalesBucket, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"created_at": bson.M{"$gte": filter.From, "$lt": filter.To}}}},
+		{{Key: "$lookup", Value: bson.M{"from": "sessions", "localField": "session_id", "foreignField": "_id", "as": "session"}}},
+		{{Key: "$unwind", Value: "$session"}},
+	}
+	if filter.HallIDs != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"session.hall_id": bson.M{"$in": filter.HallIDs}}}})
+	}
+	if groupBy == models.SalesByHallType {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$lookup", Value: bson.M{"from": "halls", "localField": "session.hall_id", "foreignField": "_id", "as": "hall"}}},
+			bson.D{{Key: "$unwind", Value: bson.M{"path": "$hall", "preserveNullAndEmptyArrays": true}}},
+		)
+	}
+
+	cancelled := bson.M{"$eq": bson.A{"$status", models.TicketCancelled}}
+	active := bson.M{"$ne": bson.A{"$status", models.TicketCancelled}}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":             bson.M{"key": salesKey(groupBy, filter), "currency": "$price.currency"},
+			"sold":            countIf(active, 1),
+			"refunded":        countIf(cancelled, 1),
+			"revenue":         countIf(active, "$price.amount"),
+			"refunded_amount": countIf(cancelled, "$price.amount"),
+			"movie_title":     bson.M{"$first": "$movie_title"},
+		}}},
+	)
+
+	label := bson.M{"$toString": "$_id.key"}
+	if groupBy == models.SalesByMovie {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$lookup", Value: bson.M{"from": "movies", "localField": "_id.key", "foreignField": "_id", "as": "movie"}}},
+		)
+		label = bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$movie.name", 0}}, "$movie_title"}}
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":             0,
+			"key":             bson.M{"$toString": "$_id.key"},
+			"label":           label,
+			"currency":        "$_id.currency",
+			"sold":            1,
+			"refunded":        1,
+			"revenue":         1,
+			"refunded_amount": 1,
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "key", Value: 1}, {Key: "currency", Value: 1}}}},
+	)
+
+	cursor, err := r.tickets.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []models.SalesBucket
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"cinema-system/internal/models"
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const auditCounterID = "audit_log"
+
+type AuditRepository struct {
+	collection *mongo.Collection
+	counters   *mongo.Collection
+}
+
+func NewAuditRepository(db *mongo.Database) *AuditRepository {
+	return &AuditRepository{
+		collection: db.Collection("audit_log"),
+		counters:   db.Collection("counters"),
+	}
+}
+
+func (r *AuditRepository) NextSeq(ctx context.Context) (int64, error) {
+	var counter struct {
+		Seq int64 `bson:"seq"`
+	}
+	err := r.counters.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": auditCounterID},
+		bson.M{"$inc": bson.M{"seq": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	return counter.Seq, err
+}
+
+func (r *AuditRepository) Append(ctx context.Context, entry *models.AuditEntry) error {
+	_, err := r.collection.InsertOne(ctx, entry)
+	return err
+}
+
+func (r *AuditRepository) Last(ctx context.Context) (*models.AuditEntry, error) {
+	var entry models.AuditEntry
+	err := r.collection.FindOne(ctx, bson.M{}, options.FindOne().SetSort(bson.D{{Key: "seq", Value: -1}})).Decode(&entry)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+var AuditListSpec = ListSpec{
+	Filters: map[string]FilterField{
+		"actor_id":    {Field: "actor_id", Kind: FilterObjectID},
+		"action":      {Field: "action", Kind: FilterString},
+		"entity_type": {Field: "entity_type", Kind: FilterString},
+		"entity_id":   {Field: "entity_id", Kind: FilterString},
+		"request_id":  {Field: "request_id", Kind: FilterString},
+		"ip":          {Field: "ip", Kind: FilterString},
+		"status":      {Field: "status", Kind: FilterInt},
+		"seq":         {Field: "seq", Kind: FilterInt},
+		"created_at":  {Field: "created_at", Kind: FilterTime},
+	},
+	Sorts: map[string]string{
+		"seq":        "seq",
+		"created_at": "created_at",
+	},
+	DefaultSort: "-seq",
+}
+
+func (r *AuditRepository) List(ctx context.Context, query *ListQuery) (*models.Page[models.AuditEntry], error) {
+	return findPage[models.AuditEntry](ctx, r.collection, bson.M{}, query)
+}
+
+func (r *AuditRepository) Scan(ctx context.Context, fn func(*models.AuditEntry) error) error {
+	return streamAll(ctx, r.collection, bson.M{}, &ListQuery{SortField: "seq"}, fn)
+}
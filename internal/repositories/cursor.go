@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrInvalidCursor = fmt.Errorf("%w: invalid cursor", ErrInvalidQuery)
+
+type pageCursor struct {
+	Sort   string `json:"s"`
+	Value  []byte `json:"v,omitempty"`
+	ID     string `json:"id,omitempty"`
+	Offset int64  `json:"o,omitempty"`
+}
+
+type cursorValue struct {
+	V bson.RawValue `bson:"v"`
+}
+
+func encodeCursor(c pageCursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(token, sort string) (*pageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c pageCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.Sort != sort {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+func keysetCursor(sort string, doc bson.Raw, field string) (pageCursor, error) {
+	c := pageCursor{Sort: sort}
+
+	id, ok := doc.Lookup("_id").ObjectIDOK()
+	if !ok {
+		return c, fmt.Errorf("document has no ObjectID _id")
+	}
+	c.ID = id.Hex()
+
+	value, err := doc.LookupErr(strings.Split(field, ".")...)
+	if err == nil && value.Type != bson.TypeNull {
+		encoded, err := bson.Marshal(cursorValue{V: value})
+		if err != nil {
+			return c, err
+		}
+		c.Value = encoded
+	}
+	return c, nil
+}
+
+func (c *pageCursor) keyset() (interface{}, primitive.ObjectID, error) {
+	id, err := primitive.ObjectIDFromHex(c.ID)
+	if err != nil {
+		return nil, primitive.NilObjectID, ErrInvalidCursor
+	}
+	if c.Value == nil {
+		return nil, id, nil
+	}
+	var value cursorValue
+	if err := bson.Unmarshal(c.Value, &value); err != nil {
+		return nil, primitive.NilObjectID, ErrInvalidCursor
+	}
+	return value.V, id, nil
+}
+
+func keysetFilter(field string, value interface{}, id primitive.ObjectID, desc bool) bson.M {
+	cmp, idCmp := "$gt", "$gt"
+	if desc {
+		cmp, idCmp = "$lt", "$lt"
+	}
+
+	if value == nil {
+		tie := bson.M{field: nil, "_id": bson.M{idCmp: id}}
+		if desc {
+			return tie
+		}
+		return bson.M{"$or": bson.A{tie, bson.M{field: bson.M{"$ne": nil}}}}
+	}
+
+	clauses := bson.A{
+		bson.M{field: bson.M{cmp: value}},
+		bson.M{field: value, "_id": bson.M{idCmp: id}},
+	}
+	if desc {
+		clauses = append(clauses, bson.M{field: nil})
+	}
+	return bson.M{"$or": clauses}
+}
+
+func sortDirection(desc bool) int {
+	if desc {
+		return -1
+	}
+	return 1
+}
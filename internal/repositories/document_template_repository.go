@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"cinema-system/internal/models"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DocumentTemplateRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDocumentTemplateRepository(db *mongo.Database) *DocumentTemplateRepository {
+	return &DocumentTemplateRepository{
+		collection: db.Collection("document_templates"),
+	}
+}
+
+func (r *DocumentTemplateRepository) FindByKind(ctx context.Context, kind models.DocumentKind) (*models.DocumentTemplate, error) {
+	var template models.DocumentTemplate
+	err := r.collection.FindOne(ctx, bson.M{"kind": kind}).Decode(&template)
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *DocumentTemplateRepository) Upsert(ctx context.Context, template *models.DocumentTemplate) error {
+	template.ID = primitive.NilObjectID
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"kind": template.Kind},
+		bson.M{"$set": template},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"cinema-system/internal/models"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ExchangeRateRepository struct {
+	collection *mongo.Collection
+}
+
+func NewExchangeRateRepository(db *mongo.Database) *ExchangeRateRepository {
+	return &ExchangeRateRepository{
+		collection: db.Collection("exchange_rates"),
+	}
+}
+
+func (r *ExchangeRateRepository) Upsert(ctx context.Context, rate *models.ExchangeRate) error {
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	return r.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"from": rate.From, "to": rate.To},
+		bson.M{"$set": bson.M{"rate": rate.Rate, "updated_at": rate.UpdatedAt}},
+		opts,
+	).Decode(rate)
+}
+
+func (r *ExchangeRateRepository) Find(ctx context.Context, from, to string) (*models.ExchangeRate, error) {
+	var rate models.ExchangeRate
+	err := r.collection.FindOne(ctx, bson.M{"from": from, "to": to}).Decode(&rate)
+	if err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (r *ExchangeRateRepository) GetAll(ctx context.Context) ([]models.ExchangeRate, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "from", Value: 1}, {Key: "to", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rates []models.ExchangeRate
+	if err = cursor.All(ctx, &rates); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+func (r *ExchangeRateRepository) Delete(ctx context.Context, from, to string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"from": from, "to": to})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
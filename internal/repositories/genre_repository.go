@@ -77,6 +77,24 @@ func (r *GenreRepository) Update(ctx context.Context, id primitive.ObjectID, gen
 	return err
 }
 
+func (r *GenreRepository) SetTranslation(ctx context.Context, id primitive.ObjectID, lang string, translation models.GenreTranslation) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"translations." + lang: translation}},
+	)
+	return err
+}
+
+func (r *GenreRepository) DeleteTranslation(ctx context.Context, id primitive.ObjectID, lang string) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$unset": bson.M{"translations." + lang: ""}},
+	)
+	return err
+}
+
 func (r *GenreRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
 	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
 	return err
@@ -3,10 +3,13 @@ package repositories
 import (
 	"cinema-system/internal/models"
 	"context"
+	"regexp"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type HallRepository struct {
@@ -64,3 +67,47 @@ func (r *HallRepository) Delete(ctx context.Context, id primitive.ObjectID) erro
 	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
 	return err
 }
+
+func (r *HallRepository) FindIDsByLocation(ctx context.Context, location string) ([]primitive.ObjectID, error) {
+	pattern := "^" + regexp.QuoteMeta(strings.TrimSpace(location)) + "$"
+	cursor, err := r.collection.Find(
+		ctx,
+		bson.M{"location": primitive.Regex{Pattern: pattern, Options: "i"}},
+		options.Find().SetProjection(bson.M{"_id": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var halls []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err = cursor.All(ctx, &halls); err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, len(halls))
+	for i, h := range halls {
+		ids[i] = h.ID
+	}
+	return ids, nil
+}
+
+var HallListSpec = ListSpec{
+	Filters: map[string]FilterField{
+		"type":     {Field: "type", Kind: FilterString},
+		"location": {Field: "location", Kind: FilterString},
+		"name":     {Field: "name", Kind: FilterString},
+	},
+	Sorts: map[string]string{
+		"name":     "name",
+		"type":     "type",
+		"location": "location",
+	},
+	DefaultSort: "name",
+}
+
+func (r *HallRepository) List(ctx context.Context, query *ListQuery) (*models.Page[models.Hall], error) {
+	return findPage[models.Hall](ctx, r.collection, bson.M{}, query)
+}
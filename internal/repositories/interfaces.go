@@ -0,0 +1,282 @@
+package repositories
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/money"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TransactionRunner interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type AnalyticsStore interface {
+	SessionOccupancy(ctx context.Context, filter AnalyticsFilter) ([]models.SessionOccupancy, error)
+	Sales(ctx context.Context, filter AnalyticsFilter, groupBy models.SalesDimension) ([]models.SalesBucket, error)
+}
+
+type AuditStore interface {
+	NextSeq(ctx context.Context) (int64, error)
+	Append(ctx context.Context, entry *models.AuditEntry) error
+	Last(ctx context.Context) (*models.AuditEntry, error)
+	List(ctx context.Context, query *ListQuery) (*models.Page[models.AuditEntry], error)
+	Scan(ctx context.Context, fn func(*models.AuditEntry) error) error
+}
+
+type DocumentTemplateStore interface {
+	FindByKind(ctx context.Context, kind models.DocumentKind) (*models.DocumentTemplate, error)
+	Upsert(ctx context.Context, template *models.DocumentTemplate) error
+}
+
+type ExchangeRateStore interface {
+	Upsert(ctx context.Context, rate *models.ExchangeRate) error
+	Find(ctx context.Context, from, to string) (*models.ExchangeRate, error)
+	GetAll(ctx context.Context) ([]models.ExchangeRate, error)
+	Delete(ctx context.Context, from, to string) error
+}
+
+type GenreStore interface {
+	Create(ctx context.Context, genre *models.Genre) error
+	GetAll(ctx context.Context) ([]models.Genre, error)
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.Genre, error)
+	FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Genre, error)
+	Update(ctx context.Context, id primitive.ObjectID, genre *models.Genre) error
+	SetTranslation(ctx context.Context, id primitive.ObjectID, lang string, translation models.GenreTranslation) error
+	DeleteTranslation(ctx context.Context, id primitive.ObjectID, lang string) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+type HallStore interface {
+	Create(ctx context.Context, hall *models.Hall) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.Hall, error)
+	GetAll(ctx context.Context) ([]models.Hall, error)
+	Update(ctx context.Context, id primitive.ObjectID, hall *models.Hall) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	FindIDsByLocation(ctx context.Context, location string) ([]primitive.ObjectID, error)
+	List(ctx context.Context, query *ListQuery) (*models.Page[models.Hall], error)
+}
+
+type MovieGenreStore interface {
+	Create(ctx context.Context, movieGenre *models.MovieGenre) error
+	GetGenresByMovieID(ctx context.Context, movieID primitive.ObjectID) ([]models.MovieGenre, error)
+	GetGenresByMovieIDs(ctx context.Context, movieIDs []primitive.ObjectID) ([]models.MovieGenre, error)
+	GetMoviesByGenreID(ctx context.Context, genreID primitive.ObjectID) ([]models.MovieGenre, error)
+	DeleteByMovieID(ctx context.Context, movieID primitive.ObjectID) error
+}
+
+type MovieStore interface {
+	Create(ctx context.Context, movie *models.Movie) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.Movie, error)
+	FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Movie, error)
+	GetAll(ctx context.Context) ([]models.Movie, error)
+	Update(ctx context.Context, id primitive.ObjectID, movie *models.Movie) error
+	SetTranslation(ctx context.Context, id primitive.ObjectID, lang string, translation models.MovieTranslation) error
+	DeleteTranslation(ctx context.Context, id primitive.ObjectID, lang string) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	IncrementRatingStats(ctx context.Context, movieID primitive.ObjectID, delta *models.RatingStats, prior models.RatingPrior) error
+	SetRatingStats(ctx context.Context, movieID primitive.ObjectID, stats *models.RatingStats, rating float64) error
+	IncrementPopularity(ctx context.Context, movieID primitive.ObjectID, delta int) error
+	Search(ctx context.Context, filter MovieFilter) (*models.Page[models.Movie], error)
+}
+
+type NotificationPreferenceStore interface {
+	FindByUserID(ctx context.Context, userID primitive.ObjectID) (*models.NotificationPreferences, error)
+	Upsert(ctx context.Context, prefs *models.NotificationPreferences) error
+}
+
+type NotificationStore interface {
+	Create(ctx context.Context, notification *models.Notification) error
+	CreateIfAbsent(ctx context.Context, notification *models.Notification) (bool, error)
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.Notification, error)
+	FindDue(ctx context.Context, now time.Time, limit int64) ([]models.Notification, error)
+	FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.Notification, error)
+	FindByStatus(ctx context.Context, status models.NotificationStatus) ([]models.Notification, error)
+	MarkSent(ctx context.Context, id primitive.ObjectID, attempts int, sentAt time.Time) error
+	MarkAttemptFailed(ctx context.Context, id primitive.ObjectID, attempts int, lastError string, status models.NotificationStatus, nextAttemptAt time.Time) error
+	Requeue(ctx context.Context, id primitive.ObjectID, now time.Time) error
+}
+
+type OutboxStore interface {
+	Add(ctx context.Context, event *models.OutboxEvent) error
+	ClaimNext(ctx context.Context, now time.Time, lease time.Duration) (*models.OutboxEvent, error)
+	MarkProcessed(ctx context.Context, id primitive.ObjectID, attempts int, processedAt time.Time) error
+	MarkAttemptFailed(ctx context.Context, id primitive.ObjectID, attempts int, lastError string, status models.OutboxStatus, nextAttemptAt time.Time) error
+}
+
+type PaymentCardStore interface {
+	Create(ctx context.Context, card *models.PaymentCard) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.PaymentCard, error)
+	FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.PaymentCard, error)
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	Update(ctx context.Context, id primitive.ObjectID, card *models.PaymentCard) error
+}
+
+type PaymentCodeStore interface {
+	FindByCode(ctx context.Context, code string) (*models.PaymentCode, error)
+	MarkAsUsed(ctx context.Context, id primitive.ObjectID, userID primitive.ObjectID) error
+}
+
+type PaymentStore interface {
+	Create(ctx context.Context, payment *models.Payment) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.Payment, error)
+	FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.Payment, error)
+	FindByTransactionCode(ctx context.Context, code string) (*models.Payment, error)
+	UpdateStatus(ctx context.Context, id primitive.ObjectID, status models.PaymentStatus) error
+	GetAll(ctx context.Context) ([]models.Payment, error)
+	List(ctx context.Context, query *ListQuery) (*models.Page[models.Payment], error)
+	Stream(ctx context.Context, query *ListQuery, fn func(*models.Payment) error) error
+}
+
+type ProcessedEventStore interface {
+	Exists(ctx context.Context, eventID primitive.ObjectID, handler string) (bool, error)
+	Record(ctx context.Context, eventID primitive.ObjectID, handler string) error
+}
+
+type RecommendationStore interface {
+	Replace(ctx context.Context, rec *models.Recommendation) error
+	Find(ctx context.Context, kind models.RecommendationKind, subjectID primitive.ObjectID) (*models.Recommendation, error)
+	DeleteComputedBefore(ctx context.Context, before time.Time) error
+}
+
+type ReviewStore interface {
+	Create(ctx context.Context, review *models.Review) error
+	GetAll(ctx context.Context) ([]models.Review, error)
+	GetByMovie(ctx context.Context, movieID primitive.ObjectID) ([]models.Review, error)
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.Review, error)
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	GetAverageRating(ctx context.Context, movieID primitive.ObjectID) (float64, error)
+	GetRatingStats(ctx context.Context, movieID primitive.ObjectID) (*models.RatingStats, error)
+	GetByUser(ctx context.Context, userID primitive.ObjectID) ([]models.Review, error)
+	CheckUserReview(ctx context.Context, userID, movieID primitive.ObjectID) (bool, error)
+	Update(ctx context.Context, review *models.Review) error
+	UpdateReviewerName(ctx context.Context, userID primitive.ObjectID, newName string) error
+	SetStatus(ctx context.Context, id primitive.ObjectID, status models.ReviewStatus, reason string, moderatorID *primitive.ObjectID, at time.Time) error
+	IncrementReportCount(ctx context.Context, id primitive.ObjectID) (*models.Review, error)
+	ResetReportCount(ctx context.Context, id primitive.ObjectID) error
+	IncrementVotes(ctx context.Context, id primitive.ObjectID, helpful, unhelpful int) error
+	SetReply(ctx context.Context, id primitive.ObjectID, reply *models.ReviewReply) error
+	ListPublishedByMovie(ctx context.Context, movieID primitive.ObjectID, query *ListQuery) (*models.Page[models.Review], error)
+	List(ctx context.Context, query *ListQuery) (*models.Page[models.Review], error)
+}
+
+type ReviewVoteStore interface {
+	Find(ctx context.Context, reviewID, userID primitive.ObjectID) (*models.ReviewVote, error)
+	Upsert(ctx context.Context, vote *models.ReviewVote) error
+	Delete(ctx context.Context, reviewID, userID primitive.ObjectID) error
+}
+
+type ReviewReportStore interface {
+	Create(ctx context.Context, report *models.ReviewReport) error
+	ListByReview(ctx context.Context, reviewID primitive.ObjectID) ([]models.ReviewReport, error)
+	ResolveByReview(ctx context.Context, reviewID primitive.ObjectID, at time.Time) error
+}
+
+type SessionStore interface {
+	Create(ctx context.Context, session *models.Session) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.Session, error)
+	FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Session, error)
+	GetByMovie(ctx context.Context, movieID primitive.ObjectID) ([]models.Session, error)
+	GetOverlappingByHall(ctx context.Context, hallID primitive.ObjectID, startTime, endTime time.Time) ([]models.Session, error)
+	GetUpcoming(ctx context.Context) ([]models.Session, error)
+	Update(ctx context.Context, id primitive.ObjectID, session *models.Session) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	GetUpcomingMovieIDs(ctx context.Context) ([]primitive.ObjectID, error)
+	GetStartingBetween(ctx context.Context, from, to time.Time) ([]models.Session, error)
+	GetMovieIDsBetween(ctx context.Context, from, to time.Time, hallIDs []primitive.ObjectID) ([]primitive.ObjectID, error)
+}
+
+type TicketStore interface {
+	Create(ctx context.Context, ticket *models.Ticket) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.Ticket, error)
+	GetByPayment(ctx context.Context, paymentID primitive.ObjectID) (*models.Ticket, error)
+	GetByPaymentIDs(ctx context.Context, paymentIDs []primitive.ObjectID) ([]models.Ticket, error)
+	GetBySession(ctx context.Context, sessionID primitive.ObjectID) ([]models.Ticket, error)
+	UpdateStatus(ctx context.Context, ticketID primitive.ObjectID, status models.TicketStatus) error
+	GetAll(ctx context.Context) ([]models.Ticket, error)
+	CheckSeatAvailability(ctx context.Context, sessionID primitive.ObjectID, row, seat int) (bool, error)
+	GetByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.Ticket, error)
+	MarkUsed(ctx context.Context, ticketID primitive.ObjectID, usedAt time.Time) (bool, error)
+	MarkCancelled(ctx context.Context, ticketID primitive.ObjectID) (bool, error)
+	List(ctx context.Context, query *ListQuery) (*models.Page[models.Ticket], error)
+	ListByUserID(ctx context.Context, userID primitive.ObjectID, query *ListQuery) (*models.Page[models.Ticket], error)
+	Stream(ctx context.Context, query *ListQuery, fn func(*models.Ticket) error) error
+}
+
+type UserStore interface {
+	Create(ctx context.Context, user *models.User) error
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.User, error)
+	UpdateBalance(ctx context.Context, userID primitive.ObjectID, newBalance money.Money) error
+	GetAll(ctx context.Context) ([]models.User, error)
+	Update(ctx context.Context, user *models.User) error
+	Stream(ctx context.Context, query *ListQuery, fn func(*models.User) error) error
+}
+
+type WalletPassStore interface {
+	Create(ctx context.Context, pass *models.WalletPass) error
+	FindByTicketID(ctx context.Context, ticketID primitive.ObjectID) (*models.WalletPass, error)
+	FindBySerial(ctx context.Context, serial string) (*models.WalletPass, error)
+	FindUpdatedSince(ctx context.Context, serials []string, since time.Time) ([]models.WalletPass, error)
+	TouchBySession(ctx context.Context, sessionID primitive.ObjectID, updatedAt time.Time) ([]models.WalletPass, error)
+	Void(ctx context.Context, ticketIDs []primitive.ObjectID, updatedAt time.Time) ([]models.WalletPass, error)
+}
+
+type WalletRegistrationStore interface {
+	Register(ctx context.Context, reg *models.WalletRegistration) (bool, error)
+	Unregister(ctx context.Context, deviceID, passTypeID, serial string) error
+	FindByDevice(ctx context.Context, deviceID, passTypeID string) ([]models.WalletRegistration, error)
+	FindBySerials(ctx context.Context, serials []string) ([]models.WalletRegistration, error)
+}
+
+type WebhookDeliveryStore interface {
+	Create(ctx context.Context, delivery *models.WebhookDelivery) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.WebhookDelivery, error)
+	Exists(ctx context.Context, subscriptionID, eventID primitive.ObjectID) (bool, error)
+	FindBySubscription(ctx context.Context, subscriptionID primitive.ObjectID, limit int64) ([]models.WebhookDelivery, error)
+	FindDue(ctx context.Context, now time.Time, limit int64) ([]models.WebhookDelivery, error)
+	MarkSucceeded(ctx context.Context, id primitive.ObjectID, attempts, responseCode int, responseBody string, deliveredAt time.Time) error
+	MarkAttemptFailed(ctx context.Context, id primitive.ObjectID, attempts, responseCode int, responseBody, lastError string, status models.WebhookDeliveryStatus, nextAttemptAt time.Time) error
+	Requeue(ctx context.Context, id primitive.ObjectID, now time.Time) error
+}
+
+type WebhookSubscriptionStore interface {
+	Create(ctx context.Context, subscription *models.WebhookSubscription) error
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.WebhookSubscription, error)
+	GetAll(ctx context.Context) ([]models.WebhookSubscription, error)
+	FindActiveByEventType(ctx context.Context, eventType string) ([]models.WebhookSubscription, error)
+	Update(ctx context.Context, subscription *models.WebhookSubscription) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+var (
+	_ TransactionRunner           = (*Transactor)(nil)
+	_ AnalyticsStore              = (*AnalyticsRepository)(nil)
+	_ AuditStore                  = (*AuditRepository)(nil)
+	_ DocumentTemplateStore       = (*DocumentTemplateRepository)(nil)
+	_ ExchangeRateStore           = (*ExchangeRateRepository)(nil)
+	_ GenreStore                  = (*GenreRepository)(nil)
+	_ HallStore                   = (*HallRepository)(nil)
+	_ MovieGenreStore             = (*MovieGenreRepository)(nil)
+	_ MovieStore                  = (*MovieRepository)(nil)
+	_ NotificationPreferenceStore = (*NotificationPreferenceRepository)(nil)
+	_ NotificationStore           = (*NotificationRepository)(nil)
+	_ OutboxStore                 = (*OutboxRepository)(nil)
+	_ PaymentCardStore            = (*PaymentCardRepository)(nil)
+	_ PaymentCodeStore            = (*PaymentCodeRepository)(nil)
+	_ PaymentStore                = (*PaymentRepository)(nil)
+	_ ProcessedEventStore         = (*ProcessedEventRepository)(nil)
+	_ RecommendationStore         = (*RecommendationRepository)(nil)
+	_ ReviewReportStore           = (*ReviewReportRepository)(nil)
+	_ ReviewStore                 = (*ReviewRepository)(nil)
+	_ ReviewVoteStore             = (*ReviewVoteRepository)(nil)
+	_ SessionStore                = (*SessionRepository)(nil)
+	_ TicketStore                 = (*TicketRepository)(nil)
+	_ UserStore                   = (*UserRepository)(nil)
+	_ WalletPassStore             = (*WalletPassRepository)(nil)
+	_ WalletRegistrationStore     = (*WalletRegistrationRepository)(nil)
+	_ WebhookDeliveryStore        = (*WebhookDeliveryRepository)(nil)
+	_ WebhookSubscriptionStore    = (*WebhookSubscriptionRepository)(nil)
+)
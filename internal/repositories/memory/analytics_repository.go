@@ -0,0 +1,140 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"context"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AnalyticsRepository struct {
+	tickets  *collection[models.Ticket]
+	sessions *collection[models.Session]
+	halls    *collection[models.Hall]
+	movies   *collection[models.Movie]
+}
+
+func NewAnalyticsRepository(tickets *TicketRepository, sessions *SessionRepository, halls *HallRepository, movies *MovieRepository) *AnalyticsRepository {
+	return &AnalyticsRepository{
+		tickets:  tickets.tickets,
+		sessions: sessions.sessions,
+		halls:    halls.halls,
+		movies:   movies.movies,
+	}
+}
+
+func inHalls(hallIDs []primitive.ObjectID, hallID primitive.ObjectID) bool {
+	return hallIDs == nil || containsAny([]primitive.ObjectID{hallID}, hallIDs)
+}
+
+func (r *AnalyticsRepository) SessionOccupancy(ctx context.Context, filter repositories.AnalyticsFilter) ([]models.SessionOccupancy, error) {
+	sessions, err := r.sessions.find(func(s *models.Session) bool {
+		return !s.StartTime.Before(filter.From) && s.StartTime.Before(filter.To) && inHalls(filter.HallIDs, s.HallID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]models.SessionOccupancy, 0, len(sessions))
+	for _, session := range sessions {
+		row := models.SessionOccupancy{
+			SessionID: session.ID,
+			MovieID:   session.MovieID,
+			HallID:    session.HallID,
+			StartTime: session.StartTime,
+		}
+		if hall, err := r.halls.get(session.HallID); err == nil {
+			row.HallName, row.HallType = hall.Name, hall.Type
+			row.Capacity = hall.TotalRows * hall.SeatsPerRow
+		}
+		if movie, err := r.movies.get(session.MovieID); err == nil {
+			row.MovieName = movie.Name
+		}
+		sold, err := r.tickets.count(func(t *models.Ticket) bool {
+			return t.SessionID == session.ID && t.Status != models.TicketCancelled
+		})
+		if err != nil {
+			return nil, err
+		}
+		row.Sold = int(sold)
+		rows = append(rows, row)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if !rows[i].StartTime.Equal(rows[j].StartTime) {
+			return rows[i].StartTime.Before(rows[j].StartTime)
+		}
+		return compareObjectIDs(rows[i].SessionID, rows[j].SessionID) < 0
+	})
+	return rows, nil
+}
+
+func (r *AnalyticsRepository) Sales(ctx context.Context, filter repositories.AnalyticsFilter, groupBy models.SalesDimension) ([]models.SalesBucket, error) {
+	tickets, err := r.tickets.find(func(t *models.Ticket) bool {
+		return !t.CreatedAt.Before(filter.From) && t.CreatedAt.Before(filter.To)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type bucketKey struct{ key, currency string }
+	buckets := make(map[bucketKey]*models.SalesBucket)
+	for _, ticket := range tickets {
+		session, err := r.sessions.get(ticket.SessionID)
+		if err != nil {
+			continue
+		}
+		if !inHalls(filter.HallIDs, session.HallID) {
+			continue
+		}
+
+		key, label := "total", "total"
+		switch groupBy {
+		case models.SalesByMovie:
+			key, label = session.MovieID.Hex(), ticket.MovieTitle
+			if movie, err := r.movies.get(session.MovieID); err == nil {
+				label = movie.Name
+			}
+		case models.SalesByHallType:
+			key = ""
+			if hall, err := r.halls.get(session.HallID); err == nil {
+				key = string(hall.Type)
+			}
+			label = key
+		case models.SalesByTicketType:
+			key = string(ticket.Type)
+			label = key
+		case models.SalesByPeriod:
+			key = filter.Interval.Bucket(ticket.CreatedAt, filter.Location)
+			label = key
+		}
+
+		k := bucketKey{key, ticket.Price.Currency}
+		bucket, ok := buckets[k]
+		if !ok {
+			bucket = &models.SalesBucket{Key: key, Label: label, Currency: ticket.Price.Currency}
+			buckets[k] = bucket
+		}
+		if ticket.Status == models.TicketCancelled {
+			bucket.Refunded++
+			bucket.RefundedAmount += ticket.Price.Amount
+		} else {
+			bucket.TicketsSold++
+			bucket.RevenueAmount += ticket.Price.Amount
+		}
+	}
+
+	rows := make([]models.SalesBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		rows = append(rows, *bucket)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Key != rows[j].Key {
+			return rows[i].Key < rows[j].Key
+		}
+		return rows[i].Currency < rows[j].Currency
+	})
+	return rows, nil
+}
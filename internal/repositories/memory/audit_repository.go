@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type auditCounter struct {
+	ID  primitive.ObjectID `bson:"_id"`
+	Seq int64              `bson:"seq"`
+}
+
+type AuditRepository struct {
+	entries  *collection[models.AuditEntry]
+	counters *collection[auditCounter]
+}
+
+func NewAuditRepository() *AuditRepository {
+	r := &AuditRepository{
+		entries:  newCollection(func(e *models.AuditEntry) *primitive.ObjectID { return &e.ID }),
+		counters: newCollection(func(c *auditCounter) *primitive.ObjectID { return &c.ID }),
+	}
+	r.counters.insert(&auditCounter{})
+	return r
+}
+
+func (r *AuditRepository) NextSeq(ctx context.Context) (int64, error) {
+	var seq int64
+	_, err := r.counters.update(func(*auditCounter) bool { return true }, func(c *auditCounter) {
+		c.Seq++
+		seq = c.Seq
+	}, 1)
+	return seq, err
+}
+
+func (r *AuditRepository) Append(ctx context.Context, entry *models.AuditEntry) error {
+	exists, err := r.entries.count(func(existing *models.AuditEntry) bool { return existing.Seq == entry.Seq })
+	if err != nil {
+		return err
+	}
+	if exists > 0 {
+		return mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: duplicateKeyCode, Message: "duplicate key error"}}}
+	}
+	return r.entries.insert(entry)
+}
+
+func (r *AuditRepository) Last(ctx context.Context) (*models.AuditEntry, error) {
+	entries, err := r.entries.find(nil)
+	if err != nil {
+		return nil, err
+	}
+	var last *models.AuditEntry
+	for i := range entries {
+		if last == nil || entries[i].Seq > last.Seq {
+			last = &entries[i]
+		}
+	}
+	return last, nil
+}
+
+func (r *AuditRepository) List(ctx context.Context, query *repositories.ListQuery) (*models.Page[models.AuditEntry], error) {
+	entries, err := r.entries.entries(nil)
+	if err != nil {
+		return nil, err
+	}
+	return paginate(entries, query)
+}
+
+func (r *AuditRepository) Scan(ctx context.Context, fn func(*models.AuditEntry) error) error {
+	entries, err := r.entries.entries(nil)
+	if err != nil {
+		return err
+	}
+	return stream(entries, &repositories.ListQuery{SortField: "seq"}, fn)
+}
@@ -0,0 +1,224 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const duplicateKeyCode = 11000
+
+type collection[T any] struct {
+	mu   sync.RWMutex
+	docs map[primitive.ObjectID]bson.Raw
+	id   func(*T) *primitive.ObjectID
+}
+
+type entry[T any] struct {
+	doc T
+	raw bson.Raw
+}
+
+func newCollection[T any](id func(*T) *primitive.ObjectID) *collection[T] {
+	return &collection[T]{docs: map[primitive.ObjectID]bson.Raw{}, id: id}
+}
+
+func (c *collection[T]) insert(doc *T) error {
+	id := c.id(doc)
+	if id.IsZero() {
+		*id = primitive.NewObjectID()
+	}
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.docs[*id]; exists {
+		return mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: duplicateKeyCode, Message: "duplicate key error"}}}
+	}
+	c.docs[*id] = raw
+	return nil
+}
+
+func (c *collection[T]) sortedIDs() []primitive.ObjectID {
+	ids := make([]primitive.ObjectID, 0, len(c.docs))
+	for id := range c.docs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return compareObjectIDs(ids[i], ids[j]) < 0 })
+	return ids
+}
+
+func (c *collection[T]) entries(match func(*T) bool) ([]entry[T], error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var out []entry[T]
+	for _, id := range c.sortedIDs() {
+		raw := c.docs[id]
+		var doc T
+		if err := bson.Unmarshal(raw, &doc); err != nil {
+			return nil, err
+		}
+		if match == nil || match(&doc) {
+			out = append(out, entry[T]{doc: doc, raw: raw})
+		}
+	}
+	return out, nil
+}
+
+func (c *collection[T]) find(match func(*T) bool) ([]T, error) {
+	entries, err := c.entries(match)
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]T, len(entries))
+	for i, e := range entries {
+		docs[i] = e.doc
+	}
+	return docs, nil
+}
+
+func (c *collection[T]) findOne(match func(*T) bool) (*T, error) {
+	docs, err := c.find(match)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &docs[0], nil
+}
+
+func (c *collection[T]) get(id primitive.ObjectID) (*T, error) {
+	c.mu.RLock()
+	raw, ok := c.docs[id]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	var doc T
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (c *collection[T]) count(match func(*T) bool) (int64, error) {
+	entries, err := c.entries(match)
+	return int64(len(entries)), err
+}
+
+func (c *collection[T]) update(match func(*T) bool, apply func(*T), limit int) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	modified := 0
+	for _, id := range c.sortedIDs() {
+		if limit > 0 && modified >= limit {
+			break
+		}
+		var doc T
+		if err := bson.Unmarshal(c.docs[id], &doc); err != nil {
+			return modified, err
+		}
+		if !match(&doc) {
+			continue
+		}
+		apply(&doc)
+		*c.id(&doc) = id
+		raw, err := bson.Marshal(&doc)
+		if err != nil {
+			return modified, err
+		}
+		c.docs[id] = raw
+		modified++
+	}
+	return modified, nil
+}
+
+func (c *collection[T]) set(match func(*T) bool, fields interface{}, limit int) (int, error) {
+	raw, err := bson.Marshal(fields)
+	if err != nil {
+		return 0, err
+	}
+	var values bson.M
+	if err := bson.Unmarshal(raw, &values); err != nil {
+		return 0, err
+	}
+	delete(values, "_id")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	modified := 0
+	for _, id := range c.sortedIDs() {
+		if limit > 0 && modified >= limit {
+			break
+		}
+		var doc T
+		if err := bson.Unmarshal(c.docs[id], &doc); err != nil {
+			return modified, err
+		}
+		if !match(&doc) {
+			continue
+		}
+
+		var current bson.M
+		if err := bson.Unmarshal(c.docs[id], &current); err != nil {
+			return modified, err
+		}
+		for k, v := range values {
+			current[k] = v
+		}
+		updated, err := bson.Marshal(current)
+		if err != nil {
+			return modified, err
+		}
+		c.docs[id] = updated
+		modified++
+	}
+	return modified, nil
+}
+
+func (c *collection[T]) remove(match func(*T) bool) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for _, id := range c.sortedIDs() {
+		var doc T
+		if err := bson.Unmarshal(c.docs[id], &doc); err != nil {
+			return removed, err
+		}
+		if match(&doc) {
+			delete(c.docs, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (c *collection[T]) snapshot() func() {
+	c.mu.RLock()
+	saved := make(map[primitive.ObjectID]bson.Raw, len(c.docs))
+	for id, raw := range c.docs {
+		saved[id] = raw
+	}
+	c.mu.RUnlock()
+
+	return func() {
+		c.mu.Lock()
+		c.docs = saved
+		c.mu.Unlock()
+	}
+}
+
+func byID[T any](c *collection[T], id primitive.ObjectID) func(*T) bool {
+	return func(doc *T) bool { return *c.id(doc) == id }
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"context"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ExchangeRateRepository struct {
+	rates *collection[models.ExchangeRate]
+}
+
+func NewExchangeRateRepository() *ExchangeRateRepository {
+	return &ExchangeRateRepository{
+		rates: newCollection(func(r *models.ExchangeRate) *primitive.ObjectID { return &r.ID }),
+	}
+}
+
+func ratePair(from, to string) func(*models.ExchangeRate) bool {
+	return func(r *models.ExchangeRate) bool { return r.From == from && r.To == to }
+}
+
+func (r *ExchangeRateRepository) Upsert(ctx context.Context, rate *models.ExchangeRate) error {
+	updated, err := r.rates.update(ratePair(rate.From, rate.To), func(existing *models.ExchangeRate) {
+		existing.Rate = rate.Rate
+		existing.UpdatedAt = rate.UpdatedAt
+		rate.ID = existing.ID
+	}, 1)
+	if err != nil || updated > 0 {
+		return err
+	}
+	return r.rates.insert(rate)
+}
+
+func (r *ExchangeRateRepository) Find(ctx context.Context, from, to string) (*models.ExchangeRate, error) {
+	return r.rates.findOne(ratePair(from, to))
+}
+
+func (r *ExchangeRateRepository) GetAll(ctx context.Context) ([]models.ExchangeRate, error) {
+	rates, err := r.rates.find(nil)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].From != rates[j].From {
+			return rates[i].From < rates[j].From
+		}
+		return rates[i].To < rates[j].To
+	})
+	return rates, nil
+}
+
+func (r *ExchangeRateRepository) Delete(ctx context.Context, from, to string) error {
+	removed, err := r.rates.remove(ratePair(from, to))
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
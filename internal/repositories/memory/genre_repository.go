@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type GenreRepository struct {
+	genres *collection[models.Genre]
+}
+
+func NewGenreRepository() *GenreRepository {
+	return &GenreRepository{
+		genres: newCollection(func(g *models.Genre) *primitive.ObjectID { return &g.ID }),
+	}
+}
+
+func (r *GenreRepository) Create(ctx context.Context, genre *models.Genre) error {
+	return r.genres.insert(genre)
+}
+
+func (r *GenreRepository) GetAll(ctx context.Context) ([]models.Genre, error) {
+	return r.genres.find(nil)
+}
+
+func (r *GenreRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Genre, error) {
+	return r.genres.get(id)
+}
+
+func (r *GenreRepository) FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Genre, error) {
+	return r.genres.find(func(g *models.Genre) bool { return containsAny([]primitive.ObjectID{g.ID}, ids) })
+}
+
+func (r *GenreRepository) Update(ctx context.Context, id primitive.ObjectID, genre *models.Genre) error {
+	_, err := r.genres.set(byID(r.genres, id), genre, 1)
+	return err
+}
+
+func (r *GenreRepository) SetTranslation(ctx context.Context, id primitive.ObjectID, lang string, translation models.GenreTranslation) error {
+	_, err := r.genres.update(byID(r.genres, id), func(g *models.Genre) {
+		if g.Translations == nil {
+			g.Translations = make(map[string]models.GenreTranslation)
+		}
+		g.Translations[lang] = translation
+	}, 1)
+	return err
+}
+
+func (r *GenreRepository) DeleteTranslation(ctx context.Context, id primitive.ObjectID, lang string) error {
+	_, err := r.genres.update(byID(r.genres, id), func(g *models.Genre) {
+		delete(g.Translations, lang)
+	}, 1)
+	return err
+}
+
+func (r *GenreRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.genres.remove(byID(r.genres, id))
+	return err
+}
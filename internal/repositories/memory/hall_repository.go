@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"context"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type HallRepository struct {
+	halls *collection[models.Hall]
+}
+
+func NewHallRepository() *HallRepository {
+	return &HallRepository{
+		halls: newCollection(func(h *models.Hall) *primitive.ObjectID { return &h.ID }),
+	}
+}
+
+func (r *HallRepository) Create(ctx context.Context, hall *models.Hall) error {
+	return r.halls.insert(hall)
+}
+
+func (r *HallRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Hall, error) {
+	return r.halls.get(id)
+}
+
+func (r *HallRepository) GetAll(ctx context.Context) ([]models.Hall, error) {
+	return r.halls.find(nil)
+}
+
+func (r *HallRepository) Update(ctx context.Context, id primitive.ObjectID, hall *models.Hall) error {
+	_, err := r.halls.set(byID(r.halls, id), hall, 1)
+	return err
+}
+
+func (r *HallRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.halls.remove(byID(r.halls, id))
+	return err
+}
+
+func (r *HallRepository) FindIDsByLocation(ctx context.Context, location string) ([]primitive.ObjectID, error) {
+	location = strings.TrimSpace(location)
+	halls, err := r.halls.find(func(h *models.Hall) bool { return strings.EqualFold(h.Location, location) })
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, len(halls))
+	for i, h := range halls {
+		ids[i] = h.ID
+	}
+	return ids, nil
+}
+
+func (r *HallRepository) List(ctx context.Context, query *repositories.ListQuery) (*models.Page[models.Hall], error) {
+	entries, err := r.halls.entries(nil)
+	if err != nil {
+		return nil, err
+	}
+	return paginate(entries, query)
+}
@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MovieGenreRepository struct {
+	movieGenres *collection[models.MovieGenre]
+}
+
+func NewMovieGenreRepository() *MovieGenreRepository {
+	return &MovieGenreRepository{
+		movieGenres: newCollection(func(mg *models.MovieGenre) *primitive.ObjectID { return &mg.ID }),
+	}
+}
+
+func (r *MovieGenreRepository) Create(ctx context.Context, movieGenre *models.MovieGenre) error {
+	return r.movieGenres.insert(movieGenre)
+}
+
+func (r *MovieGenreRepository) GetGenresByMovieID(ctx context.Context, movieID primitive.ObjectID) ([]models.MovieGenre, error) {
+	return r.movieGenres.find(func(mg *models.MovieGenre) bool { return mg.MovieID == movieID })
+}
+
+func (r *MovieGenreRepository) GetGenresByMovieIDs(ctx context.Context, movieIDs []primitive.ObjectID) ([]models.MovieGenre, error) {
+	return r.movieGenres.find(func(mg *models.MovieGenre) bool { return containsAny([]primitive.ObjectID{mg.MovieID}, movieIDs) })
+}
+
+func (r *MovieGenreRepository) GetMoviesByGenreID(ctx context.Context, genreID primitive.ObjectID) ([]models.MovieGenre, error) {
+	return r.movieGenres.find(func(mg *models.MovieGenre) bool { return mg.GenreID == genreID })
+}
+
+func (r *MovieGenreRepository) DeleteByMovieID(ctx context.Context, movieID primitive.ObjectID) error {
+	_, err := r.movieGenres.remove(func(mg *models.MovieGenre) bool { return mg.MovieID == movieID })
+	return err
+}
@@ -0,0 +1,224 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MovieRepository struct {
+	movies *collection[models.Movie]
+}
+
+func NewMovieRepository() *MovieRepository {
+	return &MovieRepository{
+		movies: newCollection(func(m *models.Movie) *primitive.ObjectID { return &m.ID }),
+	}
+}
+
+func (r *MovieRepository) Create(ctx context.Context, movie *models.Movie) error {
+	return r.movies.insert(movie)
+}
+
+func (r *MovieRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Movie, error) {
+	return r.movies.get(id)
+}
+
+func (r *MovieRepository) FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Movie, error) {
+	return r.movies.find(func(m *models.Movie) bool { return containsAny([]primitive.ObjectID{m.ID}, ids) })
+}
+
+func (r *MovieRepository) GetAll(ctx context.Context) ([]models.Movie, error) {
+	movies, err := r.movies.find(nil)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(movies, func(i, j int) bool { return movies[i].Rating > movies[j].Rating })
+	return movies, nil
+}
+
+func (r *MovieRepository) Update(ctx context.Context, id primitive.ObjectID, movie *models.Movie) error {
+	raw, err := bson.Marshal(movie)
+	if err != nil {
+		return err
+	}
+	var fields bson.M
+	if err := bson.Unmarshal(raw, &fields); err != nil {
+		return err
+	}
+	delete(fields, "popularity")
+	delete(fields, "score")
+	delete(fields, "rating")
+	delete(fields, "rating_stats")
+	_, err = r.movies.set(byID(r.movies, id), fields, 1)
+	return err
+}
+
+func (r *MovieRepository) SetTranslation(ctx context.Context, id primitive.ObjectID, lang string, translation models.MovieTranslation) error {
+	_, err := r.movies.update(byID(r.movies, id), func(m *models.Movie) {
+		if m.Translations == nil {
+			m.Translations = make(map[string]models.MovieTranslation)
+		}
+		m.Translations[lang] = translation
+	}, 1)
+	return err
+}
+
+func (r *MovieRepository) DeleteTranslation(ctx context.Context, id primitive.ObjectID, lang string) error {
+	_, err := r.movies.update(byID(r.movies, id), func(m *models.Movie) {
+		delete(m.Translations, lang)
+	}, 1)
+	return err
+}
+
+func (r *MovieRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.movies.remove(byID(r.movies, id))
+	return err
+}
+
+func (r *MovieRepository) IncrementRatingStats(ctx context.Context, movieID primitive.ObjectID, delta *models.RatingStats, prior models.RatingPrior) error {
+	updated, err := r.movies.update(byID(r.movies, movieID), func(m *models.Movie) {
+		if m.RatingStats == nil {
+			m.RatingStats = &models.RatingStats{}
+		}
+		if m.RatingStats.Histogram == nil {
+			m.RatingStats.Histogram = make(map[string]int)
+		}
+		m.RatingStats.Count += delta.Count
+		m.RatingStats.Sum += delta.Sum
+		m.RatingStats.Weight += delta.Weight
+		for score, n := range delta.Histogram {
+			m.RatingStats.Histogram[score] += n
+		}
+		m.RatingStats.Compute(prior.Mean, prior.Weight)
+		m.Rating = m.RatingStats.Rating(prior)
+	}, 1)
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *MovieRepository) SetRatingStats(ctx context.Context, movieID primitive.ObjectID, stats *models.RatingStats, rating float64) error {
+	updated, err := r.movies.set(byID(r.movies, movieID), bson.M{"rating_stats": stats, "rating": rating}, 1)
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *MovieRepository) IncrementPopularity(ctx context.Context, movieID primitive.ObjectID, delta int) error {
+	_, err := r.movies.update(byID(r.movies, movieID), func(m *models.Movie) { m.Popularity += delta }, 1)
+	return err
+}
+
+var movieSortFields = map[string]string{
+	models.MovieSortRelevance:  scoreField,
+	models.MovieSortRating:     "rating",
+	models.MovieSortRelease:    "release_date",
+	models.MovieSortName:       "name",
+	models.MovieSortPopularity: "popularity",
+}
+
+func (r *MovieRepository) Search(ctx context.Context, filter repositories.MovieFilter) (*models.Page[models.Movie], error) {
+	field, ok := movieSortFields[filter.Sort]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown sort %q", repositories.ErrInvalidQuery, filter.Sort)
+	}
+
+	terms := strings.Fields(strings.ToLower(filter.Search))
+	entries, err := r.movies.entries(func(m *models.Movie) bool {
+		if len(filter.GenreIDs) > 0 && !containsAny(m.Genres, filter.GenreIDs) {
+			return false
+		}
+		if filter.AgeRating != "" && m.AgeRating != filter.AgeRating {
+			return false
+		}
+		if filter.MinRating != nil && m.Rating < *filter.MinRating {
+			return false
+		}
+		if filter.ComingSoon != nil && m.IsComingSoon != *filter.ComingSoon {
+			return false
+		}
+		if filter.MovieIDs != nil && !containsAny([]primitive.ObjectID{m.ID}, filter.MovieIDs) {
+			return false
+		}
+		return len(terms) == 0 || textScore(m, terms) > 0
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(terms) > 0 {
+		for i := range entries {
+			entries[i].doc.Score = textScore(&entries[i].doc, terms)
+			if entries[i].raw, err = bson.Marshal(&entries[i].doc); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sortKey := filter.Sort
+	if filter.Desc {
+		sortKey = "-" + sortKey
+	}
+	return paginate(entries, &repositories.ListQuery{
+		Sort:      sortKey,
+		SortField: field,
+		Desc:      filter.Desc,
+		Limit:     filter.Limit,
+		Cursor:    filter.Cursor,
+	})
+}
+
+func textScore(m *models.Movie, terms []string) float64 {
+	names := []string{m.Name}
+	descriptions := []string{m.Description}
+	for _, t := range m.Translations {
+		names = append(names, t.Name)
+		descriptions = append(descriptions, t.Description)
+	}
+	name := strings.Fields(strings.ToLower(strings.Join(names, " ")))
+	description := strings.Fields(strings.ToLower(strings.Join(descriptions, " ")))
+
+	var score float64
+	for _, term := range terms {
+		score += 10 * float64(countWord(name, term))
+		score += 2 * float64(countWord(description, term))
+	}
+	return score
+}
+
+func countWord(words []string, term string) int {
+	n := 0
+	for _, w := range words {
+		if strings.Trim(w, ".,;:!?\"'()") == term {
+			n++
+		}
+	}
+	return n
+}
+
+func containsAny(values, candidates []primitive.ObjectID) bool {
+	for _, v := range values {
+		for _, c := range candidates {
+			if v == c {
+				return true
+			}
+		}
+	}
+	return false
+}
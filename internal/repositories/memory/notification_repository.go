@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"context"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type NotificationRepository struct {
+	notifications *collection[models.Notification]
+}
+
+func NewNotificationRepository() *NotificationRepository {
+	return &NotificationRepository{
+		notifications: newCollection(func(n *models.Notification) *primitive.ObjectID { return &n.ID }),
+	}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	return r.notifications.insert(notification)
+}
+
+func (r *NotificationRepository) CreateIfAbsent(ctx context.Context, notification *models.Notification) (bool, error) {
+	if notification.DedupKey != "" {
+		count, err := r.notifications.count(func(n *models.Notification) bool { return n.DedupKey == notification.DedupKey })
+		if err != nil || count > 0 {
+			return false, err
+		}
+	}
+	return true, r.notifications.insert(notification)
+}
+
+func (r *NotificationRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Notification, error) {
+	return r.notifications.get(id)
+}
+
+func (r *NotificationRepository) FindDue(ctx context.Context, now time.Time, limit int64) ([]models.Notification, error) {
+	due, err := r.notifications.find(func(n *models.Notification) bool {
+		return n.Status == models.NotificationPending && !n.NextAttemptAt.After(now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(due[j].NextAttemptAt) })
+	if limit > 0 && int64(len(due)) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (r *NotificationRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.Notification, error) {
+	return r.newestFirst(func(n *models.Notification) bool { return n.UserID == userID })
+}
+
+func (r *NotificationRepository) FindByStatus(ctx context.Context, status models.NotificationStatus) ([]models.Notification, error) {
+	return r.newestFirst(func(n *models.Notification) bool { return n.Status == status })
+}
+
+func (r *NotificationRepository) newestFirst(match func(*models.Notification) bool) ([]models.Notification, error) {
+	found, err := r.notifications.find(match)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].CreatedAt.After(found[j].CreatedAt) })
+	return found, nil
+}
+
+func (r *NotificationRepository) MarkSent(ctx context.Context, id primitive.ObjectID, attempts int, sentAt time.Time) error {
+	_, err := r.notifications.update(byID(r.notifications, id), func(n *models.Notification) {
+		n.Status = models.NotificationSent
+		n.Attempts = attempts
+		n.SentAt = &sentAt
+		n.LastError = ""
+	}, 1)
+	return err
+}
+
+func (r *NotificationRepository) MarkAttemptFailed(ctx context.Context, id primitive.ObjectID, attempts int, lastError string, status models.NotificationStatus, nextAttemptAt time.Time) error {
+	_, err := r.notifications.update(byID(r.notifications, id), func(n *models.Notification) {
+		n.Status = status
+		n.Attempts = attempts
+		n.LastError = lastError
+		n.NextAttemptAt = nextAttemptAt
+	}, 1)
+	return err
+}
+
+func (r *NotificationRepository) Requeue(ctx context.Context, id primitive.ObjectID, now time.Time) error {
+	_, err := r.notifications.update(func(n *models.Notification) bool {
+		return n.ID == id && n.Status == models.NotificationFailed
+	}, func(n *models.Notification) {
+		n.Status = models.NotificationPending
+		n.Attempts = 0
+		n.NextAttemptAt = now
+	}, 1)
+	return err
+}
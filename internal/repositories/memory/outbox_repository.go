@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"context"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type OutboxRepository struct {
+	events *collection[models.OutboxEvent]
+}
+
+func NewOutboxRepository() *OutboxRepository {
+	return &OutboxRepository{
+		events: newCollection(func(e *models.OutboxEvent) *primitive.ObjectID { return &e.ID }),
+	}
+}
+
+func (r *OutboxRepository) Add(ctx context.Context, event *models.OutboxEvent) error {
+	return r.events.insert(event)
+}
+
+func (r *OutboxRepository) ClaimNext(ctx context.Context, now time.Time, lease time.Duration) (*models.OutboxEvent, error) {
+	due, err := r.events.find(func(e *models.OutboxEvent) bool {
+		return e.Status == models.OutboxPending && !e.NextAttemptAt.After(now)
+	})
+	if err != nil || len(due) == 0 {
+		return nil, err
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].CreatedAt.Before(due[j].CreatedAt) })
+
+	event := due[0]
+	claimed, err := r.events.update(func(e *models.OutboxEvent) bool {
+		return e.ID == event.ID && e.Status == models.OutboxPending && !e.NextAttemptAt.After(now)
+	}, func(e *models.OutboxEvent) {
+		e.NextAttemptAt = now.Add(lease)
+	}, 1)
+	if err != nil || claimed == 0 {
+		return nil, err
+	}
+	return r.events.get(event.ID)
+}
+
+func (r *OutboxRepository) MarkProcessed(ctx context.Context, id primitive.ObjectID, attempts int, processedAt time.Time) error {
+	_, err := r.events.update(byID(r.events, id), func(e *models.OutboxEvent) {
+		e.Status = models.OutboxProcessed
+		e.Attempts = attempts
+		e.ProcessedAt = &processedAt
+		e.LastError = ""
+	}, 1)
+	return err
+}
+
+func (r *OutboxRepository) MarkAttemptFailed(ctx context.Context, id primitive.ObjectID, attempts int, lastError string, status models.OutboxStatus, nextAttemptAt time.Time) error {
+	_, err := r.events.update(byID(r.events, id), func(e *models.OutboxEvent) {
+		e.Status = status
+		e.Attempts = attempts
+		e.LastError = lastError
+		e.NextAttemptAt = nextAttemptAt
+	}, 1)
+	return err
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestOutboxClaimLeaseAndRetry(t *testing.T) {
+	ctx := context.Background()
+	repo := NewOutboxRepository()
+	now := time.Now().Truncate(time.Millisecond)
+	lease := time.Minute
+	payload, err := bson.Marshal(bson.M{})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	event := &models.OutboxEvent{Type: "ticket.booked", Payload: payload, Status: models.OutboxPending, NextAttemptAt: now, CreatedAt: now}
+	done := &models.OutboxEvent{Type: "ticket.booked", Payload: payload, Status: models.OutboxProcessed, NextAttemptAt: now.Add(-time.Hour), CreatedAt: now.Add(-time.Hour)}
+	for _, e := range []*models.OutboxEvent{event, done} {
+		if err := repo.Add(ctx, e); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	claim := func(at time.Time) *models.OutboxEvent {
+		t.Helper()
+		claimed, err := repo.ClaimNext(ctx, at, lease)
+		if err != nil {
+			t.Fatalf("ClaimNext: %v", err)
+		}
+		return claimed
+	}
+
+	if claimed := claim(now); claimed == nil || claimed.ID != event.ID || !claimed.NextAttemptAt.Equal(now.Add(lease)) {
+		t.Fatalf("first claim = %+v, want the pending event leased until %s", claimed, now.Add(lease))
+	}
+	if claimed := claim(now.Add(lease / 2)); claimed != nil {
+		t.Fatalf("claim within lease = %+v, want nothing", claimed)
+	}
+	if claimed := claim(now.Add(lease)); claimed == nil || claimed.ID != event.ID {
+		t.Fatalf("claim after lease = %+v, want the event again", claimed)
+	}
+
+	retryAt := now.Add(10 * time.Minute)
+	if err := repo.MarkAttemptFailed(ctx, event.ID, 1, "boom", models.OutboxPending, retryAt); err != nil {
+		t.Fatalf("MarkAttemptFailed: %v", err)
+	}
+	if claimed := claim(retryAt.Add(-time.Second)); claimed != nil {
+		t.Fatalf("claim before retry = %+v, want nothing", claimed)
+	}
+	claimed := claim(retryAt)
+	if claimed == nil || claimed.Attempts != 1 || claimed.LastError != "boom" {
+		t.Fatalf("claim at retry = %+v, want the failed attempt", claimed)
+	}
+
+	if err := repo.MarkAttemptFailed(ctx, event.ID, models.MaxOutboxAttempts, "boom", models.OutboxFailed, retryAt); err != nil {
+		t.Fatalf("MarkAttemptFailed: %v", err)
+	}
+	if claimed := claim(now.Add(24 * time.Hour)); claimed != nil {
+		t.Fatalf("claim = %+v, want failed and processed events skipped", claimed)
+	}
+}
@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PaymentCardRepository struct {
+	cards *collection[models.PaymentCard]
+}
+
+func NewPaymentCardRepository() *PaymentCardRepository {
+	return &PaymentCardRepository{
+		cards: newCollection(func(c *models.PaymentCard) *primitive.ObjectID { return &c.ID }),
+	}
+}
+
+func (r *PaymentCardRepository) Create(ctx context.Context, card *models.PaymentCard) error {
+	return r.cards.insert(card)
+}
+
+func (r *PaymentCardRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.PaymentCard, error) {
+	return r.cards.get(id)
+}
+
+func (r *PaymentCardRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.PaymentCard, error) {
+	return r.cards.find(func(c *models.PaymentCard) bool { return c.UserID == userID })
+}
+
+func (r *PaymentCardRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.cards.remove(byID(r.cards, id))
+	return err
+}
+
+func (r *PaymentCardRepository) Update(ctx context.Context, id primitive.ObjectID, card *models.PaymentCard) error {
+	_, err := r.cards.set(byID(r.cards, id), card, 1)
+	return err
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PaymentRepository struct {
+	payments *collection[models.Payment]
+}
+
+func NewPaymentRepository() *PaymentRepository {
+	return &PaymentRepository{
+		payments: newCollection(func(p *models.Payment) *primitive.ObjectID { return &p.ID }),
+	}
+}
+
+func (r *PaymentRepository) Create(ctx context.Context, payment *models.Payment) error {
+	return r.payments.insert(payment)
+}
+
+func (r *PaymentRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Payment, error) {
+	return r.payments.get(id)
+}
+
+func (r *PaymentRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.Payment, error) {
+	return r.payments.find(func(p *models.Payment) bool { return p.UserID == userID })
+}
+
+func (r *PaymentRepository) FindByTransactionCode(ctx context.Context, code string) (*models.Payment, error) {
+	return r.payments.findOne(func(p *models.Payment) bool { return p.TransactionCode == code })
+}
+
+func (r *PaymentRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, status models.PaymentStatus) error {
+	_, err := r.payments.set(byID(r.payments, id), bson.M{"status": status}, 1)
+	return err
+}
+
+func (r *PaymentRepository) GetAll(ctx context.Context) ([]models.Payment, error) {
+	return r.payments.find(nil)
+}
+
+func (r *PaymentRepository) List(ctx context.Context, query *repositories.ListQuery) (*models.Page[models.Payment], error) {
+	entries, err := r.payments.entries(nil)
+	if err != nil {
+		return nil, err
+	}
+	return paginate(entries, query)
+}
+
+func (r *PaymentRepository) Stream(ctx context.Context, query *repositories.ListQuery, fn func(*models.Payment) error) error {
+	entries, err := r.payments.entries(nil)
+	if err != nil {
+		return err
+	}
+	return stream(entries, query, fn)
+}
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"cinema-system/internal/repositories"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type processedEvent struct {
+	ID          primitive.ObjectID
+	EventID     primitive.ObjectID
+	Handler     string
+	ProcessedAt time.Time
+}
+
+type ProcessedEventRepository struct {
+	events *collection[processedEvent]
+}
+
+func NewProcessedEventRepository() *ProcessedEventRepository {
+	return &ProcessedEventRepository{
+		events: newCollection(func(e *processedEvent) *primitive.ObjectID { return &e.ID }),
+	}
+}
+
+func (r *ProcessedEventRepository) Exists(ctx context.Context, eventID primitive.ObjectID, handler string) (bool, error) {
+	count, err := r.events.count(func(e *processedEvent) bool { return e.EventID == eventID && e.Handler == handler })
+	return count > 0, err
+}
+
+func (r *ProcessedEventRepository) Record(ctx context.Context, eventID primitive.ObjectID, handler string) error {
+	done, err := r.Exists(ctx, eventID, handler)
+	if err != nil {
+		return err
+	}
+	if done {
+		return repositories.ErrAlreadyProcessed
+	}
+	return r.events.insert(&processedEvent{EventID: eventID, Handler: handler, ProcessedAt: time.Now()})
+}
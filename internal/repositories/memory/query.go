@@ -0,0 +1,327 @@
+package memory
+
+import (
+	"bytes"
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const scoreField = "score"
+
+type offsetCursor struct {
+	Sort   string `json:"s"`
+	Offset int64  `json:"o"`
+}
+
+func encodeCursor(c offsetCursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(token, sort string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, repositories.ErrInvalidCursor
+	}
+	var c offsetCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.Sort != sort || c.Offset < 0 {
+		return 0, repositories.ErrInvalidCursor
+	}
+	return c.Offset, nil
+}
+
+func paginate[T any](entries []entry[T], query *repositories.ListQuery) (*models.Page[T], error) {
+	offset, err := decodeCursor(query.Cursor, query.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	matched, err := filterAndSort(entries, query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.Page[T]{Items: []T{}, Total: int64(len(matched)), Limit: query.Limit, Page: query.Page}
+	if query.Page > 0 {
+		offset = (query.Page - 1) * query.Limit
+	}
+	if offset >= int64(len(matched)) {
+		return page, nil
+	}
+
+	end := offset + query.Limit
+	if end > int64(len(matched)) {
+		end = int64(len(matched))
+	}
+	for _, e := range matched[offset:end] {
+		page.Items = append(page.Items, e.doc)
+	}
+	if end < int64(len(matched)) && query.Page == 0 {
+		page.NextCursor = encodeCursor(offsetCursor{Sort: query.Sort, Offset: end})
+	}
+	return page, nil
+}
+
+func filterAndSort[T any](entries []entry[T], query *repositories.ListQuery) ([]entry[T], error) {
+	matched := make([]entry[T], 0, len(entries))
+	for _, e := range entries {
+		ok, err := matchFilter(e.raw, query.Filter)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, e)
+		}
+	}
+
+	field, desc := query.SortField, query.Desc
+	if field == scoreField {
+		desc = true
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		cmp := compareValues(lookup(matched[i].raw, field), lookup(matched[j].raw, field))
+		if cmp == 0 {
+			cmp = compareValues(lookup(matched[i].raw, "_id"), lookup(matched[j].raw, "_id"))
+			if field == scoreField {
+				return cmp < 0
+			}
+		}
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return matched, nil
+}
+
+func stream[T any](entries []entry[T], query *repositories.ListQuery, fn func(*T) error) error {
+	matched, err := filterAndSort(entries, query)
+	if err != nil {
+		return err
+	}
+	for i := range matched {
+		if err := fn(&matched[i].doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func lookup(raw bson.Raw, field string) bson.RawValue {
+	value, err := raw.LookupErr(strings.Split(field, ".")...)
+	if err != nil {
+		return bson.RawValue{Type: bsontype.Null}
+	}
+	return value
+}
+
+func toRawValue(v interface{}) (bson.RawValue, error) {
+	if raw, ok := v.(bson.RawValue); ok {
+		return raw, nil
+	}
+	t, data, err := bson.MarshalValue(v)
+	if err != nil {
+		return bson.RawValue{}, err
+	}
+	return bson.RawValue{Type: t, Value: data}, nil
+}
+
+func matchFilter(raw bson.Raw, filter bson.M) (bool, error) {
+	for field, condition := range filter {
+		switch field {
+		case "$and", "$or":
+			clauses, ok := condition.(bson.A)
+			if !ok {
+				return false, fmt.Errorf("memory: unsupported %s clause %T", field, condition)
+			}
+			matchedAny := false
+			for _, clause := range clauses {
+				sub, ok := clause.(bson.M)
+				if !ok {
+					return false, fmt.Errorf("memory: unsupported %s clause %T", field, clause)
+				}
+				matched, err := matchFilter(raw, sub)
+				if err != nil {
+					return false, err
+				}
+				if field == "$and" && !matched {
+					return false, nil
+				}
+				matchedAny = matchedAny || matched
+			}
+			if field == "$or" && !matchedAny {
+				return false, nil
+			}
+			continue
+		}
+
+		value := lookup(raw, field)
+		operators, ok := condition.(bson.M)
+		if !ok {
+			operators = bson.M{"$eq": condition}
+		}
+		for op, operand := range operators {
+			matched, err := matchOperator(value, op, operand)
+			if err != nil {
+				return false, err
+			}
+			if !matched {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+func matchOperator(value bson.RawValue, op string, operand interface{}) (bool, error) {
+	if op == "$in" {
+		values, ok := operand.(bson.A)
+		if !ok {
+			return false, fmt.Errorf("memory: unsupported $in operand %T", operand)
+		}
+		for _, v := range values {
+			matched, err := matchOperator(value, "$eq", v)
+			if err != nil || matched {
+				return matched, err
+			}
+		}
+		return false, nil
+	}
+
+	target, err := toRawValue(operand)
+	if err != nil {
+		return false, err
+	}
+
+	if value.Type == bsontype.Array && op == "$eq" {
+		elements, err := value.Array().Values()
+		if err != nil {
+			return false, err
+		}
+		for _, element := range elements {
+			if compareValues(element, target) == 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	cmp := compareValues(value, target)
+	comparable := typeOrder(value.Type) == typeOrder(target.Type)
+	switch op {
+	case "$eq":
+		return cmp == 0, nil
+	case "$ne":
+		return cmp != 0, nil
+	case "$gt":
+		return comparable && cmp > 0, nil
+	case "$gte":
+		return comparable && cmp >= 0, nil
+	case "$lt":
+		return comparable && cmp < 0, nil
+	case "$lte":
+		return comparable && cmp <= 0, nil
+	default:
+		return false, fmt.Errorf("memory: unsupported operator %s", op)
+	}
+}
+
+func typeOrder(t bsontype.Type) int {
+	switch t {
+	case bsontype.Null, bsontype.Undefined:
+		return 1
+	case bsontype.Int32, bsontype.Int64, bsontype.Double, bsontype.Decimal128:
+		return 2
+	case bsontype.String, bsontype.Symbol:
+		return 3
+	case bsontype.EmbeddedDocument:
+		return 4
+	case bsontype.Array:
+		return 5
+	case bsontype.Binary:
+		return 6
+	case bsontype.ObjectID:
+		return 7
+	case bsontype.Boolean:
+		return 8
+	case bsontype.DateTime:
+		return 9
+	case bsontype.Timestamp:
+		return 10
+	default:
+		return 11
+	}
+}
+
+func compareValues(a, b bson.RawValue) int {
+	if oa, ob := typeOrder(a.Type), typeOrder(b.Type); oa != ob {
+		return oa - ob
+	}
+
+	switch typeOrder(a.Type) {
+	case 1:
+		return 0
+	case 2:
+		fa, fb := asFloat(a), asFloat(b)
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	case 3:
+		return strings.Compare(a.StringValue(), b.StringValue())
+	case 7:
+		return compareObjectIDs(a.ObjectID(), b.ObjectID())
+	case 8:
+		ba, bb := a.Boolean(), b.Boolean()
+		switch {
+		case ba == bb:
+			return 0
+		case !ba:
+			return -1
+		}
+		return 1
+	case 9:
+		da, db := a.DateTime(), b.DateTime()
+		switch {
+		case da < db:
+			return -1
+		case da > db:
+			return 1
+		}
+		return 0
+	default:
+		return bytes.Compare(a.Value, b.Value)
+	}
+}
+
+func asFloat(v bson.RawValue) float64 {
+	switch v.Type {
+	case bsontype.Int32:
+		return float64(v.Int32())
+	case bsontype.Int64:
+		return float64(v.Int64())
+	case bsontype.Double:
+		return v.Double()
+	default:
+		return 0
+	}
+}
+
+func compareObjectIDs(a, b primitive.ObjectID) int {
+	return bytes.Compare(a[:], b[:])
+}
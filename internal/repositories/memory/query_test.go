@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/money"
+	"cinema-system/internal/repositories"
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTicketListFiltersSortsAndPages(t *testing.T) {
+	ctx := context.Background()
+	repo := NewTicketRepository()
+	userID := primitive.NewObjectID()
+	base := time.Now().Truncate(time.Millisecond)
+
+	for i, status := range []models.TicketStatus{models.TicketPaid, models.TicketCancelled, models.TicketPaid, models.TicketUsed, models.TicketPaid} {
+		ticket := &models.Ticket{
+			UserID:    userID,
+			SessionID: primitive.NewObjectID(),
+			Price:     money.New(int64(1000+100*i), money.DefaultCurrency),
+			Status:    status,
+			CreatedAt: base.Add(time.Duration(i) * time.Minute),
+		}
+		if err := repo.Create(ctx, ticket); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+	if err := repo.Create(ctx, &models.Ticket{UserID: primitive.NewObjectID(), Status: models.TicketPaid}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	values := url.Values{"status": {"PAID,USED"}, "price_from": {"1100"}, "sort": {"-price"}, "limit": {"2"}}
+	query, err := repositories.ParseListQuery(values, repositories.TicketListSpec, time.UTC)
+	if err != nil {
+		t.Fatalf("ParseListQuery: %v", err)
+	}
+
+	page, err := repo.ListByUserID(ctx, userID, query)
+	if err != nil {
+		t.Fatalf("ListByUserID: %v", err)
+	}
+	if page.Total != 3 || len(page.Items) != 2 || page.Items[0].Price.Amount != 1400 || page.Items[1].Price.Amount != 1300 || page.NextCursor == "" {
+		t.Fatalf("unexpected first page: %+v", page)
+	}
+
+	query.Cursor = page.NextCursor
+	page, err = repo.ListByUserID(ctx, userID, query)
+	if err != nil {
+		t.Fatalf("ListByUserID: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Price.Amount != 1200 || page.NextCursor != "" {
+		t.Fatalf("unexpected second page: %+v", page)
+	}
+
+	query.Sort = "price"
+	if _, err := repo.ListByUserID(ctx, userID, query); !errors.Is(err, repositories.ErrInvalidCursor) {
+		t.Fatalf("err = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestTransactorRollsBack(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	user := &models.User{Email: "a@example.com", Balance: money.New(5000, money.DefaultCurrency)}
+	if err := store.Users.Create(ctx, user); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	failure := errors.New("boom")
+	err := store.Transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := store.Users.UpdateBalance(ctx, user.ID, money.Zero(money.DefaultCurrency)); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("err = %v, want %v", err, failure)
+	}
+
+	stored, err := store.Users.FindByID(ctx, user.ID)
+	if err != nil || stored.Balance.Amount != 5000 {
+		t.Fatalf("user = %+v, err = %v", stored, err)
+	}
+}
+
+func TestDateFiltersUseConfiguredLocation(t *testing.T) {
+	almaty := time.FixedZone("+05:00", 5*60*60)
+	values := url.Values{"created_at_from": {"2026-03-14"}, "created_at_to": {"2026-03-14"}}
+	query, err := repositories.ParseListQuery(values, repositories.TicketListSpec, almaty)
+	if err != nil {
+		t.Fatalf("ParseListQuery: %v", err)
+	}
+
+	conditions := query.Filter["created_at"].(bson.M)
+	from, to := conditions["$gte"].(time.Time), conditions["$lt"].(time.Time)
+	if want := time.Date(2026, 3, 13, 19, 0, 0, 0, time.UTC); !from.Equal(want) {
+		t.Errorf("from = %s, want %s", from, want)
+	}
+	if want := time.Date(2026, 3, 14, 19, 0, 0, 0, time.UTC); !to.Equal(want) {
+		t.Errorf("to = %s, want %s", to, want)
+	}
+}
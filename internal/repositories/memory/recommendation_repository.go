@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RecommendationRepository struct {
+	recommendations *collection[models.Recommendation]
+}
+
+func NewRecommendationRepository() *RecommendationRepository {
+	return &RecommendationRepository{
+		recommendations: newCollection(func(r *models.Recommendation) *primitive.ObjectID { return &r.ID }),
+	}
+}
+
+func recommendationKey(kind models.RecommendationKind, subjectID primitive.ObjectID) func(*models.Recommendation) bool {
+	return func(r *models.Recommendation) bool { return r.Kind == kind && r.SubjectID == subjectID }
+}
+
+func (r *RecommendationRepository) Replace(ctx context.Context, rec *models.Recommendation) error {
+	updated, err := r.recommendations.update(recommendationKey(rec.Kind, rec.SubjectID), func(existing *models.Recommendation) {
+		existing.Items = rec.Items
+		existing.ComputedAt = rec.ComputedAt
+		rec.ID = existing.ID
+	}, 1)
+	if err != nil || updated > 0 {
+		return err
+	}
+	return r.recommendations.insert(rec)
+}
+
+func (r *RecommendationRepository) Find(ctx context.Context, kind models.RecommendationKind, subjectID primitive.ObjectID) (*models.Recommendation, error) {
+	return r.recommendations.findOne(recommendationKey(kind, subjectID))
+}
+
+func (r *RecommendationRepository) DeleteComputedBefore(ctx context.Context, before time.Time) error {
+	_, err := r.recommendations.remove(func(rec *models.Recommendation) bool { return rec.ComputedAt.Before(before) })
+	return err
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"context"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ReviewReportRepository struct {
+	reports *collection[models.ReviewReport]
+}
+
+func NewReviewReportRepository() *ReviewReportRepository {
+	return &ReviewReportRepository{
+		reports: newCollection(func(r *models.ReviewReport) *primitive.ObjectID { return &r.ID }),
+	}
+}
+
+func (r *ReviewReportRepository) Create(ctx context.Context, report *models.ReviewReport) error {
+	exists, err := r.reports.count(func(existing *models.ReviewReport) bool {
+		return existing.ReviewID == report.ReviewID && existing.UserID == report.UserID
+	})
+	if err != nil {
+		return err
+	}
+	if exists > 0 {
+		return mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: duplicateKeyCode, Message: "duplicate key error"}}}
+	}
+	return r.reports.insert(report)
+}
+
+func (r *ReviewReportRepository) ListByReview(ctx context.Context, reviewID primitive.ObjectID) ([]models.ReviewReport, error) {
+	reports, err := r.reports.find(func(report *models.ReviewReport) bool { return report.ReviewID == reviewID })
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].CreatedAt.Before(reports[j].CreatedAt) })
+	return reports, nil
+}
+
+func (r *ReviewReportRepository) ResolveByReview(ctx context.Context, reviewID primitive.ObjectID, at time.Time) error {
+	_, err := r.reports.update(func(report *models.ReviewReport) bool {
+		return report.ReviewID == reviewID && !report.Resolved
+	}, func(report *models.ReviewReport) {
+		report.Resolved = true
+		report.ResolvedAt = &at
+	}, 0)
+	return err
+}
@@ -0,0 +1,162 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ReviewRepository struct {
+	reviews *collection[models.Review]
+}
+
+func NewReviewRepository() *ReviewRepository {
+	return &ReviewRepository{
+		reviews: newCollection(func(r *models.Review) *primitive.ObjectID { return &r.ID }),
+	}
+}
+
+func (r *ReviewRepository) Create(ctx context.Context, review *models.Review) error {
+	return r.reviews.insert(review)
+}
+
+func (r *ReviewRepository) GetAll(ctx context.Context) ([]models.Review, error) {
+	return r.reviews.find(nil)
+}
+
+func (r *ReviewRepository) GetByMovie(ctx context.Context, movieID primitive.ObjectID) ([]models.Review, error) {
+	return r.reviews.find(func(rv *models.Review) bool { return rv.MovieID == movieID })
+}
+
+func (r *ReviewRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Review, error) {
+	return r.reviews.get(id)
+}
+
+func (r *ReviewRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.reviews.remove(byID(r.reviews, id))
+	return err
+}
+
+func (r *ReviewRepository) GetAverageRating(ctx context.Context, movieID primitive.ObjectID) (float64, error) {
+	reviews, err := r.reviews.find(func(rv *models.Review) bool {
+		return rv.MovieID == movieID && rv.Status == models.ReviewPublished
+	})
+	if err != nil || len(reviews) == 0 {
+		return 0, err
+	}
+	var sum, weights float64
+	for _, rv := range reviews {
+		sum += float64(rv.Rating) * rv.RatingWeight()
+		weights += rv.RatingWeight()
+	}
+	return sum / weights, nil
+}
+
+func (r *ReviewRepository) GetRatingStats(ctx context.Context, movieID primitive.ObjectID) (*models.RatingStats, error) {
+	reviews, err := r.reviews.find(func(rv *models.Review) bool {
+		return rv.MovieID == movieID && rv.Status == models.ReviewPublished
+	})
+	if err != nil {
+		return nil, err
+	}
+	stats := &models.RatingStats{Histogram: make(map[string]int)}
+	for _, rv := range reviews {
+		stats.Add(rv.Rating, rv.RatingWeight())
+	}
+	return stats, nil
+}
+
+func (r *ReviewRepository) GetByUser(ctx context.Context, userID primitive.ObjectID) ([]models.Review, error) {
+	return r.reviews.find(func(rv *models.Review) bool { return rv.UserID == userID })
+}
+
+func (r *ReviewRepository) CheckUserReview(ctx context.Context, userID, movieID primitive.ObjectID) (bool, error) {
+	count, err := r.reviews.count(func(rv *models.Review) bool {
+		return rv.UserID == userID && rv.MovieID == movieID
+	})
+	return count > 0, err
+}
+
+func (r *ReviewRepository) Update(ctx context.Context, review *models.Review) error {
+	_, err := r.reviews.set(byID(r.reviews, review.ID), review, 1)
+	return err
+}
+
+func (r *ReviewRepository) UpdateReviewerName(ctx context.Context, userID primitive.ObjectID, newName string) error {
+	_, err := r.reviews.set(func(rv *models.Review) bool { return rv.UserID == userID }, bson.M{"user_name": newName}, 0)
+	return err
+}
+
+func (r *ReviewRepository) SetStatus(ctx context.Context, id primitive.ObjectID, status models.ReviewStatus, reason string, moderatorID *primitive.ObjectID, at time.Time) error {
+	_, err := r.reviews.update(byID(r.reviews, id), func(rv *models.Review) {
+		rv.Status = status
+		rv.ModerationReason = reason
+		rv.ModeratedBy = moderatorID
+		rv.ModeratedAt = &at
+	}, 1)
+	return err
+}
+
+func (r *ReviewRepository) IncrementReportCount(ctx context.Context, id primitive.ObjectID) (*models.Review, error) {
+	updated, err := r.reviews.update(byID(r.reviews, id), func(rv *models.Review) { rv.ReportCount++ }, 1)
+	if err != nil {
+		return nil, err
+	}
+	if updated == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	return r.reviews.get(id)
+}
+
+func (r *ReviewRepository) ResetReportCount(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.reviews.set(byID(r.reviews, id), bson.M{"report_count": 0}, 1)
+	return err
+}
+
+func (r *ReviewRepository) IncrementVotes(ctx context.Context, id primitive.ObjectID, helpful, unhelpful int) error {
+	updated, err := r.reviews.update(byID(r.reviews, id), func(rv *models.Review) {
+		rv.HelpfulCount += helpful
+		rv.UnhelpfulCount += unhelpful
+	}, 1)
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *ReviewRepository) SetReply(ctx context.Context, id primitive.ObjectID, reply *models.ReviewReply) error {
+	updated, err := r.reviews.update(byID(r.reviews, id), func(rv *models.Review) { rv.Reply = reply }, 1)
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *ReviewRepository) ListPublishedByMovie(ctx context.Context, movieID primitive.ObjectID, query *repositories.ListQuery) (*models.Page[models.Review], error) {
+	entries, err := r.reviews.entries(func(rv *models.Review) bool {
+		return rv.MovieID == movieID && rv.Status == models.ReviewPublished
+	})
+	if err != nil {
+		return nil, err
+	}
+	return paginate(entries, query)
+}
+
+func (r *ReviewRepository) List(ctx context.Context, query *repositories.ListQuery) (*models.Page[models.Review], error) {
+	entries, err := r.reviews.entries(nil)
+	if err != nil {
+		return nil, err
+	}
+	return paginate(entries, query)
+}
@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ReviewVoteRepository struct {
+	votes *collection[models.ReviewVote]
+}
+
+func NewReviewVoteRepository() *ReviewVoteRepository {
+	return &ReviewVoteRepository{
+		votes: newCollection(func(v *models.ReviewVote) *primitive.ObjectID { return &v.ID }),
+	}
+}
+
+func voteKey(reviewID, userID primitive.ObjectID) func(*models.ReviewVote) bool {
+	return func(v *models.ReviewVote) bool { return v.ReviewID == reviewID && v.UserID == userID }
+}
+
+func (r *ReviewVoteRepository) Find(ctx context.Context, reviewID, userID primitive.ObjectID) (*models.ReviewVote, error) {
+	return r.votes.findOne(voteKey(reviewID, userID))
+}
+
+func (r *ReviewVoteRepository) Upsert(ctx context.Context, vote *models.ReviewVote) error {
+	updated, err := r.votes.update(voteKey(vote.ReviewID, vote.UserID), func(existing *models.ReviewVote) {
+		existing.Helpful = vote.Helpful
+		vote.ID = existing.ID
+		vote.CreatedAt = existing.CreatedAt
+	}, 1)
+	if err != nil || updated > 0 {
+		return err
+	}
+	return r.votes.insert(vote)
+}
+
+func (r *ReviewVoteRepository) Delete(ctx context.Context, reviewID, userID primitive.ObjectID) error {
+	removed, err := r.votes.remove(voteKey(reviewID, userID))
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SessionRepository struct {
+	sessions *collection[models.Session]
+}
+
+func NewSessionRepository() *SessionRepository {
+	return &SessionRepository{
+		sessions: newCollection(func(s *models.Session) *primitive.ObjectID { return &s.ID }),
+	}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *models.Session) error {
+	return r.sessions.insert(session)
+}
+
+func (r *SessionRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Session, error) {
+	return r.sessions.get(id)
+}
+
+func (r *SessionRepository) FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Session, error) {
+	return r.sessions.find(func(s *models.Session) bool { return containsAny([]primitive.ObjectID{s.ID}, ids) })
+}
+
+func (r *SessionRepository) GetByMovie(ctx context.Context, movieID primitive.ObjectID) ([]models.Session, error) {
+	now := time.Now()
+	return r.sessions.find(func(s *models.Session) bool {
+		return s.MovieID == movieID && !s.StartTime.Before(now)
+	})
+}
+
+func (r *SessionRepository) GetOverlappingByHall(ctx context.Context, hallID primitive.ObjectID, startTime, endTime time.Time) ([]models.Session, error) {
+	return r.sessions.find(func(s *models.Session) bool {
+		return s.HallID == hallID && s.StartTime.Before(endTime) && s.EndTime.After(startTime)
+	})
+}
+
+func (r *SessionRepository) GetUpcoming(ctx context.Context) ([]models.Session, error) {
+	now := time.Now()
+	return r.sessions.find(func(s *models.Session) bool { return !s.StartTime.Before(now) })
+}
+
+func (r *SessionRepository) Update(ctx context.Context, id primitive.ObjectID, session *models.Session) error {
+	_, err := r.sessions.set(byID(r.sessions, id), session, 1)
+	return err
+}
+
+func (r *SessionRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.sessions.remove(byID(r.sessions, id))
+	return err
+}
+
+func (r *SessionRepository) GetUpcomingMovieIDs(ctx context.Context) ([]primitive.ObjectID, error) {
+	sessions, err := r.GetUpcoming(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return distinctMovieIDs(sessions), nil
+}
+
+func (r *SessionRepository) GetStartingBetween(ctx context.Context, from, to time.Time) ([]models.Session, error) {
+	return r.sessions.find(func(s *models.Session) bool {
+		return s.StartTime.After(from) && !s.StartTime.After(to)
+	})
+}
+
+func (r *SessionRepository) GetMovieIDsBetween(ctx context.Context, from, to time.Time, hallIDs []primitive.ObjectID) ([]primitive.ObjectID, error) {
+	sessions, err := r.sessions.find(func(s *models.Session) bool {
+		if s.StartTime.Before(from) || !s.StartTime.Before(to) {
+			return false
+		}
+		if hallIDs == nil {
+			return true
+		}
+		for _, id := range hallIDs {
+			if s.HallID == id {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	return distinctMovieIDs(sessions), nil
+}
+
+func distinctMovieIDs(sessions []models.Session) []primitive.ObjectID {
+	seen := map[primitive.ObjectID]bool{}
+	ids := []primitive.ObjectID{}
+	for _, s := range sessions {
+		if !seen[s.MovieID] {
+			seen[s.MovieID] = true
+			ids = append(ids, s.MovieID)
+		}
+	}
+	return ids
+}
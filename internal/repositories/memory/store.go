@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"cinema-system/internal/repositories"
+	"context"
+	"sync"
+)
+
+type Store struct {
+	Users           *UserRepository
+	Movies          *MovieRepository
+	Genres          *GenreRepository
+	MovieGenres     *MovieGenreRepository
+	Halls           *HallRepository
+	Sessions        *SessionRepository
+	Tickets         *TicketRepository
+	Payments        *PaymentRepository
+	PaymentCards    *PaymentCardRepository
+	Reviews         *ReviewRepository
+	ReviewReports   *ReviewReportRepository
+	ReviewVotes     *ReviewVoteRepository
+	Notifications   *NotificationRepository
+	Outbox          *OutboxRepository
+	ProcessedEvents *ProcessedEventRepository
+	ExchangeRates   *ExchangeRateRepository
+	Recommendations *RecommendationRepository
+	Analytics       *AnalyticsRepository
+	Audit           *AuditRepository
+	Transactor      *Transactor
+}
+
+func NewStore() *Store {
+	s := &Store{
+		Users:           NewUserRepository(),
+		Movies:          NewMovieRepository(),
+		Genres:          NewGenreRepository(),
+		MovieGenres:     NewMovieGenreRepository(),
+		Halls:           NewHallRepository(),
+		Sessions:        NewSessionRepository(),
+		Tickets:         NewTicketRepository(),
+		Payments:        NewPaymentRepository(),
+		PaymentCards:    NewPaymentCardRepository(),
+		Reviews:         NewReviewRepository(),
+		ReviewReports:   NewReviewReportRepository(),
+		ReviewVotes:     NewReviewVoteRepository(),
+		Notifications:   NewNotificationRepository(),
+		Outbox:          NewOutboxRepository(),
+		ProcessedEvents: NewProcessedEventRepository(),
+		ExchangeRates:   NewExchangeRateRepository(),
+		Recommendations: NewRecommendationRepository(),
+		Audit:           NewAuditRepository(),
+	}
+	s.Analytics = NewAnalyticsRepository(s.Tickets, s.Sessions, s.Halls, s.Movies)
+	s.Transactor = NewTransactor(
+		s.Users.users,
+		s.Movies.movies,
+		s.Genres.genres,
+		s.MovieGenres.movieGenres,
+		s.Halls.halls,
+		s.Sessions.sessions,
+		s.Tickets.tickets,
+		s.Payments.payments,
+		s.PaymentCards.cards,
+		s.Reviews.reviews,
+		s.ReviewReports.reports,
+		s.ReviewVotes.votes,
+		s.Notifications.notifications,
+		s.Outbox.events,
+		s.ProcessedEvents.events,
+		s.ExchangeRates.rates,
+		s.Recommendations.recommendations,
+		s.Audit.entries,
+		s.Audit.counters,
+	)
+	return s
+}
+
+type snapshotter interface {
+	snapshot() func()
+}
+
+type Transactor struct {
+	mu          sync.Mutex
+	collections []snapshotter
+}
+
+func NewTransactor(collections ...snapshotter) *Transactor {
+	return &Transactor{collections: collections}
+}
+
+func (t *Transactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	restore := make([]func(), len(t.collections))
+	for i, c := range t.collections {
+		restore[i] = c.snapshot()
+	}
+
+	if err := fn(ctx); err != nil {
+		for _, r := range restore {
+			r()
+		}
+		return err
+	}
+	return nil
+}
+
+var (
+	_ repositories.TransactionRunner   = (*Transactor)(nil)
+	_ repositories.AnalyticsStore      = (*AnalyticsRepository)(nil)
+	_ repositories.AuditStore          = (*AuditRepository)(nil)
+	_ repositories.UserStore           = (*UserRepository)(nil)
+	_ repositories.MovieStore          = (*MovieRepository)(nil)
+	_ repositories.GenreStore          = (*GenreRepository)(nil)
+	_ repositories.MovieGenreStore     = (*MovieGenreRepository)(nil)
+	_ repositories.HallStore           = (*HallRepository)(nil)
+	_ repositories.SessionStore        = (*SessionRepository)(nil)
+	_ repositories.TicketStore         = (*TicketRepository)(nil)
+	_ repositories.PaymentStore        = (*PaymentRepository)(nil)
+	_ repositories.PaymentCardStore    = (*PaymentCardRepository)(nil)
+	_ repositories.ReviewStore         = (*ReviewRepository)(nil)
+	_ repositories.ReviewReportStore   = (*ReviewReportRepository)(nil)
+	_ repositories.ReviewVoteStore     = (*ReviewVoteRepository)(nil)
+	_ repositories.NotificationStore   = (*NotificationRepository)(nil)
+	_ repositories.OutboxStore         = (*OutboxRepository)(nil)
+	_ repositories.ProcessedEventStore = (*ProcessedEventRepository)(nil)
+	_ repositories.ExchangeRateStore   = (*ExchangeRateRepository)(nil)
+	_ repositories.RecommendationStore = (*RecommendationRepository)(nil)
+)
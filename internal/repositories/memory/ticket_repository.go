@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type TicketRepository struct {
+	tickets *collection[models.Ticket]
+}
+
+func NewTicketRepository() *TicketRepository {
+	return &TicketRepository{
+		tickets: newCollection(func(t *models.Ticket) *primitive.ObjectID { return &t.ID }),
+	}
+}
+
+func (r *TicketRepository) Create(ctx context.Context, ticket *models.Ticket) error {
+	if ticket.Status != models.TicketCancelled {
+		available, err := r.CheckSeatAvailability(ctx, ticket.SessionID, ticket.RowNumber, ticket.SeatNumber)
+		if err != nil {
+			return err
+		}
+		if !available {
+			return mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: duplicateKeyCode, Message: "duplicate key error"}}}
+		}
+	}
+	return r.tickets.insert(ticket)
+}
+
+func (r *TicketRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Ticket, error) {
+	return r.tickets.get(id)
+}
+
+func (r *TicketRepository) GetByPayment(ctx context.Context, paymentID primitive.ObjectID) (*models.Ticket, error) {
+	return r.tickets.findOne(func(t *models.Ticket) bool { return t.PaymentID == paymentID })
+}
+
+func (r *TicketRepository) GetByPaymentIDs(ctx context.Context, paymentIDs []primitive.ObjectID) ([]models.Ticket, error) {
+	ids := make(map[primitive.ObjectID]bool, len(paymentIDs))
+	for _, id := range paymentIDs {
+		ids[id] = true
+	}
+	return r.tickets.find(func(t *models.Ticket) bool { return ids[t.PaymentID] })
+}
+
+func (r *TicketRepository) GetBySession(ctx context.Context, sessionID primitive.ObjectID) ([]models.Ticket, error) {
+	return r.tickets.find(func(t *models.Ticket) bool {
+		return t.SessionID == sessionID && t.Status != models.TicketCancelled
+	})
+}
+
+func (r *TicketRepository) UpdateStatus(ctx context.Context, ticketID primitive.ObjectID, status models.TicketStatus) error {
+	_, err := r.tickets.set(byID(r.tickets, ticketID), bson.M{"status": status}, 1)
+	return err
+}
+
+func (r *TicketRepository) GetAll(ctx context.Context) ([]models.Ticket, error) {
+	return r.tickets.find(nil)
+}
+
+func (r *TicketRepository) CheckSeatAvailability(ctx context.Context, sessionID primitive.ObjectID, row, seat int) (bool, error) {
+	count, err := r.tickets.count(func(t *models.Ticket) bool {
+		return t.SessionID == sessionID && t.RowNumber == row && t.SeatNumber == seat && t.Status != models.TicketCancelled
+	})
+	return count == 0, err
+}
+
+func (r *TicketRepository) GetByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.Ticket, error) {
+	return r.tickets.find(func(t *models.Ticket) bool { return t.UserID == userID })
+}
+
+func (r *TicketRepository) MarkUsed(ctx context.Context, ticketID primitive.ObjectID, usedAt time.Time) (bool, error) {
+	modified, err := r.tickets.update(func(t *models.Ticket) bool {
+		return t.ID == ticketID && t.Status == models.TicketPaid
+	}, func(t *models.Ticket) {
+		t.Status = models.TicketUsed
+		t.UsedAt = &usedAt
+	}, 1)
+	return modified > 0, err
+}
+
+func (r *TicketRepository) MarkCancelled(ctx context.Context, ticketID primitive.ObjectID) (bool, error) {
+	modified, err := r.tickets.update(func(t *models.Ticket) bool {
+		return t.ID == ticketID && t.Status == models.TicketPaid
+	}, func(t *models.Ticket) {
+		t.Status = models.TicketCancelled
+	}, 1)
+	return modified > 0, err
+}
+
+func (r *TicketRepository) List(ctx context.Context, query *repositories.ListQuery) (*models.Page[models.Ticket], error) {
+	entries, err := r.tickets.entries(nil)
+	if err != nil {
+		return nil, err
+	}
+	return paginate(entries, query)
+}
+
+func (r *TicketRepository) ListByUserID(ctx context.Context, userID primitive.ObjectID, query *repositories.ListQuery) (*models.Page[models.Ticket], error) {
+	entries, err := r.tickets.entries(func(t *models.Ticket) bool { return t.UserID == userID })
+	if err != nil {
+		return nil, err
+	}
+	return paginate(entries, query)
+}
+
+func (r *TicketRepository) Stream(ctx context.Context, query *repositories.ListQuery, fn func(*models.Ticket) error) error {
+	entries, err := r.tickets.entries(nil)
+	if err != nil {
+		return err
+	}
+	return stream(entries, query, fn)
+}
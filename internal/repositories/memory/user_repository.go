@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/money"
+	"cinema-system/internal/repositories"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type UserRepository struct {
+	users *collection[models.User]
+}
+
+func NewUserRepository() *UserRepository {
+	return &UserRepository{
+		users: newCollection(func(u *models.User) *primitive.ObjectID { return &u.ID }),
+	}
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
+	return r.users.insert(user)
+}
+
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	return r.users.findOne(func(u *models.User) bool { return u.Email == email })
+}
+
+func (r *UserRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
+	return r.users.get(id)
+}
+
+func (r *UserRepository) UpdateBalance(ctx context.Context, userID primitive.ObjectID, newBalance money.Money) error {
+	_, err := r.users.set(byID(r.users, userID), bson.M{"balance": newBalance}, 1)
+	return err
+}
+
+func (r *UserRepository) GetAll(ctx context.Context) ([]models.User, error) {
+	return r.users.find(nil)
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
+	_, err := r.users.set(byID(r.users, user.ID), user, 1)
+	return err
+}
+
+func (r *UserRepository) Stream(ctx context.Context, query *repositories.ListQuery, fn func(*models.User) error) error {
+	entries, err := r.users.entries(nil)
+	if err != nil {
+		return err
+	}
+	return stream(entries, query, fn)
+}
@@ -42,6 +42,23 @@ func (r *MovieGenreRepository) GetGenresByMovieID(ctx context.Context, movieID p
 	return movieGenres, nil
 }
 
+func (r *MovieGenreRepository) GetGenresByMovieIDs(ctx context.Context, movieIDs []primitive.ObjectID) ([]models.MovieGenre, error) {
+	if len(movieIDs) == 0 {
+		return []models.MovieGenre{}, nil
+	}
+	cursor, err := r.collection.Find(ctx, bson.M{"movie_id": bson.M{"$in": movieIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var movieGenres []models.MovieGenre
+	if err = cursor.All(ctx, &movieGenres); err != nil {
+		return nil, err
+	}
+	return movieGenres, nil
+}
+
 func (r *MovieGenreRepository) GetMoviesByGenreID(ctx context.Context, genreID primitive.ObjectID) ([]models.MovieGenre, error) {
 	cursor, err := r.collection.Find(ctx, bson.M{"genre_id": genreID})
 	if err != nil {
@@ -3,6 +3,9 @@ package repositories
 import (
 	"cinema-system/internal/models"
 	"context"
+	"fmt"
+	"strconv"
+
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -37,6 +40,23 @@ func (r *MovieRepository) FindByID(ctx context.Context, id primitive.ObjectID) (
 	return &movie, nil
 }
 
+func (r *MovieRepository) FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Movie, error) {
+	if len(ids) == 0 {
+		return []models.Movie{}, nil
+	}
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var movies []models.Movie
+	if err = cursor.All(ctx, &movies); err != nil {
+		return nil, err
+	}
+	return movies, nil
+}
+
 func (r *MovieRepository) GetAll(ctx context.Context) ([]models.Movie, error) {
 	findOptions := options.Find().SetSort(bson.D{{Key: "rating", Value: -1}})
 	cursor, err := r.collection.Find(ctx, bson.M{}, findOptions)
@@ -53,10 +73,23 @@ func (r *MovieRepository) GetAll(ctx context.Context) ([]models.Movie, error) {
 }
 
 func (r *MovieRepository) Update(ctx context.Context, id primitive.ObjectID, movie *models.Movie) error {
-	_, err := r.collection.UpdateOne(
+	raw, err := bson.Marshal(movie)
+	if err != nil {
+		return err
+	}
+	var fields bson.M
+	if err := bson.Unmarshal(raw, &fields); err != nil {
+		return err
+	}
+	delete(fields, "popularity")
+	delete(fields, "score")
+	delete(fields, "rating")
+	delete(fields, "rating_stats")
+
+	_, err = r.collection.UpdateOne(
 		ctx,
 		bson.M{"_id": id},
-		bson.M{"$set": movie},
+		bson.M{"$set": fields},
 	)
 	return err
 }
@@ -66,11 +99,158 @@ func (r *MovieRepository) Delete(ctx context.Context, id primitive.ObjectID) err
 	return err
 }
 
-func (r *MovieRepository) UpdateRating(ctx context.Context, movieID primitive.ObjectID, newRating float64) error {
+func (r *MovieRepository) SetTranslation(ctx context.Context, id primitive.ObjectID, lang string, translation models.MovieTranslation) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"translations." + lang: translation}},
+	)
+	return err
+}
+
+func (r *MovieRepository) DeleteTranslation(ctx context.Context, id primitive.ObjectID, lang string) error {
 	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$unset": bson.M{"translations." + lang: ""}},
+	)
+	return err
+}
+
+func (r *MovieRepository) IncrementRatingStats(ctx context.Context, movieID primitive.ObjectID, delta *models.RatingStats, prior models.RatingPrior) error {
+	counters := bson.M{
+		"rating_stats.count":  incrementExpr("$rating_stats.count", delta.Count),
+		"rating_stats.sum":    incrementExpr("$rating_stats.sum", delta.Sum),
+		"rating_stats.weight": incrementExpr("$rating_stats.weight", delta.Weight),
+	}
+	for score := models.MinReviewRating; score <= models.MaxReviewRating; score++ {
+		key := strconv.Itoa(score)
+		counters["rating_stats.histogram."+key] = incrementExpr("$rating_stats.histogram."+key, delta.Histogram[key])
+	}
+
+	hasWeight := bson.M{"$gt": bson.A{"$rating_stats.weight", 0}}
+	derived := bson.M{
+		"rating_stats.average": bson.M{"$cond": bson.A{
+			hasWeight,
+			bson.M{"$divide": bson.A{"$rating_stats.sum", "$rating_stats.weight"}},
+			0,
+		}},
+		"rating_stats.bayesian": bson.M{"$cond": bson.A{
+			hasWeight,
+			bson.M{"$divide": bson.A{
+				bson.M{"$add": bson.A{prior.Mean * prior.Weight, "$rating_stats.sum"}},
+				bson.M{"$add": bson.A{prior.Weight, "$rating_stats.weight"}},
+			}},
+			0,
+		}},
+	}
+	rating := "$rating_stats.average"
+	if prior.Bayesian {
+		rating = "$rating_stats.bayesian"
+	}
+
+	result, err := r.collection.UpdateOne(
 		ctx,
 		bson.M{"_id": movieID},
-		bson.M{"$set": bson.M{"rating": newRating}},
+		mongo.Pipeline{
+			{{Key: "$set", Value: counters}},
+			{{Key: "$set", Value: derived}},
+			{{Key: "$set", Value: bson.M{"rating": rating}}},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func incrementExpr(field string, delta any) bson.M {
+	return bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{field, 0}}, delta}}
+}
+
+func (r *MovieRepository) SetRatingStats(ctx context.Context, movieID primitive.ObjectID, stats *models.RatingStats, rating float64) error {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": movieID},
+		bson.M{"$set": bson.M{"rating_stats": stats, "rating": rating}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *MovieRepository) IncrementPopularity(ctx context.Context, movieID primitive.ObjectID, delta int) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": movieID},
+		bson.M{"$inc": bson.M{"popularity": delta}},
 	)
 	return err
 }
+
+type MovieFilter struct {
+	Search     string
+	GenreIDs   []primitive.ObjectID
+	AgeRating  string
+	MinRating  *float64
+	ComingSoon *bool
+	MovieIDs   []primitive.ObjectID
+	Sort       string
+	Desc       bool
+	Limit      int64
+	Cursor     string
+}
+
+var movieSortFields = map[string]string{
+	models.MovieSortRelevance:  textScoreField,
+	models.MovieSortRating:     "rating",
+	models.MovieSortRelease:    "release_date",
+	models.MovieSortName:       "name",
+	models.MovieSortPopularity: "popularity",
+}
+
+func (r *MovieRepository) Search(ctx context.Context, filter MovieFilter) (*models.Page[models.Movie], error) {
+	field, ok := movieSortFields[filter.Sort]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown sort %q", ErrInvalidQuery, filter.Sort)
+	}
+
+	conditions := bson.M{}
+	if filter.Search != "" {
+		conditions["$text"] = bson.M{"$search": filter.Search}
+	}
+	if len(filter.GenreIDs) > 0 {
+		conditions["genres"] = bson.M{"$in": filter.GenreIDs}
+	}
+	if filter.AgeRating != "" {
+		conditions["age_rating"] = filter.AgeRating
+	}
+	if filter.MinRating != nil {
+		conditions["rating"] = bson.M{"$gte": *filter.MinRating}
+	}
+	if filter.ComingSoon != nil {
+		conditions["is_coming_soon"] = *filter.ComingSoon
+	}
+	if filter.MovieIDs != nil {
+		conditions["_id"] = bson.M{"$in": filter.MovieIDs}
+	}
+
+	sort := filter.Sort
+	if filter.Desc {
+		sort = "-" + sort
+	}
+	return findPage[models.Movie](ctx, r.collection, conditions, &ListQuery{
+		Sort:      sort,
+		SortField: field,
+		Desc:      filter.Desc,
+		Limit:     filter.Limit,
+		Cursor:    filter.Cursor,
+	})
+}
@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"cinema-system/internal/models"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type NotificationPreferenceRepository struct {
+	collection *mongo.Collection
+}
+
+func NewNotificationPreferenceRepository(db *mongo.Database) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{
+		collection: db.Collection("notification_preferences"),
+	}
+}
+
+func (r *NotificationPreferenceRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID) (*models.NotificationPreferences, error) {
+	var prefs models.NotificationPreferences
+	err := r.collection.FindOne(ctx, bson.M{"user_id": userID}).Decode(&prefs)
+	if err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+func (r *NotificationPreferenceRepository) Upsert(ctx context.Context, prefs *models.NotificationPreferences) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"user_id": prefs.UserID},
+		bson.M{"$set": prefs},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
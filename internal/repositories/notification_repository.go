@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"cinema-system/internal/models"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type NotificationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewNotificationRepository(db *mongo.Database) *NotificationRepository {
+	return &NotificationRepository{
+		collection: db.Collection("notifications"),
+	}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	result, err := r.collection.InsertOne(ctx, notification)
+	if err != nil {
+		return err
+	}
+	notification.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *NotificationRepository) CreateIfAbsent(ctx context.Context, notification *models.Notification) (bool, error) {
+	if notification.DedupKey == "" {
+		return true, r.Create(ctx, notification)
+	}
+
+	notification.ID = primitive.NewObjectID()
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"dedup_key": notification.DedupKey},
+		bson.M{"$setOnInsert": notification},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return false, err
+	}
+	if result.UpsertedCount == 0 {
+		notification.ID = primitive.NilObjectID
+		return false, nil
+	}
+	return true, nil
+}
+
+func (r *NotificationRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Notification, error) {
+	var notification models.Notification
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&notification)
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+func (r *NotificationRepository) FindDue(ctx context.Context, now time.Time, limit int64) ([]models.Notification, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"status":          models.NotificationPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var notifications []models.Notification
+	if err = cursor.All(ctx, &notifications); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *NotificationRepository) FindByUserID(ctx context.Context, userID primitive.ObjectID) ([]models.Notification, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var notifications []models.Notification
+	if err = cursor.All(ctx, &notifications); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *NotificationRepository) FindByStatus(ctx context.Context, status models.NotificationStatus) ([]models.Notification, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"status": status}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var notifications []models.Notification
+	if err = cursor.All(ctx, &notifications); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *NotificationRepository) MarkSent(ctx context.Context, id primitive.ObjectID, attempts int, sentAt time.Time) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$set":   bson.M{"status": models.NotificationSent, "attempts": attempts, "sent_at": sentAt},
+			"$unset": bson.M{"last_error": ""},
+		},
+	)
+	return err
+}
+
+func (r *NotificationRepository) MarkAttemptFailed(ctx context.Context, id primitive.ObjectID, attempts int, lastError string, status models.NotificationStatus, nextAttemptAt time.Time) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"status":          status,
+			"attempts":        attempts,
+			"last_error":      lastError,
+			"next_attempt_at": nextAttemptAt,
+		}},
+	)
+	return err
+}
+
+func (r *NotificationRepository) Requeue(ctx context.Context, id primitive.ObjectID, now time.Time) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "status": models.NotificationFailed},
+		bson.M{"$set": bson.M{
+			"status":          models.NotificationPending,
+			"attempts":        0,
+			"next_attempt_at": now,
+		}},
+	)
+	return err
+}
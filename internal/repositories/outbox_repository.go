@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"cinema-system/internal/models"
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OutboxRepository struct {
+	collection *mongo.Collection
+}
+
+func NewOutboxRepository(db *mongo.Database) *OutboxRepository {
+	return &OutboxRepository{
+		collection: db.Collection("outbox"),
+	}
+}
+
+func (r *OutboxRepository) Add(ctx context.Context, event *models.OutboxEvent) error {
+	result, err := r.collection.InsertOne(ctx, event)
+	if err != nil {
+		return err
+	}
+	event.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *OutboxRepository) ClaimNext(ctx context.Context, now time.Time, lease time.Duration) (*models.OutboxEvent, error) {
+	var event models.OutboxEvent
+	err := r.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{
+			"status":          models.OutboxPending,
+			"next_attempt_at": bson.M{"$lte": now},
+		},
+		bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}},
+		options.FindOneAndUpdate().
+			SetSort(bson.D{{Key: "created_at", Value: 1}}).
+			SetReturnDocument(options.After),
+	).Decode(&event)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *OutboxRepository) MarkProcessed(ctx context.Context, id primitive.ObjectID, attempts int, processedAt time.Time) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$set":   bson.M{"status": models.OutboxProcessed, "attempts": attempts, "processed_at": processedAt},
+			"$unset": bson.M{"last_error": ""},
+		},
+	)
+	return err
+}
+
+func (r *OutboxRepository) MarkAttemptFailed(ctx context.Context, id primitive.ObjectID, attempts int, lastError string, status models.OutboxStatus, nextAttemptAt time.Time) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"status":          status,
+			"attempts":        attempts,
+			"last_error":      lastError,
+			"next_attempt_at": nextAttemptAt,
+		}},
+	)
+	return err
+}
@@ -81,4 +81,30 @@ func (r *PaymentRepository) GetAll(ctx context.Context) ([]models.Payment, error
 		return nil, err
 	}
 	return payments, nil
-}
\ No newline at end of file
+}
+
+var PaymentListSpec = ListSpec{
+	Filters: map[string]FilterField{
+		"status":           {Field: "status", Kind: FilterString},
+		"user_id":          {Field: "user_id", Kind: FilterObjectID},
+		"payment_card_id":  {Field: "payment_card_id", Kind: FilterObjectID},
+		"transaction_code": {Field: "transaction_code", Kind: FilterString},
+		"amount":           {Field: "amount.amount", Kind: FilterInt},
+		"currency":         {Field: "amount.currency", Kind: FilterString},
+		"created_at":       {Field: "created_at", Kind: FilterTime},
+	},
+	Sorts: map[string]string{
+		"created_at": "created_at",
+		"amount":     "amount.amount",
+		"status":     "status",
+	},
+	DefaultSort: "-created_at",
+}
+
+func (r *PaymentRepository) List(ctx context.Context, query *ListQuery) (*models.Page[models.Payment], error) {
+	return findPage[models.Payment](ctx, r.collection, bson.M{}, query)
+}
+
+func (r *PaymentRepository) Stream(ctx context.Context, query *ListQuery, fn func(*models.Payment) error) error {
+	return streamAll(ctx, r.collection, bson.M{}, query, fn)
+}
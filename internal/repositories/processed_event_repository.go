@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ErrAlreadyProcessed = errors.New("event already processed by handler")
+
+type ProcessedEventRepository struct {
+	collection *mongo.Collection
+}
+
+func NewProcessedEventRepository(db *mongo.Database) *ProcessedEventRepository {
+	return &ProcessedEventRepository{
+		collection: db.Collection("processed_events"),
+	}
+}
+
+func (r *ProcessedEventRepository) Exists(ctx context.Context, eventID primitive.ObjectID, handler string) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"event_id": eventID, "handler": handler})
+	return count > 0, err
+}
+
+func (r *ProcessedEventRepository) Record(ctx context.Context, eventID primitive.ObjectID, handler string) error {
+	_, err := r.collection.InsertOne(ctx, bson.M{
+		"event_id":     eventID,
+		"handler":      handler,
+		"processed_at": time.Now(),
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrAlreadyProcessed
+	}
+	return err
+}
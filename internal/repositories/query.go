@@ -0,0 +1,295 @@
+package repositories
+
+import (
+	"cinema-system/internal/apperrors"
+	"cinema-system/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrInvalidQuery = apperrors.Validation("invalid_query", "invalid query")
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
+	textScoreField = "score"
+)
+
+type FilterKind int
+
+const (
+	FilterString FilterKind = iota
+	FilterObjectID
+	FilterInt
+	FilterFloat
+	FilterTime
+	FilterBool
+)
+
+type FilterField struct {
+	Field string
+	Kind  FilterKind
+}
+
+type ListSpec struct {
+	Filters     map[string]FilterField
+	Sorts       map[string]string
+	DefaultSort string
+}
+
+type ListQuery struct {
+	Filter    bson.M
+	Sort      string
+	SortField string
+	Desc      bool
+	Limit     int64
+	Page      int64
+	Cursor    string
+}
+
+func ParseListQuery(values url.Values, spec ListSpec, location *time.Location) (*ListQuery, error) {
+	query := &ListQuery{Filter: bson.M{}, Limit: DefaultPageSize}
+
+	if raw := values.Get("limit"); raw != "" {
+		limit, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || limit < 1 {
+			return nil, fmt.Errorf("%w: limit must be a positive integer", ErrInvalidQuery)
+		}
+		if limit > MaxPageSize {
+			limit = MaxPageSize
+		}
+		query.Limit = limit
+	}
+
+	query.Cursor = values.Get("cursor")
+	if raw := values.Get("page"); raw != "" {
+		if query.Cursor != "" {
+			return nil, fmt.Errorf("%w: use either page or cursor", ErrInvalidQuery)
+		}
+		page, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || page < 1 {
+			return nil, fmt.Errorf("%w: page must be a positive integer", ErrInvalidQuery)
+		}
+		query.Page = page
+	}
+
+	query.Sort = values.Get("sort")
+	if query.Sort == "" {
+		query.Sort = spec.DefaultSort
+	}
+	key := strings.TrimPrefix(query.Sort, "-")
+	field, ok := spec.Sorts[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown sort %q", ErrInvalidQuery, key)
+	}
+	query.SortField = field
+	query.Desc = strings.HasPrefix(query.Sort, "-")
+
+	for param, filter := range spec.Filters {
+		conditions := bson.M{}
+
+		if raw := values.Get(param); raw != "" {
+			parts := strings.Split(raw, ",")
+			parsed := make(bson.A, 0, len(parts))
+			for _, part := range parts {
+				value, err := parseFilterValue(filter.Kind, strings.TrimSpace(part), false, location)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %s: %v", ErrInvalidQuery, param, err)
+				}
+				parsed = append(parsed, value)
+			}
+			if len(parsed) == 1 {
+				conditions["$eq"] = parsed[0]
+			} else {
+				conditions["$in"] = parsed
+			}
+		}
+
+		if filter.Kind == FilterInt || filter.Kind == FilterFloat || filter.Kind == FilterTime {
+			for suffix, op := range map[string]string{"_from": "$gte", "_to": "$lte"} {
+				raw := values.Get(param + suffix)
+				if raw == "" {
+					continue
+				}
+				value, err := parseFilterValue(filter.Kind, raw, suffix == "_to", location)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %s%s: %v", ErrInvalidQuery, param, suffix, err)
+				}
+				if filter.Kind == FilterTime && suffix == "_to" && isDate(raw) {
+					op = "$lt"
+				}
+				conditions[op] = value
+			}
+		}
+
+		if len(conditions) > 0 {
+			query.Filter[filter.Field] = conditions
+		}
+	}
+
+	return query, nil
+}
+
+func isDate(raw string) bool {
+	_, err := time.Parse("2006-01-02", raw)
+	return err == nil
+}
+
+func parseFilterValue(kind FilterKind, raw string, upperBound bool, location *time.Location) (interface{}, error) {
+	switch kind {
+	case FilterObjectID:
+		return primitive.ObjectIDFromHex(raw)
+	case FilterInt:
+		return strconv.Atoi(raw)
+	case FilterFloat:
+		return strconv.ParseFloat(raw, 64)
+	case FilterBool:
+		return strconv.ParseBool(raw)
+	case FilterTime:
+		if day, err := time.ParseInLocation("2006-01-02", raw, location); err == nil {
+			if upperBound {
+				return day.AddDate(0, 0, 1), nil
+			}
+			return day, nil
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, errors.New("expected YYYY-MM-DD or RFC 3339 time")
+		}
+		return t, nil
+	default:
+		return raw, nil
+	}
+}
+
+func mergeFilters(filters ...bson.M) bson.M {
+	conditions := bson.A{}
+	for _, f := range filters {
+		if len(f) > 0 {
+			conditions = append(conditions, f)
+		}
+	}
+	switch len(conditions) {
+	case 0:
+		return bson.M{}
+	case 1:
+		return conditions[0].(bson.M)
+	default:
+		return bson.M{"$and": conditions}
+	}
+}
+
+func findPage[T any](ctx context.Context, collection *mongo.Collection, base bson.M, query *ListQuery) (*models.Page[T], error) {
+	cursor, err := decodeCursor(query.Cursor, query.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := mergeFilters(base, query.Filter)
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.Page[T]{Items: []T{}, Total: total, Limit: query.Limit, Page: query.Page}
+	findOptions := options.Find().SetLimit(query.Limit + 1)
+
+	relevance := query.SortField == textScoreField
+	if relevance {
+		score := bson.M{"$meta": "textScore"}
+		findOptions.SetProjection(bson.M{textScoreField: score})
+		findOptions.SetSort(bson.D{{Key: textScoreField, Value: score}, {Key: "_id", Value: 1}})
+	} else {
+		direction := sortDirection(query.Desc)
+		findOptions.SetSort(bson.D{{Key: query.SortField, Value: direction}, {Key: "_id", Value: direction}})
+	}
+
+	var offset int64
+	switch {
+	case query.Page > 0:
+		offset = (query.Page - 1) * query.Limit
+	case cursor != nil && relevance:
+		offset = cursor.Offset
+	case cursor != nil:
+		value, id, err := cursor.keyset()
+		if err != nil {
+			return nil, err
+		}
+		filter = mergeFilters(filter, keysetFilter(query.SortField, value, id, query.Desc))
+	}
+	if offset > 0 {
+		findOptions.SetSkip(offset)
+	}
+
+	result, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close(ctx)
+
+	var docs []bson.Raw
+	if err = result.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	hasMore := int64(len(docs)) > query.Limit
+	if hasMore {
+		docs = docs[:query.Limit]
+	}
+
+	page.Items = make([]T, len(docs))
+	for i, doc := range docs {
+		if err := bson.Unmarshal(doc, &page.Items[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	if !hasMore || query.Page > 0 {
+		return page, nil
+	}
+
+	if relevance {
+		page.NextCursor = encodeCursor(pageCursor{Sort: query.Sort, Offset: offset + query.Limit})
+		return page, nil
+	}
+
+	next, err := keysetCursor(query.Sort, docs[len(docs)-1], query.SortField)
+	if err != nil {
+		return nil, err
+	}
+	page.NextCursor = encodeCursor(next)
+	return page, nil
+}
+
+func streamAll[T any](ctx context.Context, collection *mongo.Collection, base bson.M, query *ListQuery, fn func(*T) error) error {
+	direction := sortDirection(query.Desc)
+	findOptions := options.Find().SetSort(bson.D{{Key: query.SortField, Value: direction}, {Key: "_id", Value: direction}})
+
+	cursor, err := collection.Find(ctx, mergeFilters(base, query.Filter), findOptions)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var item T
+		if err := cursor.Decode(&item); err != nil {
+			return err
+		}
+		if err := fn(&item); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"cinema-system/internal/models"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RecommendationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRecommendationRepository(db *mongo.Database) *RecommendationRepository {
+	return &RecommendationRepository{
+		collection: db.Collection("recommendations"),
+	}
+}
+
+func (r *RecommendationRepository) Replace(ctx context.Context, rec *models.Recommendation) error {
+	err := r.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"kind": rec.Kind, "subject_id": rec.SubjectID},
+		bson.M{"$set": bson.M{"items": rec.Items, "computed_at": rec.ComputedAt}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(rec)
+	return err
+}
+
+func (r *RecommendationRepository) Find(ctx context.Context, kind models.RecommendationKind, subjectID primitive.ObjectID) (*models.Recommendation, error) {
+	var rec models.Recommendation
+	err := r.collection.FindOne(ctx, bson.M{"kind": kind, "subject_id": subjectID}).Decode(&rec)
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+func (r *RecommendationRepository) DeleteComputedBefore(ctx context.Context, before time.Time) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"computed_at": bson.M{"$lt": before}})
+	return err
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"cinema-system/internal/models"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReviewReportRepository struct {
+	collection *mongo.Collection
+}
+
+func NewReviewReportRepository(db *mongo.Database) *ReviewReportRepository {
+	return &ReviewReportRepository{
+		collection: db.Collection("review_reports"),
+	}
+}
+
+func (r *ReviewReportRepository) Create(ctx context.Context, report *models.ReviewReport) error {
+	result, err := r.collection.InsertOne(ctx, report)
+	if err != nil {
+		return err
+	}
+	report.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *ReviewReportRepository) ListByReview(ctx context.Context, reviewID primitive.ObjectID) ([]models.ReviewReport, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"review_id": reviewID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	reports := []models.ReviewReport{}
+	if err = cursor.All(ctx, &reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (r *ReviewReportRepository) ResolveByReview(ctx context.Context, reviewID primitive.ObjectID, at time.Time) error {
+	_, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{"review_id": reviewID, "resolved": false},
+		bson.M{"$set": bson.M{"resolved": true, "resolved_at": at}},
+	)
+	return err
+}
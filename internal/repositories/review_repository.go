@@ -3,10 +3,13 @@ package repositories
 import (
 	"cinema-system/internal/models"
 	"context"
+	"strconv"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type ReviewRepository struct {
@@ -28,6 +31,20 @@ func (r *ReviewRepository) Create(ctx context.Context, review *models.Review) er
 	return nil
 }
 
+func (r *ReviewRepository) GetAll(ctx context.Context) ([]models.Review, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reviews []models.Review
+	if err = cursor.All(ctx, &reviews); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
 func (r *ReviewRepository) GetByMovie(ctx context.Context, movieID primitive.ObjectID) ([]models.Review, error) {
 	cursor, err := r.collection.Find(ctx, bson.M{"movie_id": movieID})
 	if err != nil {
@@ -58,10 +75,11 @@ func (r *ReviewRepository) Delete(ctx context.Context, id primitive.ObjectID) er
 
 func (r *ReviewRepository) GetAverageRating(ctx context.Context, movieID primitive.ObjectID) (float64, error) {
 	pipeline := mongo.Pipeline{
-		{{Key: "$match", Value: bson.M{"movie_id": movieID}}},
+		{{Key: "$match", Value: bson.M{"movie_id": movieID, "status": models.ReviewPublished}}},
 		{{Key: "$group", Value: bson.M{
-			"_id":       nil,
-			"avgRating": bson.M{"$avg": "$rating"},
+			"_id":         nil,
+			"weightedSum": bson.M{"$sum": bson.M{"$multiply": bson.A{"$rating", bson.M{"$ifNull": bson.A{"$weight", 1}}}}},
+			"totalWeight": bson.M{"$sum": bson.M{"$ifNull": bson.A{"$weight", 1}}},
 		}}},
 	}
 
@@ -72,17 +90,53 @@ func (r *ReviewRepository) GetAverageRating(ctx context.Context, movieID primiti
 	defer cursor.Close(ctx)
 
 	var result []struct {
-		AvgRating float64 `bson:"avgRating"`
+		WeightedSum float64 `bson:"weightedSum"`
+		TotalWeight float64 `bson:"totalWeight"`
 	}
 	if err = cursor.All(ctx, &result); err != nil {
 		return 0, err
 	}
 
-	if len(result) == 0 {
+	if len(result) == 0 || result[0].TotalWeight == 0 {
 		return 0, nil
 	}
 
-	return result[0].AvgRating, nil
+	return result[0].WeightedSum / result[0].TotalWeight, nil
+}
+
+func (r *ReviewRepository) GetRatingStats(ctx context.Context, movieID primitive.ObjectID) (*models.RatingStats, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"movie_id": movieID, "status": models.ReviewPublished}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":    "$rating",
+			"count":  bson.M{"$sum": 1},
+			"weight": bson.M{"$sum": bson.M{"$ifNull": bson.A{"$weight", 1}}},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Rating int     `bson:"_id"`
+		Count  int     `bson:"count"`
+		Weight float64 `bson:"weight"`
+	}
+	if err = cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+
+	stats := &models.RatingStats{Histogram: make(map[string]int)}
+	for _, bucket := range result {
+		stats.Count += bucket.Count
+		stats.Sum += float64(bucket.Rating) * bucket.Weight
+		stats.Weight += bucket.Weight
+		stats.Histogram[strconv.Itoa(bucket.Rating)] += bucket.Count
+	}
+	return stats, nil
 }
 
 func (r *ReviewRepository) GetByUser(ctx context.Context, userID primitive.ObjectID) ([]models.Review, error) {
@@ -135,3 +189,104 @@ func (r *ReviewRepository) UpdateReviewerName(ctx context.Context, userID primit
 	)
 	return err
 }
+
+func (r *ReviewRepository) SetStatus(ctx context.Context, id primitive.ObjectID, status models.ReviewStatus, reason string, moderatorID *primitive.ObjectID, at time.Time) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"status":            status,
+			"moderation_reason": reason,
+			"moderated_by":      moderatorID,
+			"moderated_at":      at,
+		}},
+	)
+	return err
+}
+
+func (r *ReviewRepository) IncrementReportCount(ctx context.Context, id primitive.ObjectID) (*models.Review, error) {
+	var review models.Review
+	err := r.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$inc": bson.M{"report_count": 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&review)
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *ReviewRepository) ResetReportCount(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"report_count": 0}})
+	return err
+}
+
+func (r *ReviewRepository) IncrementVotes(ctx context.Context, id primitive.ObjectID, helpful, unhelpful int) error {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$inc": bson.M{"helpful_count": helpful, "unhelpful_count": unhelpful}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *ReviewRepository) SetReply(ctx context.Context, id primitive.ObjectID, reply *models.ReviewReply) error {
+	update := bson.M{"$set": bson.M{"reply": reply}}
+	if reply == nil {
+		update = bson.M{"$unset": bson.M{"reply": ""}}
+	}
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+var ReviewListSpec = ListSpec{
+	Filters: map[string]FilterField{
+		"user_id":     {Field: "user_id", Kind: FilterObjectID},
+		"rating":      {Field: "rating", Kind: FilterInt},
+		"created_at":  {Field: "created_at", Kind: FilterTime},
+		"has_comment": {Field: "has_comment", Kind: FilterBool},
+	},
+	Sorts: map[string]string{
+		"created_at": "created_at",
+		"rating":     "rating",
+		"helpful":    "helpful_count",
+	},
+	DefaultSort: "-created_at",
+}
+
+var ReviewModerationListSpec = ListSpec{
+	Filters: map[string]FilterField{
+		"status":       {Field: "status", Kind: FilterString},
+		"movie_id":     {Field: "movie_id", Kind: FilterObjectID},
+		"user_id":      {Field: "user_id", Kind: FilterObjectID},
+		"report_count": {Field: "report_count", Kind: FilterInt},
+		"created_at":   {Field: "created_at", Kind: FilterTime},
+	},
+	Sorts: map[string]string{
+		"created_at":   "created_at",
+		"report_count": "report_count",
+	},
+	DefaultSort: "created_at",
+}
+
+func (r *ReviewRepository) ListPublishedByMovie(ctx context.Context, movieID primitive.ObjectID, query *ListQuery) (*models.Page[models.Review], error) {
+	return findPage[models.Review](ctx, r.collection, bson.M{"movie_id": movieID, "status": models.ReviewPublished}, query)
+}
+
+func (r *ReviewRepository) List(ctx context.Context, query *ListQuery) (*models.Page[models.Review], error) {
+	return findPage[models.Review](ctx, r.collection, bson.M{}, query)
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"cinema-system/internal/models"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReviewVoteRepository struct {
+	collection *mongo.Collection
+}
+
+func NewReviewVoteRepository(db *mongo.Database) *ReviewVoteRepository {
+	return &ReviewVoteRepository{
+		collection: db.Collection("review_votes"),
+	}
+}
+
+func (r *ReviewVoteRepository) Find(ctx context.Context, reviewID, userID primitive.ObjectID) (*models.ReviewVote, error) {
+	var vote models.ReviewVote
+	err := r.collection.FindOne(ctx, bson.M{"review_id": reviewID, "user_id": userID}).Decode(&vote)
+	if err != nil {
+		return nil, err
+	}
+	return &vote, nil
+}
+
+func (r *ReviewVoteRepository) Upsert(ctx context.Context, vote *models.ReviewVote) error {
+	err := r.collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"review_id": vote.ReviewID, "user_id": vote.UserID},
+		bson.M{
+			"$set":         bson.M{"helpful": vote.Helpful},
+			"$setOnInsert": bson.M{"created_at": vote.CreatedAt},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(vote)
+	return err
+}
+
+func (r *ReviewVoteRepository) Delete(ctx context.Context, reviewID, userID primitive.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"review_id": reviewID, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -38,6 +38,23 @@ func (r *SessionRepository) FindByID(ctx context.Context, id primitive.ObjectID)
 	return &session, nil
 }
 
+func (r *SessionRepository) FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]models.Session, error) {
+	if len(ids) == 0 {
+		return []models.Session{}, nil
+	}
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []models.Session
+	if err = cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
 func (r *SessionRepository) GetByMovie(ctx context.Context, movieID primitive.ObjectID) ([]models.Session, error) {
 	cursor, err := r.collection.Find(ctx, bson.M{
 		"movie_id":   movieID,
@@ -127,3 +144,39 @@ func (r *SessionRepository) GetUpcomingMovieIDs(ctx context.Context) ([]primitiv
 	}
 	return ids, nil
 }
+
+func (r *SessionRepository) GetStartingBetween(ctx context.Context, from, to time.Time) ([]models.Session, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"start_time": bson.M{"$gt": from, "$lte": to},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []models.Session
+	if err = cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (r *SessionRepository) GetMovieIDsBetween(ctx context.Context, from, to time.Time, hallIDs []primitive.ObjectID) ([]primitive.ObjectID, error) {
+	filter := bson.M{"start_time": bson.M{"$gte": from, "$lt": to}}
+	if hallIDs != nil {
+		filter["hall_id"] = bson.M{"$in": hallIDs}
+	}
+
+	values, err := r.collection.Distinct(ctx, "movie_id", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(values))
+	for _, v := range values {
+		if id, ok := v.(primitive.ObjectID); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
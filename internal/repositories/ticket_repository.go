@@ -3,6 +3,7 @@ package repositories
 import (
 	"cinema-system/internal/models"
 	"context"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -129,3 +130,65 @@ func (r *TicketRepository) GetByUserID(ctx context.Context, userID primitive.Obj
 	}
 	return tickets, nil
 }
+
+func (r *TicketRepository) MarkUsed(ctx context.Context, ticketID primitive.ObjectID, usedAt time.Time) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": ticketID, "status": models.TicketPaid},
+		bson.M{"$set": bson.M{"status": models.TicketUsed, "used_at": usedAt}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *TicketRepository) MarkCancelled(ctx context.Context, ticketID primitive.ObjectID) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": ticketID, "status": models.TicketPaid},
+		bson.M{"$set": bson.M{"status": models.TicketCancelled}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+var TicketListSpec = ListSpec{
+	Filters: map[string]FilterField{
+		"status":      {Field: "status", Kind: FilterString},
+		"type":        {Field: "type", Kind: FilterString},
+		"user_id":     {Field: "user_id", Kind: FilterObjectID},
+		"session_id":  {Field: "session_id", Kind: FilterObjectID},
+		"payment_id":  {Field: "payment_id", Kind: FilterObjectID},
+		"movie_title": {Field: "movie_title", Kind: FilterString},
+		"price":       {Field: "price.amount", Kind: FilterInt},
+		"currency":    {Field: "price.currency", Kind: FilterString},
+		"created_at":  {Field: "created_at", Kind: FilterTime},
+	},
+	Sorts: map[string]string{
+		"created_at":  "created_at",
+		"price":       "price.amount",
+		"status":      "status",
+		"movie_title": "movie_title",
+	},
+	DefaultSort: "-created_at",
+}
+
+func (r *TicketRepository) List(ctx context.Context, query *ListQuery) (*models.Page[models.Ticket], error) {
+	return findPage[models.Ticket](ctx, r.collection, bson.M{}, query)
+}
+
+func (r *TicketRepository) ListByUserID(ctx context.Context, userID primitive.ObjectID, query *ListQuery) (*models.Page[models.Ticket], error) {
+	return findPage[models.Ticket](ctx, r.collection, bson.M{
+		"$or": []bson.M{
+			{"user_id": userID},
+			{"user_id": userID.Hex()},
+		},
+	}, query)
+}
+
+func (r *TicketRepository) Stream(ctx context.Context, query *ListQuery, fn func(*models.Ticket) error) error {
+	return streamAll(ctx, r.collection, bson.M{}, query, fn)
+}
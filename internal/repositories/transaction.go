@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const detectTimeout = 5 * time.Second
+
+type Transactor struct {
+	client    *mongo.Client
+	mu        sync.Mutex
+	detected  bool
+	supported bool
+}
+
+func NewTransactor(client *mongo.Client) *Transactor {
+	return &Transactor{client: client}
+}
+
+func (t *Transactor) detect(ctx context.Context) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.detected {
+		return t.supported, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), detectTimeout)
+	defer cancel()
+
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := t.client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return false, fmt.Errorf("detect transaction support: %w", err)
+	}
+	t.detected = true
+	t.supported = hello.SetName != "" || hello.Msg == "isdbgrid"
+	if !t.supported {
+		slog.WarnContext(ctx, "MongoDB deployment does not support transactions, outbox writes are not atomic")
+	}
+	return t.supported, nil
+}
+
+func (t *Transactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	supported, err := t.detect(ctx)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fn(ctx)
+	}
+
+	session, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"cinema-system/internal/models"
+	"cinema-system/internal/money"
 	"context"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -46,7 +47,7 @@ func (r *UserRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*
 	return &user, nil
 }
 
-func (r *UserRepository) UpdateBalance(ctx context.Context, userID primitive.ObjectID, newBalance float64) error {
+func (r *UserRepository) UpdateBalance(ctx context.Context, userID primitive.ObjectID, newBalance money.Money) error {
 	_, err := r.collection.UpdateOne(
 		ctx,
 		bson.M{"_id": userID},
@@ -77,3 +78,20 @@ func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
 	)
 	return err
 }
+
+var UserListSpec = ListSpec{
+	Filters: map[string]FilterField{
+		"role":       {Field: "role", Kind: FilterString},
+		"email":      {Field: "email", Kind: FilterString},
+		"created_at": {Field: "created_at", Kind: FilterTime},
+	},
+	Sorts: map[string]string{
+		"created_at": "created_at",
+		"email":      "email",
+	},
+	DefaultSort: "-created_at",
+}
+
+func (r *UserRepository) Stream(ctx context.Context, query *ListQuery, fn func(*models.User) error) error {
+	return streamAll(ctx, r.collection, bson.M{}, query, fn)
+}
@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"cinema-system/internal/models"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type WalletPassRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWalletPassRepository(db *mongo.Database) *WalletPassRepository {
+	return &WalletPassRepository{
+		collection: db.Collection("wallet_passes"),
+	}
+}
+
+func (r *WalletPassRepository) Create(ctx context.Context, pass *models.WalletPass) error {
+	result, err := r.collection.InsertOne(ctx, pass)
+	if err != nil {
+		return err
+	}
+	pass.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *WalletPassRepository) FindByTicketID(ctx context.Context, ticketID primitive.ObjectID) (*models.WalletPass, error) {
+	var pass models.WalletPass
+	err := r.collection.FindOne(ctx, bson.M{"ticket_id": ticketID}).Decode(&pass)
+	if err != nil {
+		return nil, err
+	}
+	return &pass, nil
+}
+
+func (r *WalletPassRepository) FindBySerial(ctx context.Context, serial string) (*models.WalletPass, error) {
+	var pass models.WalletPass
+	err := r.collection.FindOne(ctx, bson.M{"serial_number": serial}).Decode(&pass)
+	if err != nil {
+		return nil, err
+	}
+	return &pass, nil
+}
+
+func (r *WalletPassRepository) FindUpdatedSince(ctx context.Context, serials []string, since time.Time) ([]models.WalletPass, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"serial_number": bson.M{"$in": serials},
+		"updated_at":    bson.M{"$gt": since},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var passes []models.WalletPass
+	if err = cursor.All(ctx, &passes); err != nil {
+		return nil, err
+	}
+	return passes, nil
+}
+
+func (r *WalletPassRepository) TouchBySession(ctx context.Context, sessionID primitive.ObjectID, updatedAt time.Time) ([]models.WalletPass, error) {
+	_, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{"session_id": sessionID},
+		bson.M{"$set": bson.M{"updated_at": updatedAt}},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"session_id": sessionID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var passes []models.WalletPass
+	if err = cursor.All(ctx, &passes); err != nil {
+		return nil, err
+	}
+	return passes, nil
+}
+
+func (r *WalletPassRepository) Void(ctx context.Context, ticketIDs []primitive.ObjectID, updatedAt time.Time) ([]models.WalletPass, error) {
+	filter := bson.M{"ticket_id": bson.M{"$in": ticketIDs}}
+	_, err := r.collection.UpdateMany(
+		ctx,
+		filter,
+		bson.M{"$set": bson.M{"voided": true, "updated_at": updatedAt}},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var passes []models.WalletPass
+	if err = cursor.All(ctx, &passes); err != nil {
+		return nil, err
+	}
+	return passes, nil
+}
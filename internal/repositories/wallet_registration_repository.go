@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"cinema-system/internal/models"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WalletRegistrationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWalletRegistrationRepository(db *mongo.Database) *WalletRegistrationRepository {
+	return &WalletRegistrationRepository{
+		collection: db.Collection("wallet_registrations"),
+	}
+}
+
+func (r *WalletRegistrationRepository) Register(ctx context.Context, reg *models.WalletRegistration) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"device_id":     reg.DeviceID,
+			"pass_type_id":  reg.PassTypeID,
+			"serial_number": reg.SerialNumber,
+		},
+		bson.M{
+			"$set":         bson.M{"push_token": reg.PushToken},
+			"$setOnInsert": bson.M{"created_at": time.Now()},
+		},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.UpsertedCount > 0, nil
+}
+
+func (r *WalletRegistrationRepository) Unregister(ctx context.Context, deviceID, passTypeID, serial string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{
+		"device_id":     deviceID,
+		"pass_type_id":  passTypeID,
+		"serial_number": serial,
+	})
+	return err
+}
+
+func (r *WalletRegistrationRepository) FindByDevice(ctx context.Context, deviceID, passTypeID string) ([]models.WalletRegistration, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"device_id":    deviceID,
+		"pass_type_id": passTypeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var registrations []models.WalletRegistration
+	if err = cursor.All(ctx, &registrations); err != nil {
+		return nil, err
+	}
+	return registrations, nil
+}
+
+func (r *WalletRegistrationRepository) FindBySerials(ctx context.Context, serials []string) ([]models.WalletRegistration, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"serial_number": bson.M{"$in": serials}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var registrations []models.WalletRegistration
+	if err = cursor.All(ctx, &registrations); err != nil {
+		return nil, err
+	}
+	return registrations, nil
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"cinema-system/internal/models"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookDeliveryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWebhookDeliveryRepository(db *mongo.Database) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		collection: db.Collection("webhook_deliveries"),
+	}
+}
+
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, delivery *models.WebhookDelivery) error {
+	result, err := r.collection.InsertOne(ctx, delivery)
+	if err != nil {
+		return err
+	}
+	delivery.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&delivery)
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookDeliveryRepository) Exists(ctx context.Context, subscriptionID, eventID primitive.ObjectID) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"subscription_id": subscriptionID, "event_id": eventID})
+	return count > 0, err
+}
+
+func (r *WebhookDeliveryRepository) FindBySubscription(ctx context.Context, subscriptionID primitive.ObjectID, limit int64) ([]models.WebhookDelivery, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, bson.M{"subscription_id": subscriptionID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []models.WebhookDelivery
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepository) FindDue(ctx context.Context, now time.Time, limit int64) ([]models.WebhookDelivery, error) {
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"status":          models.WebhookDeliveryPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var deliveries []models.WebhookDelivery
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepository) MarkSucceeded(ctx context.Context, id primitive.ObjectID, attempts, responseCode int, responseBody string, deliveredAt time.Time) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$set": bson.M{
+				"status":        models.WebhookDeliverySucceeded,
+				"attempts":      attempts,
+				"response_code": responseCode,
+				"response_body": responseBody,
+				"delivered_at":  deliveredAt,
+			},
+			"$unset": bson.M{"last_error": ""},
+		},
+	)
+	return err
+}
+
+func (r *WebhookDeliveryRepository) MarkAttemptFailed(ctx context.Context, id primitive.ObjectID, attempts, responseCode int, responseBody, lastError string, status models.WebhookDeliveryStatus, nextAttemptAt time.Time) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"status":          status,
+			"attempts":        attempts,
+			"response_code":   responseCode,
+			"response_body":   responseBody,
+			"last_error":      lastError,
+			"next_attempt_at": nextAttemptAt,
+		}},
+	)
+	return err
+}
+
+func (r *WebhookDeliveryRepository) Requeue(ctx context.Context, id primitive.ObjectID, now time.Time) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"status":          models.WebhookDeliveryPending,
+			"attempts":        0,
+			"next_attempt_at": now,
+		}},
+	)
+	return err
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"cinema-system/internal/models"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookSubscriptionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWebhookSubscriptionRepository(db *mongo.Database) *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{
+		collection: db.Collection("webhook_subscriptions"),
+	}
+}
+
+func (r *WebhookSubscriptionRepository) Create(ctx context.Context, subscription *models.WebhookSubscription) error {
+	result, err := r.collection.InsertOne(ctx, subscription)
+	if err != nil {
+		return err
+	}
+	subscription.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&subscription)
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *WebhookSubscriptionRepository) GetAll(ctx context.Context) ([]models.WebhookSubscription, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var subscriptions []models.WebhookSubscription
+	if err = cursor.All(ctx, &subscriptions); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *WebhookSubscriptionRepository) FindActiveByEventType(ctx context.Context, eventType string) ([]models.WebhookSubscription, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"active": true, "event_types": eventType})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var subscriptions []models.WebhookSubscription
+	if err = cursor.All(ctx, &subscriptions); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *WebhookSubscriptionRepository) Update(ctx context.Context, subscription *models.WebhookSubscription) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": subscription.ID},
+		bson.M{"$set": bson.M{
+			"url":         subscription.URL,
+			"event_types": subscription.EventTypes,
+			"secret":      subscription.Secret,
+			"description": subscription.Description,
+			"active":      subscription.Active,
+			"updated_at":  subscription.UpdatedAt,
+		}},
+	)
+	return err
+}
+
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
@@ -1,22 +1,38 @@
 package routes
 
 import (
+	"cinema-system/internal/config"
 	"cinema-system/internal/handlers"
+	"cinema-system/internal/health"
 	"cinema-system/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Router struct {
-	authHandler        *handlers.AuthHandler
-	movieHandler       *handlers.MovieHandler
-	sessionHandler     *handlers.SessionHandler
-	bookingHandler     *handlers.BookingHandler
-	reviewHandler      *handlers.ReviewHandler
-	hallHandler        *handlers.HallHandler
-	paymentCardHandler *handlers.PaymentCardHandler
-	paymentHandler     *handlers.PaymentHandler
-	genreHandler       *handlers.GenreHandler
+	authHandler           *handlers.AuthHandler
+	movieHandler          *handlers.MovieHandler
+	sessionHandler        *handlers.SessionHandler
+	bookingHandler        *handlers.BookingHandler
+	reviewHandler         *handlers.ReviewHandler
+	hallHandler           *handlers.HallHandler
+	paymentCardHandler    *handlers.PaymentCardHandler
+	paymentHandler        *handlers.PaymentHandler
+	genreHandler          *handlers.GenreHandler
+	entryHandler          *handlers.EntryHandler
+	documentHandler       *handlers.DocumentHandler
+	walletHandler         *handlers.WalletHandler
+	notificationHandler   *handlers.NotificationHandler
+	webhookHandler        *handlers.WebhookHandler
+	exchangeRateHandler   *handlers.ExchangeRateHandler
+	recommendationHandler *handlers.RecommendationHandler
+	analyticsHandler      *handlers.AnalyticsHandler
+	exportHandler         *handlers.ExportHandler
+	auditHandler          *handlers.AuditHandler
+	healthHandler         *handlers.HealthHandler
+	auditor               middleware.Auditor
+	checker               *health.Checker
+	authConfig            *config.AuthConfig
 }
 
 func NewRouter(
@@ -29,22 +45,55 @@ func NewRouter(
 	paymentCardHandler *handlers.PaymentCardHandler,
 	paymentHandler *handlers.PaymentHandler,
 	genreHandler *handlers.GenreHandler,
+	entryHandler *handlers.EntryHandler,
+	documentHandler *handlers.DocumentHandler,
+	walletHandler *handlers.WalletHandler,
+	notificationHandler *handlers.NotificationHandler,
+	webhookHandler *handlers.WebhookHandler,
+	exchangeRateHandler *handlers.ExchangeRateHandler,
+	recommendationHandler *handlers.RecommendationHandler,
+	analyticsHandler *handlers.AnalyticsHandler,
+	exportHandler *handlers.ExportHandler,
+	auditHandler *handlers.AuditHandler,
+	healthHandler *handlers.HealthHandler,
+	auditor middleware.Auditor,
+	checker *health.Checker,
+	authConfig *config.AuthConfig,
 ) *Router {
 	return &Router{
-		authHandler:        authHandler,
-		movieHandler:       movieHandler,
-		sessionHandler:     sessionHandler,
-		bookingHandler:     bookingHandler,
-		reviewHandler:      reviewHandler,
-		hallHandler:        hallHandler,
-		paymentCardHandler: paymentCardHandler,
-		paymentHandler:     paymentHandler,
-		genreHandler:       genreHandler,
+		authHandler:           authHandler,
+		movieHandler:          movieHandler,
+		sessionHandler:        sessionHandler,
+		bookingHandler:        bookingHandler,
+		reviewHandler:         reviewHandler,
+		hallHandler:           hallHandler,
+		paymentCardHandler:    paymentCardHandler,
+		paymentHandler:        paymentHandler,
+		genreHandler:          genreHandler,
+		entryHandler:          entryHandler,
+		documentHandler:       documentHandler,
+		walletHandler:         walletHandler,
+		notificationHandler:   notificationHandler,
+		webhookHandler:        webhookHandler,
+		exchangeRateHandler:   exchangeRateHandler,
+		recommendationHandler: recommendationHandler,
+		analyticsHandler:      analyticsHandler,
+		exportHandler:         exportHandler,
+		auditHandler:          auditHandler,
+		healthHandler:         healthHandler,
+		auditor:               auditor,
+		checker:               checker,
+		authConfig:            authConfig,
 	}
 }
 
 func (r *Router) Setup() *gin.Engine {
-	router := gin.Default()
+	router := gin.New()
+	router.Use(gin.Recovery(), middleware.RequestID(), middleware.Tracing(), middleware.Logger(), middleware.Metrics(), middleware.Language(), middleware.ErrorHandler())
+	router.GET("/healthz", r.healthHandler.Liveness)
+	router.GET("/readyz", r.healthHandler.Readiness)
+
+	router.Use(middleware.StartupGate(r.checker))
 	router.Static("/ui", "./frontend")
 
 	public := router.Group("/api")
@@ -54,6 +103,7 @@ func (r *Router) Setup() *gin.Engine {
 
 		public.GET("/movies", r.movieHandler.GetAllMovies)
 		public.GET("/movies/:id", r.movieHandler.GetMovie)
+		public.GET("/movies/:id/similar", r.recommendationHandler.GetSimilarMovies)
 		public.GET("/sessions/upcoming", r.sessionHandler.GetUpcomingSessions)
 		public.GET("/sessions/upcoming-movie-ids", r.sessionHandler.GetUpcomingMovieIDs)
 		public.GET("/sessions/movie/:movieId", r.sessionHandler.GetMovieSessions)
@@ -62,21 +112,46 @@ func (r *Router) Setup() *gin.Engine {
 		public.GET("/halls/:id", r.hallHandler.GetHall)
 		public.GET("/genres", r.genreHandler.GetAllGenres)
 		public.GET("/reviews/movie/:movieId", r.reviewHandler.GetMovieReviews)
+		public.GET("/currencies", r.exchangeRateHandler.GetCurrencies)
+		public.GET("/exchange-rates", r.exchangeRateHandler.GetRates)
+	}
+
+	wallet := router.Group("/api/wallet/v1")
+	{
+		wallet.POST("/devices/:deviceId/registrations/:passTypeId/:serial", r.walletHandler.RegisterDevice)
+		wallet.DELETE("/devices/:deviceId/registrations/:passTypeId/:serial", r.walletHandler.UnregisterDevice)
+		wallet.GET("/devices/:deviceId/registrations/:passTypeId", r.walletHandler.GetUpdatedSerials)
+		wallet.GET("/passes/:passTypeId/:serial", r.walletHandler.GetLatestPass)
+		wallet.POST("/log", r.walletHandler.Log)
 	}
 
 	user := router.Group("/api")
-	user.Use(middleware.AuthRequired())
+	user.Use(middleware.AuthRequired(r.authConfig))
 	{
 		user.GET("/auth/me", r.authHandler.GetMe)
 		user.PUT("/auth/me", r.authHandler.UpdateProfile)
 		user.POST("/bookings", r.bookingHandler.BookTickets)
 		user.DELETE("/bookings/:id", r.bookingHandler.CancelTicket)
 		user.GET("/bookings/my", r.bookingHandler.GetMyTickets)
+		user.GET("/bookings/:id/qr", r.entryHandler.GetTicketQR)
+		user.GET("/bookings/:id/token", r.entryHandler.GetTicketToken)
+		user.GET("/bookings/:id/pdf", r.documentHandler.GetBookingPDF)
+		user.GET("/bookings/:id/wallet/apple", r.walletHandler.GetApplePass)
+		user.GET("/bookings/:id/wallet/google", r.walletHandler.GetGoogleSaveLink)
+
+		user.GET("/recommendations", r.recommendationHandler.GetMyRecommendations)
+
+		user.GET("/notifications", r.notificationHandler.GetMyNotifications)
+		user.GET("/notifications/preferences", r.notificationHandler.GetPreferences)
+		user.PUT("/notifications/preferences", r.notificationHandler.UpdatePreferences)
 
 		user.POST("/reviews", r.reviewHandler.CreateReview)
 		user.GET("/reviews/my", r.reviewHandler.GetMyReviews)
 		user.PUT("/reviews/:id", r.reviewHandler.UpdateReview)
 		user.DELETE("/reviews/:id", r.reviewHandler.DeleteReview)
+		user.POST("/reviews/:id/report", r.reviewHandler.ReportReview)
+		user.POST("/reviews/:id/vote", r.reviewHandler.VoteReview)
+		user.DELETE("/reviews/:id/vote", r.reviewHandler.RemoveVote)
 
 		user.POST("/payment-cards", r.paymentCardHandler.CreateCard)
 		user.GET("/payment-cards", r.paymentCardHandler.GetMyCards)
@@ -87,14 +162,30 @@ func (r *Router) Setup() *gin.Engine {
 		user.GET("/payments", r.paymentHandler.GetMyPayments)
 		user.GET("/payments/:id", r.paymentHandler.GetPayment)
 		user.POST("/payments/:id/refund", r.paymentHandler.RefundPayment)
+		user.GET("/payments/:id/receipt", r.documentHandler.GetPaymentReceipt)
+	}
+
+	staff := router.Group("/api/staff")
+	staff.Use(middleware.AuthRequired(r.authConfig), middleware.StaffRequired())
+	{
+		staff.POST("/scan", r.entryHandler.ScanTicket)
+		staff.POST("/scan/sync", r.entryHandler.SyncScans)
+		staff.GET("/sessions/:id/manifest", r.entryHandler.GetSessionManifest)
+		staff.PUT("/reviews/:id/reply", r.reviewHandler.ReplyToReview)
+		staff.DELETE("/reviews/:id/reply", r.reviewHandler.DeleteReply)
 	}
 
 	admin := router.Group("/api/admin")
-	admin.Use(middleware.AuthRequired(), middleware.AdminRequired())
+	admin.Use(middleware.AuthRequired(r.authConfig), middleware.AdminRequired(), middleware.Audit(r.auditor, "/api/admin/payment-cards/user/:userId"), middleware.BaseContent())
 	{
+		admin.GET("/movies", r.movieHandler.GetAllMovies)
+		admin.GET("/movies/:id", r.movieHandler.GetMovie)
 		admin.POST("/movies", r.movieHandler.CreateMovie)
 		admin.PUT("/movies/:id", r.movieHandler.UpdateMovie)
 		admin.DELETE("/movies/:id", r.movieHandler.DeleteMovie)
+		admin.GET("/movies/:id/translations", r.movieHandler.GetTranslations)
+		admin.PUT("/movies/:id/translations/:lang", r.movieHandler.SetTranslation)
+		admin.DELETE("/movies/:id/translations/:lang", r.movieHandler.DeleteTranslation)
 
 		admin.POST("/halls", r.hallHandler.CreateHall)
 		admin.GET("/halls", r.hallHandler.GetAllHalls)
@@ -108,15 +199,57 @@ func (r *Router) Setup() *gin.Engine {
 		admin.GET("/bookings", r.bookingHandler.GetAllBookings)
 		admin.GET("/bookings/session/:sessionId", r.bookingHandler.GetSessionTickets)
 
+		admin.GET("/analytics/summary", r.analyticsHandler.GetSummary)
+		admin.GET("/analytics/sales", r.analyticsHandler.GetSales)
+		admin.GET("/analytics/occupancy", r.analyticsHandler.GetOccupancy)
+
+		admin.GET("/export/tickets", r.exportHandler.ExportTickets)
+		admin.GET("/export/payments", r.exportHandler.ExportPayments)
+		admin.GET("/export/refunds", r.exportHandler.ExportRefunds)
+		admin.GET("/export/users", r.exportHandler.ExportUsers)
+		admin.GET("/export/analytics/:report", r.exportHandler.ExportAnalytics)
+
 		admin.DELETE("/reviews/:id", r.reviewHandler.DeleteReview)
+		admin.GET("/reviews/moderation", r.reviewHandler.GetModerationQueue)
+		admin.GET("/reviews/:id/reports", r.reviewHandler.GetReviewReports)
+		admin.POST("/reviews/:id/approve", r.reviewHandler.ApproveReview)
+		admin.POST("/reviews/:id/reject", r.reviewHandler.RejectReview)
+		admin.POST("/reviews/:id/hide", r.reviewHandler.HideReview)
+		admin.POST("/ratings/recompute", r.reviewHandler.RecomputeRatings)
+		admin.GET("/ratings/recompute", r.reviewHandler.GetRecomputeStatus)
 
+		admin.GET("/genres", r.genreHandler.GetAllGenres)
 		admin.POST("/genres", r.genreHandler.CreateGenre)
 		admin.PUT("/genres/:id", r.genreHandler.UpdateGenre)
 		admin.DELETE("/genres/:id", r.genreHandler.DeleteGenre)
+		admin.GET("/genres/:id/translations", r.genreHandler.GetTranslations)
+		admin.PUT("/genres/:id/translations/:lang", r.genreHandler.SetTranslation)
+		admin.DELETE("/genres/:id/translations/:lang", r.genreHandler.DeleteTranslation)
 
 		admin.GET("/payments", r.paymentHandler.GetAllPayments)
 		admin.GET("/payments/user/:userId", r.paymentHandler.GetUserPaymentsByID)
 		admin.GET("/payment-cards/user/:userId", r.paymentCardHandler.GetUserCards)
+
+		admin.GET("/notifications/failed", r.notificationHandler.GetFailedNotifications)
+		admin.POST("/notifications/:id/retry", r.notificationHandler.RetryNotification)
+
+		admin.GET("/webhooks/event-types", r.webhookHandler.GetEventTypes)
+		admin.GET("/webhooks", r.webhookHandler.GetSubscriptions)
+		admin.POST("/webhooks", r.webhookHandler.CreateSubscription)
+		admin.GET("/webhooks/:id", r.webhookHandler.GetSubscription)
+		admin.PUT("/webhooks/:id", r.webhookHandler.UpdateSubscription)
+		admin.DELETE("/webhooks/:id", r.webhookHandler.DeleteSubscription)
+		admin.GET("/webhooks/:id/deliveries", r.webhookHandler.GetDeliveries)
+		admin.POST("/webhook-deliveries/:id/redeliver", r.webhookHandler.Redeliver)
+
+		admin.GET("/document-templates/:kind", r.documentHandler.GetTemplate)
+		admin.PUT("/document-templates/:kind", r.documentHandler.UpdateTemplate)
+
+		admin.PUT("/exchange-rates/:from/:to", r.exchangeRateHandler.SetRate)
+		admin.DELETE("/exchange-rates/:from/:to", r.exchangeRateHandler.DeleteRate)
+
+		admin.GET("/audit", r.auditHandler.GetEntries)
+		admin.GET("/audit/verify", r.auditHandler.Verify)
 	}
 
 	return router
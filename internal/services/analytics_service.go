@@ -0,0 +1,221 @@
+package services
+
+import (
+	"cinema-system/internal/config"
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	analyticsDefaultRange = 30 * 24 * time.Hour
+	analyticsMaxRange     = 366 * 24 * time.Hour
+	analyticsDateLayout   = "2006-01-02"
+	defaultTopMovies      = 10
+)
+
+type AnalyticsService struct {
+	analyticsRepo repositories.AnalyticsStore
+	hallRepo      repositories.HallStore
+	location      *time.Location
+}
+
+func NewAnalyticsService(analyticsRepo repositories.AnalyticsStore, hallRepo repositories.HallStore, cfg *config.AnalyticsConfig) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo: analyticsRepo,
+		hallRepo:      hallRepo,
+		location:      cfg.Location(),
+	}
+}
+
+func (s *AnalyticsService) buildFilter(ctx context.Context, query models.AnalyticsQuery, now time.Time) (repositories.AnalyticsFilter, error) {
+	filter := repositories.AnalyticsFilter{Interval: query.Interval, Location: s.location}
+	if filter.Interval == "" {
+		filter.Interval = models.IntervalDay
+	}
+
+	year, month, day := now.In(s.location).Date()
+	filter.To = time.Date(year, month, day+1, 0, 0, 0, 0, s.location)
+	if query.To != "" {
+		to, err := time.ParseInLocation(analyticsDateLayout, query.To, s.location)
+		if err != nil {
+			return filter, fmt.Errorf("%w: to must be YYYY-MM-DD", ErrInvalidAnalyticsQuery)
+		}
+		filter.To = to.AddDate(0, 0, 1)
+	}
+	filter.From = filter.To.Add(-analyticsDefaultRange)
+	if query.From != "" {
+		from, err := time.ParseInLocation(analyticsDateLayout, query.From, s.location)
+		if err != nil {
+			return filter, fmt.Errorf("%w: from must be YYYY-MM-DD", ErrInvalidAnalyticsQuery)
+		}
+		filter.From = from
+	}
+
+	if !filter.From.Before(filter.To) {
+		return filter, fmt.Errorf("%w: from must not be after to", ErrInvalidAnalyticsQuery)
+	}
+	if filter.To.Sub(filter.From) > analyticsMaxRange {
+		return filter, fmt.Errorf("%w: date range must not exceed 366 days", ErrInvalidAnalyticsQuery)
+	}
+
+	if location := strings.TrimSpace(query.Location); location != "" {
+		hallIDs, err := s.hallRepo.FindIDsByLocation(ctx, location)
+		if err != nil {
+			return filter, err
+		}
+		if hallIDs == nil {
+			hallIDs = []primitive.ObjectID{}
+		}
+		filter.HallIDs = hallIDs
+	}
+	return filter, nil
+}
+
+func (s *AnalyticsService) GetOccupancy(ctx context.Context, query models.AnalyticsQuery) (_ *models.OccupancyReport, err error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.GetOccupancy")
+	defer tracing.End(span, &err)
+
+	filter, err := s.buildFilter(ctx, query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.analyticsRepo.SessionOccupancy(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.OccupancyReport{
+		From:     filter.From,
+		To:       filter.To,
+		Interval: filter.Interval,
+		Overall:  models.OccupancyBucket{Key: "total", Label: "total"},
+		Sessions: make([]models.SessionOccupancy, 0, len(sessions)),
+	}
+	halls := make(map[string]*models.OccupancyBucket)
+	series := make(map[string]*models.OccupancyBucket)
+	for _, session := range sessions {
+		session.Compute()
+		report.Sessions = append(report.Sessions, session)
+		report.Overall.Add(session)
+
+		hallKey := session.HallID.Hex()
+		if halls[hallKey] == nil {
+			halls[hallKey] = &models.OccupancyBucket{Key: hallKey, Label: session.HallName}
+		}
+		halls[hallKey].Add(session)
+
+		period := filter.Interval.Bucket(session.StartTime, filter.Location)
+		if series[period] == nil {
+			series[period] = &models.OccupancyBucket{Key: period, Label: period}
+		}
+		series[period].Add(session)
+	}
+	report.Halls = sortedBuckets(halls)
+	report.Series = sortedBuckets(series)
+	return report, nil
+}
+
+func sortedBuckets(buckets map[string]*models.OccupancyBucket) []models.OccupancyBucket {
+	out := make([]models.OccupancyBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		out = append(out, *bucket)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+func (s *AnalyticsService) sales(ctx context.Context, filter repositories.AnalyticsFilter, groupBy models.SalesDimension) ([]models.SalesBucket, error) {
+	rows, err := s.analyticsRepo.Sales(ctx, filter, groupBy)
+	if err != nil {
+		return nil, err
+	}
+	if rows == nil {
+		rows = []models.SalesBucket{}
+	}
+	for i := range rows {
+		rows[i].Compute()
+	}
+	return rows, nil
+}
+
+func (s *AnalyticsService) GetSales(ctx context.Context, query models.AnalyticsQuery) (_ *models.SalesReport, err error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.GetSales")
+	defer tracing.End(span, &err)
+
+	filter, err := s.buildFilter(ctx, query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	groupBy := query.GroupBy
+	if groupBy == models.SalesTotal {
+		groupBy = models.SalesByPeriod
+	}
+	rows, err := s.sales(ctx, filter, groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.SalesReport{From: filter.From, To: filter.To, GroupBy: groupBy, Rows: rows}
+	if groupBy == models.SalesByPeriod {
+		report.Interval = filter.Interval
+	}
+	return report, nil
+}
+
+func (s *AnalyticsService) GetSummary(ctx context.Context, query models.AnalyticsQuery) (_ *models.AnalyticsSummary, err error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.GetSummary")
+	defer tracing.End(span, &err)
+
+	filter, err := s.buildFilter(ctx, query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.sales(ctx, filter, models.SalesTotal)
+	if err != nil {
+		return nil, err
+	}
+	for i := range totals {
+		totals[i].Key, totals[i].Label = totals[i].Currency, totals[i].Currency
+	}
+
+	movies, err := s.sales(ctx, filter, models.SalesByMovie)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(movies, func(i, j int) bool { return movies[i].TicketsSold > movies[j].TicketsSold })
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultTopMovies
+	}
+	if len(movies) > limit {
+		movies = movies[:limit]
+	}
+
+	sessions, err := s.analyticsRepo.SessionOccupancy(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	occupancy := models.OccupancyBucket{Key: "total", Label: "total"}
+	for _, session := range sessions {
+		occupancy.Add(session)
+	}
+
+	return &models.AnalyticsSummary{
+		From:      filter.From,
+		To:        filter.To,
+		Totals:    totals,
+		TopMovies: movies,
+		Occupancy: occupancy,
+	}, nil
+}
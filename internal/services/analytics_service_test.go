@@ -0,0 +1,168 @@
+package services
+
+import (
+	"cinema-system/internal/config"
+	"cinema-system/internal/models"
+	"cinema-system/internal/money"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAnalytics(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		service := b.analyticsService()
+		hall := b.createHall(t)
+		user := b.createUser(t, 0)
+
+		popular := b.createMovie(t, "12+")
+		popular.Name = "Popular"
+		if err := b.movies.Update(ctx, popular.ID, popular); err != nil {
+			t.Fatalf("update movie: %v", err)
+		}
+		quiet := b.createMovie(t, "12+")
+		popularSession := b.createSession(t, popular, hall, 2000)
+		quietSession := b.createSession(t, quiet, hall, 1000)
+
+		seat := 0
+		sell := func(session *models.Session, ticketType models.TicketType, price int64, status models.TicketStatus) {
+			seat++
+			ticket := &models.Ticket{
+				UserID:     user.ID,
+				SessionID:  session.ID,
+				RowNumber:  1,
+				SeatNumber: seat,
+				Type:       ticketType,
+				Price:      money.New(price, money.DefaultCurrency),
+				Status:     status,
+				CreatedAt:  time.Now(),
+			}
+			if err := b.tickets.Create(ctx, ticket); err != nil {
+				t.Fatalf("create ticket: %v", err)
+			}
+		}
+		sell(popularSession, models.TicketAdult, 2000, models.TicketPaid)
+		sell(popularSession, models.TicketStudent, 1000, models.TicketPaid)
+		sell(quietSession, models.TicketAdult, 1000, models.TicketPaid)
+		sell(quietSession, models.TicketAdult, 1000, models.TicketCancelled)
+
+		loc := config.DefaultAnalyticsConfig().Location()
+		today := time.Now().In(loc)
+		query := models.AnalyticsQuery{
+			From: today.Format(analyticsDateLayout),
+			To:   today.AddDate(0, 0, 2).Format(analyticsDateLayout),
+		}
+
+		summary, err := service.GetSummary(ctx, query)
+		if err != nil {
+			t.Fatalf("GetSummary: %v", err)
+		}
+		if len(summary.Totals) != 1 {
+			t.Fatalf("totals = %+v, want one currency", summary.Totals)
+		}
+		total := summary.Totals[0]
+		if total.TicketsSold != 3 || total.Refunded != 1 || total.Revenue.Amount != 4000 || total.RefundedTotal.Amount != 1000 {
+			t.Fatalf("total = %+v", total)
+		}
+		if total.AveragePrice.Amount != 1333 || total.RefundRate != 0.25 {
+			t.Fatalf("average = %v, refund rate = %v", total.AveragePrice, total.RefundRate)
+		}
+		if len(summary.TopMovies) != 2 || summary.TopMovies[0].Key != popular.ID.Hex() || summary.TopMovies[0].Label != "Popular" || summary.TopMovies[0].TicketsSold != 2 {
+			t.Fatalf("top movies = %+v", summary.TopMovies)
+		}
+		if summary.Occupancy.Sessions != 2 || summary.Occupancy.Capacity != 100 || summary.Occupancy.Sold != 3 {
+			t.Fatalf("occupancy = %+v", summary.Occupancy)
+		}
+
+		query.GroupBy = models.SalesByTicketType
+		sales, err := service.GetSales(ctx, query)
+		if err != nil {
+			t.Fatalf("GetSales: %v", err)
+		}
+		if len(sales.Rows) != 2 || sales.Rows[0].Key != string(models.TicketAdult) || sales.Rows[0].TicketsSold != 2 || sales.Rows[0].Refunded != 1 ||
+			sales.Rows[1].Key != string(models.TicketStudent) || sales.Rows[1].Revenue.Amount != 1000 {
+			t.Fatalf("sales by ticket type = %+v", sales.Rows)
+		}
+
+		query.GroupBy = models.SalesByPeriod
+		sales, err = service.GetSales(ctx, query)
+		if err != nil {
+			t.Fatalf("GetSales by period: %v", err)
+		}
+		if len(sales.Rows) != 1 || sales.Rows[0].Key != models.IntervalDay.Bucket(time.Now(), loc) || sales.Interval != models.IntervalDay {
+			t.Fatalf("sales by period = %+v", sales)
+		}
+
+		occupancy, err := service.GetOccupancy(ctx, query)
+		if err != nil {
+			t.Fatalf("GetOccupancy: %v", err)
+		}
+		if len(occupancy.Sessions) != 2 || len(occupancy.Halls) != 1 || occupancy.Halls[0].Sold != 3 || len(occupancy.Series) != 1 {
+			t.Fatalf("occupancy = %+v", occupancy)
+		}
+		for _, session := range occupancy.Sessions {
+			if session.SessionID == popularSession.ID && (session.Sold != 2 || session.Occupancy != 0.04 || session.MovieName != "Popular") {
+				t.Fatalf("popular session = %+v", session)
+			}
+		}
+
+		query.Location = "Almaty"
+		summary, err = service.GetSummary(ctx, query)
+		if err != nil {
+			t.Fatalf("GetSummary elsewhere: %v", err)
+		}
+		if len(summary.Totals) != 0 || summary.Occupancy.Sessions != 0 {
+			t.Fatalf("summary for another cinema = %+v", summary)
+		}
+
+		if _, err := service.GetSummary(ctx, models.AnalyticsQuery{From: "yesterday"}); !errors.Is(err, ErrInvalidAnalyticsQuery) {
+			t.Fatalf("err = %v, want %v", err, ErrInvalidAnalyticsQuery)
+		}
+		if _, err := service.GetSummary(ctx, models.AnalyticsQuery{From: "2025-03-01", To: "2025-01-01"}); !errors.Is(err, ErrInvalidAnalyticsQuery) {
+			t.Fatalf("err = %v, want %v", err, ErrInvalidAnalyticsQuery)
+		}
+	})
+}
+
+func TestAnalyticsBucketsInCinemaTimezone(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		service := b.analyticsService()
+		session := b.createSession(t, b.createMovie(t, "12+"), b.createHall(t), 1000)
+
+		ticket := &models.Ticket{
+			UserID:     b.createUser(t, 0).ID,
+			SessionID:  session.ID,
+			RowNumber:  1,
+			SeatNumber: 1,
+			Type:       models.TicketAdult,
+			Price:      money.New(1000, money.DefaultCurrency),
+			Status:     models.TicketPaid,
+			CreatedAt:  time.Date(2025, 3, 1, 20, 30, 0, 0, time.UTC),
+		}
+		if err := b.tickets.Create(ctx, ticket); err != nil {
+			t.Fatalf("create ticket: %v", err)
+		}
+
+		sales, err := service.GetSales(ctx, models.AnalyticsQuery{From: "2025-03-02", To: "2025-03-02", GroupBy: models.SalesByPeriod})
+		if err != nil {
+			t.Fatalf("GetSales: %v", err)
+		}
+		if len(sales.Rows) != 1 || sales.Rows[0].Key != "2025-03-02" || sales.Rows[0].TicketsSold != 1 {
+			t.Fatalf("rows = %+v, want the 01:30 local sale in 2025-03-02", sales.Rows)
+		}
+		if want := time.Date(2025, 3, 1, 19, 0, 0, 0, time.UTC); !sales.From.Equal(want) {
+			t.Fatalf("from = %v, want %v", sales.From, want)
+		}
+
+		sales, err = service.GetSales(ctx, models.AnalyticsQuery{From: "2025-03-01", To: "2025-03-01", GroupBy: models.SalesByPeriod})
+		if err != nil {
+			t.Fatalf("GetSales: %v", err)
+		}
+		if len(sales.Rows) != 0 {
+			t.Fatalf("rows = %+v, want no sales on 2025-03-01 local time", sales.Rows)
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"cinema-system/internal/apperrors"
+	"cinema-system/internal/audit"
+	"cinema-system/internal/config"
+	"cinema-system/internal/models"
+	"cinema-system/internal/money"
+	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	AuditBookingPaid      = "balance.booking"
+	AuditBookingRefunded  = "balance.booking_refund"
+	AuditPaymentCompleted = "balance.payment"
+	AuditPaymentRefunded  = "balance.payment_refund"
+	AuditBalanceTopUp     = "balance.top_up"
+)
+
+type AuditTarget func(ctx context.Context, id primitive.ObjectID) (interface{}, error)
+
+func TrackEntity[T any](find func(ctx context.Context, id primitive.ObjectID) (*T, error)) AuditTarget {
+	return func(ctx context.Context, id primitive.ObjectID) (interface{}, error) {
+		entity, err := find(ctx, id)
+		if err != nil || entity == nil {
+			return nil, err
+		}
+		return entity, nil
+	}
+}
+
+type AuditService struct {
+	auditRepo  repositories.AuditStore
+	transactor repositories.TransactionRunner
+	key        []byte
+	targets    map[string]AuditTarget
+}
+
+func NewAuditService(auditRepo repositories.AuditStore, transactor repositories.TransactionRunner, cfg *config.AuthConfig) *AuditService {
+	return &AuditService{
+		auditRepo:  auditRepo,
+		transactor: transactor,
+		key:        []byte(cfg.AuditSecret),
+		targets:    make(map[string]AuditTarget),
+	}
+}
+
+func (s *AuditService) Track(entityType string, target AuditTarget) {
+	s.targets[entityType] = target
+}
+
+func (s *AuditService) Snapshot(ctx context.Context, entityType, entityID string) interface{} {
+	ctx, span := tracing.Start(ctx, "AuditService.Snapshot")
+	defer span.End()
+
+	target, ok := s.targets[entityType]
+	if !ok {
+		return nil
+	}
+	id, err := primitive.ObjectIDFromHex(entityID)
+	if err != nil {
+		return nil
+	}
+	entity, err := target(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return entity
+}
+
+func (s *AuditService) Record(ctx context.Context, entry *models.AuditEntry, before, after interface{}) (err error) {
+	ctx, span := tracing.Start(ctx, "AuditService.Record")
+	defer tracing.End(span, &err)
+
+	if err := s.prepare(ctx, entry, before, after); err != nil {
+		return err
+	}
+	return s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		return s.append(ctx, entry)
+	})
+}
+
+func (s *AuditService) prepare(ctx context.Context, entry *models.AuditEntry, before, after interface{}) error {
+	changes, err := audit.Diff(before, after)
+	if err != nil {
+		return err
+	}
+	entry.Changes = changes
+
+	actor := audit.ActorFrom(ctx)
+	if entry.ActorID.IsZero() {
+		entry.ActorID = actor.UserID
+	}
+	if entry.ActorRole == "" {
+		entry.ActorRole = actor.Role
+	}
+	if entry.IP == "" {
+		entry.IP = actor.IP
+	}
+	if entry.RequestID == "" {
+		entry.RequestID = actor.RequestID
+	}
+	return nil
+}
+
+func (s *AuditService) append(ctx context.Context, entry *models.AuditEntry) error {
+	seq, err := s.auditRepo.NextSeq(ctx)
+	if err != nil {
+		return err
+	}
+	last, err := s.auditRepo.Last(ctx)
+	if err != nil {
+		return err
+	}
+
+	entry.ID = primitive.NilObjectID
+	entry.Seq, entry.PrevHash = seq, ""
+	if last != nil {
+		entry.PrevHash = last.Hash
+	}
+	entry.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+	entry.Hash = audit.Hash(s.key, entry)
+	return s.auditRepo.Append(ctx, entry)
+}
+
+func (s *AuditService) RecordBalanceChange(ctx context.Context, action string, userID, paymentID primitive.ObjectID, before, after money.Money) (err error) {
+	ctx, span := tracing.Start(ctx, "AuditService.RecordBalanceChange")
+	defer tracing.End(span, &err)
+
+	entry := &models.AuditEntry{
+		Action:     action,
+		EntityType: "users",
+		EntityID:   userID.Hex(),
+		Reference:  paymentID.Hex(),
+	}
+	err = s.prepare(ctx, entry, map[string]money.Money{"balance": before}, map[string]money.Money{"balance": after})
+	if err == nil {
+		err = s.append(ctx, entry)
+	}
+	if err != nil {
+		return apperrors.Internal(fmt.Errorf("failed to audit balance change: %w", err))
+	}
+	return nil
+}
+
+func (s *AuditService) List(ctx context.Context, query *repositories.ListQuery) (*models.Page[models.AuditEntry], error) {
+	return s.auditRepo.List(ctx, query)
+}
+
+func (s *AuditService) Verify(ctx context.Context) (_ *models.AuditVerification, err error) {
+	ctx, span := tracing.Start(ctx, "AuditService.Verify")
+	defer tracing.End(span, &err)
+
+	result := &models.AuditVerification{Valid: true}
+	err = s.auditRepo.Scan(ctx, func(entry *models.AuditEntry) error {
+		if !result.Valid {
+			return nil
+		}
+		switch {
+		case entry.Seq != result.Entries+1:
+			result.Reason = fmt.Sprintf("expected sequence %d, found %d", result.Entries+1, entry.Seq)
+		case entry.PrevHash != result.LastHash:
+			result.Reason = "previous hash does not match the preceding entry"
+		case audit.Hash(s.key, entry) != entry.Hash:
+			result.Reason = "entry hash does not match its contents"
+		default:
+			result.Entries++
+			result.LastHash = entry.Hash
+			return nil
+		}
+		result.Valid = false
+		result.BrokenAt = entry.Seq
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"cinema-system/internal/audit"
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+type tamperedAuditStore struct {
+	repositories.AuditStore
+	tamper func(*models.AuditEntry)
+}
+
+func (s tamperedAuditStore) Scan(ctx context.Context, fn func(*models.AuditEntry) error) error {
+	return s.AuditStore.Scan(ctx, func(entry *models.AuditEntry) error {
+		s.tamper(entry)
+		return fn(entry)
+	})
+}
+
+func TestAuditBalanceChangesAreChained(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		admin := b.createUser(t, 0)
+		ctx := audit.WithActor(context.Background(), audit.Actor{UserID: admin.ID, Role: "ADMIN", IP: "10.0.0.7", RequestID: "req-1"})
+		user := b.createUser(t, 10000)
+		card := createCard(t, b, user.ID)
+		service := b.paymentService()
+
+		payment, err := service.CreatePayment(ctx, user.ID, models.PaymentCreate{PaymentCardID: card.ID, Amount: 3000})
+		if err != nil {
+			t.Fatalf("CreatePayment: %v", err)
+		}
+		if err := service.RefundPayment(ctx, payment.ID, user.ID); err != nil {
+			t.Fatalf("RefundPayment: %v", err)
+		}
+
+		query, err := repositories.ParseListQuery(url.Values{"entity_id": {user.ID.Hex()}, "sort": {"seq"}}, repositories.AuditListSpec, time.UTC)
+		if err != nil {
+			t.Fatalf("parse query: %v", err)
+		}
+		page, err := b.auditService().List(ctx, query)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(page.Items) != 2 {
+			t.Fatalf("entries = %d, want 2", len(page.Items))
+		}
+
+		first, second := page.Items[0], page.Items[1]
+		if first.Action != AuditPaymentCompleted || second.Action != AuditPaymentRefunded {
+			t.Errorf("actions = %s, %s", first.Action, second.Action)
+		}
+		if first.ActorID != admin.ID || first.IP != "10.0.0.7" || first.RequestID != "req-1" || first.Reference != payment.ID.Hex() {
+			t.Errorf("entry metadata = %+v", first)
+		}
+		if len(first.Changes) != 1 || first.Changes[0].Field != "balance" ||
+			!strings.Contains(string(first.Changes[0].Before), `"amount":10000`) || !strings.Contains(string(first.Changes[0].After), `"amount":7000`) {
+			t.Errorf("changes = %+v", first.Changes)
+		}
+		if second.Seq != first.Seq+1 || second.PrevHash != first.Hash {
+			t.Errorf("second entry is not chained to the first: %+v", second)
+		}
+
+		result, err := b.auditService().Verify(ctx)
+		if err != nil || !result.Valid || result.Entries != 2 || result.LastHash != second.Hash {
+			t.Fatalf("Verify = %+v, %v", result, err)
+		}
+
+		tampered := NewAuditService(tamperedAuditStore{AuditStore: b.audit, tamper: func(entry *models.AuditEntry) {
+			if entry.Seq == 1 {
+				entry.IP = "127.0.0.1"
+			}
+		}}, b.transactor, testAuthConfig)
+		result, err = tampered.Verify(ctx)
+		if err != nil || result.Valid || result.BrokenAt != 1 {
+			t.Fatalf("Verify after tampering = %+v, %v", result, err)
+		}
+
+		forged := NewAuditService(tamperedAuditStore{AuditStore: b.audit, tamper: func(entry *models.AuditEntry) {
+			if entry.Seq == 1 {
+				entry.IP = "127.0.0.1"
+				entry.Hash = audit.Hash(nil, entry)
+			}
+		}}, b.transactor, testAuthConfig)
+		result, err = forged.Verify(ctx)
+		if err != nil || result.Valid || result.BrokenAt != 1 {
+			t.Fatalf("Verify after re-hashing without the key = %+v, %v", result, err)
+		}
+	})
+}
+
+type failingAuditStore struct {
+	repositories.AuditStore
+}
+
+func (failingAuditStore) Append(context.Context, *models.AuditEntry) error {
+	return errors.New("audit log unavailable")
+}
+
+func TestBalanceChangeFailsWhenAuditCannotBeWritten(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		user := b.createUser(t, 10000)
+		card := createCard(t, b, user.ID)
+		service := NewPaymentService(b.payments, b.cards, b.users, b.outbox, b.transactor, NewAuditService(failingAuditStore{b.audit}, b.transactor, testAuthConfig))
+
+		if _, err := service.CreatePayment(ctx, user.ID, models.PaymentCreate{PaymentCardID: card.ID, Amount: 3000}); err == nil {
+			t.Fatal("CreatePayment succeeded without an audit entry")
+		}
+		if _, err := service.TopUpBalance(ctx, user.ID, models.PaymentCreate{PaymentCardID: card.ID, Amount: 3000}); err == nil {
+			t.Fatal("TopUpBalance succeeded without an audit entry")
+		}
+		if got := b.balance(t, user.ID); got != 10000 {
+			t.Fatalf("balance = %v, want the change rolled back", got)
+		}
+		assertEvents(t, b.drainEvents(t))
+
+		if _, err := b.paymentService().TopUpBalance(ctx, user.ID, models.PaymentCreate{PaymentCardID: card.ID, Amount: 3000}); err != nil {
+			t.Fatalf("TopUpBalance: %v", err)
+		}
+		last, err := b.audit.Last(ctx)
+		if err != nil || last == nil || last.Seq != 1 {
+			t.Fatalf("Last = %+v, %v, want seq 1 after the failed appends rolled back", last, err)
+		}
+	})
+}
+
+func TestAuditRecordAndBalanceChangesShareSequence(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		user := b.createUser(t, 10000)
+		card := createCard(t, b, user.ID)
+		auditService := b.auditService()
+
+		entry := &models.AuditEntry{Action: "update", EntityType: "user", EntityID: user.ID.Hex(), Method: "PUT", Path: "/api/admin/users/:id", Status: 200}
+		if err := auditService.Record(ctx, entry, nil, nil); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+		if _, err := b.paymentService().TopUpBalance(ctx, user.ID, models.PaymentCreate{PaymentCardID: card.ID, Amount: 3000}); err != nil {
+			t.Fatalf("TopUpBalance: %v", err)
+		}
+		if err := auditService.Record(ctx, &models.AuditEntry{Action: "delete", EntityType: "user", EntityID: user.ID.Hex()}, nil, nil); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+
+		result, err := auditService.Verify(ctx)
+		if err != nil || !result.Valid || result.Entries != 3 {
+			t.Fatalf("Verify = %+v, %v", result, err)
+		}
+		last, err := b.audit.Last(ctx)
+		if err != nil || last == nil || last.Seq != 3 {
+			t.Fatalf("Last = %+v, %v, want seq 3", last, err)
+		}
+	})
+}
@@ -1,38 +1,37 @@
 package services
 
 import (
-	"context"
-	"errors"
-	"time"
-
+	"cinema-system/internal/config"
 	"cinema-system/internal/models"
+	"cinema-system/internal/money"
 	"cinema-system/internal/repositories"
-	"os"
+	"cinema-system/internal/tracing"
+	"context"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"golang.org/x/crypto/bcrypt"
 )
 
-var (
-	ErrUserAlreadyExists  = errors.New("user with this email already exists")
-	ErrInvalidCredentials = errors.New("invalid credentials")
-	ErrUserNotFound       = errors.New("user not found")
-)
-
 type AuthService struct {
-	userRepo   *repositories.UserRepository
-	reviewRepo *repositories.ReviewRepository
+	userRepo   repositories.UserStore
+	reviewRepo repositories.ReviewStore
+	config     *config.AuthConfig
 }
 
-func NewAuthService(userRepo *repositories.UserRepository, reviewRepo *repositories.ReviewRepository) *AuthService {
+func NewAuthService(userRepo repositories.UserStore, reviewRepo repositories.ReviewStore, cfg *config.AuthConfig) *AuthService {
 	return &AuthService{
 		userRepo:   userRepo,
 		reviewRepo: reviewRepo,
+		config:     cfg,
 	}
 }
 
-func (s *AuthService) Register(ctx context.Context, req models.UserRegistration) (*models.User, string, error) {
+func (s *AuthService) Register(ctx context.Context, req models.UserRegistration) (_ *models.User, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Register")
+	defer tracing.End(span, &err)
+
 	existing, _ := s.userRepo.FindByEmail(ctx, req.Email)
 	if existing != nil {
 		return nil, "", ErrUserAlreadyExists
@@ -50,7 +49,7 @@ func (s *AuthService) Register(ctx context.Context, req models.UserRegistration)
 		PhoneNumber:  req.PhoneNumber,
 		PasswordHash: string(hashedPassword),
 		Role:         models.RoleUser,
-		Balance:      0.0,
+		Balance:      money.Zero(money.DefaultCurrency),
 		CreatedAt:    time.Now(),
 	}
 
@@ -67,7 +66,10 @@ func (s *AuthService) Register(ctx context.Context, req models.UserRegistration)
 	return user, token, nil
 }
 
-func (s *AuthService) Login(ctx context.Context, req models.UserLogin) (*models.User, string, error) {
+func (s *AuthService) Login(ctx context.Context, req models.UserLogin) (_ *models.User, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Login")
+	defer tracing.End(span, &err)
+
 	user, err := s.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
 		return nil, "", ErrInvalidCredentials
@@ -87,25 +89,30 @@ func (s *AuthService) Login(ctx context.Context, req models.UserLogin) (*models.
 }
 
 func (s *AuthService) GenerateToken(user *models.User) (string, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		secret = "default-secret"
-	}
-
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"user_id": user.ID.Hex(),
 		"role":    user.Role,
-		"exp":     time.Now().Add(time.Hour * 72).Unix(),
+		"exp":     time.Now().Add(s.config.TokenTTL).Unix(),
 	})
 
-	return token.SignedString([]byte(secret))
+	return token.SignedString([]byte(s.config.JWTSecret))
 }
 
-func (s *AuthService) GetUserByID(ctx context.Context, id primitive.ObjectID) (*models.User, error) {
-	return s.userRepo.FindByID(ctx, id)
+func (s *AuthService) GetUserByID(ctx context.Context, id primitive.ObjectID) (_ *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.GetUserByID")
+	defer tracing.End(span, &err)
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrUserNotFound)
+	}
+	return user, nil
 }
 
-func (s *AuthService) UpdateProfile(ctx context.Context, userID primitive.ObjectID, firstName, lastName, email, phoneNumber string) error {
+func (s *AuthService) UpdateProfile(ctx context.Context, userID primitive.ObjectID, firstName, lastName, email, phoneNumber string) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.UpdateProfile")
+	defer tracing.End(span, &err)
+
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return ErrUserNotFound
@@ -114,7 +121,7 @@ func (s *AuthService) UpdateProfile(ctx context.Context, userID primitive.Object
 	if user.Email != email {
 		existing, _ := s.userRepo.FindByEmail(ctx, email)
 		if existing != nil {
-			return errors.New("email already in use")
+			return ErrEmailInUse
 		}
 	}
 
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"cinema-system/internal/config"
+	"cinema-system/internal/migrations"
+	"cinema-system/internal/models"
+	"cinema-system/internal/money"
+	"cinema-system/internal/repositories"
+	"cinema-system/internal/repositories/memory"
+	"context"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var testAuthConfig = &config.AuthConfig{
+	JWTSecret:           "test-jwt-secret",
+	TicketSigningSecret: "test-ticket-secret",
+	AuditSecret:         "test-audit-secret",
+	TokenTTL:            time.Hour,
+}
+
+type testBackend struct {
+	users           repositories.UserStore
+	movies          repositories.MovieStore
+	genres          repositories.GenreStore
+	movieGenres     repositories.MovieGenreStore
+	halls           repositories.HallStore
+	sessions        repositories.SessionStore
+	tickets         repositories.TicketStore
+	payments        repositories.PaymentStore
+	cards           repositories.PaymentCardStore
+	reviews         repositories.ReviewStore
+	reports         repositories.ReviewReportStore
+	votes           repositories.ReviewVoteStore
+	notifications   repositories.NotificationStore
+	outbox          repositories.OutboxStore
+	processed       repositories.ProcessedEventStore
+	rates           repositories.ExchangeRateStore
+	recommendations repositories.RecommendationStore
+	analytics       repositories.AnalyticsStore
+	audit           repositories.AuditStore
+	transactor      repositories.TransactionRunner
+}
+
+func forEachBackend(t *testing.T, run func(t *testing.T, b *testBackend)) {
+	t.Run("memory", func(t *testing.T) {
+		store := memory.NewStore()
+		run(t, &testBackend{
+			users:           store.Users,
+			movies:          store.Movies,
+			genres:          store.Genres,
+			movieGenres:     store.MovieGenres,
+			halls:           store.Halls,
+			sessions:        store.Sessions,
+			tickets:         store.Tickets,
+			payments:        store.Payments,
+			cards:           store.PaymentCards,
+			reviews:         store.Reviews,
+			reports:         store.ReviewReports,
+			votes:           store.ReviewVotes,
+			notifications:   store.Notifications,
+			outbox:          store.Outbox,
+			processed:       store.ProcessedEvents,
+			rates:           store.ExchangeRates,
+			recommendations: store.Recommendations,
+			analytics:       store.Analytics,
+			audit:           store.Audit,
+			transactor:      store.Transactor,
+		})
+	})
+
+	t.Run("mongo", func(t *testing.T) {
+		uri := os.Getenv("MONGO_TEST_URI")
+		if uri == "" {
+			t.Skip("MONGO_TEST_URI is not set")
+		}
+
+		db, err := config.NewDatabase(uri, "cinema_test_"+primitive.NewObjectID().Hex())
+		if err != nil {
+			t.Fatalf("connect: %v", err)
+		}
+		t.Cleanup(func() {
+			db.Database.Drop(context.Background())
+			db.Disconnect()
+		})
+		if _, err := migrations.NewMigrator(db.Database).Up(context.Background()); err != nil {
+			t.Fatalf("migrate: %v", err)
+		}
+
+		run(t, &testBackend{
+			users:           repositories.NewUserRepository(db.Database),
+			movies:          repositories.NewMovieRepository(db.Database),
+			genres:          repositories.NewGenreRepository(db.Database),
+			movieGenres:     repositories.NewMovieGenreRepository(db.Database),
+			halls:           repositories.NewHallRepository(db.Database),
+			sessions:        repositories.NewSessionRepository(db.Database),
+			tickets:         repositories.NewTicketRepository(db.Database),
+			payments:        repositories.NewPaymentRepository(db.Database),
+			cards:           repositories.NewPaymentCardRepository(db.Database),
+			reviews:         repositories.NewReviewRepository(db.Database),
+			reports:         repositories.NewReviewReportRepository(db.Database),
+			votes:           repositories.NewReviewVoteRepository(db.Database),
+			notifications:   repositories.NewNotificationRepository(db.Database),
+			outbox:          repositories.NewOutboxRepository(db.Database),
+			processed:       repositories.NewProcessedEventRepository(db.Database),
+			rates:           repositories.NewExchangeRateRepository(db.Database),
+			recommendations: repositories.NewRecommendationRepository(db.Database),
+			analytics:       repositories.NewAnalyticsRepository(db.Database),
+			audit:           repositories.NewAuditRepository(db.Database),
+			transactor:      repositories.NewTransactor(db.Client),
+		})
+	})
+}
+
+func (b *testBackend) bookingService() *BookingService {
+	return NewBookingService(b.tickets, b.sessions, b.users, b.halls, b.movies, b.payments, b.outbox, b.transactor, b.auditService())
+}
+
+func (b *testBackend) paymentService() *PaymentService {
+	return NewPaymentService(b.payments, b.cards, b.users, b.outbox, b.transactor, b.auditService())
+}
+
+func (b *testBackend) sessionService() *SessionService {
+	return NewSessionService(b.sessions, b.halls, b.movies, b.outbox, b.transactor)
+}
+
+func (b *testBackend) exchangeRateService() *ExchangeRateService {
+	return NewExchangeRateService(b.rates)
+}
+
+func (b *testBackend) movieService() *MovieService {
+	return NewMovieService(b.movies, b.genres, b.sessions, b.halls, nil)
+}
+
+func (b *testBackend) reviewService() *ReviewService {
+	return b.moderatedReviewService(config.DefaultModerationConfig())
+}
+
+func (b *testBackend) moderatedReviewService(moderation *config.ModerationConfig) *ReviewService {
+	return NewReviewService(b.reviews, b.reports, b.votes, b.movies, b.users, b.tickets, b.sessions, b.outbox, b.transactor, moderation, config.DefaultRatingConfig())
+}
+
+func (b *testBackend) recommendationService() *RecommendationService {
+	return NewRecommendationService(b.recommendations, b.movies, b.genres, b.movieGenres, b.sessions, b.tickets, b.reviews)
+}
+
+func (b *testBackend) analyticsService() *AnalyticsService {
+	return NewAnalyticsService(b.analytics, b.halls, config.DefaultAnalyticsConfig())
+}
+
+func (b *testBackend) entryService() *EntryService {
+	return NewEntryService(b.tickets, b.sessions, testAuthConfig)
+}
+
+func (b *testBackend) auditService() *AuditService {
+	return NewAuditService(b.audit, b.transactor, testAuthConfig)
+}
+
+func (b *testBackend) exportService() *ExportService {
+	return NewExportService(b.tickets, b.payments, b.users, b.analyticsService())
+}
+
+func (b *testBackend) createUser(t *testing.T, balance int64) *models.User {
+	t.Helper()
+	user := &models.User{
+		FirstName: "Test",
+		LastName:  "User",
+		Email:     primitive.NewObjectID().Hex() + "@example.com",
+		Role:      models.RoleUser,
+		Balance:   money.New(balance, money.DefaultCurrency),
+		CreatedAt: time.Now(),
+	}
+	if err := b.users.Create(context.Background(), user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return user
+}
+
+func (b *testBackend) createMovie(t *testing.T, ageRating string) *models.Movie {
+	t.Helper()
+	movie := &models.Movie{Name: "Test Movie", AgeRating: ageRating, Duration: 120, CreatedAt: time.Now()}
+	if err := b.movies.Create(context.Background(), movie); err != nil {
+		t.Fatalf("create movie: %v", err)
+	}
+	return movie
+}
+
+func (b *testBackend) createHall(t *testing.T) *models.Hall {
+	t.Helper()
+	hall := &models.Hall{Name: "Hall 1", Type: models.HallTypeStandard, Location: "Astana", TotalRows: 5, SeatsPerRow: 10}
+	if err := b.halls.Create(context.Background(), hall); err != nil {
+		t.Fatalf("create hall: %v", err)
+	}
+	return hall
+}
+
+func (b *testBackend) createSession(t *testing.T, movie *models.Movie, hall *models.Hall, price int64) *models.Session {
+	t.Helper()
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	session := &models.Session{
+		MovieID:   movie.ID,
+		HallID:    hall.ID,
+		StartTime: start,
+		EndTime:   start.Add(time.Duration(movie.Duration) * time.Minute),
+		Price:     money.New(price, hall.PriceCurrency()),
+	}
+	if err := b.sessions.Create(context.Background(), session); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	return session
+}
+
+func (b *testBackend) balance(t *testing.T, userID primitive.ObjectID) int64 {
+	t.Helper()
+	user, err := b.users.FindByID(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("find user: %v", err)
+	}
+	return user.Balance.Amount
+}
+
+func (b *testBackend) drainEvents(t *testing.T) []string {
+	t.Helper()
+	var types []string
+	now := time.Now().Add(time.Minute)
+	for {
+		event, err := b.outbox.ClaimNext(context.Background(), now, time.Hour)
+		if err != nil {
+			t.Fatalf("claim outbox event: %v", err)
+		}
+		if event == nil {
+			return types
+		}
+		types = append(types, event.Type)
+	}
+}
+
+func assertEvents(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	sort.Strings(got)
+	sort.Strings(want)
+	if len(got) != len(want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("events = %v, want %v", got, want)
+		}
+	}
+}
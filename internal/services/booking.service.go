@@ -1,34 +1,53 @@
 package services
 
 import (
+	"cinema-system/internal/apperrors"
+	"cinema-system/internal/events"
+	"cinema-system/internal/metrics"
 	"cinema-system/internal/models"
+	"cinema-system/internal/money"
 	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
 	"context"
-	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
+var ticketPriceRatios = map[models.TicketType]int64{
+	models.TicketAdult:   100,
+	models.TicketStudent: 80,
+	models.TicketPension: 70,
+	models.TicketKid:     50,
+}
+
 type BookingService struct {
-	ticketRepo  *repositories.TicketRepository
-	sessionRepo *repositories.SessionRepository
-	userRepo    *repositories.UserRepository
-	hallRepo    *repositories.HallRepository
-	movieRepo   *repositories.MovieRepository
-	paymentRepo *repositories.PaymentRepository
+	ticketRepo  repositories.TicketStore
+	sessionRepo repositories.SessionStore
+	userRepo    repositories.UserStore
+	hallRepo    repositories.HallStore
+	movieRepo   repositories.MovieStore
+	paymentRepo repositories.PaymentStore
+	outboxRepo  repositories.OutboxStore
+	transactor  repositories.TransactionRunner
+	audit       *AuditService
 	mu          sync.Mutex
 }
 
 func NewBookingService(
-	ticketRepo *repositories.TicketRepository,
-	sessionRepo *repositories.SessionRepository,
-	userRepo *repositories.UserRepository,
-	hallRepo *repositories.HallRepository,
-	movieRepo *repositories.MovieRepository,
-	paymentRepo *repositories.PaymentRepository,
+	ticketRepo repositories.TicketStore,
+	sessionRepo repositories.SessionStore,
+	userRepo repositories.UserStore,
+	hallRepo repositories.HallStore,
+	movieRepo repositories.MovieStore,
+	paymentRepo repositories.PaymentStore,
+	outboxRepo repositories.OutboxStore,
+	transactor repositories.TransactionRunner,
+	audit *AuditService,
 ) *BookingService {
 	return &BookingService{
 		ticketRepo:  ticketRepo,
@@ -37,6 +56,9 @@ func NewBookingService(
 		hallRepo:    hallRepo,
 		movieRepo:   movieRepo,
 		paymentRepo: paymentRepo,
+		outboxRepo:  outboxRepo,
+		transactor:  transactor,
+		audit:       audit,
 	}
 }
 
@@ -46,41 +68,62 @@ type SeatBookingRequest struct {
 	Type       models.TicketType `json:"type"`
 }
 
-func (s *BookingService) BookTickets(ctx context.Context, userID, sessionID primitive.ObjectID, seatRequests []SeatBookingRequest) ([]models.Ticket, error) {
+func (s *BookingService) BookTickets(ctx context.Context, userID, sessionID primitive.ObjectID, seatRequests []SeatBookingRequest) (_ []models.Ticket, err error) {
+	ctx, span := tracing.Start(ctx, "BookingService.BookTickets")
+	defer tracing.End(span, &err)
+
+	tickets, err := s.bookTickets(ctx, userID, sessionID, seatRequests)
+	if err != nil {
+		metrics.BookingFailures.WithLabelValues(apperrors.From(err).Code).Inc()
+		return nil, err
+	}
+	for _, ticket := range tickets {
+		metrics.TicketsSold.WithLabelValues(string(ticket.Type)).Inc()
+	}
+	return tickets, nil
+}
+
+func (s *BookingService) bookTickets(ctx context.Context, userID, sessionID primitive.ObjectID, seatRequests []SeatBookingRequest) ([]models.Ticket, error) {
 	if len(seatRequests) == 0 {
-		return nil, errors.New("no seats selected")
+		return nil, ErrNoSeatsSelected
 	}
 
+	_, wait := tracing.Start(ctx, "BookingService.lock")
 	s.mu.Lock()
+	wait.End()
 	defer s.mu.Unlock()
 
 	session, err := s.sessionRepo.FindByID(ctx, sessionID)
 	if err != nil {
-		return nil, errors.New("session not found")
+		return nil, notFound(err, ErrSessionNotFound)
 	}
 
 	movie, err := s.movieRepo.FindByID(ctx, session.MovieID)
 	if err != nil {
-		return nil, errors.New("movie not found")
+		return nil, notFound(err, ErrMovieNotFound)
 	}
 
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, notFound(err, ErrUserNotFound)
 	}
 
 	hall, err := s.hallRepo.FindByID(ctx, session.HallID)
 	if err != nil {
-		return nil, errors.New("hall not found")
+		return nil, notFound(err, ErrHallNotFound)
 	}
 
-	var totalPrice float64
+	if !user.Balance.SameCurrency(session.Price) {
+		return nil, ErrBalanceCurrencyMismatch.With("currency", session.Price.Currency).With("balance", user.Balance.Currency)
+	}
+
+	totalPrice := money.Zero(session.Price.Currency)
 	var tickets []models.Ticket
 	now := time.Now()
 
 	for _, req := range seatRequests {
 		if req.RowNumber < 1 || req.RowNumber > hall.TotalRows || req.SeatNumber < 1 || req.SeatNumber > hall.SeatsPerRow {
-			return nil, fmt.Errorf("invalid seat position: row %d, seat %d", req.RowNumber, req.SeatNumber)
+			return nil, ErrInvalidSeat.With("row", req.RowNumber).With("seat", req.SeatNumber)
 		}
 
 		available, err := s.ticketRepo.CheckSeatAvailability(ctx, sessionID, req.RowNumber, req.SeatNumber)
@@ -88,28 +131,22 @@ func (s *BookingService) BookTickets(ctx context.Context, userID, sessionID prim
 			return nil, err
 		}
 		if !available {
-			return nil, fmt.Errorf("seat already booked: row %d, seat %d", req.RowNumber, req.SeatNumber)
-		}
-
-		var priceMultiplier float64
-		switch req.Type {
-		case models.TicketAdult:
-			priceMultiplier = 1.0
-		case models.TicketStudent:
-			priceMultiplier = 0.8
-		case models.TicketPension:
-			priceMultiplier = 0.7
-		case models.TicketKid:
-			if movie.AgeRating == "18+" {
-				return nil, errors.New("kids tickets are not allowed for 18+ movies")
-			}
-			priceMultiplier = 0.5
-		default:
-			return nil, fmt.Errorf("invalid ticket type: %s", req.Type)
+			return nil, ErrSeatUnavailable.With("row", req.RowNumber).With("seat", req.SeatNumber)
+		}
+
+		ratio, ok := ticketPriceRatios[req.Type]
+		if !ok {
+			return nil, ErrInvalidTicketType.With("type", req.Type)
+		}
+		if req.Type == models.TicketKid && movie.AgeRating == "18+" {
+			return nil, ErrKidTicketNotAllowed
 		}
 
-		ticketPrice := session.Price * priceMultiplier
-		totalPrice += ticketPrice
+		ticketPrice := session.Price.Scale(ratio, 100)
+		totalPrice, err = totalPrice.Add(ticketPrice)
+		if err != nil {
+			return nil, err
+		}
 
 		tickets = append(tickets, models.Ticket{
 			UserID:     userID,
@@ -124,8 +161,12 @@ func (s *BookingService) BookTickets(ctx context.Context, userID, sessionID prim
 		})
 	}
 
-	if user.Balance < totalPrice {
-		return nil, fmt.Errorf("insufficient balance: need $%.2f, have $%.2f", totalPrice, user.Balance)
+	newBalance, err := user.Balance.Sub(totalPrice)
+	if err != nil {
+		return nil, err
+	}
+	if newBalance.Amount < 0 {
+		return nil, ErrInsufficientBalance.With("need", totalPrice.String()).With("have", user.Balance.String())
 	}
 
 	payment := &models.Payment{
@@ -137,23 +178,57 @@ func (s *BookingService) BookTickets(ctx context.Context, userID, sessionID prim
 		CreatedAt:       now,
 	}
 
-	err = s.paymentRepo.Create(ctx, payment)
-	if err != nil {
-		return nil, errors.New("failed to create payment record")
-	}
+	err = s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.paymentRepo.Create(ctx, payment); err != nil {
+			return apperrors.Internal(fmt.Errorf("failed to create payment record: %w", err))
+		}
 
-	newBalance := user.Balance - totalPrice
-	err = s.userRepo.UpdateBalance(ctx, userID, newBalance)
-	if err != nil {
-		return nil, errors.New("failed to deduct balance")
-	}
+		if err := s.userRepo.UpdateBalance(ctx, userID, newBalance); err != nil {
+			return apperrors.Internal(fmt.Errorf("failed to deduct balance: %w", err))
+		}
 
-	for i := range tickets {
-		tickets[i].PaymentID = payment.ID
-		err = s.ticketRepo.Create(ctx, &tickets[i])
+		ticketIDs := make([]primitive.ObjectID, len(tickets))
+		for i := range tickets {
+			tickets[i].PaymentID = payment.ID
+			if err := s.ticketRepo.Create(ctx, &tickets[i]); err != nil {
+				if mongo.IsDuplicateKeyError(err) {
+					return ErrSeatUnavailable.With("row", tickets[i].RowNumber).With("seat", tickets[i].SeatNumber)
+				}
+				return apperrors.Internal(fmt.Errorf("failed to create ticket for row %d, seat %d: %w", tickets[i].RowNumber, tickets[i].SeatNumber, err))
+			}
+			ticketIDs[i] = tickets[i].ID
+		}
+
+		booked, err := events.New(events.TicketBooked, payment.ID, events.TicketBookedPayload{
+			UserID:    userID,
+			SessionID: sessionID,
+			PaymentID: payment.ID,
+			TicketIDs: ticketIDs,
+			Total:     totalPrice,
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.outboxRepo.Add(ctx, booked); err != nil {
+			return err
+		}
+
+		completed, err := events.New(events.PaymentCompleted, payment.ID, events.PaymentCompletedPayload{
+			PaymentID:       payment.ID,
+			UserID:          userID,
+			Amount:          totalPrice,
+			TransactionCode: payment.TransactionCode,
+		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to create ticket for row %d, seat %d: %v", tickets[i].RowNumber, tickets[i].SeatNumber, err)
+			return err
 		}
+		if err := s.outboxRepo.Add(ctx, completed); err != nil {
+			return err
+		}
+		return s.audit.RecordBalanceChange(ctx, AuditBookingPaid, userID, payment.ID, user.Balance, newBalance)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return tickets, nil
@@ -164,55 +239,92 @@ func (s *BookingService) generateTransactionCode() string {
 	return fmt.Sprintf("TXN-%d-%s", timestamp, primitive.NewObjectID().Hex()[:8])
 }
 
-func (s *BookingService) CancelTicket(ctx context.Context, ticketID, userID primitive.ObjectID) error {
+func (s *BookingService) CancelTicket(ctx context.Context, ticketID, userID primitive.ObjectID) (err error) {
+	ctx, span := tracing.Start(ctx, "BookingService.CancelTicket")
+	defer tracing.End(span, &err)
+
 	ticket, err := s.ticketRepo.FindByID(ctx, ticketID)
 	if err != nil {
-		return errors.New("ticket not found")
+		return notFound(err, ErrTicketNotFound)
 	}
 
 	payment, err := s.paymentRepo.FindByID(ctx, ticket.PaymentID)
 	if err != nil {
-		return errors.New("payment not found")
+		return notFound(err, ErrPaymentNotFound)
 	}
 
 	if payment.UserID != userID {
-		return errors.New("unauthorized to cancel this ticket")
-	}
-
-	if ticket.Status == models.TicketCancelled {
-		return errors.New("ticket already cancelled")
+		return ErrTicketForbidden
 	}
 
-	session, err := s.sessionRepo.FindByID(ctx, ticket.SessionID)
-	if err != nil {
+	if err := ticketCancellable(ticket.Status); err != nil {
 		return err
 	}
 
-	user, err := s.userRepo.FindByID(ctx, userID)
-	if err != nil {
-		return err
-	}
+	err = s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		cancelled, err := s.ticketRepo.MarkCancelled(ctx, ticketID)
+		if err != nil {
+			return err
+		}
+		if !cancelled {
+			current, err := s.ticketRepo.FindByID(ctx, ticketID)
+			if err != nil {
+				return err
+			}
+			if err := ticketCancellable(current.Status); err != nil {
+				return err
+			}
+			return ErrTicketNotValid.With("status", strings.ToLower(string(current.Status)))
+		}
 
-	newBalance := user.Balance + session.Price
-	if err := s.userRepo.UpdateBalance(ctx, userID, newBalance); err != nil {
-		return err
-	}
+		user, err := s.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		newBalance, err := user.Balance.Add(ticket.Price)
+		if err != nil {
+			return err
+		}
+
+		if err := s.userRepo.UpdateBalance(ctx, userID, newBalance); err != nil {
+			return err
+		}
 
-	if err := s.paymentRepo.UpdateStatus(ctx, payment.ID, models.PaymentRefunded); err != nil {
+		if err := s.paymentRepo.UpdateStatus(ctx, payment.ID, models.PaymentRefunded); err != nil {
+			return err
+		}
+
+		event, err := events.New(events.TicketCancelled, ticketID, events.TicketCancelledPayload{
+			TicketID:  ticketID,
+			UserID:    userID,
+			SessionID: ticket.SessionID,
+			PaymentID: payment.ID,
+			Refund:    ticket.Price,
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.outboxRepo.Add(ctx, event); err != nil {
+			return err
+		}
+		return s.audit.RecordBalanceChange(ctx, AuditBookingRefunded, userID, payment.ID, user.Balance, newBalance)
+	})
+	if err != nil {
 		return err
 	}
-
-	return s.ticketRepo.UpdateStatus(ctx, ticketID, models.TicketCancelled)
+	metrics.Refunds.WithLabelValues("booking", ticket.Price.Currency).Inc()
+	return nil
 }
 
-func (s *BookingService) GetUserTickets(ctx context.Context, userID primitive.ObjectID) ([]models.Ticket, error) {
-	fmt.Printf("[DEBUG] GetUserTickets for userID: %s\n", userID.Hex())
-	tickets, err := s.ticketRepo.GetByUserID(ctx, userID)
+func (s *BookingService) GetUserTickets(ctx context.Context, userID primitive.ObjectID, query *repositories.ListQuery) (_ *models.Page[models.Ticket], err error) {
+	ctx, span := tracing.Start(ctx, "BookingService.GetUserTickets")
+	defer tracing.End(span, &err)
+
+	page, err := s.ticketRepo.ListByUserID(ctx, userID, query)
 	if err != nil {
-		fmt.Printf("[DEBUG] Error GetUserTickets: %v\n", err)
 		return nil, err
 	}
-	fmt.Printf("[DEBUG] Found %d tickets\n", len(tickets))
+	tickets := page.Items
 	for i := range tickets {
 		if tickets[i].MovieTitle == "" {
 			session, _ := s.sessionRepo.FindByID(ctx, tickets[i].SessionID)
@@ -224,21 +336,24 @@ func (s *BookingService) GetUserTickets(ctx context.Context, userID primitive.Ob
 			}
 		}
 	}
-	return tickets, nil
+	return page, nil
 }
 
 func (s *BookingService) GetSessionTickets(ctx context.Context, sessionID primitive.ObjectID) ([]models.Ticket, error) {
 	return s.ticketRepo.GetBySession(ctx, sessionID)
 }
 
-func (s *BookingService) GetAllBookings(ctx context.Context) ([]models.Ticket, error) {
-	return s.ticketRepo.GetAll(ctx)
+func (s *BookingService) GetAllBookings(ctx context.Context, query *repositories.ListQuery) (*models.Page[models.Ticket], error) {
+	return s.ticketRepo.List(ctx, query)
 }
 
-func (s *BookingService) GetSessionBookedSeats(ctx context.Context, sessionID primitive.ObjectID) ([]struct {
+func (s *BookingService) GetSessionBookedSeats(ctx context.Context, sessionID primitive.ObjectID) (_ []struct {
 	RowNumber  int `json:"row_number"`
 	SeatNumber int `json:"seat_number"`
-}, error) {
+}, err error) {
+	ctx, span := tracing.Start(ctx, "BookingService.GetSessionBookedSeats")
+	defer tracing.End(span, &err)
+
 	tickets, err := s.ticketRepo.GetBySession(ctx, sessionID)
 	if err != nil {
 		return nil, err
@@ -253,3 +368,13 @@ func (s *BookingService) GetSessionBookedSeats(ctx context.Context, sessionID pr
 	}
 	return out, nil
 }
+
+func ticketCancellable(status models.TicketStatus) error {
+	switch status {
+	case models.TicketCancelled:
+		return ErrTicketAlreadyCancelled
+	case models.TicketUsed:
+		return ErrTicketAlreadyUsed
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"cinema-system/internal/apperrors"
+	"cinema-system/internal/events"
+	"cinema-system/internal/metrics"
+	"cinema-system/internal/models"
+	"cinema-system/internal/money"
+	"cinema-system/internal/repositories"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBookTickets(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		user := b.createUser(t, 10000)
+		movie := b.createMovie(t, "12+")
+		session := b.createSession(t, movie, b.createHall(t), 1000)
+
+		tickets, err := b.bookingService().BookTickets(ctx, user.ID, session.ID, []SeatBookingRequest{
+			{RowNumber: 1, SeatNumber: 1, Type: models.TicketAdult},
+			{RowNumber: 1, SeatNumber: 2, Type: models.TicketStudent},
+		})
+		if err != nil {
+			t.Fatalf("BookTickets: %v", err)
+		}
+		if len(tickets) != 2 || tickets[0].Price.Amount != 1000 || tickets[1].Price.Amount != 800 {
+			t.Fatalf("unexpected tickets: %+v", tickets)
+		}
+		if got := b.balance(t, user.ID); got != 8200 {
+			t.Fatalf("balance = %v, want 8200", got)
+		}
+
+		payment, err := b.payments.FindByID(ctx, tickets[0].PaymentID)
+		if err != nil {
+			t.Fatalf("find payment: %v", err)
+		}
+		if payment.Status != models.PaymentCompleted || payment.Amount.Amount != 1800 {
+			t.Fatalf("unexpected payment: %+v", payment)
+		}
+
+		available, err := b.tickets.CheckSeatAvailability(ctx, session.ID, 1, 1)
+		if err != nil || available {
+			t.Fatalf("seat 1-1 available = %v, err = %v", available, err)
+		}
+		assertEvents(t, b.drainEvents(t), events.TicketBooked, events.PaymentCompleted)
+	})
+}
+
+func TestBookTicketsDiscountRounding(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		user := b.createUser(t, 10000)
+		session := b.createSession(t, b.createMovie(t, "12+"), b.createHall(t), 1005)
+		service := b.bookingService()
+
+		tickets, err := service.BookTickets(ctx, user.ID, session.ID, []SeatBookingRequest{
+			{RowNumber: 1, SeatNumber: 1, Type: models.TicketPension},
+			{RowNumber: 1, SeatNumber: 2, Type: models.TicketKid},
+		})
+		if err != nil {
+			t.Fatalf("BookTickets: %v", err)
+		}
+		if tickets[0].Price != money.New(704, "KZT") || tickets[1].Price != money.New(503, "KZT") {
+			t.Fatalf("unexpected prices: %+v, %+v", tickets[0].Price, tickets[1].Price)
+		}
+		if got := b.balance(t, user.ID); got != 8793 {
+			t.Fatalf("balance = %v, want 8793", got)
+		}
+
+		if err := service.CancelTicket(ctx, tickets[0].ID, user.ID); err != nil {
+			t.Fatalf("CancelTicket: %v", err)
+		}
+		if got := b.balance(t, user.ID); got != 9497 {
+			t.Fatalf("balance = %v, want 9497", got)
+		}
+	})
+}
+
+func TestBookTicketsRejectsOtherCurrency(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		user := b.createUser(t, 10000)
+		hall := &models.Hall{Name: "Hall USD", Type: models.HallTypeStandard, TotalRows: 5, SeatsPerRow: 10, Currency: "USD"}
+		if err := b.halls.Create(ctx, hall); err != nil {
+			t.Fatalf("create hall: %v", err)
+		}
+		session := b.createSession(t, b.createMovie(t, "12+"), hall, 500)
+
+		_, err := b.bookingService().BookTickets(ctx, user.ID, session.ID, []SeatBookingRequest{{RowNumber: 1, SeatNumber: 1, Type: models.TicketAdult}})
+		if !errors.Is(err, ErrBalanceCurrencyMismatch) {
+			t.Fatalf("err = %v, want %v", err, ErrBalanceCurrencyMismatch)
+		}
+		if got := b.balance(t, user.ID); got != 10000 {
+			t.Fatalf("balance = %v, want 10000", got)
+		}
+	})
+}
+
+func TestBookTicketsRejectsBookedSeat(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		first := b.createUser(t, 10000)
+		second := b.createUser(t, 10000)
+		session := b.createSession(t, b.createMovie(t, "12+"), b.createHall(t), 1000)
+		seat := []SeatBookingRequest{{RowNumber: 2, SeatNumber: 3, Type: models.TicketAdult}}
+		reason := apperrors.From(ErrSeatUnavailable).Code
+		sold := testutil.ToFloat64(metrics.TicketsSold.WithLabelValues(string(models.TicketAdult)))
+		failures := testutil.ToFloat64(metrics.BookingFailures.WithLabelValues(reason))
+
+		if _, err := b.bookingService().BookTickets(ctx, first.ID, session.ID, seat); err != nil {
+			t.Fatalf("first booking: %v", err)
+		}
+		if _, err := b.bookingService().BookTickets(ctx, second.ID, session.ID, seat); !errors.Is(err, ErrSeatUnavailable) {
+			t.Fatalf("err = %v, want %v", err, ErrSeatUnavailable)
+		}
+		if got := b.balance(t, second.ID); got != 10000 {
+			t.Fatalf("balance = %v, want 10000", got)
+		}
+		if got := testutil.ToFloat64(metrics.TicketsSold.WithLabelValues(string(models.TicketAdult))) - sold; got != 1 {
+			t.Fatalf("tickets sold delta = %v, want 1", got)
+		}
+		if got := testutil.ToFloat64(metrics.BookingFailures.WithLabelValues(reason)) - failures; got != 1 {
+			t.Fatalf("booking failures delta = %v, want 1", got)
+		}
+	})
+}
+
+type racingTicketStore struct {
+	repositories.TicketStore
+}
+
+func (racingTicketStore) CheckSeatAvailability(context.Context, primitive.ObjectID, int, int) (bool, error) {
+	return true, nil
+}
+
+func TestBookTicketsRejectsSeatTakenAfterAvailabilityCheck(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		first := b.createUser(t, 10000)
+		second := b.createUser(t, 10000)
+		session := b.createSession(t, b.createMovie(t, "12+"), b.createHall(t), 1000)
+		seat := []SeatBookingRequest{{RowNumber: 4, SeatNumber: 5, Type: models.TicketAdult}}
+
+		if _, err := b.bookingService().BookTickets(ctx, first.ID, session.ID, seat); err != nil {
+			t.Fatalf("first booking: %v", err)
+		}
+		b.drainEvents(t)
+
+		racing := NewBookingService(racingTicketStore{b.tickets}, b.sessions, b.users, b.halls, b.movies, b.payments, b.outbox, b.transactor, b.auditService())
+		if _, err := racing.BookTickets(ctx, second.ID, session.ID, seat); !errors.Is(err, ErrSeatUnavailable) {
+			t.Fatalf("err = %v, want %v", err, ErrSeatUnavailable)
+		}
+		if got := b.balance(t, second.ID); got != 10000 {
+			t.Fatalf("balance = %v, want the booking rolled back", got)
+		}
+		assertEvents(t, b.drainEvents(t))
+	})
+}
+
+func TestBookTicketsValidation(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		user := b.createUser(t, 500)
+		hall := b.createHall(t)
+		session := b.createSession(t, b.createMovie(t, "18+"), hall, 1000)
+		service := b.bookingService()
+
+		cases := map[string][]SeatBookingRequest{
+			"no seats":             {},
+			"seat outside hall":    {{RowNumber: hall.TotalRows + 1, SeatNumber: 1, Type: models.TicketAdult}},
+			"unknown ticket type":  {{RowNumber: 1, SeatNumber: 1, Type: "VIP"}},
+			"kid ticket for 18+":   {{RowNumber: 1, SeatNumber: 1, Type: models.TicketKid}},
+			"insufficient balance": {{RowNumber: 1, SeatNumber: 1, Type: models.TicketAdult}},
+		}
+		for name, seats := range cases {
+			if _, err := service.BookTickets(ctx, user.ID, session.ID, seats); err == nil {
+				t.Errorf("%s: expected error", name)
+			}
+		}
+
+		if got := b.balance(t, user.ID); got != 500 {
+			t.Fatalf("balance = %v, want 500", got)
+		}
+		tickets, err := b.tickets.GetBySession(ctx, session.ID)
+		if err != nil || len(tickets) != 0 {
+			t.Fatalf("tickets = %v, err = %v", tickets, err)
+		}
+		assertEvents(t, b.drainEvents(t))
+	})
+}
+
+func TestCancelTicket(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		user := b.createUser(t, 10000)
+		other := b.createUser(t, 10000)
+		session := b.createSession(t, b.createMovie(t, "12+"), b.createHall(t), 1000)
+		service := b.bookingService()
+
+		tickets, err := service.BookTickets(ctx, user.ID, session.ID, []SeatBookingRequest{
+			{RowNumber: 3, SeatNumber: 4, Type: models.TicketAdult},
+		})
+		if err != nil {
+			t.Fatalf("BookTickets: %v", err)
+		}
+		ticket := tickets[0]
+		b.drainEvents(t)
+
+		if err := service.CancelTicket(ctx, ticket.ID, other.ID); !errors.Is(err, ErrTicketForbidden) {
+			t.Fatalf("err = %v, want %v", err, ErrTicketForbidden)
+		}
+		if err := service.CancelTicket(ctx, ticket.ID, user.ID); err != nil {
+			t.Fatalf("CancelTicket: %v", err)
+		}
+		if err := service.CancelTicket(ctx, ticket.ID, user.ID); !errors.Is(err, ErrTicketAlreadyCancelled) {
+			t.Fatalf("err = %v, want %v", err, ErrTicketAlreadyCancelled)
+		}
+
+		if got := b.balance(t, user.ID); got != 10000 {
+			t.Fatalf("balance = %v, want 10000", got)
+		}
+		cancelled, err := b.tickets.FindByID(ctx, ticket.ID)
+		if err != nil || cancelled.Status != models.TicketCancelled {
+			t.Fatalf("ticket = %+v, err = %v", cancelled, err)
+		}
+		payment, err := b.payments.FindByID(ctx, ticket.PaymentID)
+		if err != nil || payment.Status != models.PaymentRefunded {
+			t.Fatalf("payment = %+v, err = %v", payment, err)
+		}
+		available, err := b.tickets.CheckSeatAvailability(ctx, session.ID, 3, 4)
+		if err != nil || !available {
+			t.Fatalf("seat 3-4 available = %v, err = %v", available, err)
+		}
+		assertEvents(t, b.drainEvents(t), events.TicketCancelled)
+	})
+}
+
+func TestCancelUsedTicket(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		user := b.createUser(t, 10000)
+		session := b.createSession(t, b.createMovie(t, "12+"), b.createHall(t), 1000)
+
+		tickets, err := b.bookingService().BookTickets(ctx, user.ID, session.ID, []SeatBookingRequest{
+			{RowNumber: 1, SeatNumber: 1, Type: models.TicketAdult},
+		})
+		if err != nil {
+			t.Fatalf("BookTickets: %v", err)
+		}
+		if used, err := b.tickets.MarkUsed(ctx, tickets[0].ID, session.StartTime); err != nil || !used {
+			t.Fatalf("MarkUsed = %v, err = %v", used, err)
+		}
+		if err := b.bookingService().CancelTicket(ctx, tickets[0].ID, user.ID); err == nil {
+			t.Fatal("expected used ticket cancellation to be rejected")
+		}
+		if got := b.balance(t, user.ID); got != 9000 {
+			t.Fatalf("balance = %v, want 9000", got)
+		}
+	})
+}
+
+type staleTicketStore struct {
+	repositories.TicketStore
+	stale *models.Ticket
+}
+
+func (s *staleTicketStore) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Ticket, error) {
+	if stale := s.stale; stale != nil && stale.ID == id {
+		s.stale = nil
+		return stale, nil
+	}
+	return s.TicketStore.FindByID(ctx, id)
+}
+
+func TestCancelTicketScannedAfterCheck(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		user := b.createUser(t, 10000)
+		session := b.createSession(t, b.createMovie(t, "12+"), b.createHall(t), 1000)
+
+		tickets, err := b.bookingService().BookTickets(ctx, user.ID, session.ID, []SeatBookingRequest{
+			{RowNumber: 1, SeatNumber: 1, Type: models.TicketAdult},
+		})
+		if err != nil {
+			t.Fatalf("BookTickets: %v", err)
+		}
+		b.drainEvents(t)
+		if used, err := b.tickets.MarkUsed(ctx, tickets[0].ID, session.StartTime); err != nil || !used {
+			t.Fatalf("MarkUsed = %v, err = %v", used, err)
+		}
+
+		stale := &staleTicketStore{TicketStore: b.tickets, stale: &tickets[0]}
+		service := NewBookingService(stale, b.sessions, b.users, b.halls, b.movies, b.payments, b.outbox, b.transactor, b.auditService())
+		if err := service.CancelTicket(ctx, tickets[0].ID, user.ID); !errors.Is(err, ErrTicketAlreadyUsed) {
+			t.Fatalf("err = %v, want %v", err, ErrTicketAlreadyUsed)
+		}
+		if got := b.balance(t, user.ID); got != 9000 {
+			t.Fatalf("balance = %v, want 9000", got)
+		}
+		ticket, err := b.tickets.FindByID(ctx, tickets[0].ID)
+		if err != nil || ticket.Status != models.TicketUsed {
+			t.Fatalf("ticket = %+v, err = %v", ticket, err)
+		}
+		assertEvents(t, b.drainEvents(t))
+	})
+}
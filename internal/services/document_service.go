@@ -0,0 +1,368 @@
+package services
+
+import (
+	"bytes"
+	"cinema-system/internal/models"
+	"cinema-system/internal/money"
+	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
+	"context"
+	_ "embed"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	documentTimeLayout = "02 Jan 2006 15:04"
+	documentFont       = "DejaVu"
+)
+
+var (
+	//go:embed fonts/DejaVuSansCondensed.ttf
+	documentFontRegular []byte
+	//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+	documentFontBold []byte
+)
+
+type DocumentService struct {
+	ticketRepo   repositories.TicketStore
+	sessionRepo  repositories.SessionStore
+	movieRepo    repositories.MovieStore
+	hallRepo     repositories.HallStore
+	paymentRepo  repositories.PaymentStore
+	cardRepo     repositories.PaymentCardStore
+	templateRepo repositories.DocumentTemplateStore
+	entryService *EntryService
+}
+
+func NewDocumentService(
+	ticketRepo repositories.TicketStore,
+	sessionRepo repositories.SessionStore,
+	movieRepo repositories.MovieStore,
+	hallRepo repositories.HallStore,
+	paymentRepo repositories.PaymentStore,
+	cardRepo repositories.PaymentCardStore,
+	templateRepo repositories.DocumentTemplateStore,
+	entryService *EntryService,
+) *DocumentService {
+	return &DocumentService{
+		ticketRepo:   ticketRepo,
+		sessionRepo:  sessionRepo,
+		movieRepo:    movieRepo,
+		hallRepo:     hallRepo,
+		paymentRepo:  paymentRepo,
+		cardRepo:     cardRepo,
+		templateRepo: templateRepo,
+		entryService: entryService,
+	}
+}
+
+func (s *DocumentService) GetTemplate(ctx context.Context, kind models.DocumentKind) (_ *models.DocumentTemplate, err error) {
+	ctx, span := tracing.Start(ctx, "DocumentService.GetTemplate")
+	defer tracing.End(span, &err)
+
+	template, err := s.templateRepo.FindByKind(ctx, kind)
+	if err != nil {
+		return models.DefaultDocumentTemplate(kind), nil
+	}
+	return template, nil
+}
+
+func (s *DocumentService) UpdateTemplate(ctx context.Context, template *models.DocumentTemplate) (err error) {
+	ctx, span := tracing.Start(ctx, "DocumentService.UpdateTemplate")
+	defer tracing.End(span, &err)
+
+	if err := template.Validate(); err != nil {
+		return err
+	}
+	template.UpdatedAt = time.Now()
+	return s.templateRepo.Upsert(ctx, template)
+}
+
+func (s *DocumentService) RenderBookingPDF(ctx context.Context, ticketID, userID primitive.ObjectID) (_ []byte, err error) {
+	ctx, span := tracing.Start(ctx, "DocumentService.RenderBookingPDF")
+	defer tracing.End(span, &err)
+
+	ticket, err := s.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+
+	if ticket.UserID != userID {
+		return nil, ErrTicketForbidden
+	}
+
+	if ticket.Status == models.TicketCancelled {
+		return nil, ErrTicketNotValid.With("status", "cancelled")
+	}
+
+	payment, err := s.paymentRepo.FindByID(ctx, ticket.PaymentID)
+	if err != nil {
+		return nil, notFound(err, ErrPaymentNotFound)
+	}
+
+	tickets, err := s.ticketRepo.GetByPaymentIDs(ctx, []primitive.ObjectID{payment.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessionRepo.FindByID(ctx, ticket.SessionID)
+	if err != nil {
+		return nil, notFound(err, ErrSessionNotFound)
+	}
+
+	movie, err := s.movieRepo.FindByID(ctx, session.MovieID)
+	if err != nil {
+		return nil, notFound(err, ErrMovieNotFound)
+	}
+
+	hall, err := s.hallRepo.FindByID(ctx, session.HallID)
+	if err != nil {
+		return nil, notFound(err, ErrHallNotFound)
+	}
+
+	template, err := s.GetTemplate(ctx, models.DocumentTicket)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := newDocument(template)
+
+	pdf.SetFont(documentFont, "B", 16)
+	pdf.CellFormat(0, 9, movie.Name, "", 1, "L", false, 0, "")
+	pdf.SetFont(documentFont, "", 11)
+	documentRow(pdf, "Session", session.StartTime.Format(documentTimeLayout))
+	documentRow(pdf, "Hall", fmt.Sprintf("%s (%s)", hall.Name, hall.Type))
+	if hall.Location != "" {
+		documentRow(pdf, "Location", hall.Location)
+	}
+	if movie.AgeRating != "" {
+		documentRow(pdf, "Age rating", movie.AgeRating)
+	}
+	documentRow(pdf, "Transaction", payment.TransactionCode)
+	pdf.Ln(4)
+
+	for i := range tickets {
+		t := &tickets[i]
+		if t.Status == models.TicketCancelled {
+			continue
+		}
+
+		token, err := s.entryService.GenerateTicketToken(t)
+		if err != nil {
+			return nil, err
+		}
+		qr, _, err := s.entryService.RenderQRCode(token, "png")
+		if err != nil {
+			return nil, err
+		}
+
+		if pdf.GetY() > 230 {
+			pdf.AddPage()
+		}
+
+		top := pdf.GetY()
+		name := "qr-" + t.ID.Hex()
+		pdf.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qr))
+		pdf.ImageOptions(name, 150, top, 40, 40, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+		pdf.SetFont(documentFont, "B", 13)
+		pdf.CellFormat(130, 8, fmt.Sprintf("Row %d, Seat %d", t.RowNumber, t.SeatNumber), "", 1, "L", false, 0, "")
+		pdf.SetFont(documentFont, "", 11)
+		documentRow(pdf, "Ticket type", string(t.Type))
+		documentRow(pdf, "Price", t.Price.String())
+		documentRow(pdf, "Ticket ID", t.ID.Hex())
+		pdf.SetY(top + 44)
+		pdf.Line(20, pdf.GetY()-2, 190, pdf.GetY()-2)
+	}
+
+	pdf.SetFont(documentFont, "B", 12)
+	pdf.CellFormat(0, 8, "Price breakdown", "", 1, "L", false, 0, "")
+	pdf.SetFont(documentFont, "B", 10)
+	pdf.CellFormat(50, 7, "Seat", "B", 0, "L", false, 0, "")
+	pdf.CellFormat(30, 7, "Type", "B", 0, "L", false, 0, "")
+	pdf.CellFormat(30, 7, "Base", "B", 0, "R", false, 0, "")
+	pdf.CellFormat(30, 7, "Discount", "B", 0, "R", false, 0, "")
+	pdf.CellFormat(30, 7, "Price", "B", 1, "R", false, 0, "")
+	pdf.SetFont(documentFont, "", 10)
+
+	total := money.Zero(session.Price.Currency)
+	for _, t := range tickets {
+		if t.Status == models.TicketCancelled {
+			continue
+		}
+		if total, err = total.Add(t.Price); err != nil {
+			return nil, err
+		}
+		discount, err := session.Price.Sub(t.Price)
+		if err != nil {
+			return nil, err
+		}
+		pdf.CellFormat(50, 7, fmt.Sprintf("Row %d, Seat %d", t.RowNumber, t.SeatNumber), "", 0, "L", false, 0, "")
+		pdf.CellFormat(30, 7, string(t.Type), "", 0, "L", false, 0, "")
+		pdf.CellFormat(30, 7, session.Price.String(), "", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 7, discount.String(), "", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 7, t.Price.String(), "", 1, "R", false, 0, "")
+	}
+	documentTotals(pdf, template, total)
+
+	return finishDocument(pdf, template)
+}
+
+func (s *DocumentService) RenderPaymentReceipt(ctx context.Context, paymentID, userID primitive.ObjectID) (_ []byte, err error) {
+	ctx, span := tracing.Start(ctx, "DocumentService.RenderPaymentReceipt")
+	defer tracing.End(span, &err)
+
+	payment, err := s.paymentRepo.FindByID(ctx, paymentID)
+	if err != nil {
+		return nil, notFound(err, ErrPaymentNotFound)
+	}
+
+	if payment.UserID != userID {
+		return nil, ErrPaymentForbidden
+	}
+
+	template, err := s.GetTemplate(ctx, models.DocumentReceipt)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := newDocument(template)
+
+	pdf.SetFont(documentFont, "", 11)
+	documentRow(pdf, "Transaction", payment.TransactionCode)
+	documentRow(pdf, "Date", payment.CreatedAt.Format(documentTimeLayout))
+	documentRow(pdf, "Status", string(payment.Status))
+	if !payment.PaymentCardID.IsZero() {
+		if card, err := s.cardRepo.FindByID(ctx, payment.PaymentCardID); err == nil {
+			documentRow(pdf, "Card", maskCardNumber(card.CardNumber))
+			documentRow(pdf, "Card holder", card.CardHolderName)
+		}
+	}
+	pdf.Ln(4)
+
+	pdf.SetFont(documentFont, "B", 10)
+	pdf.CellFormat(140, 7, "Description", "B", 0, "L", false, 0, "")
+	pdf.CellFormat(30, 7, "Amount", "B", 1, "R", false, 0, "")
+	pdf.SetFont(documentFont, "", 10)
+
+	tickets, err := s.ticketRepo.GetByPaymentIDs(ctx, []primitive.ObjectID{payment.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tickets) == 0 {
+		pdf.CellFormat(140, 7, "Balance top-up", "", 0, "L", false, 0, "")
+		pdf.CellFormat(30, 7, payment.Amount.String(), "", 1, "R", false, 0, "")
+	}
+	for _, t := range tickets {
+		description := fmt.Sprintf("%s - row %d, seat %d (%s)", t.MovieTitle, t.RowNumber, t.SeatNumber, t.Type)
+		pdf.CellFormat(140, 7, description, "", 0, "L", false, 0, "")
+		pdf.CellFormat(30, 7, t.Price.String(), "", 1, "R", false, 0, "")
+	}
+	documentTotals(pdf, template, payment.Amount)
+
+	return finishDocument(pdf, template)
+}
+
+func newDocument(template *models.DocumentTemplate) *fpdf.Fpdf {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.SetTitle(template.CinemaName+" - "+template.HeaderText, true)
+	pdf.SetCreator(template.CinemaName, true)
+	pdf.AddUTF8FontFromBytes(documentFont, "", documentFontRegular)
+	pdf.AddUTF8FontFromBytes(documentFont, "B", documentFontBold)
+	pdf.AddPage()
+
+	r, g, b := parseHexColor(template.AccentColor)
+	pdf.SetFillColor(r, g, b)
+	pdf.Rect(0, 0, 210, 32, "F")
+
+	textLeft := 20.0
+	if len(template.LogoPNG) > 0 {
+		pdf.RegisterImageOptionsReader("logo", fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(template.LogoPNG))
+		if pdf.Ok() {
+			pdf.ImageOptions("logo", 20, 6, 0, 20, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+			textLeft = 48
+		} else {
+			pdf.ClearError()
+		}
+	}
+
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetXY(textLeft, 8)
+	pdf.SetFont(documentFont, "B", 18)
+	pdf.CellFormat(0, 9, template.CinemaName, "", 2, "L", false, 0, "")
+	pdf.SetFont(documentFont, "", 11)
+	pdf.CellFormat(0, 7, template.HeaderText, "", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetY(40)
+
+	return pdf
+}
+
+func documentRow(pdf *fpdf.Fpdf, label, value string) {
+	pdf.SetFont(documentFont, "B", 11)
+	pdf.CellFormat(35, 7, label+":", "", 0, "L", false, 0, "")
+	pdf.SetFont(documentFont, "", 11)
+	pdf.CellFormat(0, 7, value, "", 1, "L", false, 0, "")
+}
+
+func documentTotals(pdf *fpdf.Fpdf, template *models.DocumentTemplate, total money.Money) {
+	pdf.Ln(2)
+	pdf.SetFont(documentFont, "B", 11)
+	pdf.CellFormat(140, 8, "Total", "T", 0, "R", false, 0, "")
+	pdf.CellFormat(30, 8, total.String(), "T", 1, "R", false, 0, "")
+	if template.VATRate > 0 {
+		basisPoints := int64(math.Round(template.VATRate * 10000))
+		vat := total.Scale(basisPoints, 10000+basisPoints)
+		pdf.SetFont(documentFont, "", 10)
+		pdf.CellFormat(140, 7, fmt.Sprintf("incl. VAT %.0f%%", template.VATRate*100), "", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 7, vat.String(), "", 1, "R", false, 0, "")
+	}
+}
+
+func finishDocument(pdf *fpdf.Fpdf, template *models.DocumentTemplate) ([]byte, error) {
+	pdf.Ln(10)
+	pdf.SetFont(documentFont, "", 9)
+	pdf.SetTextColor(100, 100, 100)
+	if template.Address != "" {
+		pdf.CellFormat(0, 5, template.Address, "", 1, "C", false, 0, "")
+	}
+	if template.TaxID != "" {
+		pdf.CellFormat(0, 5, "Tax ID: "+template.TaxID, "", 1, "C", false, 0, "")
+	}
+	if template.FooterText != "" {
+		pdf.CellFormat(0, 5, template.FooterText, "", 1, "C", false, 0, "")
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func parseHexColor(hex string) (int, int, int) {
+	if len(hex) != 7 || hex[0] != '#' {
+		return 183, 28, 28
+	}
+	value, err := strconv.ParseUint(hex[1:], 16, 32)
+	if err != nil {
+		return 183, 28, 28
+	}
+	return int(value >> 16 & 0xFF), int(value >> 8 & 0xFF), int(value & 0xFF)
+}
+
+func maskCardNumber(number string) string {
+	if len(number) < 4 {
+		return number
+	}
+	return "**** **** **** " + number[len(number)-4:]
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"bytes"
+	"cinema-system/internal/models"
+	"testing"
+)
+
+func TestDocumentEmbedsUnicodeFont(t *testing.T) {
+	template := models.DefaultDocumentTemplate(models.DocumentTicket)
+	template.CinemaName = "Кинотеатр «Мангекё»"
+	template.HeaderText = "Билет / Қазақша: ә, ғ, қ, ң, ө, ұ, ү, һ, і"
+	template.FooterText = "Рахмет!"
+
+	pdf := newDocument(template)
+	documentRow(pdf, "Фильм", "Көшпенділер")
+	out, err := finishDocument(pdf, template)
+	if err != nil {
+		t.Fatalf("finishDocument: %v", err)
+	}
+	if !bytes.Contains(out, []byte("/FontFile2")) || !bytes.Contains(out, []byte("/BaseFont /utf8dejavu")) {
+		t.Fatal("document does not embed the UTF-8 font")
+	}
+	if bytes.Contains(out, []byte("/BaseFont /Helvetica")) {
+		t.Fatal("document still uses the cp1252 core font")
+	}
+}
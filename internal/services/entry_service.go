@@ -0,0 +1,308 @@
+package services
+
+import (
+	"bytes"
+	"cinema-system/internal/config"
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	ticketTokenVersion = "v1"
+	EntryOpensBefore   = 60 * time.Minute
+	qrCodeSize         = 320
+)
+
+type ticketTokenPayload struct {
+	TicketID  string `json:"t"`
+	SessionID string `json:"s"`
+	Row       int    `json:"r"`
+	Seat      int    `json:"n"`
+}
+
+type EntryService struct {
+	ticketRepo  repositories.TicketStore
+	sessionRepo repositories.SessionStore
+	secret      []byte
+}
+
+func NewEntryService(ticketRepo repositories.TicketStore, sessionRepo repositories.SessionStore, cfg *config.AuthConfig) *EntryService {
+	return &EntryService{
+		ticketRepo:  ticketRepo,
+		sessionRepo: sessionRepo,
+		secret:      []byte(cfg.TicketSigningSecret),
+	}
+}
+
+func (s *EntryService) sign(data string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(data))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *EntryService) GenerateTicketToken(ticket *models.Ticket) (string, error) {
+	payload, err := json.Marshal(ticketTokenPayload{
+		TicketID:  ticket.ID.Hex(),
+		SessionID: ticket.SessionID.Hex(),
+		Row:       ticket.RowNumber,
+		Seat:      ticket.SeatNumber,
+	})
+	if err != nil {
+		return "", err
+	}
+	body := ticketTokenVersion + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return body + "." + s.sign(body), nil
+}
+
+func (s *EntryService) parseTicketToken(token string) (*ticketTokenPayload, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != ticketTokenVersion {
+		return nil, ErrInvalidTicketToken
+	}
+
+	body := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(s.sign(body)), []byte(parts[2])) {
+		return nil, ErrInvalidTicketToken
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidTicketToken
+	}
+
+	var payload ticketTokenPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, ErrInvalidTicketToken
+	}
+	return &payload, nil
+}
+
+func tokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *EntryService) GetTicketToken(ctx context.Context, ticketID, userID primitive.ObjectID) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "EntryService.GetTicketToken")
+	defer tracing.End(span, &err)
+
+	ticket, err := s.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return "", notFound(err, ErrTicketNotFound)
+	}
+
+	if ticket.UserID != userID {
+		return "", ErrTicketForbidden
+	}
+
+	if ticket.Status != models.TicketPaid && ticket.Status != models.TicketUsed {
+		return "", ErrTicketNotValid.With("status", strings.ToLower(string(ticket.Status)))
+	}
+
+	return s.GenerateTicketToken(ticket)
+}
+
+func (s *EntryService) RenderQRCode(token, format string) ([]byte, string, error) {
+	switch format {
+	case "", "png":
+		png, err := qrcode.Encode(token, qrcode.Medium, qrCodeSize)
+		if err != nil {
+			return nil, "", err
+		}
+		return png, "image/png", nil
+	case "svg":
+		qr, err := qrcode.New(token, qrcode.Medium)
+		if err != nil {
+			return nil, "", err
+		}
+		return renderSVG(qr.Bitmap()), "image/svg+xml", nil
+	default:
+		return nil, "", ErrUnsupportedQRFormat.With("format", format)
+	}
+}
+
+func renderSVG(bitmap [][]bool) []byte {
+	size := len(bitmap)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#fff"/>`, size, size)
+	buf.WriteString(`<path fill="#000" d="`)
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&buf, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	buf.WriteString(`"/></svg>`)
+	return buf.Bytes()
+}
+
+func (s *EntryService) ScanTicket(ctx context.Context, token string) (_ *models.TicketScan, err error) {
+	ctx, span := tracing.Start(ctx, "EntryService.ScanTicket")
+	defer tracing.End(span, &err)
+
+	return s.admit(ctx, token, time.Now())
+}
+
+func (s *EntryService) admit(ctx context.Context, token string, scannedAt time.Time) (*models.TicketScan, error) {
+	payload, err := s.parseTicketToken(token)
+	if err != nil {
+		return &models.TicketScan{Result: models.ScanRejected, Reason: "invalid signature"}, nil
+	}
+
+	ticketID, err := primitive.ObjectIDFromHex(payload.TicketID)
+	if err != nil {
+		return &models.TicketScan{Result: models.ScanRejected, Reason: "invalid ticket ID"}, nil
+	}
+
+	ticket, err := s.ticketRepo.FindByID(ctx, ticketID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &models.TicketScan{Result: models.ScanRejected, Reason: "ticket not found"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ticket.SessionID.Hex() != payload.SessionID || ticket.RowNumber != payload.Row || ticket.SeatNumber != payload.Seat {
+		return &models.TicketScan{Result: models.ScanRejected, Reason: "token does not match ticket", Ticket: ticket}, nil
+	}
+
+	if scan := unadmittable(ticket); scan != nil {
+		return scan, nil
+	}
+
+	session, err := s.sessionRepo.FindByID(ctx, ticket.SessionID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &models.TicketScan{Result: models.ScanRejected, Reason: "session not found", Ticket: ticket}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if scannedAt.Before(session.StartTime.Add(-EntryOpensBefore)) {
+		return &models.TicketScan{Result: models.ScanRejected, Reason: "entry is not open yet", Ticket: ticket}, nil
+	}
+	if scannedAt.After(session.EndTime) {
+		return &models.TicketScan{Result: models.ScanRejected, Reason: "session has ended", Ticket: ticket}, nil
+	}
+
+	marked, err := s.ticketRepo.MarkUsed(ctx, ticket.ID, scannedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if !marked {
+		current, err := s.ticketRepo.FindByID(ctx, ticket.ID)
+		if err != nil {
+			return nil, err
+		}
+		if scan := unadmittable(current); scan != nil {
+			return scan, nil
+		}
+		return nil, fmt.Errorf("ticket %s was not marked used", ticket.ID.Hex())
+	}
+
+	ticket.Status = models.TicketUsed
+	ticket.UsedAt = &scannedAt
+	return &models.TicketScan{Result: models.ScanAdmitted, Ticket: ticket, UsedAt: &scannedAt}, nil
+}
+
+func unadmittable(ticket *models.Ticket) *models.TicketScan {
+	switch ticket.Status {
+	case models.TicketPaid:
+		return nil
+	case models.TicketUsed:
+		return &models.TicketScan{Result: models.ScanDuplicate, Reason: "ticket already used", Ticket: ticket, UsedAt: ticket.UsedAt}
+	default:
+		return &models.TicketScan{Result: models.ScanRejected, Reason: "ticket is " + strings.ToLower(string(ticket.Status)), Ticket: ticket}
+	}
+}
+
+func (s *EntryService) GetSessionManifest(ctx context.Context, sessionID primitive.ObjectID) (_ *models.SessionManifest, err error) {
+	ctx, span := tracing.Start(ctx, "EntryService.GetSessionManifest")
+	defer tracing.End(span, &err)
+
+	session, err := s.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, notFound(err, ErrSessionNotFound)
+	}
+
+	tickets, err := s.ticketRepo.GetBySession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &models.SessionManifest{
+		SessionID:   sessionID,
+		ValidFrom:   session.StartTime.Add(-EntryOpensBefore),
+		ValidUntil:  session.EndTime,
+		GeneratedAt: time.Now(),
+		Tickets:     make([]models.ManifestEntry, 0, len(tickets)),
+	}
+
+	for i := range tickets {
+		ticket := &tickets[i]
+		if manifest.MovieTitle == "" {
+			manifest.MovieTitle = ticket.MovieTitle
+		}
+		token, err := s.GenerateTicketToken(ticket)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Tickets = append(manifest.Tickets, models.ManifestEntry{
+			TicketID:   ticket.ID,
+			RowNumber:  ticket.RowNumber,
+			SeatNumber: ticket.SeatNumber,
+			Status:     ticket.Status,
+			TokenHash:  tokenHash(token),
+		})
+	}
+
+	return manifest, nil
+}
+
+func (s *EntryService) SyncScans(ctx context.Context, scans []models.OfflineScan) (_ []models.TicketScan, err error) {
+	ctx, span := tracing.Start(ctx, "EntryService.SyncScans")
+	defer tracing.End(span, &err)
+
+	ordered := make([]models.OfflineScan, len(scans))
+	copy(ordered, scans)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].ScannedAt.Before(ordered[j].ScannedAt)
+	})
+
+	results := make([]models.TicketScan, 0, len(ordered))
+	for _, scan := range ordered {
+		scannedAt := scan.ScannedAt
+		if scannedAt.IsZero() || scannedAt.After(time.Now()) {
+			scannedAt = time.Now()
+		}
+
+		result, err := s.admit(ctx, scan.Token, scannedAt)
+		if err != nil {
+			return nil, err
+		}
+		result.DeviceID = scan.DeviceID
+		result.ClientID = scan.ClientID
+		result.TokenHash = tokenHash(scan.Token)
+		results = append(results, *result)
+	}
+	return results, nil
+}
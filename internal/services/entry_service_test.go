@@ -0,0 +1,201 @@
+package services
+
+import (
+	"cinema-system/internal/config"
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (b *testBackend) createScanTicket(t *testing.T, start time.Time) *models.Ticket {
+	t.Helper()
+	ctx := context.Background()
+	movie := b.createMovie(t, "12+")
+	session := &models.Session{MovieID: movie.ID, HallID: b.createHall(t).ID, StartTime: start, EndTime: start.Add(2 * time.Hour)}
+	if err := b.sessions.Create(ctx, session); err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	ticket := &models.Ticket{UserID: b.createUser(t, 0).ID, SessionID: session.ID, RowNumber: 2, SeatNumber: 5, Status: models.TicketPaid, CreatedAt: time.Now()}
+	if err := b.tickets.Create(ctx, ticket); err != nil {
+		t.Fatalf("create ticket: %v", err)
+	}
+	return ticket
+}
+
+func TestScanRejectsTamperedTokens(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		service := b.entryService()
+		ticket := b.createScanTicket(t, time.Now().Add(30*time.Minute))
+
+		token, err := service.GenerateTicketToken(ticket)
+		if err != nil {
+			t.Fatalf("GenerateTicketToken: %v", err)
+		}
+		parts := strings.Split(token, ".")
+		raw, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		moved := base64.RawURLEncoding.EncodeToString([]byte(strings.Replace(string(raw), `"n":5`, `"n":6`, 1)))
+
+		foreign := NewEntryService(b.tickets, b.sessions, &config.AuthConfig{TicketSigningSecret: "another-secret"})
+		forged, err := foreign.GenerateTicketToken(ticket)
+		if err != nil {
+			t.Fatalf("GenerateTicketToken: %v", err)
+		}
+		misplaced := *ticket
+		misplaced.SeatNumber = 6
+		mismatched, err := service.GenerateTicketToken(&misplaced)
+		if err != nil {
+			t.Fatalf("GenerateTicketToken: %v", err)
+		}
+
+		for name, tc := range map[string]struct{ token, reason string }{
+			"edited payload":  {parts[0] + "." + moved + "." + parts[2], "invalid signature"},
+			"foreign secret":  {forged, "invalid signature"},
+			"missing part":    {parts[0] + "." + parts[1], "invalid signature"},
+			"other version":   {"v2." + parts[1] + "." + parts[2], "invalid signature"},
+			"different seat":  {mismatched, "token does not match ticket"},
+			"not a token":     {"hello", "invalid signature"},
+			"empty signature": {parts[0] + "." + parts[1] + ".", "invalid signature"},
+		} {
+			scan, err := service.ScanTicket(ctx, tc.token)
+			if err != nil {
+				t.Fatalf("%s: ScanTicket: %v", name, err)
+			}
+			if scan.Result != models.ScanRejected || scan.Reason != tc.reason {
+				t.Fatalf("%s: scan = %s %q, want REJECTED %q", name, scan.Result, scan.Reason, tc.reason)
+			}
+		}
+
+		scan, err := service.ScanTicket(ctx, token)
+		if err != nil || scan.Result != models.ScanAdmitted {
+			t.Fatalf("genuine token = %+v, %v", scan, err)
+		}
+	})
+}
+
+func TestScanEnforcesEntryWindow(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		service := b.entryService()
+
+		for name, tc := range map[string]struct {
+			start  time.Time
+			reason string
+		}{
+			"too early": {time.Now().Add(EntryOpensBefore + time.Hour), "entry is not open yet"},
+			"ended":     {time.Now().Add(-3 * time.Hour), "session has ended"},
+		} {
+			ticket := b.createScanTicket(t, tc.start)
+			token, err := service.GenerateTicketToken(ticket)
+			if err != nil {
+				t.Fatalf("GenerateTicketToken: %v", err)
+			}
+			scan, err := service.ScanTicket(ctx, token)
+			if err != nil {
+				t.Fatalf("%s: ScanTicket: %v", name, err)
+			}
+			if scan.Result != models.ScanRejected || scan.Reason != tc.reason {
+				t.Fatalf("%s: scan = %s %q, want REJECTED %q", name, scan.Result, scan.Reason, tc.reason)
+			}
+		}
+	})
+}
+
+func TestSyncScansIdentifiesEachResult(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		service := b.entryService()
+		ticket := b.createScanTicket(t, time.Now().Add(30*time.Minute))
+
+		token, err := service.GenerateTicketToken(ticket)
+		if err != nil {
+			t.Fatalf("GenerateTicketToken: %v", err)
+		}
+		now := time.Now()
+		results, err := service.SyncScans(ctx, []models.OfflineScan{
+			{Token: token, ScannedAt: now.Add(-time.Minute), DeviceID: "gate-2", ClientID: "late"},
+			{Token: "garbage", ScannedAt: now.Add(-3 * time.Minute), DeviceID: "gate-1", ClientID: "bad"},
+			{Token: token, ScannedAt: now.Add(-2 * time.Minute), DeviceID: "gate-1", ClientID: "early"},
+		})
+		if err != nil {
+			t.Fatalf("SyncScans: %v", err)
+		}
+
+		want := []struct {
+			client string
+			token  string
+			result models.ScanResult
+		}{
+			{"bad", "garbage", models.ScanRejected},
+			{"early", token, models.ScanAdmitted},
+			{"late", token, models.ScanDuplicate},
+		}
+		if len(results) != len(want) {
+			t.Fatalf("results = %+v", results)
+		}
+		for i, w := range want {
+			got := results[i]
+			if got.ClientID != w.client || got.TokenHash != tokenHash(w.token) || got.Result != w.result {
+				t.Fatalf("result %d = %s %s %s, want %s %s", i, got.ClientID, got.TokenHash, got.Result, w.client, w.result)
+			}
+		}
+	})
+}
+
+func TestScanReportsTicketCancelledAfterCheck(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		ticket := b.createScanTicket(t, time.Now().Add(30*time.Minute))
+		paid := *ticket
+		if err := b.tickets.UpdateStatus(ctx, ticket.ID, models.TicketCancelled); err != nil {
+			t.Fatalf("UpdateStatus: %v", err)
+		}
+
+		service := NewEntryService(&staleTicketStore{TicketStore: b.tickets, stale: &paid}, b.sessions, testAuthConfig)
+		token, err := service.GenerateTicketToken(ticket)
+		if err != nil {
+			t.Fatalf("GenerateTicketToken: %v", err)
+		}
+		scan, err := service.ScanTicket(ctx, token)
+		if err != nil {
+			t.Fatalf("ScanTicket: %v", err)
+		}
+		if scan.Result != models.ScanRejected || scan.Reason != "ticket is cancelled" {
+			t.Fatalf("scan = %s %q, want REJECTED for the cancelled ticket", scan.Result, scan.Reason)
+		}
+	})
+}
+
+type unavailableTicketStore struct {
+	repositories.TicketStore
+}
+
+func (unavailableTicketStore) FindByID(context.Context, primitive.ObjectID) (*models.Ticket, error) {
+	return nil, errors.New("connection reset")
+}
+
+func TestEntryReturnsTicketStoreErrors(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		ticket := b.createScanTicket(t, time.Now().Add(30*time.Minute))
+		service := NewEntryService(unavailableTicketStore{b.tickets}, b.sessions, testAuthConfig)
+
+		if _, err := service.GetTicketToken(ctx, ticket.ID, ticket.UserID); err == nil || errors.Is(err, ErrTicketNotFound) {
+			t.Fatalf("GetTicketToken err = %v, want the store error", err)
+		}
+		token, err := service.GenerateTicketToken(ticket)
+		if err != nil {
+			t.Fatalf("GenerateTicketToken: %v", err)
+		}
+		if scan, err := service.ScanTicket(ctx, token); err == nil {
+			t.Fatalf("ScanTicket = %+v, want the store error", scan)
+		}
+	})
+}
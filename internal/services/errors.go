@@ -0,0 +1,88 @@
+package services
+
+import (
+	"cinema-system/internal/apperrors"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrUserNotFound            = apperrors.NotFound("user_not_found", "user not found")
+	ErrMovieNotFound           = apperrors.NotFound("movie_not_found", "movie not found")
+	ErrGenreNotFound           = apperrors.NotFound("genre_not_found", "genre not found")
+	ErrSessionNotFound         = apperrors.NotFound("session_not_found", "session not found")
+	ErrHallNotFound            = apperrors.NotFound("hall_not_found", "hall not found")
+	ErrTicketNotFound          = apperrors.NotFound("ticket_not_found", "ticket not found")
+	ErrPaymentNotFound         = apperrors.NotFound("payment_not_found", "payment not found")
+	ErrPaymentCardNotFound     = apperrors.NotFound("payment_card_not_found", "payment card not found")
+	ErrReviewNotFound          = apperrors.NotFound("review_not_found", "review not found")
+	ErrNotificationNotFound    = apperrors.NotFound("notification_not_found", "notification not found")
+	ErrWebhookNotFound         = apperrors.NotFound("webhook_not_found", "webhook subscription not found")
+	ErrWebhookDeliveryNotFound = apperrors.NotFound("webhook_delivery_not_found", "webhook delivery not found")
+	ErrWalletPassNotFound      = apperrors.NotFound("wallet_pass_not_found", "pass not found")
+	ErrExchangeRateNotFound    = apperrors.NotFound("exchange_rate_not_found", "no exchange rate from {from} to {to}")
+	ErrReviewVoteNotFound      = apperrors.NotFound("review_vote_not_found", "vote not found")
+	ErrReviewReplyNotFound     = apperrors.NotFound("review_reply_not_found", "review has no reply")
+
+	ErrNoSeatsSelected       = apperrors.Validation("no_seats_selected", "no seats selected")
+	ErrInvalidSeat           = apperrors.Validation("invalid_seat", "invalid seat position: row {row}, seat {seat}")
+	ErrInvalidTicketType     = apperrors.Validation("invalid_ticket_type", "invalid ticket type: {type}")
+	ErrKidTicketNotAllowed   = apperrors.Validation("kid_ticket_not_allowed", "kids tickets are not allowed for 18+ movies")
+	ErrInvalidRating         = apperrors.Validation("invalid_rating", "rating must be between 0 and 10")
+	ErrMovieIDRequired       = apperrors.Validation("movie_id_required", "movie ID is required")
+	ErrHallIDRequired        = apperrors.Validation("hall_id_required", "hall ID is required")
+	ErrSessionInPast         = apperrors.Validation("session_in_past", "cannot schedule sessions in the past")
+	ErrInvalidMovieQuery     = apperrors.Validation("invalid_movie_query", "invalid movie query")
+	ErrInvalidAnalyticsQuery = apperrors.Validation("invalid_analytics_query", "invalid analytics query")
+	ErrUnsupportedExport     = apperrors.Validation("unsupported_export_report", "unsupported export report: {report}")
+	ErrInvalidWebhookURL     = apperrors.Validation("invalid_webhook_url", "webhook URL must be an absolute http or https URL")
+	ErrUnsupportedEventType  = apperrors.Validation("unsupported_event_type", "unsupported event type: {type}")
+	ErrUnsupportedQRFormat   = apperrors.Validation("unsupported_qr_format", "unsupported QR format: {format}")
+	ErrInvalidTicketToken    = apperrors.Validation("invalid_ticket_token", "invalid ticket token")
+	ErrUnsupportedLanguage   = apperrors.Validation("unsupported_language", "unsupported language: {lang}")
+	ErrInvalidPrice          = apperrors.Validation("invalid_price", "price must not be negative")
+	ErrSameCurrency          = apperrors.Validation("same_currency", "exchange rate currencies must differ")
+	ErrReviewTooShort        = apperrors.Validation("review_too_short", "review must be at least {min} characters")
+	ErrReviewTooLong         = apperrors.Validation("review_too_long", "review must be at most {max} characters")
+	ErrModerationReason      = apperrors.Validation("moderation_reason_required", "a reason is required to reject a review")
+	ErrCannotReportOwn       = apperrors.Validation("cannot_report_own_review", "you cannot report your own review")
+	ErrCannotVoteOwn         = apperrors.Validation("cannot_vote_own_review", "you cannot vote on your own review")
+	ErrHallCurrency          = apperrors.Validation("hall_currency_unsupported", "halls can only be priced in {currency} while balances are kept in {currency}")
+
+	ErrUserAlreadyExists        = apperrors.Conflict("user_already_exists", "user with this email already exists")
+	ErrEmailInUse               = apperrors.Conflict("email_in_use", "email already in use")
+	ErrSeatUnavailable          = apperrors.Conflict("seat_unavailable", "seat already booked: row {row}, seat {seat}")
+	ErrReviewAlreadyExists      = apperrors.Conflict("review_already_exists", "user already reviewed this movie")
+	ErrTicketAlreadyCancelled   = apperrors.Conflict("ticket_already_cancelled", "ticket already cancelled")
+	ErrTicketAlreadyUsed        = apperrors.Conflict("ticket_already_used", "used tickets cannot be cancelled")
+	ErrTicketNotValid           = apperrors.Conflict("ticket_not_valid", "ticket is {status}")
+	ErrHallOccupied             = apperrors.Conflict("hall_occupied", "the selected hall is already occupied during this time period")
+	ErrPaymentNotRefundable     = apperrors.Conflict("payment_not_refundable", "only completed payments can be refunded")
+	ErrNotificationNotRetryable = apperrors.Conflict("notification_not_retryable", "only failed notifications can be retried")
+	ErrBalanceCurrencyMismatch  = apperrors.Conflict("balance_currency_mismatch", "amount is in {currency} but your balance is in {balance}")
+	ErrReviewAlreadyReported    = apperrors.Conflict("review_already_reported", "you already reported this review")
+	ErrReviewNotReportable      = apperrors.Conflict("review_not_reportable", "only published reviews can be reported")
+	ErrReviewNotVotable         = apperrors.Conflict("review_not_votable", "only published reviews can be voted on")
+
+	ErrTicketForbidden      = apperrors.Forbidden("ticket_forbidden", "unauthorized access to ticket")
+	ErrPaymentForbidden     = apperrors.Forbidden("payment_forbidden", "unauthorized access to payment")
+	ErrPaymentCardForbidden = apperrors.Forbidden("payment_card_forbidden", "unauthorized access to payment card")
+	ErrReviewForbidden      = apperrors.Forbidden("review_forbidden", "unauthorized access to review")
+	ErrVerifiedViewerOnly   = apperrors.Forbidden("verified_viewer_required", "only viewers with a ticket for this movie can review it")
+
+	ErrInsufficientBalance = apperrors.InsufficientFunds("insufficient_balance", "insufficient balance: need {need}, have {have}")
+
+	ErrInvalidCredentials = apperrors.Unauthorized("invalid_credentials", "invalid credentials")
+	ErrWalletUnauthorized = apperrors.Unauthorized("wallet_unauthorized", "invalid pass authentication token")
+
+	ErrAppleWalletDisabled  = apperrors.NotImplemented("apple_wallet_disabled", "apple wallet is not configured")
+	ErrGoogleWalletDisabled = apperrors.NotImplemented("google_wallet_disabled", "google wallet is not configured")
+)
+
+func notFound(err error, notFoundErr *apperrors.Error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return notFoundErr
+	}
+	return err
+}
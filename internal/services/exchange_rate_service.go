@@ -0,0 +1,143 @@
+package services
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/money"
+	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ExchangeRateService struct {
+	rateRepo repositories.ExchangeRateStore
+}
+
+func NewExchangeRateService(rateRepo repositories.ExchangeRateStore) *ExchangeRateService {
+	return &ExchangeRateService{rateRepo: rateRepo}
+}
+
+func (s *ExchangeRateService) GetRates(ctx context.Context) (_ []models.ExchangeRate, err error) {
+	ctx, span := tracing.Start(ctx, "ExchangeRateService.GetRates")
+	defer tracing.End(span, &err)
+
+	rates, err := s.rateRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rates == nil {
+		rates = []models.ExchangeRate{}
+	}
+	return rates, nil
+}
+
+func (s *ExchangeRateService) SetRate(ctx context.Context, from, to, rate string) (_ *models.ExchangeRate, err error) {
+	ctx, span := tracing.Start(ctx, "ExchangeRateService.SetRate")
+	defer tracing.End(span, &err)
+
+	source, target, err := currencyPair(from, to)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := money.ParseRate(rate); err != nil {
+		return nil, err
+	}
+
+	exchangeRate := &models.ExchangeRate{
+		From:      source.Code,
+		To:        target.Code,
+		Rate:      strings.TrimSpace(rate),
+		UpdatedAt: time.Now(),
+	}
+	if err := s.rateRepo.Upsert(ctx, exchangeRate); err != nil {
+		return nil, err
+	}
+	return exchangeRate, nil
+}
+
+func (s *ExchangeRateService) DeleteRate(ctx context.Context, from, to string) (err error) {
+	ctx, span := tracing.Start(ctx, "ExchangeRateService.DeleteRate")
+	defer tracing.End(span, &err)
+
+	source, target, err := currencyPair(from, to)
+	if err != nil {
+		return err
+	}
+	if err := s.rateRepo.Delete(ctx, source.Code, target.Code); err != nil {
+		return notFound(err, ErrExchangeRateNotFound.With("from", source.Code).With("to", target.Code))
+	}
+	return nil
+}
+
+func (s *ExchangeRateService) Convert(ctx context.Context, amount money.Money, to string) (_ money.Money, err error) {
+	ctx, span := tracing.Start(ctx, "ExchangeRateService.Convert")
+	defer tracing.End(span, &err)
+
+	target, err := money.Lookup(to)
+	if err != nil {
+		return money.Money{}, err
+	}
+	if amount.Currency == target.Code {
+		return amount, nil
+	}
+
+	rate, err := s.rate(ctx, amount.Currency, target.Code)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return amount.Convert(target.Code, rate)
+}
+
+func (s *ExchangeRateService) ApplyDisplayPrices(ctx context.Context, sessions []models.Session, currency string) (err error) {
+	ctx, span := tracing.Start(ctx, "ExchangeRateService.ApplyDisplayPrices")
+	defer tracing.End(span, &err)
+
+	for i := range sessions {
+		price, err := s.Convert(ctx, sessions[i].Price, currency)
+		if err != nil {
+			return err
+		}
+		sessions[i].DisplayPrice = &price
+	}
+	return nil
+}
+
+func (s *ExchangeRateService) rate(ctx context.Context, from, to string) (*big.Rat, error) {
+	direct, err := s.rateRepo.Find(ctx, from, to)
+	if err == nil {
+		return money.ParseRate(direct.Rate)
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	inverse, err := s.rateRepo.Find(ctx, to, from)
+	if err != nil {
+		return nil, notFound(err, ErrExchangeRateNotFound.With("from", from).With("to", to))
+	}
+	rate, err := money.ParseRate(inverse.Rate)
+	if err != nil {
+		return nil, err
+	}
+	return rate.Inv(rate), nil
+}
+
+func currencyPair(from, to string) (money.Currency, money.Currency, error) {
+	source, err := money.Lookup(from)
+	if err != nil {
+		return money.Currency{}, money.Currency{}, err
+	}
+	target, err := money.Lookup(to)
+	if err != nil {
+		return money.Currency{}, money.Currency{}, err
+	}
+	if source.Code == target.Code {
+		return money.Currency{}, money.Currency{}, ErrSameCurrency
+	}
+	return source, target, nil
+}
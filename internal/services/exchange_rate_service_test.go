@@ -0,0 +1,52 @@
+package services
+
+import (
+	"cinema-system/internal/models"
+	"cinema-system/internal/money"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestExchangeRates(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		service := b.exchangeRateService()
+
+		if _, err := service.SetRate(ctx, "KZT", "KZT", "1"); !errors.Is(err, ErrSameCurrency) {
+			t.Fatalf("err = %v, want %v", err, ErrSameCurrency)
+		}
+		if _, err := service.SetRate(ctx, "KZT", "XYZ", "1"); !errors.Is(err, money.ErrUnsupportedCurrency) {
+			t.Fatalf("err = %v, want %v", err, money.ErrUnsupportedCurrency)
+		}
+		if _, err := service.SetRate(ctx, "usd", "kzt", "500"); err != nil {
+			t.Fatalf("SetRate: %v", err)
+		}
+		if _, err := service.SetRate(ctx, "USD", "KZT", "480"); err != nil {
+			t.Fatalf("SetRate: %v", err)
+		}
+
+		rates, err := service.GetRates(ctx)
+		if err != nil || len(rates) != 1 || rates[0].From != "USD" || rates[0].To != "KZT" || rates[0].Rate != "480" {
+			t.Fatalf("rates = %+v, err = %v", rates, err)
+		}
+
+		sessions := []models.Session{{Price: money.New(240000, "KZT")}, {Price: money.New(1000, "USD")}}
+		if err := service.ApplyDisplayPrices(ctx, sessions, "USD"); err != nil {
+			t.Fatalf("ApplyDisplayPrices: %v", err)
+		}
+		if *sessions[0].DisplayPrice != money.New(500, "USD") || *sessions[1].DisplayPrice != money.New(1000, "USD") {
+			t.Fatalf("display prices = %+v, %+v", sessions[0].DisplayPrice, sessions[1].DisplayPrice)
+		}
+
+		if _, err := service.Convert(ctx, money.New(100, "KZT"), "EUR"); !errors.Is(err, ErrExchangeRateNotFound) {
+			t.Fatalf("err = %v, want %v", err, ErrExchangeRateNotFound)
+		}
+		if err := service.DeleteRate(ctx, "USD", "KZT"); err != nil {
+			t.Fatalf("DeleteRate: %v", err)
+		}
+		if err := service.DeleteRate(ctx, "USD", "KZT"); !errors.Is(err, ErrExchangeRateNotFound) {
+			t.Fatalf("err = %v, want %v", err, ErrExchangeRateNotFound)
+		}
+	})
+}
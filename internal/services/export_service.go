@@ -0,0 +1,177 @@
+package services
+
+import (
+	"cinema-system/internal/export"
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
+	"context"
+	"io"
+)
+
+const (
+	ExportSales     = "sales"
+	ExportOccupancy = "occupancy"
+)
+
+var ticketColumns = []export.Column[models.Ticket]{
+	{Key: "id", Header: "ID", Value: func(t *models.Ticket) interface{} { return t.ID }},
+	{Key: "user_id", Header: "User ID", Value: func(t *models.Ticket) interface{} { return t.UserID }},
+	{Key: "session_id", Header: "Session ID", Value: func(t *models.Ticket) interface{} { return t.SessionID }},
+	{Key: "payment_id", Header: "Payment ID", Value: func(t *models.Ticket) interface{} { return t.PaymentID }},
+	{Key: "movie_title", Header: "Movie", Value: func(t *models.Ticket) interface{} { return t.MovieTitle }},
+	{Key: "row", Header: "Row", Value: func(t *models.Ticket) interface{} { return t.RowNumber }},
+	{Key: "seat", Header: "Seat", Value: func(t *models.Ticket) interface{} { return t.SeatNumber }},
+	{Key: "type", Header: "Type", Value: func(t *models.Ticket) interface{} { return string(t.Type) }},
+	{Key: "price", Header: "Price", Value: func(t *models.Ticket) interface{} { return t.Price }},
+	{Key: "currency", Header: "Currency", Value: func(t *models.Ticket) interface{} { return t.Price.Currency }},
+	{Key: "status", Header: "Status", Value: func(t *models.Ticket) interface{} { return string(t.Status) }},
+	{Key: "used_at", Header: "Used At", Value: func(t *models.Ticket) interface{} { return t.UsedAt }},
+	{Key: "created_at", Header: "Created At", Value: func(t *models.Ticket) interface{} { return t.CreatedAt }},
+}
+
+var paymentColumns = []export.Column[models.Payment]{
+	{Key: "id", Header: "ID", Value: func(p *models.Payment) interface{} { return p.ID }},
+	{Key: "user_id", Header: "User ID", Value: func(p *models.Payment) interface{} { return p.UserID }},
+	{Key: "payment_card_id", Header: "Payment Card ID", Value: func(p *models.Payment) interface{} { return p.PaymentCardID }},
+	{Key: "transaction_code", Header: "Transaction Code", Value: func(p *models.Payment) interface{} { return p.TransactionCode }},
+	{Key: "amount", Header: "Amount", Value: func(p *models.Payment) interface{} { return p.Amount }},
+	{Key: "currency", Header: "Currency", Value: func(p *models.Payment) interface{} { return p.Amount.Currency }},
+	{Key: "status", Header: "Status", Value: func(p *models.Payment) interface{} { return string(p.Status) }},
+	{Key: "created_at", Header: "Created At", Value: func(p *models.Payment) interface{} { return p.CreatedAt }},
+}
+
+var userColumns = []export.Column[models.User]{
+	{Key: "id", Header: "ID", Value: func(u *models.User) interface{} { return u.ID }},
+	{Key: "first_name", Header: "First Name", Value: func(u *models.User) interface{} { return u.FirstName }},
+	{Key: "last_name", Header: "Last Name", Value: func(u *models.User) interface{} { return u.LastName }},
+	{Key: "email", Header: "Email", Value: func(u *models.User) interface{} { return u.Email }},
+	{Key: "phone_number", Header: "Phone Number", Value: func(u *models.User) interface{} { return u.PhoneNumber }},
+	{Key: "role", Header: "Role", Value: func(u *models.User) interface{} { return string(u.Role) }},
+	{Key: "balance", Header: "Balance", Value: func(u *models.User) interface{} { return u.Balance }},
+	{Key: "currency", Header: "Currency", Value: func(u *models.User) interface{} { return u.Balance.Currency }},
+	{Key: "created_at", Header: "Created At", Value: func(u *models.User) interface{} { return u.CreatedAt }},
+}
+
+var salesColumns = []export.Column[models.SalesBucket]{
+	{Key: "key", Header: "Key", Value: func(b *models.SalesBucket) interface{} { return b.Key }},
+	{Key: "label", Header: "Label", Value: func(b *models.SalesBucket) interface{} { return b.Label }},
+	{Key: "currency", Header: "Currency", Value: func(b *models.SalesBucket) interface{} { return b.Currency }},
+	{Key: "tickets_sold", Header: "Tickets Sold", Value: func(b *models.SalesBucket) interface{} { return b.TicketsSold }},
+	{Key: "refunded", Header: "Refunded", Value: func(b *models.SalesBucket) interface{} { return b.Refunded }},
+	{Key: "revenue", Header: "Revenue", Value: func(b *models.SalesBucket) interface{} { return b.Revenue }},
+	{Key: "refunded_total", Header: "Refunded Total", Value: func(b *models.SalesBucket) interface{} { return b.RefundedTotal }},
+	{Key: "average_price", Header: "Average Price", Value: func(b *models.SalesBucket) interface{} { return b.AveragePrice }},
+	{Key: "refund_rate", Header: "Refund Rate", Value: func(b *models.SalesBucket) interface{} { return b.RefundRate }},
+}
+
+var occupancyColumns = []export.Column[models.SessionOccupancy]{
+	{Key: "session_id", Header: "Session ID", Value: func(s *models.SessionOccupancy) interface{} { return s.SessionID }},
+	{Key: "movie_id", Header: "Movie ID", Value: func(s *models.SessionOccupancy) interface{} { return s.MovieID }},
+	{Key: "movie_name", Header: "Movie", Value: func(s *models.SessionOccupancy) interface{} { return s.MovieName }},
+	{Key: "hall_id", Header: "Hall ID", Value: func(s *models.SessionOccupancy) interface{} { return s.HallID }},
+	{Key: "hall_name", Header: "Hall", Value: func(s *models.SessionOccupancy) interface{} { return s.HallName }},
+	{Key: "hall_type", Header: "Hall Type", Value: func(s *models.SessionOccupancy) interface{} { return string(s.HallType) }},
+	{Key: "start_time", Header: "Start Time", Value: func(s *models.SessionOccupancy) interface{} { return s.StartTime }},
+	{Key: "capacity", Header: "Capacity", Value: func(s *models.SessionOccupancy) interface{} { return s.Capacity }},
+	{Key: "sold", Header: "Sold", Value: func(s *models.SessionOccupancy) interface{} { return s.Sold }},
+	{Key: "occupancy", Header: "Occupancy", Value: func(s *models.SessionOccupancy) interface{} { return s.Occupancy }},
+}
+
+type ExportService struct {
+	ticketRepo       repositories.TicketStore
+	paymentRepo      repositories.PaymentStore
+	userRepo         repositories.UserStore
+	analyticsService *AnalyticsService
+}
+
+func NewExportService(ticketRepo repositories.TicketStore, paymentRepo repositories.PaymentStore, userRepo repositories.UserStore, analyticsService *AnalyticsService) *ExportService {
+	return &ExportService{
+		ticketRepo:       ticketRepo,
+		paymentRepo:      paymentRepo,
+		userRepo:         userRepo,
+		analyticsService: analyticsService,
+	}
+}
+
+func writeTable[T any](w io.Writer, columns []export.Column[T], opts export.Options, produce func(func(*T) error) error) error {
+	table, err := export.NewTable(w, columns, opts)
+	if err != nil {
+		return err
+	}
+	if err := produce(table.Write); err != nil {
+		return err
+	}
+	return table.Close()
+}
+
+func (s *ExportService) ExportTickets(ctx context.Context, w io.Writer, query *repositories.ListQuery, opts export.Options) (err error) {
+	ctx, span := tracing.Start(ctx, "ExportService.ExportTickets")
+	defer tracing.End(span, &err)
+
+	return writeTable(w, ticketColumns, opts, func(fn func(*models.Ticket) error) error {
+		return s.ticketRepo.Stream(ctx, query, fn)
+	})
+}
+
+func (s *ExportService) ExportPayments(ctx context.Context, w io.Writer, query *repositories.ListQuery, opts export.Options) (err error) {
+	ctx, span := tracing.Start(ctx, "ExportService.ExportPayments")
+	defer tracing.End(span, &err)
+
+	return writeTable(w, paymentColumns, opts, func(fn func(*models.Payment) error) error {
+		return s.paymentRepo.Stream(ctx, query, fn)
+	})
+}
+
+func (s *ExportService) ExportRefunds(ctx context.Context, w io.Writer, query *repositories.ListQuery, opts export.Options) (err error) {
+	ctx, span := tracing.Start(ctx, "ExportService.ExportRefunds")
+	defer tracing.End(span, &err)
+
+	query.Filter["status"] = models.PaymentRefunded
+	return s.ExportPayments(ctx, w, query, opts)
+}
+
+func (s *ExportService) ExportUsers(ctx context.Context, w io.Writer, query *repositories.ListQuery, opts export.Options) (err error) {
+	ctx, span := tracing.Start(ctx, "ExportService.ExportUsers")
+	defer tracing.End(span, &err)
+
+	return writeTable(w, userColumns, opts, func(fn func(*models.User) error) error {
+		return s.userRepo.Stream(ctx, query, fn)
+	})
+}
+
+func (s *ExportService) ExportAnalytics(ctx context.Context, w io.Writer, report string, query models.AnalyticsQuery, opts export.Options) (err error) {
+	ctx, span := tracing.Start(ctx, "ExportService.ExportAnalytics")
+	defer tracing.End(span, &err)
+
+	switch report {
+	case ExportSales:
+		sales, err := s.analyticsService.GetSales(ctx, query)
+		if err != nil {
+			return err
+		}
+		return writeTable(w, salesColumns, opts, func(fn func(*models.SalesBucket) error) error {
+			for i := range sales.Rows {
+				if err := fn(&sales.Rows[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	case ExportOccupancy:
+		occupancy, err := s.analyticsService.GetOccupancy(ctx, query)
+		if err != nil {
+			return err
+		}
+		return writeTable(w, occupancyColumns, opts, func(fn func(*models.SessionOccupancy) error) error {
+			for i := range occupancy.Sessions {
+				if err := fn(&occupancy.Sessions[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	default:
+		return ErrUnsupportedExport.With("report", report)
+	}
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"bytes"
+	"cinema-system/internal/export"
+	"cinema-system/internal/models"
+	"cinema-system/internal/money"
+	"cinema-system/internal/repositories"
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func exportCSV(t *testing.T, spec repositories.ListSpec, params url.Values, run func(*bytes.Buffer, *repositories.ListQuery, export.Options) error) []string {
+	t.Helper()
+	query, err := repositories.ParseListQuery(params, spec, time.UTC)
+	if err != nil {
+		t.Fatalf("parse query: %v", err)
+	}
+	opts, err := export.ParseOptions(params)
+	if err != nil {
+		t.Fatalf("parse options: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := run(&buf, query, opts); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	return strings.Split(strings.TrimSpace(buf.String()), "\n")
+}
+
+func TestExports(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		service := b.exportService()
+		user := b.createUser(t, 0)
+		user.PasswordHash = "secret-hash"
+		if err := b.users.Update(ctx, user); err != nil {
+			t.Fatalf("update user: %v", err)
+		}
+
+		base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+		for i, status := range []models.PaymentStatus{models.PaymentCompleted, models.PaymentRefunded, models.PaymentCompleted} {
+			payment := &models.Payment{
+				UserID:          user.ID,
+				TransactionCode: "TX" + string(rune('A'+i)),
+				Amount:          money.New(int64(1000*(i+1)), money.DefaultCurrency),
+				Status:          status,
+				CreatedAt:       base.Add(time.Duration(i) * time.Hour),
+			}
+			if err := b.payments.Create(ctx, payment); err != nil {
+				t.Fatalf("create payment: %v", err)
+			}
+		}
+
+		t.Run("payments honor filters, sort and columns", func(t *testing.T) {
+			lines := exportCSV(t, repositories.PaymentListSpec, url.Values{
+				"status":  {"COMPLETED"},
+				"sort":    {"amount"},
+				"columns": {"transaction_code,amount,created_at"},
+				"limit":   {"1"},
+			}, func(buf *bytes.Buffer, query *repositories.ListQuery, opts export.Options) error {
+				return service.ExportPayments(ctx, buf, query, opts)
+			})
+			want := []string{
+				"Transaction Code,Amount,Created At",
+				"TXA,10.00,2026-03-01T12:00:00Z",
+				"TXC,30.00,2026-03-01T14:00:00Z",
+			}
+			if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+				t.Errorf("payments export = %q, want %q", lines, want)
+			}
+		})
+
+		t.Run("refunds only include refunded payments", func(t *testing.T) {
+			lines := exportCSV(t, repositories.PaymentListSpec, url.Values{
+				"status":  {"COMPLETED"},
+				"columns": {"transaction_code,status"},
+			}, func(buf *bytes.Buffer, query *repositories.ListQuery, opts export.Options) error {
+				return service.ExportRefunds(ctx, buf, query, opts)
+			})
+			if len(lines) != 2 || lines[1] != "TXB,REFUNDED" {
+				t.Errorf("refunds export = %q", lines)
+			}
+		})
+
+		t.Run("users never expose password hashes", func(t *testing.T) {
+			lines := exportCSV(t, repositories.UserListSpec, url.Values{"email": {user.Email}}, func(buf *bytes.Buffer, query *repositories.ListQuery, opts export.Options) error {
+				return service.ExportUsers(ctx, buf, query, opts)
+			})
+			if len(lines) != 2 || !strings.Contains(lines[1], user.Email) {
+				t.Fatalf("users export = %q", lines)
+			}
+			if strings.Contains(strings.Join(lines, "\n"), user.PasswordHash) {
+				t.Error("users export leaked the password hash")
+			}
+		})
+
+		t.Run("unknown analytics report", func(t *testing.T) {
+			var buf bytes.Buffer
+			err := service.ExportAnalytics(ctx, &buf, "weather", models.AnalyticsQuery{}, export.DefaultOptions())
+			if !errors.Is(err, ErrUnsupportedExport) || buf.Len() != 0 {
+				t.Errorf("error = %v, wrote %d bytes", err, buf.Len())
+			}
+		})
+	})
+}
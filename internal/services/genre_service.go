@@ -1,18 +1,20 @@
 package services
 
 import (
+	"cinema-system/internal/i18n"
 	"cinema-system/internal/models"
 	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
 	"context"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type GenreService struct {
-	genreRepo *repositories.GenreRepository
+	genreRepo repositories.GenreStore
 }
 
-func NewGenreService(genreRepo *repositories.GenreRepository) *GenreService {
+func NewGenreService(genreRepo repositories.GenreStore) *GenreService {
 	return &GenreService{genreRepo: genreRepo}
 }
 
@@ -20,18 +22,76 @@ func (s *GenreService) CreateGenre(ctx context.Context, genre *models.Genre) err
 	return s.genreRepo.Create(ctx, genre)
 }
 
-func (s *GenreService) GetAllGenres(ctx context.Context) ([]models.Genre, error) {
-	return s.genreRepo.GetAll(ctx)
+func (s *GenreService) GetAllGenres(ctx context.Context) (_ []models.Genre, err error) {
+	ctx, span := tracing.Start(ctx, "GenreService.GetAllGenres")
+	defer tracing.End(span, &err)
+
+	genres, err := s.genreRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range genres {
+		localizeGenre(ctx, &genres[i])
+	}
+	return genres, nil
 }
 
-func (s *GenreService) GetGenreByID(ctx context.Context, id primitive.ObjectID) (*models.Genre, error) {
-	return s.genreRepo.FindByID(ctx, id)
+func (s *GenreService) GetGenreByID(ctx context.Context, id primitive.ObjectID) (_ *models.Genre, err error) {
+	ctx, span := tracing.Start(ctx, "GenreService.GetGenreByID")
+	defer tracing.End(span, &err)
+
+	genre, err := s.genreRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrGenreNotFound)
+	}
+	localizeGenre(ctx, genre)
+	return genre, nil
 }
 
 func (s *GenreService) UpdateGenre(ctx context.Context, id primitive.ObjectID, genre *models.Genre) error {
 	return s.genreRepo.Update(ctx, id, genre)
 }
 
+func (s *GenreService) GetTranslations(ctx context.Context, id primitive.ObjectID) (_ map[string]models.GenreTranslation, err error) {
+	ctx, span := tracing.Start(ctx, "GenreService.GetTranslations")
+	defer tracing.End(span, &err)
+
+	genre, err := s.genreRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrGenreNotFound)
+	}
+	if genre.Translations == nil {
+		return map[string]models.GenreTranslation{}, nil
+	}
+	return genre.Translations, nil
+}
+
+func (s *GenreService) SetTranslation(ctx context.Context, id primitive.ObjectID, lang string, translation models.GenreTranslation) (err error) {
+	ctx, span := tracing.Start(ctx, "GenreService.SetTranslation")
+	defer tracing.End(span, &err)
+
+	if !i18n.IsSupported(lang) {
+		return ErrUnsupportedLanguage.With("lang", lang)
+	}
+	if _, err := s.genreRepo.FindByID(ctx, id); err != nil {
+		return notFound(err, ErrGenreNotFound)
+	}
+	return s.genreRepo.SetTranslation(ctx, id, lang, translation)
+}
+
+func (s *GenreService) DeleteTranslation(ctx context.Context, id primitive.ObjectID, lang string) (err error) {
+	ctx, span := tracing.Start(ctx, "GenreService.DeleteTranslation")
+	defer tracing.End(span, &err)
+
+	if !i18n.IsSupported(lang) {
+		return ErrUnsupportedLanguage.With("lang", lang)
+	}
+	if _, err := s.genreRepo.FindByID(ctx, id); err != nil {
+		return notFound(err, ErrGenreNotFound)
+	}
+	return s.genreRepo.DeleteTranslation(ctx, id, lang)
+}
+
 func (s *GenreService) DeleteGenre(ctx context.Context, id primitive.ObjectID) error {
 	return s.genreRepo.Delete(ctx, id)
 }
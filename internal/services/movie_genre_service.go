@@ -3,20 +3,24 @@ package services
 import (
 	"cinema-system/internal/models"
 	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
 	"context"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type MovieGenreService struct {
-	movieGenreRepo *repositories.MovieGenreRepository
+	movieGenreRepo repositories.MovieGenreStore
 }
 
-func NewMovieGenreService(movieGenreRepo *repositories.MovieGenreRepository) *MovieGenreService {
+func NewMovieGenreService(movieGenreRepo repositories.MovieGenreStore) *MovieGenreService {
 	return &MovieGenreService{movieGenreRepo: movieGenreRepo}
 }
 
-func (s *MovieGenreService) AddGenreToMovie(ctx context.Context, movieID, genreID primitive.ObjectID) error {
+func (s *MovieGenreService) AddGenreToMovie(ctx context.Context, movieID, genreID primitive.ObjectID) (err error) {
+	ctx, span := tracing.Start(ctx, "MovieGenreService.AddGenreToMovie")
+	defer tracing.End(span, &err)
+
 	movieGenre := &models.MovieGenre{
 		MovieID: movieID,
 		GenreID: genreID,
@@ -1,35 +1,53 @@
 package services
 
 import (
+	"cinema-system/internal/events"
+	"cinema-system/internal/i18n"
 	"cinema-system/internal/models"
 	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type MovieService struct {
-	movieRepo         *repositories.MovieRepository
-	genreRepo         *repositories.GenreRepository
+	movieRepo         repositories.MovieStore
+	genreRepo         repositories.GenreStore
+	sessionRepo       repositories.SessionStore
+	hallRepo          repositories.HallStore
 	movieGenreService *MovieGenreService
 }
 
 func NewMovieService(
-	movieRepo *repositories.MovieRepository,
-	genreRepo *repositories.GenreRepository,
+	movieRepo repositories.MovieStore,
+	genreRepo repositories.GenreStore,
+	sessionRepo repositories.SessionStore,
+	hallRepo repositories.HallStore,
 	movieGenreService *MovieGenreService,
 ) *MovieService {
 	return &MovieService{
 		movieRepo:         movieRepo,
 		genreRepo:         genreRepo,
+		sessionRepo:       sessionRepo,
+		hallRepo:          hallRepo,
 		movieGenreService: movieGenreService,
 	}
 }
 
-func (s *MovieService) CreateMovie(ctx context.Context, movie *models.Movie) error {
+func (s *MovieService) CreateMovie(ctx context.Context, movie *models.Movie) (err error) {
+	ctx, span := tracing.Start(ctx, "MovieService.CreateMovie")
+	defer tracing.End(span, &err)
+
 	movie.CreatedAt = time.Now()
 	movie.Rating = 0.0
+	movie.Popularity = 0
+	movie.Score = 0
 
 	genreIDs := movie.Genres
 	movie.Genres = genreIDs
@@ -46,12 +64,133 @@ func (s *MovieService) CreateMovie(ctx context.Context, movie *models.Movie) err
 	return nil
 }
 
-func (s *MovieService) GetAllMovies(ctx context.Context) ([]models.Movie, error) {
+func (s *MovieService) GetAllMovies(ctx context.Context) (_ []models.Movie, err error) {
+	ctx, span := tracing.Start(ctx, "MovieService.GetAllMovies")
+	defer tracing.End(span, &err)
+
 	movies, err := s.movieRepo.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.populateGenreNamesBulk(ctx, movies); err != nil {
+		return nil, err
+	}
+	localizeMovies(ctx, movies)
+	return movies, nil
+}
+
+func (s *MovieService) SearchMovies(ctx context.Context, query models.MovieQuery) (_ *models.Page[models.Movie], err error) {
+	ctx, span := tracing.Start(ctx, "MovieService.SearchMovies")
+	defer tracing.End(span, &err)
+
+	filter, err := s.buildMovieFilter(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.movieRepo.Search(ctx, *filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.populateGenreNamesBulk(ctx, page.Items); err != nil {
+		return nil, err
+	}
+	localizeMovies(ctx, page.Items)
+	return page, nil
+}
+
+func (s *MovieService) buildMovieFilter(ctx context.Context, query models.MovieQuery) (*repositories.MovieFilter, error) {
+	filter := &repositories.MovieFilter{
+		Search:     strings.TrimSpace(query.Search),
+		AgeRating:  query.AgeRating,
+		MinRating:  query.MinRating,
+		ComingSoon: query.ComingSoon,
+		Cursor:     query.Cursor,
+		Limit:      models.DefaultMoviePageSize,
+	}
+
+	switch {
+	case query.Limit < 0:
+		return nil, fmt.Errorf("%w: limit must be positive", ErrInvalidMovieQuery)
+	case query.Limit > models.MaxMoviePageSize:
+		filter.Limit = models.MaxMoviePageSize
+	case query.Limit > 0:
+		filter.Limit = int64(query.Limit)
+	}
+
+	for _, value := range query.GenreIDs {
+		for _, raw := range strings.Split(value, ",") {
+			if raw = strings.TrimSpace(raw); raw == "" {
+				continue
+			}
+			id, err := primitive.ObjectIDFromHex(raw)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid genre ID %q", ErrInvalidMovieQuery, raw)
+			}
+			filter.GenreIDs = append(filter.GenreIDs, id)
+		}
+	}
+
+	filter.Sort = query.Sort
+	if filter.Sort == "" {
+		filter.Sort = models.MovieSortRating
+		if filter.Search != "" {
+			filter.Sort = models.MovieSortRelevance
+		}
+	}
+	switch filter.Sort {
+	case models.MovieSortRelevance:
+		if filter.Search == "" {
+			return nil, fmt.Errorf("%w: relevance sort requires a search query", ErrInvalidMovieQuery)
+		}
+	case models.MovieSortRating, models.MovieSortRelease, models.MovieSortPopularity:
+		filter.Desc = true
+	case models.MovieSortName:
+		filter.Desc = false
+	default:
+		return nil, fmt.Errorf("%w: unknown sort %q", ErrInvalidMovieQuery, filter.Sort)
+	}
+	switch query.Order {
+	case "":
+	case "asc":
+		filter.Desc = false
+	case "desc":
+		filter.Desc = true
+	default:
+		return nil, fmt.Errorf("%w: order must be asc or desc", ErrInvalidMovieQuery)
+	}
+
+	if !query.Showing && query.ShowingOn == "" && query.Location == "" {
+		return filter, nil
+	}
+
+	var hallIDs []primitive.ObjectID
+	if query.Location != "" {
+		ids, err := s.hallRepo.FindIDsByLocation(ctx, query.Location)
+		if err != nil {
+			return nil, err
+		}
+		hallIDs = ids
+	}
+
+	from, to := time.Now(), time.Now().AddDate(1, 0, 0)
+	if query.ShowingOn != "" {
+		day, err := time.ParseInLocation("2006-01-02", query.ShowingOn, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%w: showing_on must be YYYY-MM-DD", ErrInvalidMovieQuery)
+		}
+		from, to = day, day.AddDate(0, 0, 1)
+	}
+
+	movieIDs, err := s.sessionRepo.GetMovieIDsBetween(ctx, from, to, hallIDs)
+	if err != nil {
+		return nil, err
+	}
+	filter.MovieIDs = movieIDs
+	return filter, nil
+}
 
+func (s *MovieService) populateGenreNamesBulk(ctx context.Context, movies []models.Movie) error {
 	genreIDMap := make(map[primitive.ObjectID]bool)
 	for _, m := range movies {
 		for _, gid := range m.Genres {
@@ -66,11 +205,12 @@ func (s *MovieService) GetAllMovies(ctx context.Context) ([]models.Movie, error)
 
 	genres, err := s.genreRepo.FindByIDs(ctx, uniqueGenreIDs)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	genreMap := make(map[primitive.ObjectID]string)
 	for _, g := range genres {
+		localizeGenre(ctx, &g)
 		genreMap[g.ID] = g.Name
 	}
 
@@ -84,18 +224,54 @@ func (s *MovieService) GetAllMovies(ctx context.Context) ([]models.Movie, error)
 		}
 		movie.GenreNames = names
 	}
+	return nil
+}
 
-	return movies, nil
+func (s *MovieService) HandleTicketBooked(ctx context.Context, event *models.OutboxEvent) (err error) {
+	ctx, span := tracing.Start(ctx, "MovieService.HandleTicketBooked")
+	defer tracing.End(span, &err)
+
+	var payload events.TicketBookedPayload
+	if err := events.Decode(event, &payload); err != nil {
+		return err
+	}
+	return s.adjustPopularity(ctx, payload.SessionID, len(payload.TicketIDs))
+}
+
+func (s *MovieService) HandleTicketCancelled(ctx context.Context, event *models.OutboxEvent) (err error) {
+	ctx, span := tracing.Start(ctx, "MovieService.HandleTicketCancelled")
+	defer tracing.End(span, &err)
+
+	var payload events.TicketCancelledPayload
+	if err := events.Decode(event, &payload); err != nil {
+		return err
+	}
+	return s.adjustPopularity(ctx, payload.SessionID, -1)
+}
+
+func (s *MovieService) adjustPopularity(ctx context.Context, sessionID primitive.ObjectID, delta int) error {
+	session, err := s.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+		return err
+	}
+	return s.movieRepo.IncrementPopularity(ctx, session.MovieID, delta)
 }
 
-func (s *MovieService) GetMovieByID(ctx context.Context, id primitive.ObjectID) (*models.Movie, error) {
+func (s *MovieService) GetMovieByID(ctx context.Context, id primitive.ObjectID) (_ *models.Movie, err error) {
+	ctx, span := tracing.Start(ctx, "MovieService.GetMovieByID")
+	defer tracing.End(span, &err)
+
 	movie, err := s.movieRepo.FindByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, notFound(err, ErrMovieNotFound)
 	}
 	if err := s.populateGenreNames(ctx, movie); err != nil {
 		return nil, err
 	}
+	localizeMovie(ctx, movie)
 	return movie, nil
 }
 
@@ -110,13 +286,75 @@ func (s *MovieService) populateGenreNames(ctx context.Context, movie *models.Mov
 	}
 	names := make([]string, len(genres))
 	for i, g := range genres {
+		localizeGenre(ctx, &g)
 		names[i] = g.Name
 	}
 	movie.GenreNames = names
 	return nil
 }
 
-func (s *MovieService) UpdateMovie(ctx context.Context, id primitive.ObjectID, movie *models.Movie) error {
+func localizeMovie(ctx context.Context, movie *models.Movie) {
+	if !i18n.BaseContent(ctx) {
+		movie.Localize(i18n.Language(ctx))
+	}
+}
+
+func localizeMovies(ctx context.Context, movies []models.Movie) {
+	for i := range movies {
+		localizeMovie(ctx, &movies[i])
+	}
+}
+
+func localizeGenre(ctx context.Context, genre *models.Genre) {
+	if !i18n.BaseContent(ctx) {
+		genre.Localize(i18n.Language(ctx))
+	}
+}
+
+func (s *MovieService) GetTranslations(ctx context.Context, id primitive.ObjectID) (_ map[string]models.MovieTranslation, err error) {
+	ctx, span := tracing.Start(ctx, "MovieService.GetTranslations")
+	defer tracing.End(span, &err)
+
+	movie, err := s.movieRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrMovieNotFound)
+	}
+	if movie.Translations == nil {
+		return map[string]models.MovieTranslation{}, nil
+	}
+	return movie.Translations, nil
+}
+
+func (s *MovieService) SetTranslation(ctx context.Context, id primitive.ObjectID, lang string, translation models.MovieTranslation) (err error) {
+	ctx, span := tracing.Start(ctx, "MovieService.SetTranslation")
+	defer tracing.End(span, &err)
+
+	if !i18n.IsSupported(lang) {
+		return ErrUnsupportedLanguage.With("lang", lang)
+	}
+	if _, err := s.movieRepo.FindByID(ctx, id); err != nil {
+		return notFound(err, ErrMovieNotFound)
+	}
+	return s.movieRepo.SetTranslation(ctx, id, lang, translation)
+}
+
+func (s *MovieService) DeleteTranslation(ctx context.Context, id primitive.ObjectID, lang string) (err error) {
+	ctx, span := tracing.Start(ctx, "MovieService.DeleteTranslation")
+	defer tracing.End(span, &err)
+
+	if !i18n.IsSupported(lang) {
+		return ErrUnsupportedLanguage.With("lang", lang)
+	}
+	if _, err := s.movieRepo.FindByID(ctx, id); err != nil {
+		return notFound(err, ErrMovieNotFound)
+	}
+	return s.movieRepo.DeleteTranslation(ctx, id, lang)
+}
+
+func (s *MovieService) UpdateMovie(ctx context.Context, id primitive.ObjectID, movie *models.Movie) (err error) {
+	ctx, span := tracing.Start(ctx, "MovieService.UpdateMovie")
+	defer tracing.End(span, &err)
+
 	if err := s.movieRepo.Update(ctx, id, movie); err != nil {
 		return err
 	}
@@ -134,7 +372,10 @@ func (s *MovieService) UpdateMovie(ctx context.Context, id primitive.ObjectID, m
 	return nil
 }
 
-func (s *MovieService) DeleteMovie(ctx context.Context, id primitive.ObjectID) error {
+func (s *MovieService) DeleteMovie(ctx context.Context, id primitive.ObjectID) (err error) {
+	ctx, span := tracing.Start(ctx, "MovieService.DeleteMovie")
+	defer tracing.End(span, &err)
+
 	if err := s.movieGenreService.RemoveGenresFromMovie(ctx, id); err != nil {
 		return err
 	}
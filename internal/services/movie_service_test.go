@@ -0,0 +1,109 @@
+package services
+
+import (
+	"cinema-system/internal/i18n"
+	"cinema-system/internal/models"
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMovieTranslations(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		service := b.movieService()
+
+		genre := &models.Genre{Name: "Comedy"}
+		if err := b.genres.Create(ctx, genre); err != nil {
+			t.Fatalf("create genre: %v", err)
+		}
+		movie := b.createMovie(t, "12+")
+		movie.Description = "A test movie"
+		movie.Genres = []primitive.ObjectID{genre.ID}
+		if err := b.movies.Update(ctx, movie.ID, movie); err != nil {
+			t.Fatalf("update movie: %v", err)
+		}
+
+		if err := service.SetTranslation(ctx, movie.ID, "ru", models.MovieTranslation{Name: "Тестовый фильм"}); err != nil {
+			t.Fatalf("SetTranslation: %v", err)
+		}
+		if err := service.SetTranslation(ctx, movie.ID, "kk", models.MovieTranslation{Name: "Сынақ фильмі", Description: "Сынақ"}); err != nil {
+			t.Fatalf("SetTranslation: %v", err)
+		}
+		if err := b.genres.SetTranslation(ctx, genre.ID, "ru", models.GenreTranslation{Name: "Комедия"}); err != nil {
+			t.Fatalf("genre SetTranslation: %v", err)
+		}
+		if err := service.SetTranslation(ctx, movie.ID, "de", models.MovieTranslation{Name: "Testfilm"}); !errors.Is(err, ErrUnsupportedLanguage) {
+			t.Fatalf("err = %v, want %v", err, ErrUnsupportedLanguage)
+		}
+		if err := service.SetTranslation(ctx, primitive.NewObjectID(), "ru", models.MovieTranslation{Name: "x"}); !errors.Is(err, ErrMovieNotFound) {
+			t.Fatalf("err = %v, want %v", err, ErrMovieNotFound)
+		}
+
+		ru := i18n.WithLanguage(ctx, "ru")
+		got, err := service.GetMovieByID(ru, movie.ID)
+		if err != nil {
+			t.Fatalf("GetMovieByID: %v", err)
+		}
+		if got.Name != "Тестовый фильм" || got.Description != "A test movie" {
+			t.Fatalf("ru movie = %q / %q, want translated name with base description", got.Name, got.Description)
+		}
+		if len(got.GenreNames) != 1 || got.GenreNames[0] != "Комедия" {
+			t.Fatalf("ru genres = %v", got.GenreNames)
+		}
+		if got.Translations != nil {
+			t.Fatalf("translations leaked into public response: %v", got.Translations)
+		}
+
+		base, err := service.GetMovieByID(i18n.WithBaseContent(ru), movie.ID)
+		if err != nil {
+			t.Fatalf("GetMovieByID base content: %v", err)
+		}
+		if base.Name != "Test Movie" || base.GenreNames[0] != "Comedy" || len(base.Translations) != 2 {
+			t.Fatalf("base movie = %q %v %v, want untranslated fields with translations", base.Name, base.GenreNames, base.Translations)
+		}
+
+		page, err := service.SearchMovies(i18n.WithLanguage(ctx, "kk"), models.MovieQuery{})
+		if err != nil {
+			t.Fatalf("SearchMovies: %v", err)
+		}
+		if len(page.Items) != 1 || page.Items[0].Name != "Сынақ фильмі" || page.Items[0].GenreNames[0] != "Comedy" {
+			t.Fatalf("kk page = %+v", page.Items)
+		}
+
+		if err := service.DeleteTranslation(ctx, movie.ID, "ru"); err != nil {
+			t.Fatalf("DeleteTranslation: %v", err)
+		}
+		if got, _ = service.GetMovieByID(ru, movie.ID); got.Name != "Test Movie" {
+			t.Fatalf("name after delete = %q, want fallback", got.Name)
+		}
+		translations, err := service.GetTranslations(ctx, movie.ID)
+		if err != nil {
+			t.Fatalf("GetTranslations: %v", err)
+		}
+		if _, ok := translations["kk"]; !ok || len(translations) != 1 {
+			t.Fatalf("translations = %v, want only kk", translations)
+		}
+	})
+}
+
+func TestSearchMoviesShowingOnlyListsMoviesWithSessions(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		service := b.movieService()
+
+		showing := b.createMovie(t, "12+")
+		b.createMovie(t, "16+")
+		b.createSession(t, showing, b.createHall(t), 1000)
+
+		page, err := service.SearchMovies(ctx, models.MovieQuery{Showing: true})
+		if err != nil {
+			t.Fatalf("SearchMovies: %v", err)
+		}
+		if len(page.Items) != 1 || page.Items[0].ID != showing.ID {
+			t.Fatalf("showing page = %+v, want only the movie with a session", page.Items)
+		}
+	})
+}
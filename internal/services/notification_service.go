@@ -0,0 +1,375 @@
+package services
+
+import (
+	"cinema-system/internal/events"
+	"cinema-system/internal/models"
+	"cinema-system/internal/notifications"
+	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	notificationPollInterval = 30 * time.Second
+	notificationBatchSize    = 100
+	notificationRetryBase    = time.Minute
+	maxReminderLead          = 24 * time.Hour
+)
+
+type NotificationService struct {
+	notificationRepo repositories.NotificationStore
+	preferenceRepo   repositories.NotificationPreferenceStore
+	userRepo         repositories.UserStore
+	ticketRepo       repositories.TicketStore
+	sessionRepo      repositories.SessionStore
+	movieRepo        repositories.MovieStore
+	hallRepo         repositories.HallStore
+	paymentRepo      repositories.PaymentStore
+	channels         map[models.NotificationChannel]notifications.Channel
+}
+
+func NewNotificationService(
+	notificationRepo repositories.NotificationStore,
+	preferenceRepo repositories.NotificationPreferenceStore,
+	userRepo repositories.UserStore,
+	ticketRepo repositories.TicketStore,
+	sessionRepo repositories.SessionStore,
+	movieRepo repositories.MovieStore,
+	hallRepo repositories.HallStore,
+	paymentRepo repositories.PaymentStore,
+	channels map[models.NotificationChannel]notifications.Channel,
+) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		preferenceRepo:   preferenceRepo,
+		userRepo:         userRepo,
+		ticketRepo:       ticketRepo,
+		sessionRepo:      sessionRepo,
+		movieRepo:        movieRepo,
+		hallRepo:         hallRepo,
+		paymentRepo:      paymentRepo,
+		channels:         channels,
+	}
+}
+
+func (s *NotificationService) GetPreferences(ctx context.Context, userID primitive.ObjectID) (_ *models.NotificationPreferences, err error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.GetPreferences")
+	defer tracing.End(span, &err)
+
+	prefs, err := s.preferenceRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return models.DefaultNotificationPreferences(userID), nil
+	}
+	return prefs, nil
+}
+
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userID primitive.ObjectID, prefs *models.NotificationPreferences) (err error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.UpdatePreferences")
+	defer tracing.End(span, &err)
+
+	if err := prefs.Validate(); err != nil {
+		return err
+	}
+	prefs.UserID = userID
+	prefs.UpdatedAt = time.Now()
+	return s.preferenceRepo.Upsert(ctx, prefs)
+}
+
+func (s *NotificationService) GetUserNotifications(ctx context.Context, userID primitive.ObjectID) ([]models.Notification, error) {
+	return s.notificationRepo.FindByUserID(ctx, userID)
+}
+
+func (s *NotificationService) GetFailedNotifications(ctx context.Context) ([]models.Notification, error) {
+	return s.notificationRepo.FindByStatus(ctx, models.NotificationFailed)
+}
+
+func (s *NotificationService) RetryNotification(ctx context.Context, id primitive.ObjectID) (err error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.RetryNotification")
+	defer tracing.End(span, &err)
+
+	notification, err := s.notificationRepo.FindByID(ctx, id)
+	if err != nil {
+		return notFound(err, ErrNotificationNotFound)
+	}
+	if notification.Status != models.NotificationFailed {
+		return ErrNotificationNotRetryable
+	}
+	return s.notificationRepo.Requeue(ctx, id, time.Now())
+}
+
+func (s *NotificationService) sessionDetails(ctx context.Context, session *models.Session) (string, string) {
+	movieTitle, hallName := "", ""
+	if movie, err := s.movieRepo.FindByID(ctx, session.MovieID); err == nil {
+		movieTitle = movie.Name
+	}
+	if hall, err := s.hallRepo.FindByID(ctx, session.HallID); err == nil {
+		hallName = hall.Name
+	}
+	return movieTitle, hallName
+}
+
+func seatLabels(tickets []models.Ticket) []string {
+	seats := make([]string, len(tickets))
+	for i, t := range tickets {
+		seats[i] = fmt.Sprintf("row %d seat %d", t.RowNumber, t.SeatNumber)
+	}
+	return seats
+}
+
+func (s *NotificationService) enqueue(ctx context.Context, userID primitive.ObjectID, kind models.NotificationKind, dedupKey string, data notifications.TemplateData) error {
+	prefs, err := s.GetPreferences(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !prefs.Wants(kind) {
+		return nil
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+	data.UserName = user.FirstName
+
+	subject, body, err := notifications.Render(kind, data)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, channel := range prefs.Channels() {
+		recipient := user.Email
+		if channel == models.ChannelSMS {
+			recipient = user.PhoneNumber
+		}
+
+		key := ""
+		if dedupKey != "" {
+			key = dedupKey + ":" + string(channel)
+		}
+
+		notification := &models.Notification{
+			UserID:        userID,
+			Kind:          kind,
+			Channel:       channel,
+			Recipient:     recipient,
+			Subject:       subject,
+			Body:          body,
+			DedupKey:      key,
+			Status:        models.NotificationPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+		if _, err := s.notificationRepo.CreateIfAbsent(ctx, notification); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *NotificationService) NotifyBookingConfirmed(ctx context.Context, userID primitive.ObjectID, tickets []models.Ticket, payment *models.Payment) (err error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.NotifyBookingConfirmed")
+	defer tracing.End(span, &err)
+
+	if len(tickets) == 0 {
+		return nil
+	}
+
+	session, err := s.sessionRepo.FindByID(ctx, tickets[0].SessionID)
+	if err != nil {
+		return notFound(err, ErrSessionNotFound)
+	}
+	movieTitle, hallName := s.sessionDetails(ctx, session)
+
+	return s.enqueue(ctx, userID, models.NotificationBookingConfirmed, "booking:"+payment.ID.Hex(), notifications.TemplateData{
+		MovieTitle:      movieTitle,
+		HallName:        hallName,
+		StartTime:       session.StartTime.Format(documentTimeLayout),
+		Seats:           seatLabels(tickets),
+		Total:           payment.Amount.String(),
+		TransactionCode: payment.TransactionCode,
+	})
+}
+
+func (s *NotificationService) NotifySessionCancelled(ctx context.Context, session *models.Session) (err error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.NotifySessionCancelled")
+	defer tracing.End(span, &err)
+
+	tickets, err := s.ticketRepo.GetBySession(ctx, session.ID)
+	if err != nil {
+		return err
+	}
+
+	movieTitle, hallName := s.sessionDetails(ctx, session)
+	for userID, userTickets := range ticketsByUser(tickets) {
+		err := s.enqueue(ctx, userID, models.NotificationSessionCancelled, "cancelled:"+session.ID.Hex()+":"+userID.Hex(), notifications.TemplateData{
+			MovieTitle: movieTitle,
+			HallName:   hallName,
+			StartTime:  session.StartTime.Format(documentTimeLayout),
+			Seats:      seatLabels(userTickets),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *NotificationService) HandleTicketBooked(ctx context.Context, event *models.OutboxEvent) (err error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.HandleTicketBooked")
+	defer tracing.End(span, &err)
+
+	var payload events.TicketBookedPayload
+	if err := events.Decode(event, &payload); err != nil {
+		return err
+	}
+
+	payment, err := s.paymentRepo.FindByID(ctx, payload.PaymentID)
+	if err != nil {
+		return notFound(err, ErrPaymentNotFound)
+	}
+
+	tickets, err := s.ticketRepo.GetByPaymentIDs(ctx, []primitive.ObjectID{payload.PaymentID})
+	if err != nil {
+		return err
+	}
+
+	return s.NotifyBookingConfirmed(ctx, payload.UserID, tickets, payment)
+}
+
+func (s *NotificationService) HandleSessionCancelled(ctx context.Context, event *models.OutboxEvent) (err error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.HandleSessionCancelled")
+	defer tracing.End(span, &err)
+
+	var payload events.SessionPayload
+	if err := events.Decode(event, &payload); err != nil {
+		return err
+	}
+	return s.NotifySessionCancelled(ctx, payload.Session())
+}
+
+func ticketsByUser(tickets []models.Ticket) map[primitive.ObjectID][]models.Ticket {
+	grouped := make(map[primitive.ObjectID][]models.Ticket)
+	for _, t := range tickets {
+		if t.Status != models.TicketPaid {
+			continue
+		}
+		grouped[t.UserID] = append(grouped[t.UserID], t)
+	}
+	return grouped
+}
+
+func (s *NotificationService) ScheduleReminders(ctx context.Context, now time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.ScheduleReminders")
+	defer tracing.End(span, &err)
+
+	sessions, err := s.sessionRepo.GetStartingBetween(ctx, now, now.Add(maxReminderLead))
+	if err != nil {
+		return err
+	}
+
+	for i := range sessions {
+		session := &sessions[i]
+		tickets, err := s.ticketRepo.GetBySession(ctx, session.ID)
+		if err != nil {
+			return err
+		}
+
+		grouped := ticketsByUser(tickets)
+		if len(grouped) == 0 {
+			continue
+		}
+
+		movieTitle, hallName := s.sessionDetails(ctx, session)
+		for userID, userTickets := range grouped {
+			prefs, err := s.GetPreferences(ctx, userID)
+			if err != nil {
+				return err
+			}
+			lead := time.Duration(prefs.ReminderLeadMinutes) * time.Minute
+			if session.StartTime.Sub(now) > lead {
+				continue
+			}
+
+			err = s.enqueue(ctx, userID, models.NotificationSessionReminder, "reminder:"+session.ID.Hex()+":"+userID.Hex(), notifications.TemplateData{
+				MovieTitle: movieTitle,
+				HallName:   hallName,
+				StartTime:  session.StartTime.Format(documentTimeLayout),
+				Seats:      seatLabels(userTickets),
+			})
+			if err != nil {
+				slog.ErrorContext(ctx, "notifications: failed to schedule reminder", "user_id", userID.Hex(), "error", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *NotificationService) ProcessQueue(ctx context.Context, now time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.ProcessQueue")
+	defer tracing.End(span, &err)
+
+	due, err := s.notificationRepo.FindDue(ctx, now, notificationBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range due {
+		attempts := n.Attempts + 1
+		err := s.deliver(ctx, &n)
+		if err == nil {
+			if err := s.notificationRepo.MarkSent(ctx, n.ID, attempts, time.Now()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		status := models.NotificationPending
+		if attempts >= models.MaxNotificationAttempts {
+			status = models.NotificationFailed
+		}
+		nextAttempt := now.Add(notificationRetryBase * time.Duration(1<<uint(attempts-1)))
+		if err := s.notificationRepo.MarkAttemptFailed(ctx, n.ID, attempts, err.Error(), status, nextAttempt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *NotificationService) deliver(ctx context.Context, n *models.Notification) error {
+	channel, ok := s.channels[n.Channel]
+	if !ok {
+		return fmt.Errorf("no adapter configured for channel %s", n.Channel)
+	}
+	return channel.Send(ctx, notifications.Message{
+		To:      n.Recipient,
+		Subject: n.Subject,
+		Body:    n.Body,
+	})
+}
+
+func (s *NotificationService) Run(ctx context.Context) {
+	ticker := time.NewTicker(notificationPollInterval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		if err := s.ScheduleReminders(ctx, now); err != nil {
+			slog.ErrorContext(ctx, "notifications: reminder scheduling failed", "error", err)
+		}
+		if err := s.ProcessQueue(ctx, now); err != nil {
+			slog.ErrorContext(ctx, "notifications: queue processing failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
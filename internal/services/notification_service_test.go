@@ -0,0 +1,72 @@
+package services
+
+import (
+	"cinema-system/internal/events"
+	"cinema-system/internal/models"
+	"cinema-system/internal/money"
+	"cinema-system/internal/repositories"
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type defaultPreferenceStore struct {
+	repositories.NotificationPreferenceStore
+}
+
+func (defaultPreferenceStore) FindByUserID(ctx context.Context, userID primitive.ObjectID) (*models.NotificationPreferences, error) {
+	return nil, mongo.ErrNoDocuments
+}
+
+func TestNotificationDedupInsideDispatcherTransaction(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		service := NewNotificationService(b.notifications, defaultPreferenceStore{}, b.users, b.tickets, b.sessions, b.movies, b.halls, b.payments, nil)
+		dispatcher := events.NewDispatcher(b.outbox, b.processed, b.transactor)
+		dispatcher.Subscribe(events.TicketBooked, "notifications.booking_confirmed", service.HandleTicketBooked)
+
+		user := b.createUser(t, 0)
+		session := b.createSession(t, b.createMovie(t, "12+"), b.createHall(t), 1000)
+		payment := &models.Payment{UserID: user.ID, Amount: money.New(1000, money.DefaultCurrency), Status: models.PaymentCompleted, CreatedAt: time.Now()}
+		if err := b.payments.Create(ctx, payment); err != nil {
+			t.Fatalf("create payment: %v", err)
+		}
+		ticket := &models.Ticket{UserID: user.ID, SessionID: session.ID, PaymentID: payment.ID, RowNumber: 1, SeatNumber: 1, Status: models.TicketPaid, CreatedAt: time.Now()}
+		if err := b.tickets.Create(ctx, ticket); err != nil {
+			t.Fatalf("create ticket: %v", err)
+		}
+
+		for i := 0; i < 2; i++ {
+			event, err := events.New(events.TicketBooked, payment.ID, events.TicketBookedPayload{
+				UserID:    user.ID,
+				SessionID: session.ID,
+				PaymentID: payment.ID,
+				TicketIDs: []primitive.ObjectID{ticket.ID},
+			})
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			if err := b.outbox.Add(ctx, event); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+		}
+
+		if err := dispatcher.DispatchPending(ctx); err != nil {
+			t.Fatalf("DispatchPending: %v", err)
+		}
+		if pending := b.drainEvents(t); len(pending) != 0 {
+			t.Fatalf("pending events = %v, want both deliveries processed", pending)
+		}
+
+		stored, err := b.notifications.FindByUserID(ctx, user.ID)
+		if err != nil {
+			t.Fatalf("FindByUserID: %v", err)
+		}
+		if len(stored) != 1 || stored[0].DedupKey != "booking:"+payment.ID.Hex()+":"+string(models.ChannelEmail) {
+			t.Fatalf("stored = %+v, want one email notification", stored)
+		}
+	})
+}
@@ -1,10 +1,12 @@
 package services
 
 import (
+	"cinema-system/internal/apperrors"
 	"cinema-system/internal/models"
 	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
 	"context"
-	"errors"
+	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -12,30 +14,33 @@ import (
 )
 
 type PaymentCardService struct {
-	cardRepo *repositories.PaymentCardRepository
-	userRepo *repositories.UserRepository
+	cardRepo repositories.PaymentCardStore
+	userRepo repositories.UserStore
 }
 
-func NewPaymentCardService(cardRepo *repositories.PaymentCardRepository, userRepo *repositories.UserRepository) *PaymentCardService {
+func NewPaymentCardService(cardRepo repositories.PaymentCardStore, userRepo repositories.UserStore) *PaymentCardService {
 	return &PaymentCardService{
 		cardRepo: cardRepo,
 		userRepo: userRepo,
 	}
 }
 
-func (s *PaymentCardService) CreateCard(ctx context.Context, userID primitive.ObjectID, req models.PaymentCardCreate) (*models.PaymentCard, error) {
+func (s *PaymentCardService) CreateCard(ctx context.Context, userID primitive.ObjectID, req models.PaymentCardCreate) (_ *models.PaymentCard, err error) {
+	ctx, span := tracing.Start(ctx, "PaymentCardService.CreateCard")
+	defer tracing.End(span, &err)
+
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
-	_, err := s.userRepo.FindByID(ctx, userID)
+	_, err = s.userRepo.FindByID(ctx, userID)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, notFound(err, ErrUserNotFound)
 	}
 
 	cvvHash, err := bcrypt.GenerateFromPassword([]byte(req.CVV), bcrypt.DefaultCost)
 	if err != nil {
-		return nil, errors.New("failed to process card security")
+		return nil, apperrors.Internal(fmt.Errorf("failed to process card security: %w", err))
 	}
 
 	card := &models.PaymentCard{
@@ -55,7 +60,10 @@ func (s *PaymentCardService) CreateCard(ctx context.Context, userID primitive.Ob
 	return card, nil
 }
 
-func (s *PaymentCardService) GetUserCards(ctx context.Context, userID primitive.ObjectID) ([]models.PaymentCard, error) {
+func (s *PaymentCardService) GetUserCards(ctx context.Context, userID primitive.ObjectID) (_ []models.PaymentCard, err error) {
+	ctx, span := tracing.Start(ctx, "PaymentCardService.GetUserCards")
+	defer tracing.End(span, &err)
+
 	cards, err := s.cardRepo.FindByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -63,27 +71,33 @@ func (s *PaymentCardService) GetUserCards(ctx context.Context, userID primitive.
 	return cards, nil
 }
 
-func (s *PaymentCardService) GetCardByID(ctx context.Context, cardID primitive.ObjectID, userID primitive.ObjectID) (*models.PaymentCard, error) {
+func (s *PaymentCardService) GetCardByID(ctx context.Context, cardID primitive.ObjectID, userID primitive.ObjectID) (_ *models.PaymentCard, err error) {
+	ctx, span := tracing.Start(ctx, "PaymentCardService.GetCardByID")
+	defer tracing.End(span, &err)
+
 	card, err := s.cardRepo.FindByID(ctx, cardID)
 	if err != nil {
-		return nil, errors.New("card not found")
+		return nil, notFound(err, ErrPaymentCardNotFound)
 	}
 
 	if card.UserID != userID {
-		return nil, errors.New("unauthorized access to card")
+		return nil, ErrPaymentCardForbidden
 	}
 
 	return card, nil
 }
 
-func (s *PaymentCardService) DeleteCard(ctx context.Context, cardID primitive.ObjectID, userID primitive.ObjectID) error {
+func (s *PaymentCardService) DeleteCard(ctx context.Context, cardID primitive.ObjectID, userID primitive.ObjectID) (err error) {
+	ctx, span := tracing.Start(ctx, "PaymentCardService.DeleteCard")
+	defer tracing.End(span, &err)
+
 	card, err := s.cardRepo.FindByID(ctx, cardID)
 	if err != nil {
-		return errors.New("card not found")
+		return notFound(err, ErrPaymentCardNotFound)
 	}
 
 	if card.UserID != userID {
-		return errors.New("unauthorized access to card")
+		return ErrPaymentCardForbidden
 	}
 
 	return s.cardRepo.Delete(ctx, cardID)
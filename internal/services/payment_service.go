@@ -1,10 +1,14 @@
 package services
 
 import (
+	"cinema-system/internal/apperrors"
+	"cinema-system/internal/events"
+	"cinema-system/internal/metrics"
 	"cinema-system/internal/models"
+	"cinema-system/internal/money"
 	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
@@ -12,20 +16,29 @@ import (
 )
 
 type PaymentService struct {
-	paymentRepo *repositories.PaymentRepository
-	cardRepo    *repositories.PaymentCardRepository
-	userRepo    *repositories.UserRepository
+	paymentRepo repositories.PaymentStore
+	cardRepo    repositories.PaymentCardStore
+	userRepo    repositories.UserStore
+	outboxRepo  repositories.OutboxStore
+	transactor  repositories.TransactionRunner
+	audit       *AuditService
 }
 
 func NewPaymentService(
-	paymentRepo *repositories.PaymentRepository,
-	cardRepo *repositories.PaymentCardRepository,
-	userRepo *repositories.UserRepository,
+	paymentRepo repositories.PaymentStore,
+	cardRepo repositories.PaymentCardStore,
+	userRepo repositories.UserStore,
+	outboxRepo repositories.OutboxStore,
+	transactor repositories.TransactionRunner,
+	audit *AuditService,
 ) *PaymentService {
 	return &PaymentService{
 		paymentRepo: paymentRepo,
 		cardRepo:    cardRepo,
 		userRepo:    userRepo,
+		outboxRepo:  outboxRepo,
+		transactor:  transactor,
+		audit:       audit,
 	}
 }
 
@@ -34,51 +47,77 @@ func (s *PaymentService) generateTransactionCode() string {
 	return fmt.Sprintf("TXN-%d-%s", timestamp, primitive.NewObjectID().Hex()[:8])
 }
 
-func (s *PaymentService) CreatePayment(ctx context.Context, userID primitive.ObjectID, req models.PaymentCreate) (*models.Payment, error) {
+func (s *PaymentService) CreatePayment(ctx context.Context, userID primitive.ObjectID, req models.PaymentCreate) (_ *models.Payment, err error) {
+	ctx, span := tracing.Start(ctx, "PaymentService.CreatePayment")
+	defer tracing.End(span, &err)
+
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, notFound(err, ErrUserNotFound)
 	}
 
 	card, err := s.cardRepo.FindByID(ctx, req.PaymentCardID)
 	if err != nil {
-		return nil, errors.New("payment card not found")
+		return nil, notFound(err, ErrPaymentCardNotFound)
 	}
 
 	if card.UserID != userID {
-		return nil, errors.New("unauthorized access to payment card")
+		return nil, ErrPaymentCardForbidden
+	}
+
+	amount, err := balanceAmount(user, req)
+	if err != nil {
+		return nil, err
 	}
 
-	if user.Balance < req.Amount {
-		return nil, errors.New("insufficient balance")
+	newBalance, err := user.Balance.Sub(amount)
+	if err != nil {
+		return nil, err
+	}
+	if newBalance.Amount < 0 {
+		return nil, ErrInsufficientBalance.With("need", amount.String()).With("have", user.Balance.String())
 	}
 
 	payment := &models.Payment{
 		UserID:          userID,
 		PaymentCardID:   req.PaymentCardID,
 		TransactionCode: s.generateTransactionCode(),
-		Amount:          req.Amount,
+		Amount:          amount,
 		Status:          models.PaymentPending,
 		CreatedAt:       time.Now(),
 	}
 
-	err = s.paymentRepo.Create(ctx, payment)
-	if err != nil {
-		return nil, err
-	}
-
-	newBalance := user.Balance - req.Amount
-	err = s.userRepo.UpdateBalance(ctx, userID, newBalance)
-	if err != nil {
-
-		return nil, errors.New("failed to process payment")
-	}
-
-	err = s.paymentRepo.UpdateStatus(ctx, payment.ID, models.PaymentCompleted)
+	err = s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.paymentRepo.Create(ctx, payment); err != nil {
+			return err
+		}
+
+		if err := s.userRepo.UpdateBalance(ctx, userID, newBalance); err != nil {
+			return apperrors.Internal(fmt.Errorf("failed to process payment: %w", err))
+		}
+
+		if err := s.paymentRepo.UpdateStatus(ctx, payment.ID, models.PaymentCompleted); err != nil {
+			return err
+		}
+
+		event, err := events.New(events.PaymentCompleted, payment.ID, events.PaymentCompletedPayload{
+			PaymentID:       payment.ID,
+			UserID:          userID,
+			Amount:          payment.Amount,
+			TransactionCode: payment.TransactionCode,
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.outboxRepo.Add(ctx, event); err != nil {
+			return err
+		}
+		return s.audit.RecordBalanceChange(ctx, AuditPaymentCompleted, userID, payment.ID, user.Balance, newBalance)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -87,20 +126,26 @@ func (s *PaymentService) CreatePayment(ctx context.Context, userID primitive.Obj
 	return payment, nil
 }
 
-func (s *PaymentService) GetPaymentByID(ctx context.Context, paymentID primitive.ObjectID, userID primitive.ObjectID) (*models.Payment, error) {
+func (s *PaymentService) GetPaymentByID(ctx context.Context, paymentID primitive.ObjectID, userID primitive.ObjectID) (_ *models.Payment, err error) {
+	ctx, span := tracing.Start(ctx, "PaymentService.GetPaymentByID")
+	defer tracing.End(span, &err)
+
 	payment, err := s.paymentRepo.FindByID(ctx, paymentID)
 	if err != nil {
-		return nil, errors.New("payment not found")
+		return nil, notFound(err, ErrPaymentNotFound)
 	}
 
 	if payment.UserID != userID {
-		return nil, errors.New("unauthorized access to payment")
+		return nil, ErrPaymentForbidden
 	}
 
 	return payment, nil
 }
 
-func (s *PaymentService) GetUserPayments(ctx context.Context, userID primitive.ObjectID) ([]models.Payment, error) {
+func (s *PaymentService) GetUserPayments(ctx context.Context, userID primitive.ObjectID) (_ []models.Payment, err error) {
+	ctx, span := tracing.Start(ctx, "PaymentService.GetUserPayments")
+	defer tracing.End(span, &err)
+
 	payments, err := s.paymentRepo.FindByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -108,44 +153,70 @@ func (s *PaymentService) GetUserPayments(ctx context.Context, userID primitive.O
 	return payments, nil
 }
 
-func (s *PaymentService) GetAllPayments(ctx context.Context) ([]models.Payment, error) {
-	return s.paymentRepo.GetAll(ctx)
+func (s *PaymentService) GetAllPayments(ctx context.Context, query *repositories.ListQuery) (*models.Page[models.Payment], error) {
+	return s.paymentRepo.List(ctx, query)
 }
 
-func (s *PaymentService) RefundPayment(ctx context.Context, paymentID primitive.ObjectID, userID primitive.ObjectID) error {
+func (s *PaymentService) RefundPayment(ctx context.Context, paymentID primitive.ObjectID, userID primitive.ObjectID) (err error) {
+	ctx, span := tracing.Start(ctx, "PaymentService.RefundPayment")
+	defer tracing.End(span, &err)
+
 	payment, err := s.paymentRepo.FindByID(ctx, paymentID)
 	if err != nil {
-		return errors.New("payment not found")
+		return notFound(err, ErrPaymentNotFound)
 	}
 
 	if payment.UserID != userID {
-		return errors.New("unauthorized access to payment")
+		return ErrPaymentForbidden
 	}
 
 	if payment.Status != models.PaymentCompleted {
-		return errors.New("only completed payments can be refunded")
+		return ErrPaymentNotRefundable
 	}
 
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
-		return errors.New("user not found")
+		return notFound(err, ErrUserNotFound)
 	}
 
-	newBalance := user.Balance + payment.Amount
-	err = s.userRepo.UpdateBalance(ctx, userID, newBalance)
+	newBalance, err := user.Balance.Add(payment.Amount)
 	if err != nil {
-		return errors.New("failed to process refund")
+		return err
 	}
 
-	err = s.paymentRepo.UpdateStatus(ctx, paymentID, models.PaymentRefunded)
+	err = s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.UpdateBalance(ctx, userID, newBalance); err != nil {
+			return apperrors.Internal(fmt.Errorf("failed to process refund: %w", err))
+		}
+
+		if err := s.paymentRepo.UpdateStatus(ctx, paymentID, models.PaymentRefunded); err != nil {
+			return err
+		}
+
+		event, err := events.New(events.PaymentRefunded, paymentID, events.PaymentRefundedPayload{
+			PaymentID:       paymentID,
+			UserID:          userID,
+			Amount:          payment.Amount,
+			TransactionCode: payment.TransactionCode,
+		})
+		if err != nil {
+			return err
+		}
+		if err := s.outboxRepo.Add(ctx, event); err != nil {
+			return err
+		}
+		return s.audit.RecordBalanceChange(ctx, AuditPaymentRefunded, userID, paymentID, user.Balance, newBalance)
+	})
 	if err != nil {
 		return err
 	}
-
+	metrics.Refunds.WithLabelValues("payment", payment.Amount.Currency).Inc()
 	return nil
 }
 
-func (s *PaymentService) TopUpBalance(ctx context.Context, userID primitive.ObjectID, req models.PaymentCreate) (*models.Payment, error) {
+func (s *PaymentService) TopUpBalance(ctx context.Context, userID primitive.ObjectID, req models.PaymentCreate) (_ *models.Payment, err error) {
+	ctx, span := tracing.Start(ctx, "PaymentService.TopUpBalance")
+	defer tracing.End(span, &err)
 
 	if err := req.Validate(); err != nil {
 		return nil, err
@@ -153,37 +224,62 @@ func (s *PaymentService) TopUpBalance(ctx context.Context, userID primitive.Obje
 
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, notFound(err, ErrUserNotFound)
 	}
 
 	card, err := s.cardRepo.FindByID(ctx, req.PaymentCardID)
 	if err != nil {
-		return nil, errors.New("payment card not found")
+		return nil, notFound(err, ErrPaymentCardNotFound)
 	}
 
 	if card.UserID != userID {
-		return nil, errors.New("unauthorized access to payment card")
+		return nil, ErrPaymentCardForbidden
+	}
+
+	amount, err := balanceAmount(user, req)
+	if err != nil {
+		return nil, err
+	}
+
+	newBalance, err := user.Balance.Add(amount)
+	if err != nil {
+		return nil, err
 	}
 
 	payment := &models.Payment{
 		UserID:          userID,
 		PaymentCardID:   req.PaymentCardID,
 		TransactionCode: s.generateTransactionCode(),
-		Amount:          req.Amount,
+		Amount:          amount,
 		Status:          models.PaymentPending,
 		CreatedAt:       time.Now(),
 	}
 
-	err = s.paymentRepo.Create(ctx, payment)
-	if err != nil {
-		return nil, err
-	}
+	err = s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.paymentRepo.Create(ctx, payment); err != nil {
+			return err
+		}
 
-	newBalance := user.Balance + req.Amount
-	err = s.userRepo.UpdateBalance(ctx, userID, newBalance)
+		if err := s.userRepo.UpdateBalance(ctx, userID, newBalance); err != nil {
+			return apperrors.Internal(fmt.Errorf("failed to process top-up: %w", err))
+		}
+		return s.audit.RecordBalanceChange(ctx, AuditBalanceTopUp, userID, payment.ID, user.Balance, newBalance)
+	})
 	if err != nil {
-		return nil, errors.New("failed to process top-up")
+		return nil, err
 	}
+	metrics.TopUps.WithLabelValues(amount.Currency).Inc()
 
 	return payment, nil
 }
+
+func balanceAmount(user *models.User, req models.PaymentCreate) (money.Money, error) {
+	amount := req.Money(user.Balance.Currency)
+	if err := models.ValidateAmount(amount); err != nil {
+		return money.Money{}, err
+	}
+	if !amount.SameCurrency(user.Balance) {
+		return money.Money{}, ErrBalanceCurrencyMismatch.With("currency", amount.Currency).With("balance", user.Balance.Currency)
+	}
+	return amount, nil
+}
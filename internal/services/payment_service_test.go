@@ -0,0 +1,91 @@
+package services
+
+import (
+	"cinema-system/internal/events"
+	"cinema-system/internal/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func createCard(t *testing.T, b *testBackend, userID primitive.ObjectID) *models.PaymentCard {
+	t.Helper()
+	card := &models.PaymentCard{
+		UserID:         userID,
+		CardHolderName: "Test User",
+		CardNumber:     "4111111111111111",
+		ExpiryDate:     "12/99",
+		CreatedAt:      time.Now(),
+	}
+	if err := b.cards.Create(context.Background(), card); err != nil {
+		t.Fatalf("create card: %v", err)
+	}
+	return card
+}
+
+func TestCreatePaymentAndRefund(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		user := b.createUser(t, 10000)
+		card := createCard(t, b, user.ID)
+		service := b.paymentService()
+
+		payment, err := service.CreatePayment(ctx, user.ID, models.PaymentCreate{PaymentCardID: card.ID, Amount: 3000})
+		if err != nil {
+			t.Fatalf("CreatePayment: %v", err)
+		}
+		if payment.Status != models.PaymentCompleted {
+			t.Fatalf("status = %s, want %s", payment.Status, models.PaymentCompleted)
+		}
+		if got := b.balance(t, user.ID); got != 7000 {
+			t.Fatalf("balance = %v, want 7000", got)
+		}
+
+		if err := service.RefundPayment(ctx, payment.ID, user.ID); err != nil {
+			t.Fatalf("RefundPayment: %v", err)
+		}
+		if err := service.RefundPayment(ctx, payment.ID, user.ID); err == nil {
+			t.Fatal("expected second refund to be rejected")
+		}
+		if got := b.balance(t, user.ID); got != 10000 {
+			t.Fatalf("balance = %v, want 10000", got)
+		}
+
+		stored, err := b.payments.FindByID(ctx, payment.ID)
+		if err != nil || stored.Status != models.PaymentRefunded {
+			t.Fatalf("payment = %+v, err = %v", stored, err)
+		}
+		assertEvents(t, b.drainEvents(t), events.PaymentCompleted, events.PaymentRefunded)
+	})
+}
+
+func TestPaymentOwnership(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		owner := b.createUser(t, 10000)
+		other := b.createUser(t, 10000)
+		card := createCard(t, b, owner.ID)
+		service := b.paymentService()
+
+		if _, err := service.CreatePayment(ctx, other.ID, models.PaymentCreate{PaymentCardID: card.ID, Amount: 1000}); err == nil {
+			t.Fatal("expected payment with another user's card to be rejected")
+		}
+		if _, err := service.CreatePayment(ctx, owner.ID, models.PaymentCreate{PaymentCardID: card.ID, Amount: 50000}); !errors.Is(err, ErrInsufficientBalance) {
+			t.Fatalf("err = %v, want %v", err, ErrInsufficientBalance)
+		}
+
+		payment, err := service.CreatePayment(ctx, owner.ID, models.PaymentCreate{PaymentCardID: card.ID, Amount: 1000})
+		if err != nil {
+			t.Fatalf("CreatePayment: %v", err)
+		}
+		if err := service.RefundPayment(ctx, payment.ID, other.ID); err == nil {
+			t.Fatal("expected refund by another user to be rejected")
+		}
+		if got := b.balance(t, other.ID); got != 10000 {
+			t.Fatalf("other balance = %v, want 10000", got)
+		}
+	})
+}
@@ -0,0 +1,599 @@
+package services
+
+import (
+	"cinema-system/internal/i18n"
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	recommendationRefreshInterval = time.Hour
+	recommendationListSize        = 20
+	defaultRecommendationLimit    = 10
+	likedRatingThreshold          = 7
+
+	similarityGenreWeight    = 0.6
+	similarityCoBookedWeight = 0.4
+
+	scoreSimilarityWeight = 0.45
+	scoreGenreWeight      = 0.35
+	scorePopularityWeight = 0.2
+)
+
+type RecommendationService struct {
+	recommendationRepo repositories.RecommendationStore
+	movieRepo          repositories.MovieStore
+	genreRepo          repositories.GenreStore
+	movieGenreRepo     repositories.MovieGenreStore
+	sessionRepo        repositories.SessionStore
+	ticketRepo         repositories.TicketStore
+	reviewRepo         repositories.ReviewStore
+}
+
+func NewRecommendationService(
+	recommendationRepo repositories.RecommendationStore,
+	movieRepo repositories.MovieStore,
+	genreRepo repositories.GenreStore,
+	movieGenreRepo repositories.MovieGenreStore,
+	sessionRepo repositories.SessionStore,
+	ticketRepo repositories.TicketStore,
+	reviewRepo repositories.ReviewStore,
+) *RecommendationService {
+	return &RecommendationService{
+		recommendationRepo: recommendationRepo,
+		movieRepo:          movieRepo,
+		genreRepo:          genreRepo,
+		movieGenreRepo:     movieGenreRepo,
+		sessionRepo:        sessionRepo,
+		ticketRepo:         ticketRepo,
+		reviewRepo:         reviewRepo,
+	}
+}
+
+type recommendationSnapshot struct {
+	movies        map[primitive.ObjectID]models.Movie
+	movieIDs      []primitive.ObjectID
+	showing       []primitive.ObjectID
+	movieGenres   map[primitive.ObjectID][]primitive.ObjectID
+	seeds         map[primitive.ObjectID]map[primitive.ObjectID]float64
+	ratings       map[primitive.ObjectID]map[primitive.ObjectID]int
+	coBooked      map[primitive.ObjectID]map[primitive.ObjectID]int
+	maxCoBooked   map[primitive.ObjectID]int
+	maxPopularity int
+}
+
+func (s *RecommendationService) Run(ctx context.Context) {
+	ticker := time.NewTicker(recommendationRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Refresh(ctx, time.Now()); err != nil {
+			slog.ErrorContext(ctx, "recommendations: refresh failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *RecommendationService) Refresh(ctx context.Context, now time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "RecommendationService.Refresh")
+	defer tracing.End(span, &err)
+
+	now = now.Truncate(time.Millisecond)
+	snap, err := s.loadSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+
+	recs := []*models.Recommendation{{
+		Kind:       models.RecommendationPopular,
+		SubjectID:  primitive.NilObjectID,
+		Items:      snap.popular(),
+		ComputedAt: now,
+	}}
+	for _, movieID := range snap.movieIDs {
+		recs = append(recs, &models.Recommendation{
+			Kind:       models.RecommendationSimilar,
+			SubjectID:  movieID,
+			Items:      snap.similarTo(movieID),
+			ComputedAt: now,
+		})
+	}
+	for _, userID := range sortedKeys(snap.seeds) {
+		recs = append(recs, &models.Recommendation{
+			Kind:       models.RecommendationForUser,
+			SubjectID:  userID,
+			Items:      snap.forUser(userID),
+			ComputedAt: now,
+		})
+	}
+
+	for _, rec := range recs {
+		if err := s.recommendationRepo.Replace(ctx, rec); err != nil {
+			return err
+		}
+	}
+	return s.recommendationRepo.DeleteComputedBefore(ctx, now)
+}
+
+func (s *RecommendationService) loadSnapshot(ctx context.Context) (*recommendationSnapshot, error) {
+	snap := &recommendationSnapshot{
+		movies:      make(map[primitive.ObjectID]models.Movie),
+		movieGenres: make(map[primitive.ObjectID][]primitive.ObjectID),
+		seeds:       make(map[primitive.ObjectID]map[primitive.ObjectID]float64),
+		ratings:     make(map[primitive.ObjectID]map[primitive.ObjectID]int),
+		coBooked:    make(map[primitive.ObjectID]map[primitive.ObjectID]int),
+		maxCoBooked: make(map[primitive.ObjectID]int),
+	}
+
+	movies, err := s.movieRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, movie := range movies {
+		snap.movies[movie.ID] = movie
+		snap.movieIDs = append(snap.movieIDs, movie.ID)
+		if movie.Popularity > snap.maxPopularity {
+			snap.maxPopularity = movie.Popularity
+		}
+	}
+
+	links, err := s.movieGenreRepo.GetGenresByMovieIDs(ctx, snap.movieIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		snap.movieGenres[link.MovieID] = append(snap.movieGenres[link.MovieID], link.GenreID)
+	}
+
+	showing, err := s.sessionRepo.GetUpcomingMovieIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, movieID := range showing {
+		if _, ok := snap.movies[movieID]; ok {
+			snap.showing = append(snap.showing, movieID)
+		}
+	}
+
+	booked, err := s.bookedMovies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for userID, movieIDs := range booked {
+		for _, a := range movieIDs {
+			snap.seed(userID, a, 1)
+			for _, b := range movieIDs {
+				if a == b {
+					continue
+				}
+				if snap.coBooked[a] == nil {
+					snap.coBooked[a] = make(map[primitive.ObjectID]int)
+				}
+				snap.coBooked[a][b]++
+				if snap.coBooked[a][b] > snap.maxCoBooked[a] {
+					snap.maxCoBooked[a] = snap.coBooked[a][b]
+				}
+			}
+		}
+	}
+
+	reviews, err := s.reviewRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, review := range reviews {
+		snap.seed(review.UserID, review.MovieID, float64(review.Rating-5)/5)
+		if snap.ratings[review.UserID] == nil {
+			snap.ratings[review.UserID] = make(map[primitive.ObjectID]int)
+		}
+		snap.ratings[review.UserID][review.MovieID] = review.Rating
+	}
+	return snap, nil
+}
+
+func (s *RecommendationService) bookedMovies(ctx context.Context) (map[primitive.ObjectID][]primitive.ObjectID, error) {
+	tickets, err := s.ticketRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionMovies := make(map[primitive.ObjectID]primitive.ObjectID)
+	var sessionIDs []primitive.ObjectID
+	for _, ticket := range tickets {
+		if _, ok := sessionMovies[ticket.SessionID]; !ok && ticket.Status != models.TicketCancelled {
+			sessionMovies[ticket.SessionID] = primitive.NilObjectID
+			sessionIDs = append(sessionIDs, ticket.SessionID)
+		}
+	}
+	sessions, err := s.sessionRepo.FindByIDs(ctx, sessionIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		sessionMovies[session.ID] = session.MovieID
+	}
+
+	seen := make(map[primitive.ObjectID]map[primitive.ObjectID]bool)
+	booked := make(map[primitive.ObjectID][]primitive.ObjectID)
+	for _, ticket := range tickets {
+		if ticket.Status == models.TicketCancelled {
+			continue
+		}
+		movieID := sessionMovies[ticket.SessionID]
+		if movieID.IsZero() {
+			continue
+		}
+
+		if seen[ticket.UserID] == nil {
+			seen[ticket.UserID] = make(map[primitive.ObjectID]bool)
+		}
+		if !seen[ticket.UserID][movieID] {
+			seen[ticket.UserID][movieID] = true
+			booked[ticket.UserID] = append(booked[ticket.UserID], movieID)
+		}
+	}
+	return booked, nil
+}
+
+func (snap *recommendationSnapshot) seed(userID, movieID primitive.ObjectID, weight float64) {
+	if _, ok := snap.movies[movieID]; !ok {
+		return
+	}
+	if snap.seeds[userID] == nil {
+		snap.seeds[userID] = make(map[primitive.ObjectID]float64)
+	}
+	snap.seeds[userID][movieID] = weight
+}
+
+func (snap *recommendationSnapshot) genreSimilarity(a, b primitive.ObjectID) float64 {
+	genresA, genresB := snap.movieGenres[a], snap.movieGenres[b]
+	if len(genresA) == 0 || len(genresB) == 0 {
+		return 0
+	}
+	union := make(map[primitive.ObjectID]bool, len(genresA)+len(genresB))
+	for _, g := range genresA {
+		union[g] = true
+	}
+	shared := 0
+	for _, g := range genresB {
+		if union[g] {
+			shared++
+		}
+		union[g] = true
+	}
+	return float64(shared) / float64(len(union))
+}
+
+func (snap *recommendationSnapshot) coBookedSimilarity(a, b primitive.ObjectID) float64 {
+	if snap.maxCoBooked[a] == 0 {
+		return 0
+	}
+	return float64(snap.coBooked[a][b]) / float64(snap.maxCoBooked[a])
+}
+
+func (snap *recommendationSnapshot) similarity(a, b primitive.ObjectID) float64 {
+	return similarityGenreWeight*snap.genreSimilarity(a, b) + similarityCoBookedWeight*snap.coBookedSimilarity(a, b)
+}
+
+func (snap *recommendationSnapshot) popularity(movieID primitive.ObjectID) float64 {
+	if snap.maxPopularity <= 0 {
+		return 0
+	}
+	return float64(snap.movies[movieID].Popularity) / float64(snap.maxPopularity)
+}
+
+func (snap *recommendationSnapshot) sharedGenres(a, b primitive.ObjectID) []primitive.ObjectID {
+	var shared []primitive.ObjectID
+	for _, g := range snap.movieGenres[b] {
+		for _, other := range snap.movieGenres[a] {
+			if g == other {
+				shared = append(shared, g)
+				break
+			}
+		}
+	}
+	return shared
+}
+
+func (snap *recommendationSnapshot) popular() []models.RecommendedMovie {
+	items := make([]models.RecommendedMovie, 0, len(snap.showing))
+	for _, movieID := range snap.showing {
+		items = append(items, models.RecommendedMovie{
+			MovieID: movieID,
+			Score:   snap.popularity(movieID),
+			Reason:  models.ReasonPopular,
+		})
+	}
+	return rankRecommendations(items)
+}
+
+func (snap *recommendationSnapshot) similarTo(movieID primitive.ObjectID) []models.RecommendedMovie {
+	subject := snap.movies[movieID]
+	var items []models.RecommendedMovie
+	for _, other := range snap.movieIDs {
+		if other == movieID {
+			continue
+		}
+		genre := similarityGenreWeight * snap.genreSimilarity(movieID, other)
+		coBooked := similarityCoBookedWeight * snap.coBookedSimilarity(movieID, other)
+		if genre+coBooked <= 0 {
+			continue
+		}
+
+		item := models.RecommendedMovie{MovieID: other, Score: genre + coBooked, BasedOn: &subject.ID}
+		if coBooked > genre {
+			item.Reason = models.ReasonCoBooked
+		} else {
+			item.Reason = models.ReasonSharedGenres
+			item.GenreIDs = snap.sharedGenres(movieID, other)
+		}
+		items = append(items, item)
+	}
+	return rankRecommendations(items)
+}
+
+func (snap *recommendationSnapshot) genreProfile(userID primitive.ObjectID) map[primitive.ObjectID]float64 {
+	profile := make(map[primitive.ObjectID]float64)
+	for movieID, weight := range snap.seeds[userID] {
+		for _, g := range snap.movieGenres[movieID] {
+			profile[g] += weight
+		}
+	}
+
+	maxAbs := 0.0
+	for _, v := range profile {
+		if v < 0 {
+			v = -v
+		}
+		if v > maxAbs {
+			maxAbs = v
+		}
+	}
+	if maxAbs > 0 {
+		for g := range profile {
+			profile[g] /= maxAbs
+		}
+	}
+	return profile
+}
+
+func (snap *recommendationSnapshot) forUser(userID primitive.ObjectID) []models.RecommendedMovie {
+	seeds := snap.seeds[userID]
+	seedIDs := sortedKeys(seeds)
+	profile := snap.genreProfile(userID)
+
+	var items []models.RecommendedMovie
+	for _, candidate := range snap.showing {
+		if _, seen := seeds[candidate]; seen {
+			continue
+		}
+
+		var bestSeed primitive.ObjectID
+		bestSimilarity := 0.0
+		for _, seedID := range seedIDs {
+			if seeds[seedID] <= 0 {
+				continue
+			}
+			if v := seeds[seedID] * snap.similarity(seedID, candidate); v > bestSimilarity {
+				bestSeed, bestSimilarity = seedID, v
+			}
+		}
+
+		var bestGenre primitive.ObjectID
+		genre := 0.0
+		for _, g := range snap.movieGenres[candidate] {
+			genre += profile[g]
+			if bestGenre.IsZero() || profile[g] > profile[bestGenre] {
+				bestGenre = g
+			}
+		}
+		if n := len(snap.movieGenres[candidate]); n > 0 {
+			genre /= float64(n)
+		}
+
+		similarityScore := scoreSimilarityWeight * bestSimilarity
+		genreScore := scoreGenreWeight * genre
+		score := similarityScore + genreScore + scorePopularityWeight*snap.popularity(candidate)
+		if score <= 0 {
+			continue
+		}
+
+		item := models.RecommendedMovie{MovieID: candidate, Score: score}
+		switch {
+		case bestSimilarity > 0 && similarityScore >= genreScore:
+			seed := snap.movies[bestSeed]
+			item.BasedOn = &seed.ID
+			if snap.ratings[userID][bestSeed] >= likedRatingThreshold {
+				item.Reason = models.ReasonLiked
+			} else {
+				item.Reason = models.ReasonWatched
+			}
+		case genre > 0:
+			item.Reason = models.ReasonGenre
+			item.GenreIDs = []primitive.ObjectID{bestGenre}
+		default:
+			item.Reason = models.ReasonPopular
+		}
+		items = append(items, item)
+	}
+	return rankRecommendations(items)
+}
+
+func rankRecommendations(items []models.RecommendedMovie) []models.RecommendedMovie {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		return items[i].MovieID.Hex() < items[j].MovieID.Hex()
+	})
+	if len(items) > recommendationListSize {
+		items = items[:recommendationListSize]
+	}
+	if items == nil {
+		items = []models.RecommendedMovie{}
+	}
+	return items
+}
+
+func sortedKeys[V any](m map[primitive.ObjectID]V) []primitive.ObjectID {
+	keys := make([]primitive.ObjectID, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Hex() < keys[j].Hex() })
+	return keys
+}
+
+func (s *RecommendationService) GetForUser(ctx context.Context, userID primitive.ObjectID, limit int) (_ *models.Recommendation, err error) {
+	ctx, span := tracing.Start(ctx, "RecommendationService.GetForUser")
+	defer tracing.End(span, &err)
+
+	rec, err := s.recommendationRepo.Find(ctx, models.RecommendationForUser, userID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		rec, err = s.recommendationRepo.Find(ctx, models.RecommendationPopular, primitive.NilObjectID)
+	}
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		rec, err = &models.Recommendation{Kind: models.RecommendationPopular}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rec, s.hydrate(ctx, rec, limit)
+}
+
+func (s *RecommendationService) GetSimilar(ctx context.Context, movieID primitive.ObjectID, limit int) (_ *models.Recommendation, err error) {
+	ctx, span := tracing.Start(ctx, "RecommendationService.GetSimilar")
+	defer tracing.End(span, &err)
+
+	if _, err := s.movieRepo.FindByID(ctx, movieID); err != nil {
+		return nil, notFound(err, ErrMovieNotFound)
+	}
+	rec, err := s.recommendationRepo.Find(ctx, models.RecommendationSimilar, movieID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		rec, err = &models.Recommendation{Kind: models.RecommendationSimilar, SubjectID: movieID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rec, s.hydrate(ctx, rec, limit)
+}
+
+func (s *RecommendationService) hydrate(ctx context.Context, rec *models.Recommendation, limit int) error {
+	if limit <= 0 {
+		limit = defaultRecommendationLimit
+	}
+
+	var movieIDs, genreIDs []primitive.ObjectID
+	for _, item := range rec.Items {
+		movieIDs = append(movieIDs, item.MovieID)
+		if item.BasedOn != nil {
+			movieIDs = append(movieIDs, *item.BasedOn)
+		}
+		genreIDs = append(genreIDs, item.GenreIDs...)
+	}
+	movies, err := s.movieRepo.FindByIDs(ctx, movieIDs)
+	if err != nil {
+		return err
+	}
+	genres, err := s.genreRepo.FindByIDs(ctx, genreIDs)
+	if err != nil {
+		return err
+	}
+
+	movieByID := make(map[primitive.ObjectID]*models.Movie, len(movies))
+	for i := range movies {
+		localizeMovie(ctx, &movies[i])
+		movieByID[movies[i].ID] = &movies[i]
+	}
+	genreNames := make(map[primitive.ObjectID]string, len(genres))
+	for i := range genres {
+		localizeGenre(ctx, &genres[i])
+		genreNames[genres[i].ID] = genres[i].Name
+	}
+
+	lang := i18n.Language(ctx)
+	items := make([]models.RecommendedMovie, 0, limit)
+	for _, item := range rec.Items {
+		if len(items) >= limit {
+			break
+		}
+		movie, ok := movieByID[item.MovieID]
+		if !ok {
+			continue
+		}
+		item.Movie = movie
+		item.Explanation = explainRecommendation(lang, item, movieByID, genreNames)
+		items = append(items, item)
+	}
+	rec.Items = items
+	return nil
+}
+
+var recommendationExplanations = map[string]map[models.RecommendationReason]string{
+	"en": {
+		models.ReasonLiked:        "Because you liked {movie}",
+		models.ReasonWatched:      "Because you watched {movie}",
+		models.ReasonGenre:        "Because you like {genres}",
+		models.ReasonCoBooked:     "Often booked together with {movie}",
+		models.ReasonSharedGenres: "Shares genres with {movie}: {genres}",
+		models.ReasonPopular:      "Popular right now",
+	},
+	"ru": {
+		models.ReasonLiked:        "Потому что вам понравился «{movie}»",
+		models.ReasonWatched:      "Потому что вы смотрели «{movie}»",
+		models.ReasonGenre:        "Потому что вам нравится жанр: {genres}",
+		models.ReasonCoBooked:     "Часто бронируют вместе с «{movie}»",
+		models.ReasonSharedGenres: "Общие жанры с «{movie}»: {genres}",
+		models.ReasonPopular:      "Популярно сейчас",
+	},
+	"kk": {
+		models.ReasonLiked:        "Сізге «{movie}» ұнағандықтан",
+		models.ReasonWatched:      "Сіз «{movie}» көргендіктен",
+		models.ReasonGenre:        "Сізге ұнайтын жанр: {genres}",
+		models.ReasonCoBooked:     "«{movie}» фильмімен жиі бірге брондалады",
+		models.ReasonSharedGenres: "«{movie}» фильмімен ортақ жанрлар: {genres}",
+		models.ReasonPopular:      "Қазір танымал",
+	},
+}
+
+func explainRecommendation(lang string, item models.RecommendedMovie, movies map[primitive.ObjectID]*models.Movie, genreNames map[primitive.ObjectID]string) string {
+	reason := item.Reason
+	var movie string
+	if item.BasedOn != nil {
+		if based, ok := movies[*item.BasedOn]; ok {
+			movie = based.Name
+		} else {
+			reason = models.ReasonPopular
+		}
+	}
+	var genres []string
+	for _, id := range item.GenreIDs {
+		if name, ok := genreNames[id]; ok {
+			genres = append(genres, name)
+		}
+	}
+	if len(genres) == 0 && (reason == models.ReasonGenre || reason == models.ReasonSharedGenres) {
+		reason = models.ReasonPopular
+	}
+
+	messages, ok := recommendationExplanations[lang]
+	if !ok {
+		messages = recommendationExplanations[i18n.Default]
+	}
+	return strings.NewReplacer("{movie}", movie, "{genres}", strings.Join(genres, ", ")).Replace(messages[reason])
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"cinema-system/internal/i18n"
+	"cinema-system/internal/models"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRecommendations(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		service := b.recommendationService()
+		hall := b.createHall(t)
+
+		drama := &models.Genre{Name: "Drama"}
+		comedy := &models.Genre{Name: "Comedy"}
+		for _, genre := range []*models.Genre{drama, comedy} {
+			if err := b.genres.Create(ctx, genre); err != nil {
+				t.Fatalf("create genre: %v", err)
+			}
+		}
+
+		newMovie := func(name string, genre *models.Genre) (*models.Movie, *models.Session) {
+			movie := b.createMovie(t, "12+")
+			movie.Name = name
+			if err := b.movies.Update(ctx, movie.ID, movie); err != nil {
+				t.Fatalf("update movie: %v", err)
+			}
+			if err := b.movieGenres.Create(ctx, &models.MovieGenre{MovieID: movie.ID, GenreID: genre.ID}); err != nil {
+				t.Fatalf("link genre: %v", err)
+			}
+			return movie, b.createSession(t, movie, hall, 1000)
+		}
+		seen, seenSession := newMovie("Seen", drama)
+		sameGenre, _ := newMovie("Same Genre", drama)
+		coBooked, coBookedSession := newMovie("Co-booked", comedy)
+
+		seat := 0
+		book := func(user *models.User, session *models.Session) {
+			seat++
+			ticket := &models.Ticket{UserID: user.ID, SessionID: session.ID, RowNumber: 1, SeatNumber: seat, Status: models.TicketPaid, CreatedAt: time.Now()}
+			if err := b.tickets.Create(ctx, ticket); err != nil {
+				t.Fatalf("create ticket: %v", err)
+			}
+		}
+
+		viewer := b.createUser(t, 0)
+		other := b.createUser(t, 0)
+		newcomer := b.createUser(t, 0)
+		book(viewer, seenSession)
+		book(other, seenSession)
+		book(other, coBookedSession)
+		review := &models.Review{UserID: viewer.ID, MovieID: seen.ID, Rating: 9, Status: models.ReviewPublished, CreatedAt: time.Now()}
+		if err := b.reviews.Create(ctx, review); err != nil {
+			t.Fatalf("create review: %v", err)
+		}
+
+		if err := service.Refresh(ctx, time.Now()); err != nil {
+			t.Fatalf("Refresh: %v", err)
+		}
+
+		rec, err := service.GetForUser(ctx, viewer.ID, 0)
+		if err != nil {
+			t.Fatalf("GetForUser: %v", err)
+		}
+		if rec.Kind != models.RecommendationForUser || len(rec.Items) != 2 {
+			t.Fatalf("recommendations = %+v, want two personal items", rec)
+		}
+		first, second := rec.Items[0], rec.Items[1]
+		if first.MovieID != sameGenre.ID || first.Reason != models.ReasonGenre || first.Explanation != "Because you like Drama" {
+			t.Fatalf("first = %+v, want %s recommended for its genre", first, sameGenre.Name)
+		}
+		if second.MovieID != coBooked.ID || second.Reason != models.ReasonLiked || second.BasedOn == nil || *second.BasedOn != seen.ID {
+			t.Fatalf("second = %+v, want %s recommended because the viewer liked %s", second, coBooked.Name, seen.Name)
+		}
+		if second.Movie == nil || second.Movie.Name != coBooked.Name || !strings.Contains(second.Explanation, seen.Name) {
+			t.Fatalf("second = %+v, want hydrated movie and explanation naming %s", second, seen.Name)
+		}
+
+		fallback, err := service.GetForUser(ctx, newcomer.ID, 1)
+		if err != nil {
+			t.Fatalf("GetForUser newcomer: %v", err)
+		}
+		if fallback.Kind != models.RecommendationPopular || len(fallback.Items) != 1 || fallback.Items[0].Reason != models.ReasonPopular {
+			t.Fatalf("fallback = %+v, want one popular item", fallback)
+		}
+
+		similar, err := service.GetSimilar(ctx, seen.ID, 0)
+		if err != nil {
+			t.Fatalf("GetSimilar: %v", err)
+		}
+		if len(similar.Items) != 2 || similar.Items[0].MovieID != sameGenre.ID || similar.Items[0].Reason != models.ReasonSharedGenres ||
+			similar.Items[1].MovieID != coBooked.ID || similar.Items[1].Reason != models.ReasonCoBooked {
+			t.Fatalf("similar = %+v, want shared-genre movie then co-booked movie", similar.Items)
+		}
+
+		if err := b.genres.SetTranslation(ctx, drama.ID, "ru", models.GenreTranslation{Name: "Драма"}); err != nil {
+			t.Fatalf("genre SetTranslation: %v", err)
+		}
+		ru, err := service.GetForUser(i18n.WithLanguage(ctx, "ru"), viewer.ID, 2)
+		if err != nil {
+			t.Fatalf("GetForUser ru: %v", err)
+		}
+		if got := ru.Items[0].Explanation; got != "Потому что вам нравится жанр: Драма" {
+			t.Fatalf("ru explanation = %q", got)
+		}
+		if got := ru.Items[1].Explanation; got != "Потому что вам понравился «Seen»" {
+			t.Fatalf("ru explanation = %q", got)
+		}
+		stored, err := b.recommendations.Find(ctx, models.RecommendationForUser, viewer.ID)
+		if err != nil {
+			t.Fatalf("find stored recommendation: %v", err)
+		}
+		if stored.Items[0].Explanation != "" || len(stored.Items[0].GenreIDs) != 1 || stored.Items[0].GenreIDs[0] != drama.ID {
+			t.Fatalf("stored item = %+v, want reason data without explanation", stored.Items[0])
+		}
+
+		if _, err := service.GetSimilar(ctx, primitive.NewObjectID(), 0); !errors.Is(err, ErrMovieNotFound) {
+			t.Fatalf("err = %v, want %v", err, ErrMovieNotFound)
+		}
+
+		if err := service.Refresh(ctx, time.Now().Add(time.Minute)); err != nil {
+			t.Fatalf("second Refresh: %v", err)
+		}
+		if rec, err := service.GetForUser(ctx, viewer.ID, 1); err != nil || len(rec.Items) != 1 || rec.Items[0].MovieID != sameGenre.ID {
+			t.Fatalf("after refresh = %+v, %v", rec, err)
+		}
+	})
+}
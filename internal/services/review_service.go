@@ -1,86 +1,282 @@
 package services
 
 import (
+	"cinema-system/internal/config"
+	"cinema-system/internal/events"
 	"cinema-system/internal/models"
 	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
 	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)\S+|\b[a-z0-9-]+\.(com|net|org|ru|kz|io|info|xyz|me)\b`)
+
 type ReviewService struct {
-	reviewRepo *repositories.ReviewRepository
-	movieRepo  *repositories.MovieRepository
-	userRepo   *repositories.UserRepository
+	reviewRepo  repositories.ReviewStore
+	reportRepo  repositories.ReviewReportStore
+	voteRepo    repositories.ReviewVoteStore
+	movieRepo   repositories.MovieStore
+	userRepo    repositories.UserStore
+	ticketRepo  repositories.TicketStore
+	sessionRepo repositories.SessionStore
+	outboxRepo  repositories.OutboxStore
+	transactor  repositories.TransactionRunner
+	moderation  *config.ModerationConfig
+	ratings     *config.RatingConfig
+
+	recompute       chan struct{}
+	recomputeMu     sync.Mutex
+	recomputeStatus models.RatingRecomputeStatus
 }
 
 func NewReviewService(
-	reviewRepo *repositories.ReviewRepository,
-	movieRepo *repositories.MovieRepository,
-	userRepo *repositories.UserRepository,
+	reviewRepo repositories.ReviewStore,
+	reportRepo repositories.ReviewReportStore,
+	voteRepo repositories.ReviewVoteStore,
+	movieRepo repositories.MovieStore,
+	userRepo repositories.UserStore,
+	ticketRepo repositories.TicketStore,
+	sessionRepo repositories.SessionStore,
+	outboxRepo repositories.OutboxStore,
+	transactor repositories.TransactionRunner,
+	moderation *config.ModerationConfig,
+	ratings *config.RatingConfig,
 ) *ReviewService {
 	return &ReviewService{
-		reviewRepo: reviewRepo,
-		movieRepo:  movieRepo,
-		userRepo:   userRepo,
+		reviewRepo:  reviewRepo,
+		reportRepo:  reportRepo,
+		voteRepo:    voteRepo,
+		movieRepo:   movieRepo,
+		userRepo:    userRepo,
+		ticketRepo:  ticketRepo,
+		sessionRepo: sessionRepo,
+		outboxRepo:  outboxRepo,
+		transactor:  transactor,
+		moderation:  moderation,
+		ratings:     ratings,
+		recompute:   make(chan struct{}, 1),
+		recomputeStatus: models.RatingRecomputeStatus{
+			State: models.RecomputeIdle,
+		},
 	}
 }
 
-func (s *ReviewService) CreateReview(ctx context.Context, review *models.Review) error {
+func (s *ReviewService) CreateReview(ctx context.Context, review *models.Review) (err error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.CreateReview")
+	defer tracing.End(span, &err)
+
 	exists, err := s.reviewRepo.CheckUserReview(ctx, review.UserID, review.MovieID)
 	if err != nil {
 		return err
 	}
 	if exists {
-		return errors.New("user already reviewed this movie")
+		return ErrReviewAlreadyExists
 	}
 
 	if review.Rating < 0 || review.Rating > 10 {
-		return errors.New("rating must be between 0 and 10")
+		return ErrInvalidRating
+	}
+
+	status, reason, err := s.screen(review.Comment)
+	if err != nil {
+		return err
 	}
 
 	movie, err := s.movieRepo.FindByID(ctx, review.MovieID)
 	if err != nil {
-		return errors.New("movie not found")
+		return notFound(err, ErrMovieNotFound)
 	}
 
 	user, err := s.userRepo.FindByID(ctx, review.UserID)
 	if err != nil {
-		return errors.New("user not found")
+		return notFound(err, ErrUserNotFound)
+	}
+
+	verified, weight, err := s.verify(ctx, movie, review.UserID)
+	if err != nil {
+		return err
 	}
 
 	review.UserName = user.FirstName + " " + user.LastName
 	review.MovieTitle = movie.Name
 	review.CreatedAt = time.Now()
+	review.Status = status
+	review.ModerationReason = reason
+	review.ModeratedBy = nil
+	review.ModeratedAt = nil
+	review.ReportCount = 0
+	review.HasComment = strings.TrimSpace(review.Comment) != ""
+	review.HelpfulCount = 0
+	review.UnhelpfulCount = 0
+	review.Reply = nil
+	review.VerifiedViewer = verified
+	review.Weight = weight
+
+	return s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.reviewRepo.Create(ctx, review); err != nil {
+			return err
+		}
+		if err := s.adjustRating(ctx, review.MovieID, nil, review); err != nil {
+			return err
+		}
+		return s.publish(ctx, events.ReviewCreated, review)
+	})
+}
 
-	if err := s.reviewRepo.Create(ctx, review); err != nil {
-		return err
+func (s *ReviewService) verify(ctx context.Context, movie *models.Movie, userID primitive.ObjectID) (bool, float64, error) {
+	verified, err := s.isVerifiedViewer(ctx, userID, movie.ID)
+	if err != nil {
+		return false, 0, err
+	}
+	if verified {
+		return true, 1, nil
+	}
+
+	switch movie.EffectiveReviewPolicy() {
+	case models.ReviewPolicyVerifiedOnly:
+		return false, 0, ErrVerifiedViewerOnly
+	case models.ReviewPolicyWeighted:
+		return false, s.moderation.UnverifiedWeight, nil
+	default:
+		return false, 1, nil
+	}
+}
+
+func (s *ReviewService) isVerifiedViewer(ctx context.Context, userID, movieID primitive.ObjectID) (bool, error) {
+	tickets, err := s.ticketRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, err
 	}
 
-	go s.updateMovieRating(context.Background(), review.MovieID)
+	now := time.Now()
+	sessions := make(map[primitive.ObjectID]*models.Session)
+	for _, ticket := range tickets {
+		if ticket.Status != models.TicketUsed && ticket.Status != models.TicketPaid {
+			continue
+		}
 
-	return nil
+		session, ok := sessions[ticket.SessionID]
+		if !ok {
+			session, err = s.sessionRepo.FindByID(ctx, ticket.SessionID)
+			if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+				return false, err
+			}
+			sessions[ticket.SessionID] = session
+		}
+		if session == nil || session.MovieID != movieID {
+			continue
+		}
+		if ticket.Status == models.TicketUsed || session.EndTime.Before(now) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *ReviewService) screen(comment string) (models.ReviewStatus, string, error) {
+	length := utf8.RuneCountInString(strings.TrimSpace(comment))
+	if length < s.moderation.MinLength {
+		return "", "", ErrReviewTooShort.With("min", s.moderation.MinLength)
+	}
+	if s.moderation.MaxLength > 0 && length > s.moderation.MaxLength {
+		return "", "", ErrReviewTooLong.With("max", s.moderation.MaxLength)
+	}
+
+	if len(s.moderation.BannedWords) > 0 {
+		words := strings.FieldsFunc(strings.ToLower(comment), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, word := range words {
+			for _, banned := range s.moderation.BannedWords {
+				if word == banned {
+					return models.ReviewPending, "contains banned word", nil
+				}
+			}
+		}
+	}
+	if !s.moderation.AllowLinks && linkPattern.MatchString(comment) {
+		return models.ReviewPending, "contains link", nil
+	}
+	if s.moderation.RequireApproval {
+		return models.ReviewPending, "awaiting approval", nil
+	}
+	return models.ReviewPublished, "", nil
 }
 
-func (s *ReviewService) updateMovieRating(ctx context.Context, movieID primitive.ObjectID) {
-	avgRating, err := s.reviewRepo.GetAverageRating(ctx, movieID)
+func (s *ReviewService) publish(ctx context.Context, eventType string, review *models.Review) error {
+	event, err := events.New(eventType, review.ID, events.ReviewPayload{
+		ReviewID: review.ID,
+		MovieID:  review.MovieID,
+		UserID:   review.UserID,
+		Rating:   review.Rating,
+	})
 	if err != nil {
-		return
+		return err
+	}
+	return s.outboxRepo.Add(ctx, event)
+}
+
+func (s *ReviewService) adjustRating(ctx context.Context, movieID primitive.ObjectID, before, after *models.Review) error {
+	delta := &models.RatingStats{}
+	if before != nil && before.Status == models.ReviewPublished {
+		delta.Remove(before.Rating, before.RatingWeight())
+	}
+	if after != nil && after.Status == models.ReviewPublished {
+		delta.Add(after.Rating, after.RatingWeight())
 	}
+	if delta.IsZero() {
+		return nil
+	}
+
+	err := s.movieRepo.IncrementRatingStats(ctx, movieID, delta, s.prior())
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil
+	}
+	return err
+}
+
+func (s *ReviewService) prior() models.RatingPrior {
+	return models.RatingPrior{Mean: s.ratings.PriorMean, Weight: s.ratings.PriorWeight, Bayesian: s.ratings.Bayesian}
+}
 
-	s.movieRepo.UpdateRating(ctx, movieID, avgRating)
+func (s *ReviewService) updateMovieRating(ctx context.Context, movieID primitive.ObjectID) error {
+	return s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		stats, err := s.reviewRepo.GetRatingStats(ctx, movieID)
+		if err != nil {
+			return err
+		}
+		return s.saveRating(ctx, movieID, stats)
+	})
 }
 
-func (s *ReviewService) GetMovieReviews(ctx context.Context, movieID primitive.ObjectID) ([]models.Review, error) {
-	reviews, err := s.reviewRepo.GetByMovie(ctx, movieID)
+func (s *ReviewService) saveRating(ctx context.Context, movieID primitive.ObjectID, stats *models.RatingStats) error {
+	stats.Compute(s.ratings.PriorMean, s.ratings.PriorWeight)
+	return s.movieRepo.SetRatingStats(ctx, movieID, stats, stats.Rating(s.prior()))
+}
+
+func (s *ReviewService) GetMovieReviews(ctx context.Context, movieID primitive.ObjectID, query *repositories.ListQuery) (_ *models.Page[models.Review], err error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.GetMovieReviews")
+	defer tracing.End(span, &err)
+
+	page, err := s.reviewRepo.ListPublishedByMovie(ctx, movieID, query)
 	if err != nil {
 		return nil, err
 	}
 
+	reviews := page.Items
 	for i := range reviews {
 		if reviews[i].UserName == "" {
 			u, _ := s.userRepo.FindByID(ctx, reviews[i].UserID)
@@ -89,17 +285,17 @@ func (s *ReviewService) GetMovieReviews(ctx context.Context, movieID primitive.O
 			}
 		}
 	}
-	return reviews, nil
+	return page, nil
 }
 
-func (s *ReviewService) GetMyReviews(ctx context.Context, userID primitive.ObjectID) ([]models.Review, error) {
-	fmt.Printf("[DEBUG] GetMyReviews for userID: %s\n", userID.Hex())
+func (s *ReviewService) GetMyReviews(ctx context.Context, userID primitive.ObjectID) (_ []models.Review, err error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.GetMyReviews")
+	defer tracing.End(span, &err)
+
 	reviews, err := s.reviewRepo.GetByUser(ctx, userID)
 	if err != nil {
-		fmt.Printf("[DEBUG] Error GetMyReviews: %v\n", err)
 		return nil, err
 	}
-	fmt.Printf("[DEBUG] Found %d reviews\n", len(reviews))
 
 	for i := range reviews {
 		if reviews[i].MovieTitle == "" {
@@ -112,83 +308,459 @@ func (s *ReviewService) GetMyReviews(ctx context.Context, userID primitive.Objec
 	return reviews, nil
 }
 
-func (s *ReviewService) DeleteReview(ctx context.Context, reviewID, userID primitive.ObjectID, isAdmin bool) error {
+func (s *ReviewService) DeleteReview(ctx context.Context, reviewID, userID primitive.ObjectID, isAdmin bool) (err error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.DeleteReview")
+	defer tracing.End(span, &err)
+
+	return s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		review, err := s.reviewRepo.FindByID(ctx, reviewID)
+		if err != nil {
+			return notFound(err, ErrReviewNotFound)
+		}
+
+		if !isAdmin && review.UserID != userID {
+			return ErrReviewForbidden
+		}
+
+		if err := s.reviewRepo.Delete(ctx, reviewID); err != nil {
+			return err
+		}
+		if err := s.adjustRating(ctx, review.MovieID, review, nil); err != nil {
+			return err
+		}
+		return s.publish(ctx, events.ReviewDeleted, review)
+	})
+}
+
+func (s *ReviewService) UpdateReview(ctx context.Context, reviewID, userID primitive.ObjectID, rating int, comment string) (err error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.UpdateReview")
+	defer tracing.End(span, &err)
+
 	review, err := s.reviewRepo.FindByID(ctx, reviewID)
 	if err != nil {
-		return errors.New("review not found")
+		return notFound(err, ErrReviewNotFound)
+	}
+
+	if review.UserID != userID {
+		return ErrReviewForbidden
+	}
+
+	if rating < 0 || rating > 10 {
+		return ErrInvalidRating
 	}
 
-	if !isAdmin && review.UserID != userID {
-		return errors.New("unauthorized to delete this review")
+	status, reason, err := s.screen(comment)
+	if err != nil {
+		return err
 	}
 
-	if err := s.reviewRepo.Delete(ctx, reviewID); err != nil {
+	movie, err := s.movieRepo.FindByID(ctx, review.MovieID)
+	if err != nil {
+		return notFound(err, ErrMovieNotFound)
+	}
+	verified, weight, err := s.verify(ctx, movie, userID)
+	if err != nil {
 		return err
 	}
 
-	go s.updateMovieRating(context.Background(), review.MovieID)
+	return s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		review, err := s.reviewRepo.FindByID(ctx, reviewID)
+		if err != nil {
+			return notFound(err, ErrReviewNotFound)
+		}
+		before := *review
+
+		review.Rating = rating
+		review.VerifiedViewer = verified
+		review.Weight = weight
+		review.Comment = comment
+		review.HasComment = strings.TrimSpace(comment) != ""
+		review.CreatedAt = time.Now()
 
-	return nil
+		if err := s.reviewRepo.Update(ctx, review); err != nil {
+			return err
+		}
+		if review.Status == models.ReviewPublished || review.Status == models.ReviewPending {
+			review.Status = status
+			review.ModerationReason = reason
+			if err := s.reviewRepo.SetStatus(ctx, reviewID, status, reason, nil, review.CreatedAt); err != nil {
+				return err
+			}
+		}
+		if err := s.adjustRating(ctx, review.MovieID, &before, review); err != nil {
+			return err
+		}
+		return s.publish(ctx, events.ReviewUpdated, review)
+	})
 }
 
-func (s *ReviewService) UpdateReview(ctx context.Context, reviewID, userID primitive.ObjectID, rating int, comment string) error {
+func (s *ReviewService) ReportReview(ctx context.Context, reviewID, userID primitive.ObjectID, req *models.ReviewReportRequest) (_ *models.ReviewReport, err error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.ReportReview")
+	defer tracing.End(span, &err)
+
 	review, err := s.reviewRepo.FindByID(ctx, reviewID)
 	if err != nil {
-		return errors.New("review not found")
+		return nil, notFound(err, ErrReviewNotFound)
+	}
+	if review.UserID == userID {
+		return nil, ErrCannotReportOwn
+	}
+	if review.Status != models.ReviewPublished {
+		return nil, ErrReviewNotReportable
 	}
 
-	if review.UserID != userID {
-		return errors.New("unauthorized to update this review")
+	report := &models.ReviewReport{
+		ReviewID:  reviewID,
+		UserID:    userID,
+		Reason:    req.Reason,
+		Comment:   req.Comment,
+		CreatedAt: time.Now(),
 	}
 
-	if rating < 0 || rating > 10 {
-		return errors.New("rating must be between 0 and 10")
+	err = s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.reportRepo.Create(ctx, report); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return ErrReviewAlreadyReported
+			}
+			return err
+		}
+
+		updated, err := s.reviewRepo.IncrementReportCount(ctx, reviewID)
+		if err != nil {
+			return notFound(err, ErrReviewNotFound)
+		}
+		threshold := s.moderation.ReportThreshold
+		if threshold <= 0 || updated.ReportCount < threshold || updated.Status != models.ReviewPublished {
+			return nil
+		}
+
+		reason := fmt.Sprintf("hidden after %d reports", updated.ReportCount)
+		if err := s.reviewRepo.SetStatus(ctx, reviewID, models.ReviewHidden, reason, nil, report.CreatedAt); err != nil {
+			return err
+		}
+		if err := s.adjustRating(ctx, updated.MovieID, updated, nil); err != nil {
+			return err
+		}
+		return s.publish(ctx, events.ReviewUpdated, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *ReviewService) VoteReview(ctx context.Context, reviewID, userID primitive.ObjectID, helpful bool) (_ *models.Review, err error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.VoteReview")
+	defer tracing.End(span, &err)
+
+	var review *models.Review
+	err = s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		review, err = s.reviewRepo.FindByID(ctx, reviewID)
+		if err != nil {
+			return notFound(err, ErrReviewNotFound)
+		}
+		if review.UserID == userID {
+			return ErrCannotVoteOwn
+		}
+		if review.Status != models.ReviewPublished {
+			return ErrReviewNotVotable
+		}
+
+		existing, err := s.voteRepo.Find(ctx, reviewID, userID)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
+		if existing != nil && existing.Helpful == helpful {
+			return nil
+		}
+
+		helpfulDelta, unhelpfulDelta := voteDelta(helpful, 1)
+		if existing != nil {
+			h, u := voteDelta(existing.Helpful, -1)
+			helpfulDelta += h
+			unhelpfulDelta += u
+		}
+
+		vote := &models.ReviewVote{ReviewID: reviewID, UserID: userID, Helpful: helpful, CreatedAt: time.Now()}
+		if err := s.voteRepo.Upsert(ctx, vote); err != nil {
+			return err
+		}
+		if err := s.reviewRepo.IncrementVotes(ctx, reviewID, helpfulDelta, unhelpfulDelta); err != nil {
+			return err
+		}
+		review.HelpfulCount += helpfulDelta
+		review.UnhelpfulCount += unhelpfulDelta
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return review, nil
+}
 
-	review.Rating = rating
-	review.Comment = comment
-	review.CreatedAt = time.Now()
+func (s *ReviewService) RemoveVote(ctx context.Context, reviewID, userID primitive.ObjectID) (err error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.RemoveVote")
+	defer tracing.End(span, &err)
 
-	if err := s.reviewRepo.Update(ctx, review); err != nil {
-		return err
+	return s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.voteRepo.Find(ctx, reviewID, userID)
+		if err != nil {
+			return notFound(err, ErrReviewVoteNotFound)
+		}
+		if err := s.voteRepo.Delete(ctx, reviewID, userID); err != nil {
+			return notFound(err, ErrReviewVoteNotFound)
+		}
+		helpfulDelta, unhelpfulDelta := voteDelta(existing.Helpful, -1)
+		return notFound(s.reviewRepo.IncrementVotes(ctx, reviewID, helpfulDelta, unhelpfulDelta), ErrReviewNotFound)
+	})
+}
+
+func voteDelta(helpful bool, sign int) (int, int) {
+	if helpful {
+		return sign, 0
 	}
+	return 0, sign
+}
+
+func (s *ReviewService) ReplyToReview(ctx context.Context, reviewID, staffID primitive.ObjectID, text string) (_ *models.Review, err error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.ReplyToReview")
+	defer tracing.End(span, &err)
+
+	review, err := s.reviewRepo.FindByID(ctx, reviewID)
+	if err != nil {
+		return nil, notFound(err, ErrReviewNotFound)
+	}
+	staff, err := s.userRepo.FindByID(ctx, staffID)
+	if err != nil {
+		return nil, notFound(err, ErrUserNotFound)
+	}
+
+	now := time.Now()
+	reply := &models.ReviewReply{
+		Text:       strings.TrimSpace(text),
+		AuthorID:   staffID,
+		AuthorName: staff.FirstName + " " + staff.LastName,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if review.Reply != nil {
+		reply.CreatedAt = review.Reply.CreatedAt
+	}
+
+	if err := s.reviewRepo.SetReply(ctx, reviewID, reply); err != nil {
+		return nil, notFound(err, ErrReviewNotFound)
+	}
+	review.Reply = reply
+	return review, nil
+}
+
+func (s *ReviewService) DeleteReply(ctx context.Context, reviewID primitive.ObjectID) (err error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.DeleteReply")
+	defer tracing.End(span, &err)
+
+	review, err := s.reviewRepo.FindByID(ctx, reviewID)
+	if err != nil {
+		return notFound(err, ErrReviewNotFound)
+	}
+	if review.Reply == nil {
+		return ErrReviewReplyNotFound
+	}
+	return notFound(s.reviewRepo.SetReply(ctx, reviewID, nil), ErrReviewNotFound)
+}
+
+func (s *ReviewService) GetModerationQueue(ctx context.Context, query *repositories.ListQuery) (_ *models.Page[models.Review], err error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.GetModerationQueue")
+	defer tracing.End(span, &err)
+
+	if _, ok := query.Filter["status"]; !ok {
+		query.Filter["status"] = bson.M{"$in": bson.A{string(models.ReviewPending), string(models.ReviewHidden)}}
+	}
+	return s.reviewRepo.List(ctx, query)
+}
+
+func (s *ReviewService) GetReviewReports(ctx context.Context, reviewID primitive.ObjectID) (_ []models.ReviewReport, err error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.GetReviewReports")
+	defer tracing.End(span, &err)
+
+	if _, err := s.reviewRepo.FindByID(ctx, reviewID); err != nil {
+		return nil, notFound(err, ErrReviewNotFound)
+	}
+	return s.reportRepo.ListByReview(ctx, reviewID)
+}
 
-	go s.updateMovieRating(context.Background(), review.MovieID)
+func (s *ReviewService) ApproveReview(ctx context.Context, reviewID, moderatorID primitive.ObjectID, reason string) (_ *models.Review, err error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.ApproveReview")
+	defer tracing.End(span, &err)
 
-	return nil
+	return s.moderate(ctx, reviewID, moderatorID, models.ReviewPublished, reason)
+}
+
+func (s *ReviewService) RejectReview(ctx context.Context, reviewID, moderatorID primitive.ObjectID, reason string) (_ *models.Review, err error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.RejectReview")
+	defer tracing.End(span, &err)
+
+	if strings.TrimSpace(reason) == "" {
+		return nil, ErrModerationReason
+	}
+	return s.moderate(ctx, reviewID, moderatorID, models.ReviewRejected, reason)
+}
+
+func (s *ReviewService) HideReview(ctx context.Context, reviewID, moderatorID primitive.ObjectID, reason string) (_ *models.Review, err error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.HideReview")
+	defer tracing.End(span, &err)
+
+	return s.moderate(ctx, reviewID, moderatorID, models.ReviewHidden, reason)
+}
+
+func (s *ReviewService) moderate(ctx context.Context, reviewID, moderatorID primitive.ObjectID, status models.ReviewStatus, reason string) (*models.Review, error) {
+	now := time.Now()
+	reason = strings.TrimSpace(reason)
+
+	var review *models.Review
+	err := s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		review, err = s.reviewRepo.FindByID(ctx, reviewID)
+		if err != nil {
+			return notFound(err, ErrReviewNotFound)
+		}
+		before := *review
+
+		if err := s.reviewRepo.SetStatus(ctx, reviewID, status, reason, &moderatorID, now); err != nil {
+			return err
+		}
+		if err := s.reportRepo.ResolveByReview(ctx, reviewID, now); err != nil {
+			return err
+		}
+		if status == models.ReviewPublished {
+			if err := s.reviewRepo.ResetReportCount(ctx, reviewID); err != nil {
+				return err
+			}
+			review.ReportCount = 0
+		}
+
+		review.Status = status
+		review.ModerationReason = reason
+		review.ModeratedBy = &moderatorID
+		review.ModeratedAt = &now
+		if err := s.adjustRating(ctx, review.MovieID, &before, review); err != nil {
+			return err
+		}
+		return s.publish(ctx, events.ReviewUpdated, review)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
 }
 
 func (s *ReviewService) CalculateMovieRating(ctx context.Context, movieID primitive.ObjectID) (float64, error) {
 	return s.reviewRepo.GetAverageRating(ctx, movieID)
 }
 
-func (s *ReviewService) BatchUpdateRatings(ctx context.Context, movieIDs []primitive.ObjectID) error {
-	var wg sync.WaitGroup
-	errChan := make(chan error, len(movieIDs))
+func (s *ReviewService) BatchUpdateRatings(ctx context.Context, movieIDs []primitive.ObjectID) (err error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.BatchUpdateRatings")
+	defer tracing.End(span, &err)
 
-	for _, movieID := range movieIDs {
+	workers := s.ratings.RecomputeConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	queue := make(chan primitive.ObjectID)
+	for i := 0; i < workers; i++ {
 		wg.Add(1)
-		go func(id primitive.ObjectID) {
+		go func() {
 			defer wg.Done()
-			avgRating, err := s.reviewRepo.GetAverageRating(ctx, id)
-			if err != nil {
-				errChan <- err
-				return
+			for id := range queue {
+				if err := s.updateMovieRating(ctx, id); err != nil {
+					mu.Lock()
+					errs = append(errs, fmt.Errorf("movie %s: %w", id.Hex(), err))
+					mu.Unlock()
+				}
 			}
-			if err := s.movieRepo.UpdateRating(ctx, id, avgRating); err != nil {
-				errChan <- err
-			}
-		}(movieID)
+		}()
 	}
 
+	for _, movieID := range movieIDs {
+		queue <- movieID
+	}
+	close(queue)
 	wg.Wait()
-	close(errChan)
 
-	for err := range errChan {
-		if err != nil {
-			return err
+	return errors.Join(errs...)
+}
+
+func (s *ReviewService) RecomputeAllRatings(ctx context.Context) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.RecomputeAllRatings")
+	defer tracing.End(span, &err)
+
+	movies, err := s.movieRepo.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	movieIDs := make([]primitive.ObjectID, len(movies))
+	for i := range movies {
+		movieIDs[i] = movies[i].ID
+	}
+	return len(movieIDs), s.BatchUpdateRatings(ctx, movieIDs)
+}
+
+func (s *ReviewService) RequestRecompute() models.RatingRecomputeStatus {
+	s.recomputeMu.Lock()
+	defer s.recomputeMu.Unlock()
+
+	select {
+	case s.recompute <- struct{}{}:
+		if s.recomputeStatus.State == models.RecomputeIdle {
+			s.recomputeStatus.State = models.RecomputeQueued
 		}
+	default:
 	}
+	return s.recomputeStatus
+}
+
+func (s *ReviewService) RecomputeStatus() models.RatingRecomputeStatus {
+	s.recomputeMu.Lock()
+	defer s.recomputeMu.Unlock()
+	return s.recomputeStatus
+}
+
+func (s *ReviewService) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.recompute:
+		}
+
+		started := time.Now()
+		s.recomputeMu.Lock()
+		s.recomputeStatus = models.RatingRecomputeStatus{State: models.RecomputeRunning, StartedAt: &started}
+		s.recomputeMu.Unlock()
 
-	return nil
+		movies, err := s.RecomputeAllRatings(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "reviews: rating recompute failed", "error", err)
+		}
+
+		finished := time.Now()
+		s.recomputeMu.Lock()
+		s.recomputeStatus.Movies = movies
+		s.recomputeStatus.FinishedAt = &finished
+		if err != nil {
+			s.recomputeStatus.Error = err.Error()
+		}
+		s.recomputeStatus.State = models.RecomputeIdle
+		if len(s.recompute) > 0 {
+			s.recomputeStatus.State = models.RecomputeQueued
+		}
+		s.recomputeMu.Unlock()
+	}
 }
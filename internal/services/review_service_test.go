@@ -0,0 +1,448 @@
+package services
+
+import (
+	"cinema-system/internal/config"
+	"cinema-system/internal/events"
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestReviewRatingAggregation(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		movie := b.createMovie(t, "12+")
+		service := b.reviewService()
+
+		for _, rating := range []int{6, 9} {
+			review := &models.Review{MovieID: movie.ID, UserID: b.createUser(t, 0).ID, Rating: rating}
+			if err := service.CreateReview(ctx, review); err != nil {
+				t.Fatalf("CreateReview: %v", err)
+			}
+		}
+
+		avg, err := b.reviews.GetAverageRating(ctx, movie.ID)
+		if err != nil || avg != 7.5 {
+			t.Fatalf("average = %v, err = %v, want 7.5", avg, err)
+		}
+		empty, err := b.reviews.GetAverageRating(ctx, b.createMovie(t, "12+").ID)
+		if err != nil || empty != 0 {
+			t.Fatalf("empty average = %v, err = %v, want 0", empty, err)
+		}
+
+		assertEvents(t, b.drainEvents(t), events.ReviewCreated, events.ReviewCreated)
+		if err := service.updateMovieRating(ctx, movie.ID); err != nil {
+			t.Fatalf("updateMovieRating: %v", err)
+		}
+		stored, err := b.movies.FindByID(ctx, movie.ID)
+		if err != nil || stored.Rating != 7.5 {
+			t.Fatalf("movie rating = %+v, err = %v", stored, err)
+		}
+	})
+}
+
+func TestDuplicateReviewRejected(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		movie := b.createMovie(t, "12+")
+		user := b.createUser(t, 0)
+		service := b.reviewService()
+
+		if err := service.CreateReview(ctx, &models.Review{MovieID: movie.ID, UserID: user.ID, Rating: 8}); err != nil {
+			t.Fatalf("CreateReview: %v", err)
+		}
+		if err := service.CreateReview(ctx, &models.Review{MovieID: movie.ID, UserID: user.ID, Rating: 3}); !errors.Is(err, ErrReviewAlreadyExists) {
+			t.Fatalf("err = %v, want %v", err, ErrReviewAlreadyExists)
+		}
+	})
+}
+
+func TestReviewAutoModeration(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		movie := b.createMovie(t, "12+")
+		moderation := config.DefaultModerationConfig()
+		moderation.BannedWords = []string{"trash"}
+		moderation.MinLength = 5
+		service := b.moderatedReviewService(moderation)
+
+		cases := []struct {
+			comment string
+			status  models.ReviewStatus
+			err     error
+		}{
+			{comment: "Great movie!", status: models.ReviewPublished},
+			{comment: "Total TRASH, avoid", status: models.ReviewPending},
+			{comment: "Watch it free at www.example.com", status: models.ReviewPending},
+			{comment: "meh", err: ErrReviewTooShort},
+		}
+		for _, tc := range cases {
+			review := &models.Review{MovieID: movie.ID, UserID: b.createUser(t, 0).ID, Rating: 5, Comment: tc.comment}
+			err := service.CreateReview(ctx, review)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("%q: err = %v, want %v", tc.comment, err, tc.err)
+				}
+				continue
+			}
+			if err != nil || review.Status != tc.status {
+				t.Fatalf("%q: status = %s, err = %v, want %s", tc.comment, review.Status, err, tc.status)
+			}
+		}
+
+		avg, err := b.reviews.GetAverageRating(ctx, movie.ID)
+		if err != nil || avg != 5 {
+			t.Fatalf("average = %v, err = %v, want only the published review", avg, err)
+		}
+		page, err := service.GetMovieReviews(ctx, movie.ID, &repositories.ListQuery{Filter: bson.M{}, SortField: "created_at", Limit: 10})
+		if err != nil || len(page.Items) != 1 {
+			t.Fatalf("public reviews = %+v, err = %v, want 1", page, err)
+		}
+	})
+}
+
+func TestReviewReportsHideAndApprove(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		movie := b.createMovie(t, "12+")
+		author := b.createUser(t, 0)
+		moderator := b.createUser(t, 0)
+		moderation := config.DefaultModerationConfig()
+		moderation.ReportThreshold = 2
+		service := b.moderatedReviewService(moderation)
+
+		review := &models.Review{MovieID: movie.ID, UserID: author.ID, Rating: 9, Comment: "Loved it"}
+		if err := service.CreateReview(ctx, review); err != nil {
+			t.Fatalf("CreateReview: %v", err)
+		}
+		b.drainEvents(t)
+
+		report := &models.ReviewReportRequest{Reason: models.ReportSpoiler}
+		if _, err := service.ReportReview(ctx, review.ID, author.ID, report); !errors.Is(err, ErrCannotReportOwn) {
+			t.Fatalf("own report err = %v, want %v", err, ErrCannotReportOwn)
+		}
+
+		first := b.createUser(t, 0)
+		if _, err := service.ReportReview(ctx, review.ID, first.ID, report); err != nil {
+			t.Fatalf("ReportReview: %v", err)
+		}
+		if _, err := service.ReportReview(ctx, review.ID, b.createUser(t, 0).ID, report); err != nil {
+			t.Fatalf("ReportReview: %v", err)
+		}
+		assertEvents(t, b.drainEvents(t), events.ReviewUpdated)
+
+		hidden, err := b.reviews.FindByID(ctx, review.ID)
+		if err != nil || hidden.Status != models.ReviewHidden || hidden.ReportCount != 2 {
+			t.Fatalf("review = %+v, err = %v, want hidden with 2 reports", hidden, err)
+		}
+		if _, err := service.ReportReview(ctx, review.ID, first.ID, report); !errors.Is(err, ErrReviewNotReportable) {
+			t.Fatalf("report hidden err = %v, want %v", err, ErrReviewNotReportable)
+		}
+
+		queue, err := service.GetModerationQueue(ctx, &repositories.ListQuery{Filter: bson.M{}, SortField: "created_at", Limit: 10})
+		if err != nil || len(queue.Items) != 1 {
+			t.Fatalf("queue = %+v, err = %v, want 1", queue, err)
+		}
+
+		if _, err := service.RejectReview(ctx, review.ID, moderator.ID, " "); !errors.Is(err, ErrModerationReason) {
+			t.Fatalf("reject err = %v, want %v", err, ErrModerationReason)
+		}
+		approved, err := service.ApproveReview(ctx, review.ID, moderator.ID, "")
+		if err != nil || approved.Status != models.ReviewPublished {
+			t.Fatalf("approved = %+v, err = %v", approved, err)
+		}
+		assertEvents(t, b.drainEvents(t), events.ReviewUpdated)
+
+		stored, err := b.reviews.FindByID(ctx, review.ID)
+		if err != nil || stored.ReportCount != 0 || stored.ModeratedBy == nil || *stored.ModeratedBy != moderator.ID {
+			t.Fatalf("stored = %+v, err = %v", stored, err)
+		}
+		reports, err := service.GetReviewReports(ctx, review.ID)
+		if err != nil || len(reports) != 2 || !reports[0].Resolved {
+			t.Fatalf("reports = %+v, err = %v", reports, err)
+		}
+	})
+}
+
+func TestEditingModeratedReviewKeepsItOutOfRating(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		movie := b.createMovie(t, "12+")
+		moderator := b.createUser(t, 0)
+		service := b.reviewService()
+
+		var reviews []*models.Review
+		for _, rating := range []int{6, 10, 2} {
+			review := &models.Review{MovieID: movie.ID, UserID: b.createUser(t, 0).ID, Rating: rating}
+			if err := service.CreateReview(ctx, review); err != nil {
+				t.Fatalf("CreateReview: %v", err)
+			}
+			reviews = append(reviews, review)
+		}
+		rejected, hidden := reviews[1], reviews[2]
+		if _, err := service.RejectReview(ctx, rejected.ID, moderator.ID, "off topic"); err != nil {
+			t.Fatalf("RejectReview: %v", err)
+		}
+		if _, err := service.HideReview(ctx, hidden.ID, moderator.ID, "spoilers"); err != nil {
+			t.Fatalf("HideReview: %v", err)
+		}
+
+		for _, review := range []*models.Review{rejected, hidden} {
+			if err := service.UpdateReview(ctx, review.ID, review.UserID, 9, "edited after moderation"); err != nil {
+				t.Fatalf("UpdateReview: %v", err)
+			}
+		}
+
+		for review, want := range map[*models.Review]models.ReviewStatus{rejected: models.ReviewRejected, hidden: models.ReviewHidden} {
+			stored, err := b.reviews.FindByID(ctx, review.ID)
+			if err != nil || stored.Status != want || stored.Rating != 9 || stored.ModeratedBy == nil {
+				t.Fatalf("stored = %+v, err = %v, want %s with moderator kept", stored, err, want)
+			}
+		}
+		stored, err := b.movies.FindByID(ctx, movie.ID)
+		if err != nil || stored.RatingStats == nil || stored.RatingStats.Count != 1 || stored.Rating != 6 {
+			t.Fatalf("movie rating = %+v, err = %v, want only the published review", stored, err)
+		}
+	})
+}
+
+func TestVerifiedViewerReviews(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		hall := b.createHall(t)
+		service := b.reviewService()
+
+		ticketFor := func(t *testing.T, movie *models.Movie, status models.TicketStatus) primitive.ObjectID {
+			t.Helper()
+			user := b.createUser(t, 0)
+			session := b.createSession(t, movie, hall, 150000)
+			ticket := &models.Ticket{UserID: user.ID, SessionID: session.ID, Status: status, CreatedAt: time.Now()}
+			if err := b.tickets.Create(ctx, ticket); err != nil {
+				t.Fatalf("create ticket: %v", err)
+			}
+			return user.ID
+		}
+
+		restricted := b.createMovie(t, "12+")
+		restricted.ReviewPolicy = models.ReviewPolicyVerifiedOnly
+		if err := b.movies.Update(ctx, restricted.ID, restricted); err != nil {
+			t.Fatalf("update movie: %v", err)
+		}
+		for _, userID := range []primitive.ObjectID{b.createUser(t, 0).ID, ticketFor(t, restricted, models.TicketPaid)} {
+			err := service.CreateReview(ctx, &models.Review{MovieID: restricted.ID, UserID: userID, Rating: 7})
+			if !errors.Is(err, ErrVerifiedViewerOnly) {
+				t.Fatalf("err = %v, want %v", err, ErrVerifiedViewerOnly)
+			}
+		}
+		viewer := &models.Review{MovieID: restricted.ID, UserID: ticketFor(t, restricted, models.TicketUsed), Rating: 7}
+		if err := service.CreateReview(ctx, viewer); err != nil || !viewer.VerifiedViewer {
+			t.Fatalf("review = %+v, err = %v, want verified", viewer, err)
+		}
+
+		weighted := b.createMovie(t, "12+")
+		weighted.ReviewPolicy = models.ReviewPolicyWeighted
+		if err := b.movies.Update(ctx, weighted.ID, weighted); err != nil {
+			t.Fatalf("update movie: %v", err)
+		}
+		verified := &models.Review{MovieID: weighted.ID, UserID: ticketFor(t, weighted, models.TicketUsed), Rating: 10}
+		unverified := &models.Review{MovieID: weighted.ID, UserID: b.createUser(t, 0).ID, Rating: 4}
+		for _, review := range []*models.Review{verified, unverified} {
+			if err := service.CreateReview(ctx, review); err != nil {
+				t.Fatalf("CreateReview: %v", err)
+			}
+		}
+		if unverified.VerifiedViewer || unverified.Weight != 0.5 {
+			t.Fatalf("unverified = %+v, want weight 0.5", unverified)
+		}
+		avg, err := b.reviews.GetAverageRating(ctx, weighted.ID)
+		if err != nil || avg != 8 {
+			t.Fatalf("average = %v, err = %v, want 8", avg, err)
+		}
+	})
+}
+
+func TestRatingStatsMaintainedIncrementally(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		movie := b.createMovie(t, "12+")
+		service := b.reviewService()
+
+		var reviews []*models.Review
+		for _, rating := range []int{10, 8, 8} {
+			review := &models.Review{MovieID: movie.ID, UserID: b.createUser(t, 0).ID, Rating: rating}
+			if err := service.CreateReview(ctx, review); err != nil {
+				t.Fatalf("CreateReview: %v", err)
+			}
+			reviews = append(reviews, review)
+		}
+		if err := service.UpdateReview(ctx, reviews[0].ID, reviews[0].UserID, 5, "changed my mind"); err != nil {
+			t.Fatalf("UpdateReview: %v", err)
+		}
+		if err := service.DeleteReview(ctx, reviews[1].ID, reviews[1].UserID, false); err != nil {
+			t.Fatalf("DeleteReview: %v", err)
+		}
+
+		stored, err := b.movies.FindByID(ctx, movie.ID)
+		if err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+		stats := stored.RatingStats
+		if stats == nil || stats.Count != 2 || stored.Rating != 6.5 || stats.Average != 6.5 {
+			t.Fatalf("rating = %v, stats = %+v, want 2 reviews averaging 6.5", stored.Rating, stats)
+		}
+		if stats.Histogram["5"] != 1 || stats.Histogram["8"] != 1 || stats.Histogram["10"] != 0 || len(stats.Histogram) != 11 {
+			t.Fatalf("histogram = %v", stats.Histogram)
+		}
+
+		if err := b.movies.SetRatingStats(ctx, movie.ID, &models.RatingStats{}, 0); err != nil {
+			t.Fatalf("SetRatingStats: %v", err)
+		}
+		bayesian := config.DefaultRatingConfig()
+		bayesian.Bayesian = true
+		bayesian.RecomputeConcurrency = 2
+		recompute := NewReviewService(b.reviews, b.reports, b.votes, b.movies, b.users, b.tickets, b.sessions, b.outbox, b.transactor, config.DefaultModerationConfig(), bayesian)
+		count, err := recompute.RecomputeAllRatings(ctx)
+		if err != nil || count == 0 {
+			t.Fatalf("RecomputeAllRatings = %d, %v", count, err)
+		}
+		stored, err = b.movies.FindByID(ctx, movie.ID)
+		want := (6.0*5 + 13) / 7
+		if err != nil || stored.RatingStats.Count != 2 || stored.Rating != want || stored.RatingStats.Average != 6.5 {
+			t.Fatalf("recomputed = %v, stats = %+v, want bayesian %v", stored.Rating, stored.RatingStats, want)
+		}
+	})
+}
+
+func TestRatingRecomputeRunsInBackground(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		movie := b.createMovie(t, "12+")
+		service := b.reviewService()
+		if err := service.CreateReview(ctx, &models.Review{MovieID: movie.ID, UserID: b.createUser(t, 0).ID, Rating: 7}); err != nil {
+			t.Fatalf("CreateReview: %v", err)
+		}
+		if err := b.movies.SetRatingStats(ctx, movie.ID, &models.RatingStats{}, 0); err != nil {
+			t.Fatalf("SetRatingStats: %v", err)
+		}
+
+		if status := service.RequestRecompute(); status.State != models.RecomputeQueued {
+			t.Fatalf("status = %+v, want queued", status)
+		}
+		workers, stop := context.WithCancel(ctx)
+		defer stop()
+		go service.Run(workers)
+
+		deadline := time.Now().Add(2 * time.Second)
+		status := service.RecomputeStatus()
+		for status.FinishedAt == nil && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+			status = service.RecomputeStatus()
+		}
+		if status.State != models.RecomputeIdle || status.Movies == 0 || status.Error != "" {
+			t.Fatalf("status = %+v, want finished", status)
+		}
+		stored, err := b.movies.FindByID(ctx, movie.ID)
+		if err != nil || stored.Rating != 7 || stored.RatingStats.Count != 1 {
+			t.Fatalf("recomputed = %+v, err = %v", stored, err)
+		}
+	})
+}
+
+func TestReviewVotesAndSorting(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		movie := b.createMovie(t, "12+")
+		service := b.reviewService()
+
+		author := b.createUser(t, 0)
+		quiet := &models.Review{MovieID: movie.ID, UserID: b.createUser(t, 0).ID, Rating: 9}
+		loved := &models.Review{MovieID: movie.ID, UserID: author.ID, Rating: 6, Comment: "Slow but rewarding"}
+		for _, review := range []*models.Review{quiet, loved} {
+			if err := service.CreateReview(ctx, review); err != nil {
+				t.Fatalf("CreateReview: %v", err)
+			}
+		}
+
+		if _, err := service.VoteReview(ctx, loved.ID, author.ID, true); !errors.Is(err, ErrCannotVoteOwn) {
+			t.Fatalf("own vote err = %v, want %v", err, ErrCannotVoteOwn)
+		}
+
+		voter := b.createUser(t, 0)
+		if _, err := service.VoteReview(ctx, loved.ID, voter.ID, false); err != nil {
+			t.Fatalf("VoteReview: %v", err)
+		}
+		voted, err := service.VoteReview(ctx, loved.ID, voter.ID, true)
+		if err != nil || voted.HelpfulCount != 1 || voted.UnhelpfulCount != 0 {
+			t.Fatalf("changed vote = %+v, err = %v, want 1 helpful", voted, err)
+		}
+		if _, err := service.VoteReview(ctx, loved.ID, voter.ID, true); err != nil {
+			t.Fatalf("repeat vote: %v", err)
+		}
+		if _, err := service.VoteReview(ctx, loved.ID, b.createUser(t, 0).ID, true); err != nil {
+			t.Fatalf("VoteReview: %v", err)
+		}
+
+		query, err := repositories.ParseListQuery(url.Values{"sort": {"-helpful"}}, repositories.ReviewListSpec, time.UTC)
+		if err != nil {
+			t.Fatalf("ParseListQuery: %v", err)
+		}
+		page, err := service.GetMovieReviews(ctx, movie.ID, query)
+		if err != nil || len(page.Items) != 2 || page.Items[0].ID != loved.ID || page.Items[0].HelpfulCount != 2 {
+			t.Fatalf("most helpful first = %+v, err = %v", page, err)
+		}
+
+		query, err = repositories.ParseListQuery(url.Values{"has_comment": {"true"}, "rating_from": {"5"}, "rating_to": {"8"}}, repositories.ReviewListSpec, time.UTC)
+		if err != nil {
+			t.Fatalf("ParseListQuery: %v", err)
+		}
+		page, err = service.GetMovieReviews(ctx, movie.ID, query)
+		if err != nil || len(page.Items) != 1 || page.Items[0].ID != loved.ID {
+			t.Fatalf("filtered = %+v, err = %v", page, err)
+		}
+
+		if err := service.RemoveVote(ctx, loved.ID, voter.ID); err != nil {
+			t.Fatalf("RemoveVote: %v", err)
+		}
+		if err := service.RemoveVote(ctx, loved.ID, voter.ID); !errors.Is(err, ErrReviewVoteNotFound) {
+			t.Fatalf("second RemoveVote err = %v, want %v", err, ErrReviewVoteNotFound)
+		}
+		stored, err := b.reviews.FindByID(ctx, loved.ID)
+		if err != nil || stored.HelpfulCount != 1 {
+			t.Fatalf("stored = %+v, err = %v, want 1 helpful", stored, err)
+		}
+	})
+}
+
+func TestStaffReply(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		service := b.reviewService()
+		review := &models.Review{MovieID: b.createMovie(t, "12+").ID, UserID: b.createUser(t, 0).ID, Rating: 3, Comment: "Sound was too loud"}
+		if err := service.CreateReview(ctx, review); err != nil {
+			t.Fatalf("CreateReview: %v", err)
+		}
+
+		staff := b.createUser(t, 0)
+		if _, err := service.ReplyToReview(ctx, review.ID, staff.ID, "Thanks, we recalibrated hall 1."); err != nil {
+			t.Fatalf("ReplyToReview: %v", err)
+		}
+		stored, err := b.reviews.FindByID(ctx, review.ID)
+		if err != nil || stored.Reply == nil || stored.Reply.AuthorID != staff.ID {
+			t.Fatalf("stored = %+v, err = %v, want a staff reply", stored, err)
+		}
+
+		if err := service.DeleteReply(ctx, review.ID); err != nil {
+			t.Fatalf("DeleteReply: %v", err)
+		}
+		if err := service.DeleteReply(ctx, review.ID); !errors.Is(err, ErrReviewReplyNotFound) {
+			t.Fatalf("second DeleteReply err = %v, want %v", err, ErrReviewReplyNotFound)
+		}
+	})
+}
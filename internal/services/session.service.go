@@ -1,53 +1,72 @@
 package services
 
 import (
+	"cinema-system/internal/events"
 	"cinema-system/internal/models"
+	"cinema-system/internal/money"
 	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
 	"context"
-	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type SessionService struct {
-	sessionRepo *repositories.SessionRepository
-	hallRepo    *repositories.HallRepository
-	movieRepo   *repositories.MovieRepository
+	sessionRepo repositories.SessionStore
+	hallRepo    repositories.HallStore
+	movieRepo   repositories.MovieStore
+	outboxRepo  repositories.OutboxStore
+	transactor  repositories.TransactionRunner
 }
 
 func NewSessionService(
-	sessionRepo *repositories.SessionRepository,
-	hallRepo *repositories.HallRepository,
-	movieRepo *repositories.MovieRepository,
+	sessionRepo repositories.SessionStore,
+	hallRepo repositories.HallStore,
+	movieRepo repositories.MovieStore,
+	outboxRepo repositories.OutboxStore,
+	transactor repositories.TransactionRunner,
 ) *SessionService {
 	return &SessionService{
 		sessionRepo: sessionRepo,
 		hallRepo:    hallRepo,
 		movieRepo:   movieRepo,
+		outboxRepo:  outboxRepo,
+		transactor:  transactor,
 	}
 }
 
-func (s *SessionService) CreateSession(ctx context.Context, session *models.Session) error {
+func (s *SessionService) CreateSession(ctx context.Context, session *models.Session) (err error) {
+	ctx, span := tracing.Start(ctx, "SessionService.CreateSession")
+	defer tracing.End(span, &err)
+
 	if session.MovieID.IsZero() {
-		return errors.New("Movie ID is required")
+		return ErrMovieIDRequired
 	}
 	if session.HallID.IsZero() {
-		return errors.New("Hall ID is required")
+		return ErrHallIDRequired
 	}
 
 	movie, err := s.movieRepo.FindByID(ctx, session.MovieID)
 	if err != nil {
-		return errors.New("Movie does not exist")
+		return notFound(err, ErrMovieNotFound)
 	}
 
-	_, err = s.hallRepo.FindByID(ctx, session.HallID)
+	hall, err := s.hallRepo.FindByID(ctx, session.HallID)
 	if err != nil {
-		return errors.New("Hall does not exist")
+		return notFound(err, ErrHallNotFound)
+	}
+
+	if session.Price.Amount < 0 {
+		return ErrInvalidPrice
+	}
+	if hall.PriceCurrency() != money.DefaultCurrency {
+		return ErrHallCurrency.With("currency", money.DefaultCurrency)
 	}
+	session.Price.Currency = hall.PriceCurrency()
 
 	if session.StartTime.Before(time.Now().Add(-5 * time.Minute)) {
-		return errors.New("Cannot schedule sessions in the past")
+		return ErrSessionInPast
 	}
 
 	session.EndTime = session.StartTime.Add(time.Duration(movie.Duration) * time.Minute)
@@ -57,10 +76,20 @@ func (s *SessionService) CreateSession(ctx context.Context, session *models.Sess
 		return err
 	}
 	if len(overlapping) > 0 {
-		return errors.New("The selected hall is already occupied during this time period")
+		return ErrHallOccupied
 	}
 
-	return s.sessionRepo.Create(ctx, session)
+	return s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.sessionRepo.Create(ctx, session); err != nil {
+			return err
+		}
+
+		event, err := events.New(events.SessionCreated, session.ID, events.NewSessionPayload(session))
+		if err != nil {
+			return err
+		}
+		return s.outboxRepo.Add(ctx, event)
+	})
 }
 
 func (s *SessionService) GetSessionsByMovie(ctx context.Context, movieID primitive.ObjectID) ([]models.Session, error) {
@@ -75,30 +104,53 @@ func (s *SessionService) GetUpcomingMovieIDs(ctx context.Context) ([]primitive.O
 	return s.sessionRepo.GetUpcomingMovieIDs(ctx)
 }
 
-func (s *SessionService) GetSessionByID(ctx context.Context, id primitive.ObjectID) (*models.Session, error) {
-	return s.sessionRepo.FindByID(ctx, id)
+func (s *SessionService) GetSessionByID(ctx context.Context, id primitive.ObjectID) (_ *models.Session, err error) {
+	ctx, span := tracing.Start(ctx, "SessionService.GetSessionByID")
+	defer tracing.End(span, &err)
+
+	session, err := s.sessionRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, notFound(err, ErrSessionNotFound)
+	}
+	return session, nil
 }
 
-func (s *SessionService) UpdateSession(ctx context.Context, id primitive.ObjectID, session *models.Session) error {
+func (s *SessionService) UpdateSession(ctx context.Context, id primitive.ObjectID, session *models.Session) (err error) {
+	ctx, span := tracing.Start(ctx, "SessionService.UpdateSession")
+	defer tracing.End(span, &err)
+
+	existing, err := s.sessionRepo.FindByID(ctx, id)
+	if err != nil {
+		return notFound(err, ErrSessionNotFound)
+	}
+
 	if session.MovieID.IsZero() {
-		return errors.New("Movie ID is required")
+		return ErrMovieIDRequired
 	}
 	if session.HallID.IsZero() {
-		return errors.New("Hall ID is required")
+		return ErrHallIDRequired
 	}
 
 	movie, err := s.movieRepo.FindByID(ctx, session.MovieID)
 	if err != nil {
-		return errors.New("Movie does not exist")
+		return notFound(err, ErrMovieNotFound)
 	}
 
-	_, err = s.hallRepo.FindByID(ctx, session.HallID)
+	hall, err := s.hallRepo.FindByID(ctx, session.HallID)
 	if err != nil {
-		return errors.New("Hall does not exist")
+		return notFound(err, ErrHallNotFound)
 	}
 
+	if session.Price.Amount < 0 {
+		return ErrInvalidPrice
+	}
+	if hall.PriceCurrency() != money.DefaultCurrency {
+		return ErrHallCurrency.With("currency", money.DefaultCurrency)
+	}
+	session.Price.Currency = hall.PriceCurrency()
+
 	if session.StartTime.Before(time.Now().Add(-5 * time.Minute)) {
-		return errors.New("Cannot schedule sessions in the past")
+		return ErrSessionInPast
 	}
 
 	session.EndTime = session.StartTime.Add(time.Duration(movie.Duration) * time.Minute)
@@ -110,13 +162,50 @@ func (s *SessionService) UpdateSession(ctx context.Context, id primitive.ObjectI
 
 	for _, o := range overlapping {
 		if o.ID != id {
-			return errors.New("The selected hall is already occupied during this time period")
+			return ErrHallOccupied
 		}
 	}
 
-	return s.sessionRepo.Update(ctx, id, session)
+	return s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.sessionRepo.Update(ctx, id, session); err != nil {
+			return err
+		}
+
+		if existing.StartTime.Equal(session.StartTime) && existing.HallID == session.HallID {
+			return nil
+		}
+
+		session.ID = id
+		event, err := events.New(events.SessionRescheduled, id, events.NewSessionPayload(session))
+		if err != nil {
+			return err
+		}
+		return s.outboxRepo.Add(ctx, event)
+	})
 }
 
-func (s *SessionService) DeleteSession(ctx context.Context, id primitive.ObjectID) error {
-	return s.sessionRepo.Delete(ctx, id)
+func (s *SessionService) DeleteSession(ctx context.Context, id primitive.ObjectID) (err error) {
+	ctx, span := tracing.Start(ctx, "SessionService.DeleteSession")
+	defer tracing.End(span, &err)
+
+	session, err := s.sessionRepo.FindByID(ctx, id)
+	if err != nil {
+		return notFound(err, ErrSessionNotFound)
+	}
+
+	return s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.sessionRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		if !session.StartTime.After(time.Now()) {
+			return nil
+		}
+
+		event, err := events.New(events.SessionCancelled, id, events.NewSessionPayload(session))
+		if err != nil {
+			return err
+		}
+		return s.outboxRepo.Add(ctx, event)
+	})
 }
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"cinema-system/internal/events"
+	"cinema-system/internal/models"
+	"cinema-system/internal/money"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCreateSessionConflicts(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		movie := b.createMovie(t, "12+")
+		hall := b.createHall(t)
+		otherHall := b.createHall(t)
+		service := b.sessionService()
+		start := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+
+		first := &models.Session{MovieID: movie.ID, HallID: hall.ID, StartTime: start, Price: money.New(1000, money.DefaultCurrency)}
+		if err := service.CreateSession(ctx, first); err != nil {
+			t.Fatalf("CreateSession: %v", err)
+		}
+		if !first.EndTime.Equal(start.Add(120 * time.Minute)) {
+			t.Fatalf("end time = %v, want %v", first.EndTime, start.Add(120*time.Minute))
+		}
+
+		cases := []struct {
+			name    string
+			session *models.Session
+			wantErr bool
+		}{
+			{"overlapping start", &models.Session{MovieID: movie.ID, HallID: hall.ID, StartTime: start.Add(time.Hour), Price: money.New(1000, money.DefaultCurrency)}, true},
+			{"overlapping end", &models.Session{MovieID: movie.ID, HallID: hall.ID, StartTime: start.Add(-time.Hour), Price: money.New(1000, money.DefaultCurrency)}, true},
+			{"other hall", &models.Session{MovieID: movie.ID, HallID: otherHall.ID, StartTime: start, Price: money.New(1000, money.DefaultCurrency)}, false},
+			{"back to back", &models.Session{MovieID: movie.ID, HallID: hall.ID, StartTime: start.Add(120 * time.Minute), Price: money.New(1000, money.DefaultCurrency)}, false},
+			{"in the past", &models.Session{MovieID: movie.ID, HallID: hall.ID, StartTime: time.Now().Add(-time.Hour), Price: money.New(1000, money.DefaultCurrency)}, true},
+		}
+		for _, tc := range cases {
+			err := service.CreateSession(ctx, tc.session)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("%s: err = %v, wantErr %v", tc.name, err, tc.wantErr)
+			}
+		}
+		assertEvents(t, b.drainEvents(t), events.SessionCreated, events.SessionCreated, events.SessionCreated)
+	})
+}
+
+func TestCreateSessionRejectsForeignCurrencyHall(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		hall := &models.Hall{Name: "Hall USD", Type: models.HallTypeStandard, TotalRows: 5, SeatsPerRow: 10, Currency: "USD"}
+		if err := b.halls.Create(ctx, hall); err != nil {
+			t.Fatalf("create hall: %v", err)
+		}
+
+		session := &models.Session{MovieID: b.createMovie(t, "12+").ID, HallID: hall.ID, StartTime: time.Now().Add(48 * time.Hour), Price: money.New(1000, "USD")}
+		if err := b.sessionService().CreateSession(ctx, session); !errors.Is(err, ErrHallCurrency) {
+			t.Fatalf("err = %v, want %v", err, ErrHallCurrency)
+		}
+		assertEvents(t, b.drainEvents(t))
+	})
+}
+
+func TestUpdateSession(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		movie := b.createMovie(t, "12+")
+		hall := b.createHall(t)
+		service := b.sessionService()
+		start := time.Now().Add(72 * time.Hour).Truncate(time.Minute)
+
+		first := &models.Session{MovieID: movie.ID, HallID: hall.ID, StartTime: start, Price: money.New(1000, money.DefaultCurrency)}
+		second := &models.Session{MovieID: movie.ID, HallID: hall.ID, StartTime: start.Add(3 * time.Hour), Price: money.New(1000, money.DefaultCurrency)}
+		for _, s := range []*models.Session{first, second} {
+			if err := service.CreateSession(ctx, s); err != nil {
+				t.Fatalf("CreateSession: %v", err)
+			}
+		}
+		b.drainEvents(t)
+
+		moved := &models.Session{MovieID: movie.ID, HallID: hall.ID, StartTime: start.Add(30 * time.Minute), Price: money.New(1200, money.DefaultCurrency)}
+		if err := service.UpdateSession(ctx, first.ID, moved); err != nil {
+			t.Fatalf("UpdateSession overlapping only itself: %v", err)
+		}
+
+		clash := &models.Session{MovieID: movie.ID, HallID: hall.ID, StartTime: start.Add(2 * time.Hour), Price: money.New(1200, money.DefaultCurrency)}
+		if err := service.UpdateSession(ctx, first.ID, clash); !errors.Is(err, ErrHallOccupied) {
+			t.Fatalf("err = %v, want %v", err, ErrHallOccupied)
+		}
+
+		repriced := &models.Session{MovieID: movie.ID, HallID: hall.ID, StartTime: start.Add(30 * time.Minute), Price: money.New(1500, money.DefaultCurrency)}
+		if err := service.UpdateSession(ctx, first.ID, repriced); err != nil {
+			t.Fatalf("UpdateSession price only: %v", err)
+		}
+
+		stored, err := b.sessions.FindByID(ctx, first.ID)
+		if err != nil {
+			t.Fatalf("find session: %v", err)
+		}
+		if stored.Price.Amount != 1500 || !stored.StartTime.Equal(start.Add(30*time.Minute)) {
+			t.Fatalf("unexpected session: %+v", stored)
+		}
+		assertEvents(t, b.drainEvents(t), events.SessionRescheduled)
+	})
+}
+
+func TestDeleteSession(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, b *testBackend) {
+		ctx := context.Background()
+		session := b.createSession(t, b.createMovie(t, "12+"), b.createHall(t), 10)
+
+		if err := b.sessionService().DeleteSession(ctx, session.ID); err != nil {
+			t.Fatalf("DeleteSession: %v", err)
+		}
+		if _, err := b.sessions.FindByID(ctx, session.ID); err == nil {
+			t.Fatal("expected session to be deleted")
+		}
+		if err := b.sessionService().DeleteSession(ctx, session.ID); err == nil {
+			t.Fatal("expected deleting a missing session to fail")
+		}
+		assertEvents(t, b.drainEvents(t), events.SessionCancelled)
+	})
+}
@@ -0,0 +1,858 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"cinema-system/internal/config"
+	"cinema-system/internal/events"
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mozilla.org/pkcs7"
+)
+
+const googleWalletScope = "https://www.googleapis.com/auth/wallet_object.issuer"
+
+type WalletService struct {
+	config           *config.WalletConfig
+	ticketRepo       repositories.TicketStore
+	sessionRepo      repositories.SessionStore
+	movieRepo        repositories.MovieStore
+	hallRepo         repositories.HallStore
+	passRepo         repositories.WalletPassStore
+	registrationRepo repositories.WalletRegistrationStore
+	outboxRepo       repositories.OutboxStore
+	entryService     *EntryService
+	httpClient       *http.Client
+	apnsClient       *http.Client
+
+	tokenMu     sync.Mutex
+	googleToken string
+	tokenExpiry time.Time
+}
+
+func NewWalletService(
+	cfg *config.WalletConfig,
+	ticketRepo repositories.TicketStore,
+	sessionRepo repositories.SessionStore,
+	movieRepo repositories.MovieStore,
+	hallRepo repositories.HallStore,
+	passRepo repositories.WalletPassStore,
+	registrationRepo repositories.WalletRegistrationStore,
+	outboxRepo repositories.OutboxStore,
+	entryService *EntryService,
+) *WalletService {
+	s := &WalletService{
+		config:           cfg,
+		ticketRepo:       ticketRepo,
+		sessionRepo:      sessionRepo,
+		movieRepo:        movieRepo,
+		hallRepo:         hallRepo,
+		passRepo:         passRepo,
+		registrationRepo: registrationRepo,
+		outboxRepo:       outboxRepo,
+		entryService:     entryService,
+		httpClient:       &http.Client{Timeout: 15 * time.Second},
+	}
+
+	if cfg.Apple != nil {
+		s.apnsClient = &http.Client{
+			Timeout: 15 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig:   &tls.Config{Certificates: []tls.Certificate{cfg.Apple.KeyPair}},
+				ForceAttemptHTTP2: true,
+			},
+		}
+	}
+
+	return s
+}
+
+type walletTicket struct {
+	ticket  *models.Ticket
+	session *models.Session
+	movie   *models.Movie
+	hall    *models.Hall
+}
+
+func (s *WalletService) loadTicket(ctx context.Context, ticket *models.Ticket) (*walletTicket, error) {
+	session, err := s.sessionRepo.FindByID(ctx, ticket.SessionID)
+	if err != nil {
+		return nil, notFound(err, ErrSessionNotFound)
+	}
+
+	movie, err := s.movieRepo.FindByID(ctx, session.MovieID)
+	if err != nil {
+		return nil, notFound(err, ErrMovieNotFound)
+	}
+
+	hall, err := s.hallRepo.FindByID(ctx, session.HallID)
+	if err != nil {
+		return nil, notFound(err, ErrHallNotFound)
+	}
+
+	return &walletTicket{ticket: ticket, session: session, movie: movie, hall: hall}, nil
+}
+
+func (s *WalletService) loadOwnedTicket(ctx context.Context, ticketID, userID primitive.ObjectID) (*walletTicket, error) {
+	ticket, err := s.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+
+	if ticket.UserID != userID {
+		return nil, ErrTicketForbidden
+	}
+
+	if ticket.Status != models.TicketPaid && ticket.Status != models.TicketUsed {
+		return nil, ErrTicketNotValid.With("status", strings.ToLower(string(ticket.Status)))
+	}
+
+	return s.loadTicket(ctx, ticket)
+}
+
+func (s *WalletService) ensurePass(ctx context.Context, ticket *models.Ticket) (*models.WalletPass, error) {
+	pass, err := s.passRepo.FindByTicketID(ctx, ticket.ID)
+	if err == nil {
+		return pass, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	pass = &models.WalletPass{
+		TicketID:     ticket.ID,
+		SessionID:    ticket.SessionID,
+		SerialNumber: ticket.ID.Hex(),
+		AuthToken:    hex.EncodeToString(token),
+		UpdatedAt:    now,
+		CreatedAt:    now,
+	}
+	err = s.passRepo.Create(ctx, pass)
+	if mongo.IsDuplicateKeyError(err) {
+		return s.passRepo.FindByTicketID(ctx, ticket.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pass, nil
+}
+
+func (s *WalletService) GenerateApplePass(ctx context.Context, ticketID, userID primitive.ObjectID) (_ []byte, err error) {
+	ctx, span := tracing.Start(ctx, "WalletService.GenerateApplePass")
+	defer tracing.End(span, &err)
+
+	if s.config.Apple == nil {
+		return nil, ErrAppleWalletDisabled
+	}
+
+	wt, err := s.loadOwnedTicket(ctx, ticketID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	pass, err := s.ensurePass(ctx, wt.ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildPKPass(wt, pass)
+}
+
+func (s *WalletService) GetLatestPass(ctx context.Context, passTypeID, serial, authToken string) (_ []byte, _ time.Time, err error) {
+	ctx, span := tracing.Start(ctx, "WalletService.GetLatestPass")
+	defer tracing.End(span, &err)
+
+	if s.config.Apple == nil {
+		return nil, time.Time{}, ErrAppleWalletDisabled
+	}
+
+	pass, err := s.authorizePass(ctx, passTypeID, serial, authToken)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	ticket, err := s.ticketRepo.FindByID(ctx, pass.TicketID)
+	if err != nil {
+		return nil, time.Time{}, ErrWalletPassNotFound
+	}
+
+	wt, err := s.loadTicket(ctx, ticket)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	data, err := s.buildPKPass(wt, pass)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return data, pass.UpdatedAt, nil
+}
+
+func (s *WalletService) authorizePass(ctx context.Context, passTypeID, serial, authToken string) (*models.WalletPass, error) {
+	if passTypeID != s.config.Apple.PassTypeID {
+		return nil, ErrWalletPassNotFound
+	}
+
+	pass, err := s.passRepo.FindBySerial(ctx, serial)
+	if err != nil {
+		return nil, ErrWalletPassNotFound
+	}
+
+	if subtle.ConstantTimeCompare([]byte(pass.AuthToken), []byte(authToken)) != 1 {
+		return nil, ErrWalletUnauthorized
+	}
+	return pass, nil
+}
+
+func (s *WalletService) RegisterDevice(ctx context.Context, deviceID, passTypeID, serial, authToken, pushToken string) (_ bool, err error) {
+	ctx, span := tracing.Start(ctx, "WalletService.RegisterDevice")
+	defer tracing.End(span, &err)
+
+	if s.config.Apple == nil {
+		return false, ErrAppleWalletDisabled
+	}
+
+	if _, err := s.authorizePass(ctx, passTypeID, serial, authToken); err != nil {
+		return false, err
+	}
+
+	return s.registrationRepo.Register(ctx, &models.WalletRegistration{
+		DeviceID:     deviceID,
+		PushToken:    pushToken,
+		PassTypeID:   passTypeID,
+		SerialNumber: serial,
+	})
+}
+
+func (s *WalletService) UnregisterDevice(ctx context.Context, deviceID, passTypeID, serial, authToken string) (err error) {
+	ctx, span := tracing.Start(ctx, "WalletService.UnregisterDevice")
+	defer tracing.End(span, &err)
+
+	if s.config.Apple == nil {
+		return ErrAppleWalletDisabled
+	}
+
+	if _, err := s.authorizePass(ctx, passTypeID, serial, authToken); err != nil {
+		return err
+	}
+
+	return s.registrationRepo.Unregister(ctx, deviceID, passTypeID, serial)
+}
+
+func (s *WalletService) GetUpdatedSerials(ctx context.Context, deviceID, passTypeID, updatedSince string) (_ *models.WalletSerials, err error) {
+	ctx, span := tracing.Start(ctx, "WalletService.GetUpdatedSerials")
+	defer tracing.End(span, &err)
+
+	if s.config.Apple == nil {
+		return nil, ErrAppleWalletDisabled
+	}
+
+	registrations, err := s.registrationRepo.FindByDevice(ctx, deviceID, passTypeID)
+	if err != nil {
+		return nil, err
+	}
+	if len(registrations) == 0 {
+		return nil, nil
+	}
+
+	serials := make([]string, len(registrations))
+	for i, r := range registrations {
+		serials[i] = r.SerialNumber
+	}
+
+	var since time.Time
+	if updatedSince != "" {
+		if unix, err := strconv.ParseInt(updatedSince, 10, 64); err == nil {
+			since = time.Unix(unix, 0)
+		}
+	}
+
+	passes, err := s.passRepo.FindUpdatedSince(ctx, serials, since)
+	if err != nil {
+		return nil, err
+	}
+	if len(passes) == 0 {
+		return nil, nil
+	}
+
+	result := &models.WalletSerials{SerialNumbers: make([]string, 0, len(passes))}
+	var latest time.Time
+	for _, p := range passes {
+		result.SerialNumbers = append(result.SerialNumbers, p.SerialNumber)
+		if p.UpdatedAt.After(latest) {
+			latest = p.UpdatedAt
+		}
+	}
+	result.LastUpdated = strconv.FormatInt(latest.Unix(), 10)
+	return result, nil
+}
+
+func (s *WalletService) buildPKPass(wt *walletTicket, pass *models.WalletPass) ([]byte, error) {
+	apple := s.config.Apple
+
+	token, err := s.entryService.GenerateTicketToken(wt.ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	passJSON := map[string]interface{}{
+		"formatVersion":       1,
+		"passTypeIdentifier":  apple.PassTypeID,
+		"serialNumber":        pass.SerialNumber,
+		"teamIdentifier":      apple.TeamID,
+		"organizationName":    apple.OrganizationName,
+		"description":         "Cinema ticket: " + wt.movie.Name,
+		"logoText":            apple.OrganizationName,
+		"foregroundColor":     "rgb(255, 255, 255)",
+		"backgroundColor":     "rgb(183, 28, 28)",
+		"labelColor":          "rgb(255, 205, 210)",
+		"authenticationToken": pass.AuthToken,
+		"relevantDate":        wt.session.StartTime.Format(time.RFC3339),
+		"expirationDate":      wt.session.EndTime.Format(time.RFC3339),
+		"voided":              pass.Voided || wt.ticket.Status == models.TicketCancelled,
+		"barcodes": []map[string]string{{
+			"format":          "PKBarcodeFormatQR",
+			"message":         token,
+			"messageEncoding": "iso-8859-1",
+			"altText":         wt.ticket.ID.Hex(),
+		}},
+		"eventTicket": map[string]interface{}{
+			"primaryFields": []map[string]interface{}{
+				{"key": "movie", "label": "MOVIE", "value": wt.movie.Name},
+			},
+			"secondaryFields": []map[string]interface{}{
+				{"key": "starts", "label": "STARTS", "value": wt.session.StartTime.Format(time.RFC3339), "dateStyle": "PKDateStyleMedium", "timeStyle": "PKDateStyleShort", "changeMessage": "Session time changed to %@"},
+				{"key": "hall", "label": "HALL", "value": wt.hall.Name, "changeMessage": "Your session moved to hall %@"},
+			},
+			"auxiliaryFields": []map[string]interface{}{
+				{"key": "row", "label": "ROW", "value": wt.ticket.RowNumber},
+				{"key": "seat", "label": "SEAT", "value": wt.ticket.SeatNumber},
+				{"key": "type", "label": "TICKET", "value": string(wt.ticket.Type)},
+			},
+			"backFields": []map[string]interface{}{
+				{"key": "location", "label": "Location", "value": wt.hall.Location},
+				{"key": "price", "label": "Price", "value": wt.ticket.Price.String()},
+				{"key": "ticket", "label": "Ticket ID", "value": wt.ticket.ID.Hex()},
+			},
+		},
+	}
+	if apple.WebServiceURL != "" {
+		passJSON["webServiceURL"] = apple.WebServiceURL
+	}
+
+	passData, err := json.Marshal(passJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	icon, icon2x, err := s.passIcons()
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{
+		"pass.json":   passData,
+		"icon.png":    icon,
+		"icon@2x.png": icon2x,
+		"logo.png":    icon,
+	}
+
+	manifest := make(map[string]string, len(files))
+	for name, data := range files {
+		sum := sha1.Sum(data)
+		manifest[name] = hex.EncodeToString(sum[:])
+	}
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := s.signManifest(manifestData)
+	if err != nil {
+		return nil, err
+	}
+
+	files["manifest.json"] = manifestData
+	files["signature"] = signature
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, data := range files {
+		w, err := archive.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *WalletService) signManifest(manifest []byte) ([]byte, error) {
+	apple := s.config.Apple
+
+	signedData, err := pkcs7.NewSignedData(manifest)
+	if err != nil {
+		return nil, err
+	}
+	signedData.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+
+	var parents []*x509.Certificate
+	if apple.WWDR != nil {
+		parents = append(parents, apple.WWDR)
+	}
+	if err := signedData.AddSignerChain(apple.Certificate, apple.KeyPair.PrivateKey, parents, pkcs7.SignerInfoConfig{}); err != nil {
+		return nil, fmt.Errorf("failed to sign pass: %w", err)
+	}
+	signedData.Detach()
+	return signedData.Finish()
+}
+
+func (s *WalletService) passIcons() ([]byte, []byte, error) {
+	if s.config.Apple.IconFile != "" {
+		data, err := os.ReadFile(s.config.Apple.IconFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		return data, data, nil
+	}
+
+	icon, err := solidPNG(29)
+	if err != nil {
+		return nil, nil, err
+	}
+	icon2x, err := solidPNG(58)
+	if err != nil {
+		return nil, nil, err
+	}
+	return icon, icon2x, nil
+}
+
+func solidPNG(size int) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	fill := color.RGBA{R: 183, G: 28, B: 28, A: 255}
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			img.Set(x, y, fill)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *WalletService) googleClassID(sessionID primitive.ObjectID) string {
+	return s.config.Google.IssuerID + ".session-" + sessionID.Hex()
+}
+
+func localizedString(value string) map[string]interface{} {
+	return map[string]interface{}{
+		"defaultValue": map[string]string{"language": "en-US", "value": value},
+	}
+}
+
+func (s *WalletService) googleClass(wt *walletTicket) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           s.googleClassID(wt.session.ID),
+		"issuerName":   s.config.Google.IssuerName,
+		"reviewStatus": "UNDER_REVIEW",
+		"eventName":    localizedString(wt.movie.Name),
+		"venue": map[string]interface{}{
+			"name":    localizedString(wt.hall.Name),
+			"address": localizedString(wt.hall.Location),
+		},
+		"dateTime": map[string]string{
+			"start": wt.session.StartTime.Format(time.RFC3339),
+			"end":   wt.session.EndTime.Format(time.RFC3339),
+		},
+	}
+}
+
+func (s *WalletService) googleObjectID(ticketID primitive.ObjectID) string {
+	return s.config.Google.IssuerID + "." + ticketID.Hex()
+}
+
+func googleObjectState(pass *models.WalletPass, ticket *models.Ticket) string {
+	if pass.Voided || ticket.Status == models.TicketCancelled {
+		return "INACTIVE"
+	}
+	return "ACTIVE"
+}
+
+func (s *WalletService) googleObject(wt *walletTicket, pass *models.WalletPass) (map[string]interface{}, error) {
+	token, err := s.entryService.GenerateTicketToken(wt.ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"id":           s.googleObjectID(wt.ticket.ID),
+		"classId":      s.googleClassID(wt.session.ID),
+		"state":        googleObjectState(pass, wt.ticket),
+		"ticketNumber": wt.ticket.ID.Hex(),
+		"ticketType":   localizedString(string(wt.ticket.Type)),
+		"seatInfo": map[string]interface{}{
+			"row":     localizedString(strconv.Itoa(wt.ticket.RowNumber)),
+			"seat":    localizedString(strconv.Itoa(wt.ticket.SeatNumber)),
+			"section": localizedString(string(wt.hall.Type)),
+		},
+		"barcode": map[string]string{
+			"type":          "QR_CODE",
+			"value":         token,
+			"alternateText": wt.ticket.ID.Hex(),
+		},
+		"faceValue": map[string]interface{}{
+			"micros":       wt.ticket.Price.Micros(),
+			"currencyCode": wt.ticket.Price.Currency,
+		},
+	}, nil
+}
+
+func (s *WalletService) GoogleSaveLink(ctx context.Context, ticketID, userID primitive.ObjectID) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "WalletService.GoogleSaveLink")
+	defer tracing.End(span, &err)
+
+	if s.config.Google == nil {
+		return "", ErrGoogleWalletDisabled
+	}
+
+	wt, err := s.loadOwnedTicket(ctx, ticketID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	pass, err := s.ensurePass(ctx, wt.ticket)
+	if err != nil {
+		return "", err
+	}
+
+	object, err := s.googleObject(wt, pass)
+	if err != nil {
+		return "", err
+	}
+
+	claims := jwt.MapClaims{
+		"iss": s.config.Google.Account.ClientEmail,
+		"aud": "google",
+		"typ": "savetowallet",
+		"iat": time.Now().Unix(),
+		"payload": map[string]interface{}{
+			"eventTicketClasses": []interface{}{s.googleClass(wt)},
+			"eventTicketObjects": []interface{}{object},
+		},
+	}
+	if len(s.config.Google.Origins) > 0 {
+		claims["origins"] = s.config.Google.Origins
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(s.config.Google.SigningKey)
+	if err != nil {
+		return "", err
+	}
+	return "https://pay.google.com/gp/v/save/" + signed, nil
+}
+
+func (s *WalletService) HandleSessionRescheduled(ctx context.Context, event *models.OutboxEvent) (err error) {
+	ctx, span := tracing.Start(ctx, "WalletService.HandleSessionRescheduled")
+	defer tracing.End(span, &err)
+
+	var payload events.SessionPayload
+	if err := events.Decode(event, &payload); err != nil {
+		return err
+	}
+	return s.NotifySessionChanged(ctx, payload.SessionID)
+}
+
+func (s *WalletService) NotifySessionChanged(ctx context.Context, sessionID primitive.ObjectID) (err error) {
+	ctx, span := tracing.Start(ctx, "WalletService.NotifySessionChanged")
+	defer tracing.End(span, &err)
+
+	passes, err := s.passRepo.TouchBySession(ctx, sessionID, time.Now())
+	if err != nil {
+		return err
+	}
+	return s.enqueuePush(ctx, sessionID, passes, false)
+}
+
+func (s *WalletService) HandleTicketCancelled(ctx context.Context, event *models.OutboxEvent) (err error) {
+	ctx, span := tracing.Start(ctx, "WalletService.HandleTicketCancelled")
+	defer tracing.End(span, &err)
+
+	var payload events.TicketCancelledPayload
+	if err := events.Decode(event, &payload); err != nil {
+		return err
+	}
+	return s.VoidPasses(ctx, []primitive.ObjectID{payload.TicketID})
+}
+
+func (s *WalletService) HandlePaymentRefunded(ctx context.Context, event *models.OutboxEvent) (err error) {
+	ctx, span := tracing.Start(ctx, "WalletService.HandlePaymentRefunded")
+	defer tracing.End(span, &err)
+
+	var payload events.PaymentRefundedPayload
+	if err := events.Decode(event, &payload); err != nil {
+		return err
+	}
+
+	tickets, err := s.ticketRepo.GetByPaymentIDs(ctx, []primitive.ObjectID{payload.PaymentID})
+	if err != nil {
+		return err
+	}
+	if len(tickets) == 0 {
+		return nil
+	}
+
+	ticketIDs := make([]primitive.ObjectID, len(tickets))
+	for i, t := range tickets {
+		ticketIDs[i] = t.ID
+	}
+	return s.VoidPasses(ctx, ticketIDs)
+}
+
+func (s *WalletService) VoidPasses(ctx context.Context, ticketIDs []primitive.ObjectID) (err error) {
+	ctx, span := tracing.Start(ctx, "WalletService.VoidPasses")
+	defer tracing.End(span, &err)
+
+	passes, err := s.passRepo.Void(ctx, ticketIDs, time.Now())
+	if err != nil {
+		return err
+	}
+	return s.enqueuePush(ctx, primitive.NilObjectID, passes, true)
+}
+
+func (s *WalletService) enqueuePush(ctx context.Context, sessionID primitive.ObjectID, passes []models.WalletPass, voided bool) error {
+	if len(passes) == 0 || (s.config.Apple == nil && s.config.Google == nil) {
+		return nil
+	}
+
+	payload := events.WalletPassesChangedPayload{SessionID: sessionID, Voided: voided}
+	for _, pass := range passes {
+		payload.TicketIDs = append(payload.TicketIDs, pass.TicketID)
+		payload.SerialNumbers = append(payload.SerialNumbers, pass.SerialNumber)
+	}
+
+	aggregateID := sessionID
+	if aggregateID.IsZero() {
+		aggregateID = passes[0].TicketID
+	}
+	event, err := events.New(events.WalletPassesChanged, aggregateID, payload)
+	if err != nil {
+		return err
+	}
+	return s.outboxRepo.Add(ctx, event)
+}
+
+func (s *WalletService) HandlePassesChanged(ctx context.Context, event *models.OutboxEvent) (err error) {
+	ctx, span := tracing.Start(ctx, "WalletService.HandlePassesChanged")
+	defer tracing.End(span, &err)
+
+	var payload events.WalletPassesChangedPayload
+	if err := events.Decode(event, &payload); err != nil {
+		return err
+	}
+
+	var errs []error
+	if s.config.Apple != nil {
+		if err := s.pushAppleUpdates(ctx, payload.SerialNumbers); err != nil {
+			errs = append(errs, fmt.Errorf("apple push: %w", err))
+		}
+	}
+
+	if s.config.Google != nil {
+		if !payload.SessionID.IsZero() && len(payload.TicketIDs) > 0 {
+			if err := s.patchGoogleClass(ctx, payload.SessionID, payload.TicketIDs[0]); err != nil {
+				errs = append(errs, fmt.Errorf("google class %s: %w", payload.SessionID.Hex(), err))
+			}
+		}
+		if payload.Voided {
+			for _, ticketID := range payload.TicketIDs {
+				path := "/eventTicketObject/" + url.PathEscape(s.googleObjectID(ticketID))
+				if err := s.patchGoogle(ctx, path, map[string]interface{}{"state": "INACTIVE"}); err != nil {
+					errs = append(errs, fmt.Errorf("google object %s: %w", ticketID.Hex(), err))
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *WalletService) pushAppleUpdates(ctx context.Context, serials []string) error {
+	registrations, err := s.registrationRepo.FindBySerials(ctx, serials)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	pushed := make(map[string]bool)
+	for _, reg := range registrations {
+		if pushed[reg.PushToken] {
+			continue
+		}
+		pushed[reg.PushToken] = true
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.Apple.APNsURL+"/3/device/"+reg.PushToken, strings.NewReader("{}"))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("apns-topic", reg.PassTypeID)
+
+		resp, err := s.apnsClient.Do(req)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("device %s: %w", reg.DeviceID, err))
+			continue
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		switch {
+		case resp.StatusCode == http.StatusOK:
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+			errs = append(errs, fmt.Errorf("device %s: APNs returned %s", reg.DeviceID, resp.Status))
+		default:
+			slog.WarnContext(ctx, "wallet: APNs rejected push", "device_id", reg.DeviceID, "status", resp.Status)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *WalletService) patchGoogleClass(ctx context.Context, sessionID, ticketID primitive.ObjectID) error {
+	ticket, err := s.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return err
+	}
+	wt, err := s.loadTicket(ctx, ticket)
+	if err != nil {
+		return err
+	}
+
+	class := s.googleClass(wt)
+	delete(class, "reviewStatus")
+	return s.patchGoogle(ctx, "/eventTicketClass/"+url.PathEscape(s.googleClassID(sessionID)), class)
+}
+
+func (s *WalletService) patchGoogle(ctx context.Context, path string, resource map[string]interface{}) error {
+	body, err := json.Marshal(resource)
+	if err != nil {
+		return err
+	}
+
+	token, err := s.googleAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, s.config.Google.APIEndpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("google wallet API returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (s *WalletService) googleAccessToken(ctx context.Context) (string, error) {
+	s.tokenMu.Lock()
+	defer s.tokenMu.Unlock()
+
+	if s.googleToken != "" && time.Now().Before(s.tokenExpiry) {
+		return s.googleToken, nil
+	}
+
+	account := s.config.Google.Account
+	now := time.Now()
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   account.ClientEmail,
+		"scope": googleWalletScope,
+		"aud":   account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}).SignedString(s.config.Google.SigningKey)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("google token endpoint returned %s", resp.Status)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+
+	s.googleToken = token.AccessToken
+	s.tokenExpiry = now.Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+	return s.googleToken, nil
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"cinema-system/internal/config"
+	"cinema-system/internal/events"
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGooglePassCarriesSignedEntryToken(t *testing.T) {
+	entry := NewEntryService(nil, nil, &config.AuthConfig{TicketSigningSecret: "wallet-test-secret"})
+	wallet := &WalletService{
+		config:       &config.WalletConfig{Google: &config.GoogleWalletConfig{IssuerID: "3388000000012345"}},
+		entryService: entry,
+	}
+	ticket := &models.Ticket{ID: primitive.NewObjectID(), SessionID: primitive.NewObjectID(), RowNumber: 4, SeatNumber: 7, Status: models.TicketPaid}
+	wt := &walletTicket{ticket: ticket, session: &models.Session{ID: ticket.SessionID}, movie: &models.Movie{Name: "Dune"}, hall: &models.Hall{Name: "Hall 1"}}
+
+	object, err := wallet.googleObject(wt, &models.WalletPass{TicketID: ticket.ID})
+	if err != nil {
+		t.Fatalf("googleObject: %v", err)
+	}
+	if object["state"] != "ACTIVE" {
+		t.Fatalf("state = %v, want ACTIVE", object["state"])
+	}
+	barcode := object["barcode"].(map[string]string)["value"]
+	payload, err := entry.parseTicketToken(barcode)
+	if err != nil {
+		t.Fatalf("barcode %q is not an entry token: %v", barcode, err)
+	}
+	if payload.TicketID != ticket.ID.Hex() || payload.Row != 4 || payload.Seat != 7 {
+		t.Fatalf("payload = %+v", payload)
+	}
+
+	object, err = wallet.googleObject(wt, &models.WalletPass{TicketID: ticket.ID, Voided: true})
+	if err != nil {
+		t.Fatalf("googleObject: %v", err)
+	}
+	if object["state"] != "INACTIVE" {
+		t.Fatalf("voided pass state = %v, want INACTIVE", object["state"])
+	}
+}
+
+type fixedRegistrationStore struct {
+	repositories.WalletRegistrationStore
+	registrations []models.WalletRegistration
+}
+
+func (s fixedRegistrationStore) FindBySerials(context.Context, []string) ([]models.WalletRegistration, error) {
+	return s.registrations, nil
+}
+
+func TestPassesChangedReturnsRetryablePushFailures(t *testing.T) {
+	for status, wantErr := range map[int]bool{
+		http.StatusOK:                 false,
+		http.StatusGone:               false,
+		http.StatusTooManyRequests:    true,
+		http.StatusServiceUnavailable: true,
+	} {
+		apns := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+		wallet := &WalletService{
+			config:           &config.WalletConfig{Apple: &config.AppleWalletConfig{APNsURL: apns.URL}},
+			registrationRepo: fixedRegistrationStore{registrations: []models.WalletRegistration{{DeviceID: "device", PushToken: "token", PassTypeID: "pass.cinema"}}},
+			apnsClient:       apns.Client(),
+		}
+		event, err := events.New(events.WalletPassesChanged, primitive.NewObjectID(), events.WalletPassesChangedPayload{SerialNumbers: []string{"serial"}})
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+
+		err = wallet.HandlePassesChanged(context.Background(), event)
+		apns.Close()
+		if (err != nil) != wantErr {
+			t.Errorf("APNs %d: err = %v, want error %v", status, err, wantErr)
+		}
+	}
+}
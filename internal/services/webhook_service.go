@@ -0,0 +1,361 @@
+package services
+
+import (
+	"bytes"
+	"cinema-system/internal/events"
+	"cinema-system/internal/models"
+	"cinema-system/internal/repositories"
+	"cinema-system/internal/tracing"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	webhookPollInterval   = 10 * time.Second
+	webhookBatchSize      = 50
+	webhookRetryBase      = 30 * time.Second
+	webhookMaxRetryWait   = 6 * time.Hour
+	webhookDeliveryLogMax = 100
+	webhookResponseMax    = 1024
+)
+
+var WebhookEventTypes = []string{
+	events.TicketBooked,
+	events.TicketCancelled,
+	events.PaymentCompleted,
+	events.PaymentRefunded,
+	events.SessionCreated,
+	events.SessionRescheduled,
+	events.SessionCancelled,
+}
+
+type WebhookService struct {
+	subscriptionRepo repositories.WebhookSubscriptionStore
+	deliveryRepo     repositories.WebhookDeliveryStore
+	httpClient       *http.Client
+}
+
+func NewWebhookService(
+	subscriptionRepo repositories.WebhookSubscriptionStore,
+	deliveryRepo repositories.WebhookDeliveryStore,
+) *WebhookService {
+	return &WebhookService{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		httpClient:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func validateWebhookRequest(req *models.WebhookSubscriptionRequest) error {
+	target, err := url.Parse(req.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+
+	for _, eventType := range req.EventTypes {
+		supported := false
+		for _, known := range WebhookEventTypes {
+			if eventType == known {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return ErrUnsupportedEventType.With("type", eventType)
+		}
+	}
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+func webhookSecretHint(secret string) string {
+	if len(secret) < 12 {
+		return "…"
+	}
+	return "…" + secret[len(secret)-4:]
+}
+
+func (s *WebhookService) CreateSubscription(ctx context.Context, req *models.WebhookSubscriptionRequest) (_ *models.WebhookSubscription, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.CreateSubscription")
+	defer tracing.End(span, &err)
+
+	if err := validateWebhookRequest(req); err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	now := time.Now()
+	subscription := &models.WebhookSubscription{
+		URL:         req.URL,
+		EventTypes:  req.EventTypes,
+		Secret:      secret,
+		SecretHint:  webhookSecretHint(secret),
+		Description: req.Description,
+		Active:      active,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.subscriptionRepo.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *WebhookService) GetSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	return s.subscriptionRepo.GetAll(ctx)
+}
+
+func (s *WebhookService) GetSubscription(ctx context.Context, id primitive.ObjectID) (_ *models.WebhookSubscription, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.GetSubscription")
+	defer tracing.End(span, &err)
+
+	subscription, err := s.subscriptionRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, ErrWebhookNotFound
+	}
+	return subscription, nil
+}
+
+func (s *WebhookService) UpdateSubscription(ctx context.Context, id primitive.ObjectID, req *models.WebhookSubscriptionRequest) (_ *models.WebhookSubscription, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.UpdateSubscription")
+	defer tracing.End(span, &err)
+
+	subscription, err := s.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateWebhookRequest(req); err != nil {
+		return nil, err
+	}
+
+	subscription.URL = req.URL
+	subscription.EventTypes = req.EventTypes
+	subscription.Description = req.Description
+	if req.Secret != "" {
+		subscription.Secret = req.Secret
+		subscription.SecretHint = webhookSecretHint(req.Secret)
+	}
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+	subscription.UpdatedAt = time.Now()
+
+	if err := s.subscriptionRepo.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id primitive.ObjectID) (err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.DeleteSubscription")
+	defer tracing.End(span, &err)
+
+	if _, err := s.GetSubscription(ctx, id); err != nil {
+		return err
+	}
+	return s.subscriptionRepo.Delete(ctx, id)
+}
+
+func (s *WebhookService) GetDeliveries(ctx context.Context, subscriptionID primitive.ObjectID) (_ []models.WebhookDelivery, err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.GetDeliveries")
+	defer tracing.End(span, &err)
+
+	if _, err := s.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	return s.deliveryRepo.FindBySubscription(ctx, subscriptionID, webhookDeliveryLogMax)
+}
+
+func (s *WebhookService) Redeliver(ctx context.Context, deliveryID primitive.ObjectID) (err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Redeliver")
+	defer tracing.End(span, &err)
+
+	if _, err := s.deliveryRepo.FindByID(ctx, deliveryID); err != nil {
+		return ErrWebhookDeliveryNotFound
+	}
+	return s.deliveryRepo.Requeue(ctx, deliveryID, time.Now())
+}
+
+func webhookBody(event *models.OutboxEvent) (string, error) {
+	var data bson.M
+	if err := events.Decode(event, &data); err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"id":          event.ID.Hex(),
+		"type":        event.Type,
+		"occurred_at": event.CreatedAt.UTC(),
+		"data":        data,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+func (s *WebhookService) HandleEvent(ctx context.Context, event *models.OutboxEvent) (err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.HandleEvent")
+	defer tracing.End(span, &err)
+
+	subscriptions, err := s.subscriptionRepo.FindActiveByEventType(ctx, event.Type)
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	body, err := webhookBody(event)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, subscription := range subscriptions {
+		exists, err := s.deliveryRepo.Exists(ctx, subscription.ID, event.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		delivery := &models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			URL:            subscription.URL,
+			Payload:        body,
+			Status:         models.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		}
+		if err := s.deliveryRepo.Create(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func SignWebhook(secret, timestamp, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *WebhookService) ProcessQueue(ctx context.Context, now time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.ProcessQueue")
+	defer tracing.End(span, &err)
+
+	due, err := s.deliveryRepo.FindDue(ctx, now, webhookBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range due {
+		attempts := d.Attempts + 1
+		code, body, err := s.send(ctx, &d)
+		if err == nil {
+			if err := s.deliveryRepo.MarkSucceeded(ctx, d.ID, attempts, code, body, time.Now()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		status := models.WebhookDeliveryPending
+		if attempts >= models.MaxWebhookAttempts {
+			status = models.WebhookDeliveryFailed
+		}
+		wait := webhookRetryBase * time.Duration(1<<uint(attempts-1))
+		if wait > webhookMaxRetryWait {
+			wait = webhookMaxRetryWait
+		}
+		if err := s.deliveryRepo.MarkAttemptFailed(ctx, d.ID, attempts, code, body, err.Error(), status, now.Add(wait)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *WebhookService) send(ctx context.Context, d *models.WebhookDelivery) (int, string, error) {
+	subscription, err := s.subscriptionRepo.FindByID(ctx, d.SubscriptionID)
+	if err != nil {
+		return 0, "", ErrWebhookNotFound
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewBufferString(d.Payload))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "cinema-system-webhooks/1.0")
+	req.Header.Set("X-Webhook-Event", d.EventType)
+	req.Header.Set("X-Webhook-Delivery", d.ID.Hex())
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", SignWebhook(subscription.Secret, timestamp, d.Payload))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseMax))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, string(body), fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, string(body), nil
+}
+
+func (s *WebhookService) Run(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.ProcessQueue(ctx, time.Now()); err != nil {
+			slog.ErrorContext(ctx, "webhooks: queue processing failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
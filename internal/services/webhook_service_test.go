@@ -0,0 +1,61 @@
+package services
+
+import (
+	"cinema-system/internal/models"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWebhookSecretOnlyReturnedOnCreate(t *testing.T) {
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subscription := models.WebhookSubscription{
+		URL:        "https://partner.example/hooks",
+		Secret:     secret,
+		SecretHint: webhookSecretHint(secret),
+	}
+
+	listed, err := json.Marshal([]models.WebhookSubscription{subscription})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(listed), secret) || strings.Contains(string(listed), `"secret"`) {
+		t.Fatalf("subscription JSON leaks the secret: %s", listed)
+	}
+	if !strings.Contains(string(listed), `"secret_hint":"…`+secret[len(secret)-4:]+`"`) {
+		t.Fatalf("subscription JSON has no secret hint: %s", listed)
+	}
+
+	created, err := json.Marshal(models.WebhookSubscriptionCreated{WebhookSubscription: subscription, Secret: secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(created), `"secret":"`+secret+`"`) {
+		t.Fatalf("create response does not return the secret: %s", created)
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	const (
+		secret    = "whsec_test"
+		timestamp = "1700000000"
+		body      = `{"event":"ticket.booked"}`
+	)
+	signature := SignWebhook(secret, timestamp, body)
+	if want := "sha256=2f3fc35c91faf131b92f89d68e8a3a9e08946e59837f456c48495ed001e4b9a2"; signature != want {
+		t.Fatalf("signature = %s, want %s", signature, want)
+	}
+
+	for name, other := range map[string]string{
+		"secret":    SignWebhook("whsec_other", timestamp, body),
+		"timestamp": SignWebhook(secret, "1700000001", body),
+		"body":      SignWebhook(secret, timestamp, `{"event":"ticket.cancelled"}`),
+	} {
+		if other == signature {
+			t.Fatalf("changing the %s does not change the signature", name)
+		}
+	}
+}
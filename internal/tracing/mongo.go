@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func MongoMonitor(next *event.CommandMonitor) *event.CommandMonitor {
+	if next == nil {
+		next = &event.CommandMonitor{}
+	}
+	var spans sync.Map
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			attrs := []attribute.KeyValue{
+				semconv.DBSystemMongoDB,
+				semconv.DBNamespace(e.DatabaseName),
+				semconv.DBOperationName(e.CommandName),
+			}
+			name := e.CommandName
+			if collection, ok := collectionOf(e); ok {
+				attrs = append(attrs, semconv.DBCollectionName(collection))
+				name += " " + collection
+			}
+			_, span := StartClient(ctx, name, attrs...)
+			spans.Store(e.RequestID, span)
+			if next.Started != nil {
+				next.Started(ctx, e)
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			if span, ok := spans.LoadAndDelete(e.RequestID); ok {
+				span.(trace.Span).End()
+			}
+			if next.Succeeded != nil {
+				next.Succeeded(ctx, e)
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			if value, ok := spans.LoadAndDelete(e.RequestID); ok {
+				span := value.(trace.Span)
+				span.SetStatus(codes.Error, e.Failure)
+				span.End()
+			}
+			if next.Failed != nil {
+				next.Failed(ctx, e)
+			}
+		},
+	}
+}
+
+func collectionOf(e *event.CommandStartedEvent) (string, bool) {
+	element, err := e.Command.IndexErr(0)
+	if err != nil {
+		return "", false
+	}
+	return element.Value().StringValueOK()
+}
@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMongoMonitor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	Install(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	var forwarded int
+	monitor := MongoMonitor(&event.CommandMonitor{
+		Succeeded: func(context.Context, *event.CommandSucceededEvent) { forwarded++ },
+		Failed:    func(context.Context, *event.CommandFailedEvent) { forwarded++ },
+	})
+
+	ctx, parent := Start(context.Background(), "BookingService.BookTickets")
+	command, err := bson.Marshal(bson.D{{Key: "find", Value: "tickets"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	monitor.Started(ctx, &event.CommandStartedEvent{Command: command, DatabaseName: "cinema", CommandName: "find", RequestID: 1})
+	monitor.Succeeded(ctx, &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{RequestID: 1, Duration: time.Millisecond}})
+	monitor.Started(ctx, &event.CommandStartedEvent{Command: command, DatabaseName: "cinema", CommandName: "find", RequestID: 2})
+	monitor.Failed(ctx, &event.CommandFailedEvent{CommandFinishedEvent: event.CommandFinishedEvent{RequestID: 2}, Failure: "boom"})
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 || forwarded != 2 {
+		t.Fatalf("spans = %d, forwarded = %d", len(spans), forwarded)
+	}
+	if spans[0].Name() != "find tickets" || spans[0].Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Fatalf("span %q is not a child of the service span", spans[0].Name())
+	}
+	if spans[1].Status().Code != codes.Error {
+		t.Fatalf("failed command status = %v", spans[1].Status())
+	}
+}
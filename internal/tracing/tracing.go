@@ -0,0 +1,160 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "cinema-system"
+
+type Options struct {
+	Exporter     string
+	OTLPEndpoint string
+	File         string
+	ServiceName  string
+}
+
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return start(ctx, name, trace.SpanKindInternal, attrs)
+}
+
+func StartServer(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return start(ctx, name, trace.SpanKindServer, attrs)
+}
+
+func StartClient(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return start(ctx, name, trace.SpanKindClient, attrs)
+}
+
+func start(ctx context.Context, name string, kind trace.SpanKind, attrs []attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+}
+
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+func End(span trace.Span, err *error) {
+	if err != nil {
+		RecordError(span, *err)
+	}
+	span.End()
+}
+
+func Install(provider trace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	Install(otel.GetTracerProvider())
+
+	exporter, err := newExporter(ctx, opts)
+	if err != nil || exporter == nil {
+		return func(context.Context) error { return nil }, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithAttributes(semconv.ServiceName(serviceNameOrDefault(opts.ServiceName))),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: build resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	Install(provider)
+	return provider.Shutdown, nil
+}
+
+func serviceNameOrDefault(name string) string {
+	if name == "" {
+		return instrumentationName
+	}
+	return name
+}
+
+func newExporter(ctx context.Context, opts Options) (sdktrace.SpanExporter, error) {
+	kind := strings.ToLower(opts.Exporter)
+	if kind == "" && opts.OTLPEndpoint != "" {
+		kind = "otlp"
+	}
+
+	switch kind {
+	case "", "none":
+		return nil, nil
+	case "otlp":
+		var clientOpts []otlptracehttp.Option
+		if opts.OTLPEndpoint != "" {
+			clientOpts = append(clientOpts, otlptracehttp.WithEndpointURL(strings.TrimSuffix(opts.OTLPEndpoint, "/")+"/v1/traces"))
+		}
+		exporter, err := otlptracehttp.New(ctx, clientOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("tracing: create OTLP exporter: %w", err)
+		}
+		return exporter, nil
+	case "console", "stdout":
+		return writerExporter(os.Stdout)
+	case "file":
+		path := opts.File
+		if path == "" {
+			path = "traces.jsonl"
+		}
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("tracing: open %s: %w", path, err)
+		}
+		exporter, err := writerExporter(file)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		return fileExporter{SpanExporter: exporter, file: file}, nil
+	default:
+		return nil, fmt.Errorf("tracing: unsupported exporter %q", kind)
+	}
+}
+
+func writerExporter(w io.Writer) (sdktrace.SpanExporter, error) {
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+	if err != nil {
+		return nil, fmt.Errorf("tracing: create stdout exporter: %w", err)
+	}
+	return exporter, nil
+}
+
+type fileExporter struct {
+	sdktrace.SpanExporter
+	file *os.File
+}
+
+func (e fileExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+	if closeErr := e.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,38 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEndRecordsReturnedError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	Install(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	call := func(fail bool) (err error) {
+		_, span := Start(context.Background(), "MovieService.GetMovie")
+		defer End(span, &err)
+		if fail {
+			return errors.New("movie not found")
+		}
+		return nil
+	}
+	call(false)
+	call(true)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("spans = %d, want 2", len(spans))
+	}
+	if spans[0].Status().Code != codes.Unset || len(spans[0].Events()) != 0 {
+		t.Fatalf("successful span status = %v, events = %v", spans[0].Status(), spans[0].Events())
+	}
+	if spans[1].Status().Code != codes.Error || spans[1].Status().Description != "movie not found" || len(spans[1].Events()) != 1 {
+		t.Fatalf("failed span status = %v, events = %v", spans[1].Status(), spans[1].Events())
+	}
+}
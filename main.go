@@ -2,38 +2,95 @@ package main
 
 import (
 	"cinema-system/internal/config"
+	"cinema-system/internal/events"
 	"cinema-system/internal/handlers"
+	"cinema-system/internal/health"
+	"cinema-system/internal/logging"
+	"cinema-system/internal/metrics"
+	"cinema-system/internal/migrations"
+	"cinema-system/internal/notifications"
 	"cinema-system/internal/repositories"
 	"cinema-system/internal/routes"
 	"cinema-system/internal/services"
-	"log"
+	"cinema-system/internal/tracing"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 func main() {
-	if err := godotenv.Load(); err != nil {
-		log.Println("Warning: .env file not found, using environment variables")
+	if err := run(os.Args[1:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+		slog.Error("cinema system exited", "error", err)
+		os.Exit(1)
 	}
+}
 
-	mongoURI := os.Getenv("MONGO_URI")
-	if mongoURI == "" {
-		mongoURI = "mongodb://localhost:27017"
+func run(osArgs []string) error {
+	envErr := godotenv.Load()
+	cfg, args, err := config.Load(osArgs)
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	if len(args) > 0 && args[0] == "config" {
+		if err := cfg.Print(os.Stdout); err != nil {
+			return fmt.Errorf("print configuration: %w", err)
+		}
+		return nil
 	}
 
-	dbName := os.Getenv("MONGO_DATABASE")
-	if dbName == "" {
-		dbName = "cinema_db"
+	slog.SetDefault(logging.New(os.Stdout, cfg.Log.Level, cfg.Log.Format))
+	if envErr != nil {
+		slog.Warn(".env file not found, using environment variables")
 	}
+	for _, warning := range cfg.Warnings() {
+		slog.Warn(warning)
+	}
+	slog.Info("effective configuration", "env", cfg.Env, "config", cfg.Redacted())
 
-	db, err := config.NewDatabase(mongoURI, dbName)
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter:     cfg.Tracing.Exporter,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		File:         cfg.Tracing.File,
+		ServiceName:  cfg.Tracing.ServiceName,
+	})
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		return fmt.Errorf("set up tracing: %w", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("failed to flush traces", "error", err)
+		}
+	}()
+
+	db, err := config.NewDatabase(cfg.Database.URI, cfg.Database.Name)
+	if err != nil {
+		return fmt.Errorf("connect to database: %w", err)
 	}
 	defer db.Disconnect()
 
-	log.Println("Successfully connected to MongoDB!")
+	migrator := migrations.NewMigrator(db.Database)
+	if len(args) > 0 && args[0] == "migrate" {
+		if err := migrations.RunCommand(context.Background(), migrator, args[1:], os.Stdout); err != nil {
+			return fmt.Errorf("migration failed: %w", err)
+		}
+		return nil
+	}
+
+	walletConfig, err := config.LoadWalletConfig(cfg.Wallet)
+	if err != nil {
+		return fmt.Errorf("load wallet configuration: %w", err)
+	}
 
 	userRepo := repositories.NewUserRepository(db.Database)
 	movieRepo := repositories.NewMovieRepository(db.Database)
@@ -43,28 +100,89 @@ func main() {
 	sessionRepo := repositories.NewSessionRepository(db.Database)
 	ticketRepo := repositories.NewTicketRepository(db.Database)
 	reviewRepo := repositories.NewReviewRepository(db.Database)
+	reviewReportRepo := repositories.NewReviewReportRepository(db.Database)
+	reviewVoteRepo := repositories.NewReviewVoteRepository(db.Database)
 	paymentCardRepo := repositories.NewPaymentCardRepository(db.Database)
 	paymentRepo := repositories.NewPaymentRepository(db.Database)
+	documentTemplateRepo := repositories.NewDocumentTemplateRepository(db.Database)
+	walletPassRepo := repositories.NewWalletPassRepository(db.Database)
+	walletRegistrationRepo := repositories.NewWalletRegistrationRepository(db.Database)
+	notificationRepo := repositories.NewNotificationRepository(db.Database)
+	notificationPreferenceRepo := repositories.NewNotificationPreferenceRepository(db.Database)
+	outboxRepo := repositories.NewOutboxRepository(db.Database)
+	processedEventRepo := repositories.NewProcessedEventRepository(db.Database)
+	transactor := repositories.NewTransactor(db.Client)
+	webhookSubscriptionRepo := repositories.NewWebhookSubscriptionRepository(db.Database)
+	webhookDeliveryRepo := repositories.NewWebhookDeliveryRepository(db.Database)
+	exchangeRateRepo := repositories.NewExchangeRateRepository(db.Database)
+	recommendationRepo := repositories.NewRecommendationRepository(db.Database)
+	analyticsRepo := repositories.NewAnalyticsRepository(db.Database)
+	auditRepo := repositories.NewAuditRepository(db.Database)
+
+	auditService := services.NewAuditService(auditRepo, transactor, &cfg.Auth)
+	auditService.Track("movies", services.TrackEntity(movieRepo.FindByID))
+	auditService.Track("sessions", services.TrackEntity(sessionRepo.FindByID))
+	auditService.Track("halls", services.TrackEntity(hallRepo.FindByID))
+	auditService.Track("genres", services.TrackEntity(genreRepo.FindByID))
+	auditService.Track("reviews", services.TrackEntity(reviewRepo.FindByID))
+	auditService.Track("webhooks", services.TrackEntity(webhookSubscriptionRepo.FindByID))
 
 	movieGenreService := services.NewMovieGenreService(movieGenreRepo)
-	movieService := services.NewMovieService(movieRepo, genreRepo, movieGenreService)
+	movieService := services.NewMovieService(movieRepo, genreRepo, sessionRepo, hallRepo, movieGenreService)
 	genreService := services.NewGenreService(genreRepo)
-	authService := services.NewAuthService(userRepo, reviewRepo)
-	sessionService := services.NewSessionService(sessionRepo, hallRepo, movieRepo)
-	bookingService := services.NewBookingService(ticketRepo, sessionRepo, userRepo, hallRepo, movieRepo, paymentRepo)
-	reviewService := services.NewReviewService(reviewRepo, movieRepo, userRepo)
+	authService := services.NewAuthService(userRepo, reviewRepo, &cfg.Auth)
+	notificationService := services.NewNotificationService(notificationRepo, notificationPreferenceRepo, userRepo, ticketRepo, sessionRepo, movieRepo, hallRepo, paymentRepo, notifications.NewChannels(cfg.Notifications))
+	entryService := services.NewEntryService(ticketRepo, sessionRepo, &cfg.Auth)
+	walletService := services.NewWalletService(walletConfig, ticketRepo, sessionRepo, movieRepo, hallRepo, walletPassRepo, walletRegistrationRepo, outboxRepo, entryService)
+	sessionService := services.NewSessionService(sessionRepo, hallRepo, movieRepo, outboxRepo, transactor)
+	bookingService := services.NewBookingService(ticketRepo, sessionRepo, userRepo, hallRepo, movieRepo, paymentRepo, outboxRepo, transactor, auditService)
+	reviewService := services.NewReviewService(reviewRepo, reviewReportRepo, reviewVoteRepo, movieRepo, userRepo, ticketRepo, sessionRepo, outboxRepo, transactor, &cfg.Moderation, &cfg.Rating)
 	paymentCardService := services.NewPaymentCardService(paymentCardRepo, userRepo)
-	paymentService := services.NewPaymentService(paymentRepo, paymentCardRepo, userRepo)
+	paymentService := services.NewPaymentService(paymentRepo, paymentCardRepo, userRepo, outboxRepo, transactor, auditService)
+	webhookService := services.NewWebhookService(webhookSubscriptionRepo, webhookDeliveryRepo)
+	exchangeRateService := services.NewExchangeRateService(exchangeRateRepo)
+	analyticsService := services.NewAnalyticsService(analyticsRepo, hallRepo, &cfg.Analytics)
+	exportService := services.NewExportService(ticketRepo, paymentRepo, userRepo, analyticsService)
+	recommendationService := services.NewRecommendationService(recommendationRepo, movieRepo, genreRepo, movieGenreRepo, sessionRepo, ticketRepo, reviewRepo)
+	documentService := services.NewDocumentService(ticketRepo, sessionRepo, movieRepo, hallRepo, paymentRepo, paymentCardRepo, documentTemplateRepo, entryService)
+
+	dispatcher := events.NewDispatcher(outboxRepo, processedEventRepo, transactor)
+	dispatcher.Subscribe(events.TicketBooked, "notifications.booking_confirmed", notificationService.HandleTicketBooked)
+	dispatcher.Subscribe(events.SessionCancelled, "notifications.session_cancelled", notificationService.HandleSessionCancelled)
+	dispatcher.Subscribe(events.SessionRescheduled, "wallet.session_changed", walletService.HandleSessionRescheduled)
+	dispatcher.Subscribe(events.TicketCancelled, "wallet.ticket_voided", walletService.HandleTicketCancelled)
+	dispatcher.Subscribe(events.PaymentRefunded, "wallet.ticket_voided", walletService.HandlePaymentRefunded)
+	dispatcher.SubscribeExternal(events.WalletPassesChanged, "wallet.push", walletService.HandlePassesChanged)
+	for _, eventType := range services.WebhookEventTypes {
+		dispatcher.Subscribe(eventType, "webhooks.fanout", webhookService.HandleEvent)
+	}
+	dispatcher.Subscribe(events.TicketBooked, "movies.popularity", movieService.HandleTicketBooked)
+	dispatcher.Subscribe(events.TicketCancelled, "movies.popularity", movieService.HandleTicketCancelled)
 
+	location := cfg.Analytics.Location()
 	authHandler := handlers.NewAuthHandler(authService)
 	movieHandler := handlers.NewMovieHandler(movieService)
-	sessionHandler := handlers.NewSessionHandler(sessionService)
-	bookingHandler := handlers.NewBookingHandler(bookingService)
-	reviewHandler := handlers.NewReviewHandler(reviewService)
-	hallHandler := handlers.NewHallHandler(hallRepo)
+	sessionHandler := handlers.NewSessionHandler(sessionService, exchangeRateService)
+	bookingHandler := handlers.NewBookingHandler(bookingService, location)
+	reviewHandler := handlers.NewReviewHandler(reviewService, location)
+	hallHandler := handlers.NewHallHandler(hallRepo, location)
 	paymentCardHandler := handlers.NewPaymentCardHandler(paymentCardService)
-	paymentHandler := handlers.NewPaymentHandler(paymentService)
+	paymentHandler := handlers.NewPaymentHandler(paymentService, location)
 	genreHandler := handlers.NewGenreHandler(genreService)
+	entryHandler := handlers.NewEntryHandler(entryService)
+	documentHandler := handlers.NewDocumentHandler(documentService)
+	walletHandler := handlers.NewWalletHandler(walletService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	exchangeRateHandler := handlers.NewExchangeRateHandler(exchangeRateService)
+	recommendationHandler := handlers.NewRecommendationHandler(recommendationService)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService)
+	exportHandler := handlers.NewExportHandler(exportService, location)
+	auditHandler := handlers.NewAuditHandler(auditService, location)
+
+	checker := health.NewChecker()
+	checker.AddCheck("mongodb", db.Ping)
+	healthHandler := handlers.NewHealthHandler(checker)
 
 	router := routes.NewRouter(
 		authHandler,
@@ -76,15 +194,135 @@ func main() {
 		paymentCardHandler,
 		paymentHandler,
 		genreHandler,
+		entryHandler,
+		documentHandler,
+		walletHandler,
+		notificationHandler,
+		webhookHandler,
+		exchangeRateHandler,
+		recommendationHandler,
+		analyticsHandler,
+		exportHandler,
+		auditHandler,
+		healthHandler,
+		auditService,
+		checker,
+		&cfg.Auth,
 	)
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	server := &http.Server{
+		Addr:              ":" + cfg.Server.Port,
+		Handler:           router.Setup(),
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+	metricsServer := &http.Server{
+		Addr:              ":" + cfg.Server.MetricsPort,
+		Handler:           metrics.Handler(),
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
 	}
+	serverErr := make(chan error, 2)
+	go func() {
+		slog.Info("🎬 Cinema System Server starting", "port", cfg.Server.Port)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+	go func() {
+		slog.Info("metrics listener starting", "port", cfg.Server.MetricsPort)
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- fmt.Errorf("metrics listener: %w", err)
+		}
+	}()
 
-	log.Printf("🎬 Cinema System Server starting on port %s", port)
-	if err := router.Setup().Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+	signals, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	startupCtx, cancelStartup := context.WithTimeout(signals, cfg.Server.StartupTimeout)
+	err = waitForDependencies(startupCtx, db, migrator, cfg.Database.AutoMigrate)
+	cancelStartup()
+	if err != nil {
+		server.Close()
+		metricsServer.Close()
+		return fmt.Errorf("startup failed: %w", err)
+	}
+
+	workers, stopWorkers := context.WithCancel(context.Background())
+	checker.Go(workers, "events.dispatcher", dispatcher.Run)
+	checker.Go(workers, "notifications", notificationService.Run)
+	checker.Go(workers, "webhooks", webhookService.Run)
+	checker.Go(workers, "recommendations", recommendationService.Run)
+	checker.Go(workers, "ratings", reviewService.Run)
+	checker.MarkReady()
+	slog.Info("server is ready")
+
+	var runErr error
+	select {
+	case <-signals.Done():
+		slog.Info("shutdown signal received, draining requests")
+	case err := <-serverErr:
+		runErr = fmt.Errorf("server failed: %w", err)
+		slog.Error("server failed, shutting down", "error", err)
+	}
+
+	checker.MarkStopping()
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancelShutdown()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("failed to drain requests", "error", err)
+	}
+	metricsServer.Close()
+	stopWorkers()
+	if err := checker.Wait(shutdownCtx); err != nil {
+		slog.Error("background workers did not stop in time", "error", err)
+	}
+	slog.Info("server stopped")
+	return runErr
+}
+
+func waitForDependencies(ctx context.Context, db *config.Database, migrator *migrations.Migrator, autoMigrate bool) error {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	warned := false
+	for {
+		ready, err := dependenciesReady(ctx, db, migrator, autoMigrate)
+		if ready {
+			return nil
+		}
+		if err != nil {
+			slog.Warn("waiting for dependencies", "error", err)
+		} else if !warned {
+			slog.Warn(`pending migrations; run "migrate up" or set AUTO_MIGRATE=true`)
+			warned = true
+		}
+
+		select {
+		case <-ctx.Done():
+			if err == nil {
+				err = errors.New("migrations are still pending")
+			}
+			return fmt.Errorf("%w: %v", ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
+
+func dependenciesReady(ctx context.Context, db *config.Database, migrator *migrations.Migrator, autoMigrate bool) (bool, error) {
+	if err := db.Ping(ctx); err != nil {
+		return false, fmt.Errorf("mongodb: %w", err)
+	}
+	if autoMigrate {
+		if _, err := migrator.Up(ctx); err != nil {
+			return false, fmt.Errorf("migrations: %w", err)
+		}
+		return true, nil
+	}
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		return false, fmt.Errorf("migrations: %w", err)
 	}
+	return len(pending) == 0, nil
 }